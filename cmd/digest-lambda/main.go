@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	scheduledigest "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/schedule/digest"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/memory"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/digest"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+func main() {
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), mustEnv("TABLE_NAME_INSIGHTS"))
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	// No mail delivery in the cloud yet: digests are stored and read back
+	// through GET /v1/digests.
+	svc := digest.NewService(insightRepo, insightRepo, insightRepo, insightRepo, insightRepo, memory.NewNotifierNoopAdapter())
+	h := scheduledigest.NewHandler(svc, tenantCtx.TenantID)
+	lambda.Start(h.Generate)
+}
+
+func mustEnv(key string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		panic("missing env var: " + key)
+	}
+	return v
+}
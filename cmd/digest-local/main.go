@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/joho/godotenv"
+
+	scheduledigest "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/schedule/digest"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/file"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/smtp"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/digest"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// Generates one digest for the default tenant against real DynamoDB and
+// exits — the local counterpart to digest-lambda, which EventBridge
+// Scheduler invokes daily and weekly. DIGEST_PERIOD picks which (daily by
+// default). The digest is written under DIGEST_DIR (./digests by default),
+// or mailed through DIGEST_SMTP_ADDR if that's set, e.g. to a local
+// Mailpit on localhost:1025.
+func main() {
+	_ = godotenv.Load()
+
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	tableName := os.Getenv("TABLE_NAME_INSIGHTS")
+	if tableName == "" {
+		log.Error("TABLE_NAME_INSIGHTS env var is required")
+		os.Exit(1)
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), tableName)
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	svc := digest.NewService(insightRepo, insightRepo, insightRepo, insightRepo, insightRepo, notifier())
+	h := scheduledigest.NewHandler(svc, tenantCtx.TenantID)
+	if err := h.Generate(ctx, scheduledigest.Event{Period: os.Getenv("DIGEST_PERIOD")}); err != nil {
+		log.Error("digest failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+func notifier() ports.Notifier {
+	if addr := os.Getenv("DIGEST_SMTP_ADDR"); addr != "" {
+		return smtp.NewDigestNotifier(addr, envOr("DIGEST_MAIL_FROM", "digest@localhost"), strings.Split(envOr("DIGEST_MAIL_TO", "me@localhost"), ","))
+	}
+	return file.NewDigestNotifier(envOr("DIGEST_DIR", "digests"))
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	sqsgraphanalytics "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/sqs/graphanalytics"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/graphanalytics"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+func main() {
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), mustEnv("TABLE_NAME_INSIGHTS"))
+
+	svc := graphanalytics.NewService(insightRepo, insightRepo, insightRepo)
+	h := sqsgraphanalytics.NewHandler(svc)
+	lambda.Start(h.Handle)
+}
+
+func mustEnv(key string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		panic("missing env var: " + key)
+	}
+	return v
+}
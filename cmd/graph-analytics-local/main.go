@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/joho/godotenv"
+
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/graphanalytics"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+// Recomputes the default tenant's graph analytics against real DynamoDB,
+// then exits — the local counterpart to graph-analytics-lambda, without
+// the KnowledgeUpdated queue in front of it.
+func main() {
+	_ = godotenv.Load()
+
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	tableName := os.Getenv("TABLE_NAME_INSIGHTS")
+	if tableName == "" {
+		log.Error("TABLE_NAME_INSIGHTS env var is required")
+		os.Exit(1)
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), tableName)
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	svc := graphanalytics.NewService(insightRepo, insightRepo, insightRepo)
+	// As of now, so it always recomputes.
+	analytics, _, err := svc.Recompute(ctx, tenantCtx.TenantID, time.Now())
+	if err != nil {
+		log.Error("graph analytics recompute failed", "err", err)
+		os.Exit(1)
+	}
+	log.Info("graph analytics recomputed",
+		"tenant_id", tenantCtx.TenantID,
+		"clusters", len(analytics.Clusters),
+		"central", len(analytics.Central),
+		"bridges", len(analytics.Bridges),
+	)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	schedulereenrich "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/schedule/reenrich"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/eventbridge"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/llmprovider"
+	ssmAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/ssm"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/llm"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/reenrich"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+const (
+	// defaultBatchLimit caps enrichment calls per run, so one invocation
+	// stays well inside the Lambda timeout. Override via REENRICH_BATCH_LIMIT.
+	defaultBatchLimit = 100
+
+	// defaultRatePerMinute spaces enrichment calls out, so a backfill never
+	// competes with the worker's live enrichment for the provider's rate
+	// limit. Override via REENRICH_RATE_PER_MINUTE.
+	defaultRatePerMinute = 60
+)
+
+func main() {
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), mustEnv("TABLE_NAME_INSIGHTS"))
+
+	secretProvider, err := ssmAdapters.NewSecretProvider(ctx)
+	if err != nil {
+		log.Error("failed to create SSM secret provider", "err", err)
+		os.Exit(1)
+	}
+
+	// Unlike the worker, a missing provider is fatal here: re-enrichment without
+	// an LLM would only count every insight as failed.
+	llmClient, err := llmprovider.FromEnv(ctx, secretProvider)
+	if err != nil {
+		log.Error("failed to configure LLM provider", "err", err)
+		os.Exit(1)
+	}
+	if llmClient == nil {
+		log.Error("an LLM provider is required (see LLM_PROVIDER)")
+		os.Exit(1)
+	}
+	limits, price, err := llmprovider.BudgetFromEnv()
+	if err != nil {
+		log.Error("failed to configure LLM budget", "err", err)
+		os.Exit(1)
+	}
+
+	domainEvents, err := eventbridge.NewDomainEventPublisher(ctx)
+	if err != nil {
+		log.Error("failed to create domain event publisher", "err", err)
+		os.Exit(1)
+	}
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	// Jobs share the tenant's budget with the worker; once it's spent a job
+	// pauses at its cursor until the next day or month. No cache: a job is
+	// an explicit request to ask the model again.
+	llmService := llm.NewService(llmClient).
+		WithBudget(budget.NewService(insightRepo, limits, price)).
+		WithSettings(insightRepo)
+	insightSvc := insight.NewService(insightRepo, llmService, domainEvents, nil, nil)
+	svc := reenrich.NewService(insightRepo, insightRepo, insightSvc,
+		envInt(log, "REENRICH_BATCH_LIMIT", defaultBatchLimit),
+		time.Minute/time.Duration(envInt(log, "REENRICH_RATE_PER_MINUTE", defaultRatePerMinute)))
+	h := schedulereenrich.NewHandler(svc, tenantCtx.TenantID)
+
+	lambda.Start(h.Run)
+}
+
+func envInt(log *slog.Logger, key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Warn("invalid "+key+", using default", "value", v, "default", fallback)
+		return fallback
+	}
+	return n
+}
+
+func mustEnv(key string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		panic("missing env var: " + key)
+	}
+	return v
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/joho/godotenv"
+
+	schedulereenrich "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/schedule/reenrich"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/llmprovider"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/memory"
+	ssmAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/ssm"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/llm"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/reenrich"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+const (
+	// defaultBatchLimit caps enrichment calls per run. Override via
+	// REENRICH_BATCH_LIMIT.
+	defaultBatchLimit = 100
+
+	// defaultRatePerMinute spaces enrichment calls out. Override via
+	// REENRICH_RATE_PER_MINUTE.
+	defaultRatePerMinute = 60
+)
+
+// Runs one pass over the tenant's pending reenrich jobs against real
+// DynamoDB and OpenAI, then exits — the local counterpart to
+// reenrich-lambda. Request a job first (POST /v1/admin/reenrich via
+// rest-local); domain events go to the noop publisher.
+func main() {
+	_ = godotenv.Load()
+
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	tableName := os.Getenv("TABLE_NAME_INSIGHTS")
+	if tableName == "" {
+		log.Error("TABLE_NAME_INSIGHTS env var is required")
+		os.Exit(1)
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), tableName)
+
+	secretProvider, err := ssmAdapters.NewSecretProvider(ctx)
+	if err != nil {
+		log.Error("ssm provider init failed", "err", err)
+		os.Exit(1)
+	}
+
+	llmClient, err := llmprovider.FromEnv(ctx, secretProvider)
+	if err != nil {
+		log.Error("failed to configure LLM provider", "err", err)
+		os.Exit(1)
+	}
+	if llmClient == nil {
+		log.Error("an LLM provider is required (see LLM_PROVIDER)")
+		os.Exit(1)
+	}
+	limits, price, err := llmprovider.BudgetFromEnv()
+	if err != nil {
+		log.Error("failed to configure LLM budget", "err", err)
+		os.Exit(1)
+	}
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	// Jobs share the tenant's budget with the worker; once it's spent a job
+	// pauses at its cursor until the next day or month. No cache: a job is
+	// an explicit request to ask the model again.
+	llmService := llm.NewService(llmClient).
+		WithBudget(budget.NewService(insightRepo, limits, price)).
+		WithSettings(insightRepo)
+	insightSvc := insight.NewService(insightRepo, llmService, memory.NewDomainEventNoopAdapter(), nil, nil)
+	svc := reenrich.NewService(insightRepo, insightRepo, insightSvc,
+		envInt(log, "REENRICH_BATCH_LIMIT", defaultBatchLimit),
+		time.Minute/time.Duration(envInt(log, "REENRICH_RATE_PER_MINUTE", defaultRatePerMinute)))
+	h := schedulereenrich.NewHandler(svc, tenantCtx.TenantID)
+
+	if err := h.Run(ctx); err != nil {
+		log.Error("reenrich run failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+func envInt(log *slog.Logger, key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Warn("invalid "+key+", using default", "value", v, "default", fallback)
+		return fallback
+	}
+	return n
+}
@@ -13,20 +13,44 @@ import (
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest"
 	restauth "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	restcontradiction "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/contradiction"
+	restdigest "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/digest"
+	restduplicate "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/duplicate"
+	restgraph "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/graph"
 	restinsight "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/insight"
 	restraindrop "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/raindrop"
 	restreadwise "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/readwise"
+	restreenrich "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/reenrich"
 	restrelationship "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/relationship"
+	restreview "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/review"
+	restsettings "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/settings"
+	resttaghistory "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/taghistory"
+	resttagmap "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/tagmap"
+	restusage "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/usage"
 	restweeklyplan "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/weeklyplan"
 	dynamodbadapter "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/eventbridge"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/llmprovider"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/memory"
 	raindropclient "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/raindrop"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/sqs"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/ssm"
+	appbudget "github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	appcontradiction "github.com/marcogerstmann/insight-processing-platform/internal/application/contradiction"
+	appdigest "github.com/marcogerstmann/insight-processing-platform/internal/application/digest"
+	appduplicate "github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
+	appgraph "github.com/marcogerstmann/insight-processing-platform/internal/application/graph"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/graphanalytics"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/ingest"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	appreenrich "github.com/marcogerstmann/insight-processing-platform/internal/application/reenrich"
 	apprelationship "github.com/marcogerstmann/insight-processing-platform/internal/application/relationship"
+	appreview "github.com/marcogerstmann/insight-processing-platform/internal/application/review"
+	appsettings "github.com/marcogerstmann/insight-processing-platform/internal/application/settings"
+	apptaghistory "github.com/marcogerstmann/insight-processing-platform/internal/application/taghistory"
+	apptagmap "github.com/marcogerstmann/insight-processing-platform/internal/application/tagmap"
 	appweeklyplan "github.com/marcogerstmann/insight-processing-platform/internal/application/weeklyplan"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
@@ -72,12 +96,42 @@ func init() {
 		slog.Error("domain event publisher init failed", "err", err)
 		os.Exit(1)
 	}
-	insightSvc := insight.NewService(insightAdapter, nil, domainEvents)
+	duplicatePolicy, err := domain.ParseDuplicatePolicy(os.Getenv("DUPLICATE_POLICY"))
+	if err != nil {
+		slog.Error("invalid DUPLICATE_POLICY", "err", err)
+		os.Exit(1)
+	}
+	duplicateSvc := appduplicate.NewService(insightAdapter, insightAdapter, duplicatePolicy, domain.DefaultDuplicateThreshold)
+	insightSvc := insight.NewService(insightAdapter, nil, domainEvents, duplicateSvc, insightAdapter)
 	insightHandler := restinsight.NewHandler(insightSvc)
-	relationshipSvc := apprelationship.NewService(insightAdapter, domainEvents)
+	relationshipSvc := apprelationship.NewService(insightAdapter, insightAdapter, insightAdapter, insightAdapter, domainEvents)
 	relationshipHandler := restrelationship.NewHandler(relationshipSvc)
 	weeklyPlanSvc := appweeklyplan.NewService(insightAdapter, insightAdapter, domainEvents)
 	weeklyPlanHandler := restweeklyplan.NewHandler(weeklyPlanSvc)
+	// Only requests and reads jobs here; the reenrich command pair, which
+	// has an LLM configured, is what works through them — hence no limit
+	// or interval.
+	reenrichSvc := appreenrich.NewService(insightAdapter, insightAdapter, insightSvc, 0, 0)
+	reenrichHandler := restreenrich.NewHandler(reenrichSvc)
+	// Reports only: the limits must match the worker's and the reenrich
+	// pair's for "exhausted" to mean what they enforce.
+	limits, price, err := llmprovider.BudgetFromEnv()
+	if err != nil {
+		slog.Error("llm budget config failed", "err", err)
+		os.Exit(1)
+	}
+	usageHandler := restusage.NewHandler(appbudget.NewService(insightAdapter, limits, price))
+	duplicateHandler := restduplicate.NewHandler(duplicateSvc)
+	settingsHandler := restsettings.NewHandler(appsettings.NewService(insightAdapter, insightAdapter))
+	graphHandler := restgraph.NewHandler(
+		appgraph.NewService(insightAdapter, insightAdapter),
+		graphanalytics.NewService(insightAdapter, insightAdapter, insightAdapter))
+	contradictionHandler := restcontradiction.NewHandler(appcontradiction.NewService(insightAdapter, insightAdapter, insightAdapter))
+	tagMapHandler := resttagmap.NewHandler(apptagmap.NewService(insightAdapter, insightAdapter))
+	tagHistoryHandler := resttaghistory.NewHandler(apptaghistory.NewService(insightAdapter, insightAdapter, insightAdapter))
+	reviewHandler := restreview.NewHandler(appreview.NewService(insightAdapter, insightAdapter, insightAdapter))
+	// Only lists digests; the digest Lambda generates and sends them.
+	digestHandler := restdigest.NewHandler(appdigest.NewService(insightAdapter, insightAdapter, insightAdapter, insightAdapter, insightAdapter, memory.NewNotifierNoopAdapter()))
 
 	publisher, err := sqs.NewSQSEventPublisher(ctx)
 	if err != nil {
@@ -103,7 +157,7 @@ func init() {
 
 	// CORS is handled by API Gateway (terraform/envs/dev/rest-api.tf), so no
 	// allowed origins are passed here.
-	ginLambda = ginadapter.NewV2(rest.NewRouter(insightHandler, readwiseHandler, raindropHandler, relationshipHandler, weeklyPlanHandler, authValidator, nil))
+	ginLambda = ginadapter.NewV2(rest.NewRouter(insightHandler, readwiseHandler, raindropHandler, relationshipHandler, weeklyPlanHandler, reenrichHandler, usageHandler, duplicateHandler, settingsHandler, graphHandler, contradictionHandler, tagMapHandler, tagHistoryHandler, reviewHandler, digestHandler, authValidator, nil))
 }
 
 func handler(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
@@ -12,20 +12,43 @@ import (
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest"
 	restauth "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	restcontradiction "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/contradiction"
+	restdigest "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/digest"
+	restduplicate "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/duplicate"
+	restgraph "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/graph"
 	restinsight "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/insight"
 	restraindrop "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/raindrop"
 	restreadwise "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/readwise"
+	restreenrich "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/reenrich"
 	restrelationship "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/relationship"
+	restreview "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/review"
+	restsettings "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/settings"
+	resttaghistory "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/taghistory"
+	resttagmap "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/tagmap"
+	restusage "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/usage"
 	restweeklyplan "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/weeklyplan"
 	dynamodbadapter "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/llmprovider"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/memory"
 	raindropclient "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/raindrop"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/sqs"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/ssm"
+	appbudget "github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	appcontradiction "github.com/marcogerstmann/insight-processing-platform/internal/application/contradiction"
+	appdigest "github.com/marcogerstmann/insight-processing-platform/internal/application/digest"
+	appduplicate "github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
+	appgraph "github.com/marcogerstmann/insight-processing-platform/internal/application/graph"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/graphanalytics"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/ingest"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	appreenrich "github.com/marcogerstmann/insight-processing-platform/internal/application/reenrich"
 	apprelationship "github.com/marcogerstmann/insight-processing-platform/internal/application/relationship"
+	appreview "github.com/marcogerstmann/insight-processing-platform/internal/application/review"
+	appsettings "github.com/marcogerstmann/insight-processing-platform/internal/application/settings"
+	apptaghistory "github.com/marcogerstmann/insight-processing-platform/internal/application/taghistory"
+	apptagmap "github.com/marcogerstmann/insight-processing-platform/internal/application/tagmap"
 	appweeklyplan "github.com/marcogerstmann/insight-processing-platform/internal/application/weeklyplan"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
@@ -60,8 +83,14 @@ func main() {
 
 	dynamoClient := awsdynamodb.NewFromConfig(awsCfg)
 	insightAdapter := dynamodbadapter.NewInsightAdapter(dynamoClient, tableName)
+	duplicatePolicy, err := domain.ParseDuplicatePolicy(os.Getenv("DUPLICATE_POLICY"))
+	if err != nil {
+		slog.Error("invalid DUPLICATE_POLICY", "err", err)
+		os.Exit(1)
+	}
+	duplicateSvc := appduplicate.NewService(insightAdapter, insightAdapter, duplicatePolicy, domain.DefaultDuplicateThreshold)
 	// Enrichment is async and belongs to the worker path only — REST returns fast.
-	insightSvc := insight.NewService(insightAdapter, nil, memory.NewDomainEventNoopAdapter())
+	insightSvc := insight.NewService(insightAdapter, nil, memory.NewDomainEventNoopAdapter(), duplicateSvc, insightAdapter)
 
 	publisher, err := sqs.NewSQSEventPublisher(ctx)
 	if err != nil {
@@ -83,13 +112,37 @@ func main() {
 	}
 
 	insightHandler := restinsight.NewHandler(insightSvc)
-	relationshipSvc := apprelationship.NewService(insightAdapter, memory.NewDomainEventNoopAdapter())
+	relationshipSvc := apprelationship.NewService(insightAdapter, insightAdapter, insightAdapter, insightAdapter, memory.NewDomainEventNoopAdapter())
 	relationshipHandler := restrelationship.NewHandler(relationshipSvc)
 	weeklyPlanSvc := appweeklyplan.NewService(insightAdapter, insightAdapter, memory.NewDomainEventNoopAdapter())
 	weeklyPlanHandler := restweeklyplan.NewHandler(weeklyPlanSvc)
+	// Only requests and reads jobs here; the reenrich command pair, which
+	// has an LLM configured, is what works through them — hence no limit
+	// or interval.
+	reenrichSvc := appreenrich.NewService(insightAdapter, insightAdapter, insightSvc, 0, 0)
+	reenrichHandler := restreenrich.NewHandler(reenrichSvc)
+	// Reports only: the limits must match the worker's and the reenrich
+	// pair's for "exhausted" to mean what they enforce.
+	limits, price, err := llmprovider.BudgetFromEnv()
+	if err != nil {
+		slog.Error("llm budget config failed", "err", err)
+		os.Exit(1)
+	}
+	usageHandler := restusage.NewHandler(appbudget.NewService(insightAdapter, limits, price))
+	duplicateHandler := restduplicate.NewHandler(duplicateSvc)
+	settingsHandler := restsettings.NewHandler(appsettings.NewService(insightAdapter, insightAdapter))
+	graphHandler := restgraph.NewHandler(
+		appgraph.NewService(insightAdapter, insightAdapter),
+		graphanalytics.NewService(insightAdapter, insightAdapter, insightAdapter))
+	contradictionHandler := restcontradiction.NewHandler(appcontradiction.NewService(insightAdapter, insightAdapter, insightAdapter))
+	tagMapHandler := resttagmap.NewHandler(apptagmap.NewService(insightAdapter, insightAdapter))
+	tagHistoryHandler := resttaghistory.NewHandler(apptaghistory.NewService(insightAdapter, insightAdapter, insightAdapter))
+	reviewHandler := restreview.NewHandler(appreview.NewService(insightAdapter, insightAdapter, insightAdapter))
+	// Only lists digests; the digest Lambda generates and sends them.
+	digestHandler := restdigest.NewHandler(appdigest.NewService(insightAdapter, insightAdapter, insightAdapter, insightAdapter, insightAdapter, memory.NewNotifierNoopAdapter()))
 	// Allow the web app's Vite dev server to call this local API from the
 	// browser. In AWS this is API Gateway's job; locally the Go server must do it.
-	router := rest.NewRouter(insightHandler, readwiseHandler, raindropHandler, relationshipHandler, weeklyPlanHandler, authValidator, []string{"http://localhost:5173"})
+	router := rest.NewRouter(insightHandler, readwiseHandler, raindropHandler, relationshipHandler, weeklyPlanHandler, reenrichHandler, usageHandler, duplicateHandler, settingsHandler, graphHandler, contradictionHandler, tagMapHandler, tagHistoryHandler, reviewHandler, digestHandler, authValidator, []string{"http://localhost:5173"})
 
 	addr := ":8081"
 	log.Printf("REST server listening on http://localhost%s", addr)
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/joho/godotenv"
+
+	scheduletagsnapshot "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/schedule/tagsnapshot"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/taghistory"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+// Takes one snapshot of the default tenant's tag relevance against real
+// DynamoDB and exits — the local counterpart to tag-snapshot-lambda, which
+// EventBridge Scheduler invokes on a recurring cadence.
+func main() {
+	_ = godotenv.Load()
+
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	tableName := os.Getenv("TABLE_NAME_INSIGHTS")
+	if tableName == "" {
+		log.Error("TABLE_NAME_INSIGHTS env var is required")
+		os.Exit(1)
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), tableName)
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	h := scheduletagsnapshot.NewHandler(taghistory.NewService(insightRepo, insightRepo, insightRepo), tenantCtx.TenantID)
+	if err := h.Snapshot(ctx); err != nil {
+		log.Error("tag snapshot failed", "err", err)
+		os.Exit(1)
+	}
+}
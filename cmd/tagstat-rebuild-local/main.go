@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/joho/godotenv"
+
+	invoketagstat "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/invoke/tagstat"
+	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tagstat"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/tenant"
+	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
+)
+
+// Rebuilds the default tenant's per-tag aggregates against real DynamoDB
+// from its tag memberships, relationship edges and reviews, then exits —
+// the local counterpart to tagstat-rebuild-lambda. Run either once for
+// data written before the aggregates existed, or whenever GET /v1/tags
+// looks off; not while ingestion is writing.
+func main() {
+	_ = godotenv.Load()
+
+	log := logging.New(os.Stdout)
+	slog.SetDefault(log)
+
+	ctx := context.Background()
+
+	tableName := os.Getenv("TABLE_NAME_INSIGHTS")
+	if tableName == "" {
+		log.Error("TABLE_NAME_INSIGHTS env var is required")
+		os.Exit(1)
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Error("failed to load aws config", "err", err)
+		os.Exit(1)
+	}
+	insightRepo := dynamoAdapters.NewInsightAdapter(dynamodb.NewFromConfig(awsCfg), tableName)
+
+	tenantCtx, err := tenant.NewResolver().Resolve()
+	if err != nil {
+		log.Error("tenant resolution failed", "err", err)
+		os.Exit(1)
+	}
+
+	h := invoketagstat.NewHandler(tagstat.NewService(insightRepo, insightRepo), tenantCtx.TenantID)
+	if err := h.Rebuild(ctx); err != nil {
+		log.Error("tag stat rebuild failed", "err", err)
+		os.Exit(1)
+	}
+}
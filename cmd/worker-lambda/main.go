@@ -12,12 +12,14 @@ import (
 	workersqs "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/sqs/worker"
 	dynamoAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/dynamodb"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/eventbridge"
-	openaiAdapter "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/openai"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/llmprovider"
 	sqsAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/sqs"
 	ssmAdapters "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/ssm"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/llm"
-	"github.com/marcogerstmann/insight-processing-platform/internal/envutil"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
 )
 
@@ -55,17 +57,43 @@ func main() {
 		os.Exit(1)
 	}
 
-	var llmService *llm.Service
-	apiKey, err := envutil.ResolveSecret(ctx, "OPENAI_API_KEY", secretProvider)
+	llmClient, err := llmprovider.FromEnv(ctx, secretProvider)
 	if err != nil {
-		log.Error("failed to resolve OpenAI API key", "err", err)
+		log.Error("failed to configure LLM provider", "err", err)
 		os.Exit(1)
 	}
-	if apiKey != "" {
-		llmService = llm.NewService(openaiAdapter.NewClient(apiKey))
+	limits, price, err := llmprovider.BudgetFromEnv()
+	if err != nil {
+		log.Error("failed to configure LLM budget", "err", err)
+		os.Exit(1)
+	}
+	threshold, coolDown, err := llmprovider.BreakerFromEnv()
+	if err != nil {
+		log.Error("failed to configure LLM circuit breaker", "err", err)
+		os.Exit(1)
+	}
+	// The keyword fallback keeps insights tagged while the LLM is down,
+	// unconfigured or over a tenant's budget; a "fallback" reenrich job
+	// redoes them once it's back. The breaker lives as long as this warm
+	// container, so an outage stops costing retries after a few messages.
+	// The cache saves paying twice for a passage imported from both sources.
+	llmService := llm.NewService(llmClient).
+		WithFallback(llm.NewFallback(insightRepo)).
+		WithBudget(budget.NewService(insightRepo, limits, price)).
+		WithBreaker(llm.NewBreaker(threshold, coolDown)).
+		WithCache(insightRepo, llm.DefaultCacheTTL).
+		WithSettings(insightRepo)
+
+	// Same passage from another source or highlight: merge, flag or let
+	// through, per DUPLICATE_POLICY (flag by default).
+	duplicatePolicy, err := domain.ParseDuplicatePolicy(os.Getenv("DUPLICATE_POLICY"))
+	if err != nil {
+		log.Error("invalid DUPLICATE_POLICY", "err", err)
+		os.Exit(1)
 	}
+	duplicates := duplicate.NewService(insightRepo, insightRepo, duplicatePolicy, domain.DefaultDuplicateThreshold)
 
-	svc := insight.NewService(insightRepo, llmService, domainEvents)
+	svc := insight.NewService(insightRepo, llmService, domainEvents, duplicates, nil)
 
 	h := workersqs.NewHandler(svc, dlqPublisher)
 	lambda.Start(h.Handle)
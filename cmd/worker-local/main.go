@@ -12,10 +12,13 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 
 	workersqs "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/sqs/worker"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/llmprovider"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/memory"
-	openaiAdapter "github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/openai"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/llm"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/logging"
 )
 
@@ -73,12 +76,42 @@ func main() {
 	dlqPublisher := memory.NewDLQNoopAdapter()
 	domainEvents := memory.NewDomainEventNoopAdapter()
 
-	var llmService *llm.Service
-	if apiKey := strings.TrimSpace(os.Getenv("OPENAI_API_KEY")); apiKey != "" {
-		llmService = llm.NewService(openaiAdapter.NewClient(apiKey))
+	// No SSM here: keys must be literal values. LLM_PROVIDER=local with
+	// LLM_MODEL set enriches against a local Ollama without any key at all.
+	llmClient, err := llmprovider.FromEnv(ctx, nil)
+	if err != nil {
+		log.Error("failed to configure LLM provider", "err", err)
+		os.Exit(1)
+	}
+	threshold, coolDown, err := llmprovider.BreakerFromEnv()
+	if err != nil {
+		log.Error("failed to configure LLM circuit breaker", "err", err)
+		os.Exit(1)
+	}
+	// Against the noop repo the keyword fallback has no tag vocabulary to
+	// map onto, so without a provider it runs but yields no tags.
+	limits, price, err := llmprovider.BudgetFromEnv()
+	if err != nil {
+		log.Error("failed to configure LLM budget", "err", err)
+		os.Exit(1)
+	}
+	llmService := llm.NewService(llmClient).
+		WithFallback(llm.NewFallback(noopRepo)).
+		WithBudget(budget.NewService(memory.NewUsageNoopAdapter(), limits, price)).
+		WithBreaker(llm.NewBreaker(threshold, coolDown)).
+		WithCache(memory.NewEnrichmentCacheMemoryAdapter(), llm.DefaultCacheTTL).
+		WithSettings(memory.NewTenantSettingsNoopAdapter())
+
+	// Same passage from another source or highlight: merge, flag or let
+	// through, per DUPLICATE_POLICY (flag by default).
+	duplicatePolicy, err := domain.ParseDuplicatePolicy(os.Getenv("DUPLICATE_POLICY"))
+	if err != nil {
+		log.Error("invalid DUPLICATE_POLICY", "err", err)
+		os.Exit(1)
 	}
+	duplicates := duplicate.NewService(noopRepo, memory.NewDuplicateNoopAdapter(), duplicatePolicy, domain.DefaultDuplicateThreshold)
 
-	svc := insight.NewService(noopRepo, llmService, domainEvents)
+	svc := insight.NewService(noopRepo, llmService, domainEvents, duplicates, nil)
 	h := workersqs.NewHandler(svc, dlqPublisher)
 
 	log.Info("invoking worker handler (local)",
@@ -86,10 +119,15 @@ func main() {
 		"records", len(ev.Records),
 	)
 
-	if err := h.Handle(ctx, ev); err != nil {
+	resp, err := h.Handle(ctx, ev)
+	if err != nil {
 		log.Error("worker handler returned error", "err", err)
 		os.Exit(1)
 	}
+	if len(resp.BatchItemFailures) > 0 {
+		log.Error("worker handler reported records for redelivery", "failures", resp.BatchItemFailures)
+		os.Exit(1)
+	}
 
 	log.Info("worker handler finished successfully")
 }
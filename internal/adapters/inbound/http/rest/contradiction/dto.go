@@ -0,0 +1,41 @@
+package contradiction
+
+import "time"
+
+type ResolveRequestDTO struct {
+	Note string `json:"note"`
+}
+
+type ResolutionDTO struct {
+	Note       string    `json:"note"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+type InsightDTO struct {
+	InsightID string `json:"insight_id"`
+	Text      string `json:"text"`
+}
+
+// ContradictionDTO is one contradicts edge in the direction it was
+// discovered; Resolution is null while it's open.
+type ContradictionDTO struct {
+	From         InsightDTO     `json:"from"`
+	To           InsightDTO     `json:"to"`
+	Confidence   float64        `json:"confidence"`
+	Rationale    string         `json:"rationale"`
+	DiscoveredAt time.Time      `json:"discovered_at"`
+	SharedTags   []string       `json:"shared_tags"`
+	Resolved     bool           `json:"resolved"`
+	Resolution   *ResolutionDTO `json:"resolution"`
+}
+
+// GroupDTO's Tag is empty for contradictions between insights that share
+// no tag. A pair sharing several tags appears in each of their groups.
+type GroupDTO struct {
+	Tag            string             `json:"tag"`
+	Contradictions []ContradictionDTO `json:"contradictions"`
+}
+
+type ListResponseDTO struct {
+	Groups []GroupDTO `json:"groups"`
+}
@@ -0,0 +1,89 @@
+package contradiction
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appcontradiction "github.com/marcogerstmann/insight-processing-platform/internal/application/contradiction"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Handler struct {
+	svc appcontradiction.Service
+}
+
+func NewHandler(svc appcontradiction.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// List is a user route: the caller's contradicts edges, grouped by the
+// tags the two insights share.
+func (h *Handler) List(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	groups, err := h.svc.List(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to list contradictions", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapGroupsToDTO(groups))
+}
+
+// Resolve is a user route marking the contradiction between :insightID
+// and :otherInsightID resolved, with an optional note. Resolving it again
+// replaces the note.
+func (h *Handler) Resolve(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	insightID := c.Param("insightID")
+	otherInsightID := c.Param("otherInsightID")
+
+	var req ResolveRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+
+	resolution, err := h.svc.Resolve(c.Request.Context(), tenantID, insightID, otherInsightID, req.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrResolutionNoteTooLong):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, ports.ErrContradictionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			slog.ErrorContext(c.Request.Context(), "failed to resolve contradiction",
+				"tenant_id", tenantID, "insight_id", insightID, "other_insight_id", otherInsightID, "err", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mapResolutionToDTO(resolution))
+}
+
+// Reopen is a user route clearing the pair's resolution.
+func (h *Handler) Reopen(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	insightID := c.Param("insightID")
+	otherInsightID := c.Param("otherInsightID")
+
+	if err := h.svc.Reopen(c.Request.Context(), tenantID, insightID, otherInsightID); err != nil {
+		if errors.Is(err, ports.ErrContradictionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "failed to reopen contradiction",
+			"tenant_id", tenantID, "insight_id", insightID, "other_insight_id", otherInsightID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
@@ -0,0 +1,142 @@
+package contradiction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeService struct {
+	groups    []domain.ContradictionGroup
+	err       error
+	called    bool
+	gotTenant string
+	gotPair   [2]string
+	gotNote   string
+}
+
+func (f *fakeService) List(_ context.Context, tenantID string) ([]domain.ContradictionGroup, error) {
+	f.called, f.gotTenant = true, tenantID
+	return f.groups, f.err
+}
+
+func (f *fakeService) Resolve(_ context.Context, tenantID, insightID, otherInsightID, note string) (domain.ContradictionResolution, error) {
+	f.called, f.gotTenant, f.gotPair, f.gotNote = true, tenantID, [2]string{insightID, otherInsightID}, note
+	return domain.ContradictionResolution{TenantID: tenantID, InsightID: insightID, OtherInsightID: otherInsightID, Note: note, ResolvedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}, f.err
+}
+
+func (f *fakeService) Reopen(_ context.Context, tenantID, insightID, otherInsightID string) error {
+	f.called, f.gotTenant, f.gotPair = true, tenantID, [2]string{insightID, otherInsightID}
+	return f.err
+}
+
+func doRequest(method, body string, route gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(method, "/v1/contradictions/a/b/resolution", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "insightID", Value: "a"}, {Key: "otherInsightID", Value: "b"}}
+	c.Set(auth.TenantIDKey, "t-1")
+	route(c)
+	return rec
+}
+
+func TestHandler_List_MapsTheGroups(t *testing.T) {
+	resolvedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	svc := &fakeService{groups: []domain.ContradictionGroup{
+		{Tag: "focus", Contradictions: []domain.Contradiction{{
+			Relationship: domain.Relationship{FromInsightID: "a", ToInsightID: "b", Type: domain.RelationContradicts, Confidence: 0.8, Rationale: "opposite advice"},
+			FromText:     "work in sprints",
+			ToText:       "work steadily",
+			SharedTags:   []string{"focus"},
+			Resolution:   &domain.ContradictionResolution{Note: "both, by task", ResolvedAt: resolvedAt},
+		}}},
+		{Contradictions: []domain.Contradiction{{Relationship: domain.Relationship{FromInsightID: "c", ToInsightID: "d"}}}},
+	}}
+
+	rec := doRequest(http.MethodGet, "", NewHandler(svc).List)
+
+	var body ListResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.gotTenant != "t-1" || len(body.Groups) != 2 {
+		t.Fatalf("status = %d body = %s, want t-1's two groups", rec.Code, rec.Body.String())
+	}
+	first := body.Groups[0].Contradictions[0]
+	if first.From.Text != "work in sprints" || first.To.InsightID != "b" || first.Rationale != "opposite advice" || !first.Resolved || first.Resolution.Note != "both, by task" {
+		t.Fatalf("first = %+v, want texts, rationale and the resolution", first)
+	}
+	if !strings.Contains(rec.Body.String(), `"shared_tags":[],"resolved":false,"resolution":null`) {
+		t.Fatalf("body = %s, want the open untagged contradiction with empty tags and a null resolution", rec.Body.String())
+	}
+}
+
+func TestHandler_List_ServiceFailure_Returns500(t *testing.T) {
+	rec := doRequest(http.MethodGet, "", NewHandler(&fakeService{err: errors.New("boom")}).List)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
+
+func TestHandler_Resolve_ReturnsTheResolution(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doRequest(http.MethodPut, `{"note":"both, by task"}`, NewHandler(svc).Resolve)
+
+	var body ResolutionDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.gotPair != [2]string{"a", "b"} || svc.gotTenant != "t-1" || body.Note != "both, by task" || body.ResolvedAt.IsZero() {
+		t.Fatalf("status = %d body = %s, want t-1's a-b resolved", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandler_Resolve_Errors(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		err  error
+		want int
+	}{
+		"malformed body":      {body: `{"note":`, want: http.StatusBadRequest},
+		"note too long":       {body: `{}`, err: domain.ErrResolutionNoteTooLong, want: http.StatusBadRequest},
+		"not a contradiction": {body: `{}`, err: ports.ErrContradictionNotFound, want: http.StatusNotFound},
+		"repository failure":  {body: `{}`, err: errors.New("boom"), want: http.StatusInternalServerError},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := doRequest(http.MethodPut, tc.body, NewHandler(&fakeService{err: tc.err}).Resolve)
+
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestHandler_Reopen(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doRequest(http.MethodDelete, "", NewHandler(svc).Reopen)
+
+	if rec.Code != http.StatusNoContent || svc.gotPair != [2]string{"a", "b"} {
+		t.Fatalf("status = %d pair = %v, want 204 for a-b", rec.Code, svc.gotPair)
+	}
+
+	rec = doRequest(http.MethodDelete, "", NewHandler(&fakeService{err: ports.ErrContradictionNotFound}).Reopen)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
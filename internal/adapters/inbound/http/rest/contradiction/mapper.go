@@ -0,0 +1,40 @@
+package contradiction
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapGroupsToDTO(groups []domain.ContradictionGroup) ListResponseDTO {
+	out := ListResponseDTO{Groups: make([]GroupDTO, 0, len(groups))}
+	for _, g := range groups {
+		group := GroupDTO{Tag: g.Tag, Contradictions: make([]ContradictionDTO, 0, len(g.Contradictions))}
+		for _, c := range g.Contradictions {
+			group.Contradictions = append(group.Contradictions, mapContradictionToDTO(c))
+		}
+		out.Groups = append(out.Groups, group)
+	}
+	return out
+}
+
+func mapContradictionToDTO(c domain.Contradiction) ContradictionDTO {
+	sharedTags := c.SharedTags
+	if sharedTags == nil {
+		sharedTags = []string{}
+	}
+	dto := ContradictionDTO{
+		From:         InsightDTO{InsightID: c.Relationship.FromInsightID, Text: c.FromText},
+		To:           InsightDTO{InsightID: c.Relationship.ToInsightID, Text: c.ToText},
+		Confidence:   c.Relationship.Confidence,
+		Rationale:    c.Relationship.Rationale,
+		DiscoveredAt: c.Relationship.DiscoveredAt,
+		SharedTags:   sharedTags,
+		Resolved:     c.Resolution != nil,
+	}
+	if c.Resolution != nil {
+		resolution := mapResolutionToDTO(*c.Resolution)
+		dto.Resolution = &resolution
+	}
+	return dto
+}
+
+func mapResolutionToDTO(r domain.ContradictionResolution) ResolutionDTO {
+	return ResolutionDTO{Note: r.Note, ResolvedAt: r.ResolvedAt}
+}
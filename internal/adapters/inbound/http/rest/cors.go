@@ -21,7 +21,7 @@ func corsMiddleware(allowedOrigins []string) gin.HandlerFunc {
 		if origin != "" && slices.Contains(allowedOrigins, origin) {
 			header := c.Writer.Header()
 			header.Set("Access-Control-Allow-Origin", origin)
-			header.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 			header.Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
 			// Signal caches that the response varies per origin, so one origin's
 			// allow header is never served to another.
@@ -0,0 +1,58 @@
+package digest
+
+import "time"
+
+type DigestInsightDTO struct {
+	InsightID string   `json:"insight_id"`
+	Source    string   `json:"source"`
+	Excerpt   string   `json:"excerpt"`
+	Tags      []string `json:"tags"`
+}
+
+type DigestRelationshipDTO struct {
+	FromInsightID string  `json:"from_insight_id"`
+	FromExcerpt   string  `json:"from_excerpt"`
+	ToInsightID   string  `json:"to_insight_id"`
+	ToExcerpt     string  `json:"to_excerpt"`
+	Type          string  `json:"type"`
+	Confidence    float64 `json:"confidence"`
+}
+
+type TagMoveDTO struct {
+	Tag   string  `json:"tag"`
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Delta float64 `json:"delta"`
+}
+
+type DigestPlanDTO struct {
+	PlanID            string `json:"plan_id"`
+	Tag               string `json:"tag"`
+	FocusSentence     string `json:"focus_sentence"`
+	Status            string `json:"status"`
+	Actions           int    `json:"actions"`
+	Requested         bool   `json:"requested"`
+	Resolved          bool   `json:"resolved"`
+	ActionsStarted    int    `json:"actions_started"`
+	ActionsFinished   int    `json:"actions_finished"`
+	CompletionPercent int    `json:"completion_percent"`
+}
+
+type DigestDTO struct {
+	Period            string                  `json:"period"`
+	Since             time.Time               `json:"since"`
+	GeneratedAt       time.Time               `json:"generated_at"`
+	Notified          bool                    `json:"notified"`
+	InsightCount      int                     `json:"insight_count"`
+	Insights          []DigestInsightDTO      `json:"insights"`
+	RelationshipCount int                     `json:"relationship_count"`
+	Relationships     []DigestRelationshipDTO `json:"relationships"`
+	TagMoves          []TagMoveDTO            `json:"tag_moves"`
+	Plans             []DigestPlanDTO         `json:"plans"`
+	Markdown          string                  `json:"markdown"`
+	HTML              string                  `json:"html"`
+}
+
+type ListResponseDTO struct {
+	Items []DigestDTO `json:"items"`
+}
@@ -0,0 +1,48 @@
+package digest
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appdigest "github.com/marcogerstmann/insight-processing-platform/internal/application/digest"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	svc appdigest.Service
+}
+
+func NewHandler(svc appdigest.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// List is a user route: up to ?limit= of the caller's past digests, daily
+// and weekly alike, newest first, each with its Markdown and HTML.
+func (h *Handler) List(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	limit := defaultLimit
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+			return
+		}
+	}
+
+	digests, err := h.svc.List(c.Request.Context(), tenantID, limit)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to list digests", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapListToDTO(digests))
+}
@@ -0,0 +1,103 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	digests   []domain.Digest
+	err       error
+	called    bool
+	gotTenant string
+	gotLimit  int
+}
+
+func (f *fakeService) Generate(context.Context, string, domain.DigestPeriod) (domain.Digest, error) {
+	return domain.Digest{}, nil
+}
+
+func (f *fakeService) List(_ context.Context, tenantID string, limit int) ([]domain.Digest, error) {
+	f.called, f.gotTenant, f.gotLimit = true, tenantID, limit
+	return f.digests, f.err
+}
+
+func doRequest(target string, svc *fakeService) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	c.Set(auth.TenantIDKey, "t-1")
+	NewHandler(svc).List(c)
+	return rec
+}
+
+func TestHandler_List_MapsTheDigests(t *testing.T) {
+	generatedAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := &fakeService{digests: []domain.Digest{{
+		Period:            domain.DigestWeekly,
+		Since:             generatedAt.AddDate(0, 0, -7),
+		GeneratedAt:       generatedAt,
+		Notified:          true,
+		InsightCount:      4,
+		Insights:          []domain.DigestInsight{{InsightID: "i-1", Source: "readwise", Excerpt: "work in sprints"}},
+		RelationshipCount: 1,
+		Relationships:     []domain.DigestRelationship{{FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}},
+		TagMoves:          []domain.TagMove{{Tag: "focus", From: 0.25, To: 0.75}},
+		Plans:             []domain.DigestPlan{{PlanID: "p-1", Tag: "focus", Status: domain.PlanStatusReady, Resolved: true, ActionsStarted: 1, CompletionPercent: 25}},
+		Markdown:          "# Weekly digest",
+		HTML:              "<h1>Weekly digest</h1>",
+	}}}
+
+	rec := doRequest("/v1/digests?limit=5", svc)
+
+	var body ListResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.gotTenant != "t-1" || svc.gotLimit != 5 || len(body.Items) != 1 {
+		t.Fatalf("status = %d body = %s, want t-1's digest at limit 5", rec.Code, rec.Body.String())
+	}
+	got := body.Items[0]
+	if got.Period != "weekly" || got.InsightCount != 4 || got.Insights[0].Excerpt != "work in sprints" ||
+		got.Relationships[0].Type != "supports" || got.TagMoves[0].Delta != 0.5 || got.Plans[0].Status != "ready" || !got.Plans[0].Resolved ||
+		got.Plans[0].ActionsStarted != 1 || got.Plans[0].CompletionPercent != 25 || !got.Notified || got.Markdown != "# Weekly digest" {
+		t.Fatalf("digest = %+v", got)
+	}
+	if !strings.Contains(rec.Body.String(), `"tags":[]`) {
+		t.Fatalf("body = %s, want empty tags, not null", rec.Body.String())
+	}
+}
+
+func TestHandler_List_DefaultsAndBoundsTheLimit(t *testing.T) {
+	svc := &fakeService{}
+	rec := doRequest("/v1/digests", svc)
+	if rec.Code != http.StatusOK || svc.gotLimit != defaultLimit || !strings.Contains(rec.Body.String(), `"items":[]`) {
+		t.Fatalf("status = %d limit = %d body = %s, want the default and an empty list", rec.Code, svc.gotLimit, rec.Body.String())
+	}
+
+	for _, limit := range []string{"0", "101", "many"} {
+		svc := &fakeService{}
+		rec := doRequest("/v1/digests?limit="+limit, svc)
+		if rec.Code != http.StatusBadRequest || svc.called {
+			t.Fatalf("limit=%s: status = %d, called = %v; want 400 without calling the service", limit, rec.Code, svc.called)
+		}
+	}
+}
+
+func TestHandler_List_ServiceFailure_Returns500(t *testing.T) {
+	rec := doRequest("/v1/digests", &fakeService{err: errors.New("boom")})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package digest
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapListToDTO(digests []domain.Digest) ListResponseDTO {
+	out := ListResponseDTO{Items: make([]DigestDTO, 0, len(digests))}
+	for _, d := range digests {
+		out.Items = append(out.Items, mapDigestToDTO(d))
+	}
+	return out
+}
+
+func mapDigestToDTO(d domain.Digest) DigestDTO {
+	dto := DigestDTO{
+		Period:            string(d.Period),
+		Since:             d.Since,
+		GeneratedAt:       d.GeneratedAt,
+		Notified:          d.Notified,
+		InsightCount:      d.InsightCount,
+		Insights:          make([]DigestInsightDTO, 0, len(d.Insights)),
+		RelationshipCount: d.RelationshipCount,
+		Relationships:     make([]DigestRelationshipDTO, 0, len(d.Relationships)),
+		TagMoves:          make([]TagMoveDTO, 0, len(d.TagMoves)),
+		Plans:             make([]DigestPlanDTO, 0, len(d.Plans)),
+		Markdown:          d.Markdown,
+		HTML:              d.HTML,
+	}
+	for _, in := range d.Insights {
+		tags := []string{}
+		if in.Tags != nil {
+			tags = in.Tags
+		}
+		dto.Insights = append(dto.Insights, DigestInsightDTO{InsightID: in.InsightID, Source: in.Source, Excerpt: in.Excerpt, Tags: tags})
+	}
+	for _, rel := range d.Relationships {
+		dto.Relationships = append(dto.Relationships, DigestRelationshipDTO{
+			FromInsightID: rel.FromInsightID,
+			FromExcerpt:   rel.FromExcerpt,
+			ToInsightID:   rel.ToInsightID,
+			ToExcerpt:     rel.ToExcerpt,
+			Type:          string(rel.Type),
+			Confidence:    rel.Confidence,
+		})
+	}
+	for _, m := range d.TagMoves {
+		dto.TagMoves = append(dto.TagMoves, TagMoveDTO{Tag: m.Tag, From: m.From, To: m.To, Delta: m.Delta()})
+	}
+	for _, p := range d.Plans {
+		dto.Plans = append(dto.Plans, DigestPlanDTO{
+			PlanID:            p.PlanID,
+			Tag:               p.Tag,
+			FocusSentence:     p.FocusSentence,
+			Status:            string(p.Status),
+			Actions:           p.Actions,
+			Requested:         p.Requested,
+			Resolved:          p.Resolved,
+			ActionsStarted:    p.ActionsStarted,
+			ActionsFinished:   p.ActionsFinished,
+			CompletionPercent: p.CompletionPercent,
+		})
+	}
+	return dto
+}
@@ -0,0 +1,20 @@
+package duplicate
+
+import "time"
+
+// ItemDTO is one flagged pair: insight_id was stored although it matched
+// duplicate_of_id. Both texts are as they were when it was flagged.
+type ItemDTO struct {
+	InsightID       string    `json:"insight_id"`
+	Source          string    `json:"source"`
+	Text            string    `json:"text"`
+	DuplicateOfID   string    `json:"duplicate_of_id"`
+	DuplicateOfText string    `json:"duplicate_of_text"`
+	Similarity      float64   `json:"similarity"`
+	DetectedAt      time.Time `json:"detected_at"`
+}
+
+type ListResponseDTO struct {
+	TenantID string    `json:"tenant_id"`
+	Items    []ItemDTO `json:"items"`
+}
@@ -0,0 +1,34 @@
+package duplicate
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
+)
+
+type Handler struct {
+	svc duplicate.Service
+}
+
+func NewHandler(svc duplicate.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// List is a user route: the caller's own tenant's flagged duplicate pairs,
+// most recent first, for review.
+func (h *Handler) List(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	duplicates, err := h.svc.List(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to list duplicates", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapDuplicatesToDTO(tenantID, duplicates))
+}
@@ -0,0 +1,65 @@
+package duplicate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	duplicate.Service
+	duplicates  []domain.Duplicate
+	err         error
+	gotTenantID string
+}
+
+func (f *fakeService) List(_ context.Context, tenantID string) ([]domain.Duplicate, error) {
+	f.gotTenantID = tenantID
+	return f.duplicates, f.err
+}
+
+func doList(h *Handler) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/duplicates", nil)
+	c.Set(auth.TenantIDKey, "t-1")
+	h.List(c)
+	return rec
+}
+
+func TestHandler_List_ReturnsTheJWTTenantsFlaggedPairs(t *testing.T) {
+	svc := &fakeService{duplicates: []domain.Duplicate{
+		{TenantID: "t-1", InsightID: "i-2", Source: "raindrop", DuplicateOfID: "i-1", Similarity: 0.92},
+	}}
+
+	rec := doList(NewHandler(svc))
+
+	if rec.Code != http.StatusOK || svc.gotTenantID != "t-1" {
+		t.Fatalf("status = %d, tenant = %q; want 200 for the JWT's tenant", rec.Code, svc.gotTenantID)
+	}
+	var body ListResponseDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Items) != 1 || body.Items[0].DuplicateOfID != "i-1" || body.Items[0].Similarity != 0.92 {
+		t.Fatalf("body = %+v", body)
+	}
+}
+
+func TestHandler_List_ServiceError_500(t *testing.T) {
+	rec := doList(NewHandler(&fakeService{err: errors.New("boom")}))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
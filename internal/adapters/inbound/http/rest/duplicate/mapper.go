@@ -0,0 +1,19 @@
+package duplicate
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapDuplicatesToDTO(tenantID string, duplicates []domain.Duplicate) ListResponseDTO {
+	items := make([]ItemDTO, len(duplicates))
+	for idx, d := range duplicates {
+		items[idx] = ItemDTO{
+			InsightID:       d.InsightID,
+			Source:          d.Source,
+			Text:            d.Text,
+			DuplicateOfID:   d.DuplicateOfID,
+			DuplicateOfText: d.DuplicateOfText,
+			Similarity:      d.Similarity,
+			DetectedAt:      d.DetectedAt,
+		}
+	}
+	return ListResponseDTO{TenantID: tenantID, Items: items}
+}
@@ -0,0 +1,63 @@
+package graph
+
+import "time"
+
+type NodeDTO struct {
+	InsightID string `json:"insight_id"`
+	Text      string `json:"text"`
+	// Depth is the node's distance in hops from the starting insight.
+	Depth int `json:"depth"`
+}
+
+// EdgeDTO is a relationship in the direction it was discovered.
+type EdgeDTO struct {
+	FromInsightID string  `json:"from_insight_id"`
+	ToInsightID   string  `json:"to_insight_id"`
+	Type          string  `json:"type"`
+	Confidence    float64 `json:"confidence"`
+	Rationale     string  `json:"rationale"`
+}
+
+type NeighborhoodResponseDTO struct {
+	Nodes []NodeDTO `json:"nodes"`
+	Edges []EdgeDTO `json:"edges"`
+	// Truncated is true if the node cap cut the neighborhood short.
+	Truncated bool `json:"truncated"`
+}
+
+// PathResponseDTO lists the path's nodes from start to end; edges[i]
+// joins nodes[i] and nodes[i+1].
+type PathResponseDTO struct {
+	Nodes []NodeDTO `json:"nodes"`
+	Edges []EdgeDTO `json:"edges"`
+}
+
+type RankedInsightDTO struct {
+	InsightID string `json:"insight_id"`
+	Text      string `json:"text"`
+	// ClusterID is 0 for an insight in no cluster.
+	ClusterID  int     `json:"cluster_id"`
+	Centrality float64 `json:"centrality"`
+	Bridging   float64 `json:"bridging"`
+}
+
+// RankedInsightsResponseDTO's ComputedAt is when the analytics were last
+// computed, null (and the list empty) if they never have been.
+type RankedInsightsResponseDTO struct {
+	ComputedAt *time.Time         `json:"computed_at"`
+	Insights   []RankedInsightDTO `json:"insights"`
+}
+
+type ClusterDTO struct {
+	ID int `json:"id"`
+	// Size counts every insight in the cluster; InsightIDs lists at most
+	// domain.MaxClusterMembers, most central first.
+	Size       int      `json:"size"`
+	InsightIDs []string `json:"insight_ids"`
+	Tags       []string `json:"tags"`
+}
+
+type ClustersResponseDTO struct {
+	ComputedAt *time.Time   `json:"computed_at"`
+	Clusters   []ClusterDTO `json:"clusters"`
+}
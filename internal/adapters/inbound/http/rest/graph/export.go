@@ -0,0 +1,315 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// exportFormat is one of the ?format= values Export accepts.
+type exportFormat struct {
+	contentType string
+	extension   string
+	write       func(io.Writer, domain.GraphExport) error
+}
+
+var exportFormats = map[string]exportFormat{
+	"graphml":   {"application/graphml+xml", "graphml", writeGraphML},
+	"gexf":      {"application/gexf+xml", "gexf", writeGEXF},
+	"cytoscape": {"application/json", "json", writeCytoscape},
+}
+
+// insightTags is what every format puts in a node's "tags" attribute: a
+// comma-separated list, since none of them has a list type Gephi reads.
+func insightTags(insight domain.Insight) string {
+	if insight.Enrichment == nil {
+		return ""
+	}
+	return strings.Join(insight.Enrichment.Tags, ",")
+}
+
+func edgeID(i int) string {
+	return "e" + strconv.Itoa(i)
+}
+
+// GraphML (http://graphml.graphdrawing.org/): node and edge attributes
+// are <data> elements keyed by the <key> declarations up front.
+
+type graphMLKey struct {
+	XMLName  xml.Name `xml:"key"`
+	ID       string   `xml:"id,attr"`
+	For      string   `xml:"for,attr"`
+	AttrName string   `xml:"attr.name,attr"`
+	AttrType string   `xml:"attr.type,attr"`
+}
+
+type graphMLNode struct {
+	XMLName xml.Name      `xml:"node"`
+	ID      string        `xml:"id,attr"`
+	Data    []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	XMLName xml.Name      `xml:"edge"`
+	ID      string        `xml:"id,attr"`
+	Source  string        `xml:"source,attr"`
+	Target  string        `xml:"target,attr"`
+	Data    []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+var graphMLKeys = []graphMLKey{
+	{ID: "text", For: "node", AttrName: "text", AttrType: "string"},
+	{ID: "source", For: "node", AttrName: "source", AttrType: "string"},
+	{ID: "tags", For: "node", AttrName: "tags", AttrType: "string"},
+	{ID: "type", For: "edge", AttrName: "type", AttrType: "string"},
+	{ID: "weight", For: "edge", AttrName: "weight", AttrType: "double"},
+	{ID: "rationale", For: "edge", AttrName: "rationale", AttrType: "string"},
+}
+
+func writeGraphML(w io.Writer, export domain.GraphExport) error {
+	x := newXMLStream(w)
+	x.start("graphml", attr("xmlns", "http://graphml.graphdrawing.org/xmlns"))
+	for _, key := range graphMLKeys {
+		x.encode(key)
+	}
+	x.start("graph", attr("id", "insights"), attr("edgedefault", "directed"))
+	for _, insight := range export.Insights {
+		x.encode(graphMLNode{ID: insight.ID, Data: []graphMLData{
+			{Key: "text", Value: insight.Text},
+			{Key: "source", Value: insight.Source},
+			{Key: "tags", Value: insightTags(insight)},
+		}})
+	}
+	for i, rel := range export.Relationships {
+		x.encode(graphMLEdge{ID: edgeID(i), Source: rel.FromInsightID, Target: rel.ToInsightID, Data: []graphMLData{
+			{Key: "type", Value: string(rel.Type)},
+			{Key: "weight", Value: strconv.FormatFloat(rel.Confidence, 'f', -1, 64)},
+			{Key: "rationale", Value: rel.Rationale},
+		}})
+	}
+	x.end("graph")
+	x.end("graphml")
+	return x.close()
+}
+
+// GEXF 1.3 (https://gexf.net/): Gephi's native format. Attributes are
+// declared per class and referenced by id; edges carry their type as
+// the label and their confidence as the weight.
+
+type gexfAttributes struct {
+	XMLName    xml.Name        `xml:"attributes"`
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	XMLName   xml.Name       `xml:"node"`
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	XMLName   xml.Name       `xml:"edge"`
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Label     string         `xml:"label,attr"`
+	Weight    float64        `xml:"weight,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+var gexfAttributeClasses = []gexfAttributes{
+	{Class: "node", Attributes: []gexfAttribute{
+		{ID: "source", Title: "source", Type: "string"},
+		{ID: "tags", Title: "tags", Type: "string"},
+	}},
+	{Class: "edge", Attributes: []gexfAttribute{
+		{ID: "rationale", Title: "rationale", Type: "string"},
+	}},
+}
+
+func writeGEXF(w io.Writer, export domain.GraphExport) error {
+	x := newXMLStream(w)
+	x.start("gexf", attr("xmlns", "http://gexf.net/1.3"), attr("version", "1.3"))
+	x.start("graph", attr("defaultedgetype", "directed"))
+	for _, class := range gexfAttributeClasses {
+		x.encode(class)
+	}
+	x.start("nodes")
+	for _, insight := range export.Insights {
+		x.encode(gexfNode{ID: insight.ID, Label: insight.Text, AttValues: []gexfAttValue{
+			{For: "source", Value: insight.Source},
+			{For: "tags", Value: insightTags(insight)},
+		}})
+	}
+	x.end("nodes")
+	x.start("edges")
+	for i, rel := range export.Relationships {
+		x.encode(gexfEdge{
+			ID:        edgeID(i),
+			Source:    rel.FromInsightID,
+			Target:    rel.ToInsightID,
+			Label:     string(rel.Type),
+			Weight:    rel.Confidence,
+			AttValues: []gexfAttValue{{For: "rationale", Value: rel.Rationale}},
+		})
+	}
+	x.end("edges")
+	x.end("graph")
+	x.end("gexf")
+	return x.close()
+}
+
+// xmlStream writes a document an element at a time: the enclosing tags as
+// tokens, each node and edge encoded on its own, so the export is never
+// held twice, once as insights and again as a document. The first error
+// sticks and is what close returns.
+type xmlStream struct {
+	enc *xml.Encoder
+	err error
+}
+
+func newXMLStream(w io.Writer) *xmlStream {
+	x := &xmlStream{enc: xml.NewEncoder(w)}
+	x.enc.Indent("", "  ")
+	_, x.err = io.WriteString(w, xml.Header)
+	return x
+}
+
+func attr(name, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+func (x *xmlStream) start(name string, attrs ...xml.Attr) {
+	if x.err == nil {
+		x.err = x.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+	}
+}
+
+func (x *xmlStream) end(name string) {
+	if x.err == nil {
+		x.err = x.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	}
+}
+
+func (x *xmlStream) encode(v any) {
+	if x.err == nil {
+		x.err = x.enc.Encode(v)
+	}
+}
+
+func (x *xmlStream) close() error {
+	if x.err != nil {
+		return fmt.Errorf("encode xml: %w", x.err)
+	}
+	return x.enc.Close()
+}
+
+// Cytoscape.js elements JSON (https://js.cytoscape.org/#notation/elements-json),
+// which Cytoscape desktop imports as well:
+// {"elements":{"nodes":[...],"edges":[...]}}, each element encoded on its
+// own as it's written.
+
+type cytoscapeNode struct {
+	Data cytoscapeNodeData `json:"data"`
+}
+
+type cytoscapeNodeData struct {
+	ID     string   `json:"id"`
+	Label  string   `json:"label"`
+	Source string   `json:"source"`
+	Tags   []string `json:"tags"`
+}
+
+type cytoscapeEdge struct {
+	Data cytoscapeEdgeData `json:"data"`
+}
+
+type cytoscapeEdgeData struct {
+	ID        string  `json:"id"`
+	Source    string  `json:"source"`
+	Target    string  `json:"target"`
+	Type      string  `json:"type"`
+	Weight    float64 `json:"weight"`
+	Rationale string  `json:"rationale"`
+}
+
+func writeCytoscape(w io.Writer, export domain.GraphExport) error {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	// list writes one JSON array of n elements, element(i) encoding the
+	// i-th. Encode's trailing newline separates them like whitespace.
+	list := func(n int, element func(i int) any) error {
+		buf.WriteByte('[')
+		for i := range n {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := enc.Encode(element(i)); err != nil {
+				return fmt.Errorf("encode json: %w", err)
+			}
+		}
+		_, err := buf.WriteString("]")
+		return err
+	}
+
+	buf.WriteString(`{"elements":{"nodes":`)
+	err := list(len(export.Insights), func(i int) any {
+		insight := export.Insights[i]
+		// Cytoscape's own "source" is an edge's tail; on a node it's
+		// just data, so the insight's source can keep the name.
+		tags := []string{}
+		if insight.Enrichment != nil {
+			tags = insight.Enrichment.Tags
+		}
+		return cytoscapeNode{Data: cytoscapeNodeData{
+			ID:     insight.ID,
+			Label:  insight.Text,
+			Source: insight.Source,
+			Tags:   tags,
+		}}
+	})
+	if err != nil {
+		return err
+	}
+	buf.WriteString(`,"edges":`)
+	err = list(len(export.Relationships), func(i int) any {
+		rel := export.Relationships[i]
+		return cytoscapeEdge{Data: cytoscapeEdgeData{
+			ID:        edgeID(i),
+			Source:    rel.FromInsightID,
+			Target:    rel.ToInsightID,
+			Type:      string(rel.Type),
+			Weight:    rel.Confidence,
+			Rationale: rel.Rationale,
+		}}
+	})
+	if err != nil {
+		return err
+	}
+	buf.WriteString("}}\n")
+	return buf.Flush()
+}
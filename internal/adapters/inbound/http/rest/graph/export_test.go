@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func sampleExport() domain.GraphExport {
+	return domain.GraphExport{
+		Insights: []domain.Insight{
+			{ID: "i-1", Source: "readwise", Text: "Less is <more> & better", Enrichment: &domain.Enrichment{Tags: []string{"design", "focus"}}},
+			{ID: "i-2", Source: "raindrop", Text: "Do one thing"},
+		},
+		Relationships: []domain.Relationship{
+			{FromInsightID: "i-2", ToInsightID: "i-1", Type: domain.RelationExampleOf, Confidence: 0.75, Rationale: "a case of it"},
+		},
+	}
+}
+
+// graphMLDoc, gexfDoc and cytoscapeDoc read back the whole documents the
+// writers stream element by element.
+
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type gexfDoc struct {
+	XMLName xml.Name `xml:"gexf"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	Version string   `xml:"version,attr"`
+	Graph   struct {
+		DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+		Attributes      []gexfAttributes `xml:"attributes"`
+		Nodes           []gexfNode       `xml:"nodes>node"`
+		Edges           []gexfEdge       `xml:"edges>edge"`
+	} `xml:"graph"`
+}
+
+type cytoscapeDoc struct {
+	Elements struct {
+		Nodes []cytoscapeNode `json:"nodes"`
+		Edges []cytoscapeEdge `json:"edges"`
+	} `json:"elements"`
+}
+
+func TestWriteGraphML_RoundTripsNodesAndTypedWeightedEdges(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGraphML(&buf, sampleExport()); err != nil {
+		t.Fatalf("writeGraphML: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Fatalf("output doesn't start with the XML header: %q", buf.String()[:40])
+	}
+
+	var doc graphMLDoc
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if doc.XMLNS != "http://graphml.graphdrawing.org/xmlns" || doc.Graph.EdgeDefault != "directed" {
+		t.Fatalf("doc = %+v, want a directed GraphML graph", doc)
+	}
+	if len(doc.Graph.Nodes) != 2 || doc.Graph.Nodes[0].Data[0].Value != "Less is <more> & better" || doc.Graph.Nodes[0].Data[2].Value != "design,focus" {
+		t.Fatalf("nodes = %+v, want text escaped and tags joined", doc.Graph.Nodes)
+	}
+	edge := doc.Graph.Edges[0]
+	if edge.Source != "i-2" || edge.Target != "i-1" || edge.Data[0].Value != "example_of" || edge.Data[1].Value != "0.75" {
+		t.Fatalf("edge = %+v, want i-2 -> i-1 example_of weighted 0.75", edge)
+	}
+}
+
+func TestWriteGEXF_RoundTripsNodesAndTypedWeightedEdges(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGEXF(&buf, sampleExport()); err != nil {
+		t.Fatalf("writeGEXF: %v", err)
+	}
+
+	var doc gexfDoc
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if doc.Version != "1.3" || len(doc.Graph.Attributes) != 2 {
+		t.Fatalf("doc = %+v, want GEXF 1.3 with node and edge attributes", doc)
+	}
+	if len(doc.Graph.Nodes) != 2 || doc.Graph.Nodes[1].Label != "Do one thing" || doc.Graph.Nodes[1].AttValues[0].Value != "raindrop" {
+		t.Fatalf("nodes = %+v, want labels and sources", doc.Graph.Nodes)
+	}
+	edge := doc.Graph.Edges[0]
+	if edge.Label != "example_of" || edge.Weight != 0.75 || edge.AttValues[0].Value != "a case of it" {
+		t.Fatalf("edge = %+v, want the type as label and confidence as weight", edge)
+	}
+}
+
+func TestWriteCytoscape_EmptyGraph_EmptyLists(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCytoscape(&buf, domain.GraphExport{}); err != nil {
+		t.Fatalf("writeCytoscape: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != `{"elements":{"nodes":[],"edges":[]}}` {
+		t.Fatalf("body = %s, want empty lists", got)
+	}
+}
+
+func TestWriteCytoscape_ElementsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCytoscape(&buf, sampleExport()); err != nil {
+		t.Fatalf("writeCytoscape: %v", err)
+	}
+
+	var doc cytoscapeDoc
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	nodes, edges := doc.Elements.Nodes, doc.Elements.Edges
+	if len(nodes) != 2 || len(nodes[0].Data.Tags) != 2 || nodes[1].Data.Tags == nil {
+		t.Fatalf("nodes = %+v, want tags as a list, empty rather than null", nodes)
+	}
+	if len(edges) != 1 || edges[0].Data.Source != "i-2" || edges[0].Data.Type != "example_of" || edges[0].Data.Weight != 0.75 {
+		t.Fatalf("edges = %+v, want the typed weighted edge", edges)
+	}
+}
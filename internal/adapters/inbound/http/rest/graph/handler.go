@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appgraph "github.com/marcogerstmann/insight-processing-platform/internal/application/graph"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/graphanalytics"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// defaultRankLimit is how many insights RankedInsights lists without a
+// ?limit=.
+const defaultRankLimit = 20
+
+type Handler struct {
+	svc       appgraph.Service
+	analytics graphanalytics.Service
+}
+
+func NewHandler(svc appgraph.Service, analytics graphanalytics.Service) *Handler {
+	return &Handler{svc: svc, analytics: analytics}
+}
+
+// Neighborhood is a user route: the insights within ?depth= hops (1 by
+// default) of :insightID in the caller's tenant. ?types= (comma-separated
+// relation types) and ?min_confidence= narrow the edges followed.
+func (h *Handler) Neighborhood(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	insightID := c.Param("insightID")
+
+	depth := 1
+	if raw := c.Query("depth"); raw != "" {
+		var err error
+		if depth, err = strconv.Atoi(raw); err != nil || depth < 1 || depth > domain.MaxGraphDepth {
+			c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrGraphDepthOutOfRange.Error()})
+			return
+		}
+	}
+	filter, ok := bindFilter(c)
+	if !ok {
+		return
+	}
+
+	graph, err := h.svc.Neighborhood(c.Request.Context(), tenantID, insightID, depth, filter)
+	if err != nil {
+		if errors.Is(err, ports.ErrInsightNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "insight not found"})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "failed to traverse insight graph",
+			"tenant_id", tenantID, "insight_id", insightID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapNeighborhoodToDTO(graph))
+}
+
+// Path is a user route: a shortest path from ?from= to ?to= in the
+// caller's tenant, with the same filters as Neighborhood. 404 if either
+// insight is missing or no path is found within the search bounds.
+func (h *Handler) Path(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	fromID, toID := c.Query("from"), c.Query("to")
+	if fromID == "" || toID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to are required"})
+		return
+	}
+	filter, ok := bindFilter(c)
+	if !ok {
+		return
+	}
+
+	path, err := h.svc.Path(c.Request.Context(), tenantID, fromID, toID, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrInsightNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "insight not found"})
+		case errors.Is(err, domain.ErrNoPath):
+			c.JSON(http.StatusNotFound, gin.H{"error": domain.ErrNoPath.Error()})
+		default:
+			slog.ErrorContext(c.Request.Context(), "failed to find insight path",
+				"tenant_id", tenantID, "from_insight_id", fromID, "to_insight_id", toID, "err", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mapPathToDTO(path))
+}
+
+// Export is a user route: the caller's whole graph as a download in
+// ?format= graphml, gexf or cytoscape, or only the insights tagged ?tag=
+// (normalized as in tagmap.Handler.Related) and the edges between them.
+func (h *Handler) Export(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	format, ok := exportFormats[c.Query("format")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be graphml, gexf or cytoscape"})
+		return
+	}
+
+	var tag domain.Tag
+	if raw := c.Query("tag"); raw != "" {
+		if tag, ok = domain.NormalizeTag(raw); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag"})
+			return
+		}
+	}
+
+	export, err := h.svc.Export(c.Request.Context(), tenantID, string(tag))
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to export insight graph", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="insights.`+format.extension+`"`)
+	c.Header("Content-Type", format.contentType)
+	c.Status(http.StatusOK)
+	// The status is already sent, so a failure here can only be logged.
+	if err := format.write(c.Writer, export); err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to write insight graph export", "tenant_id", tenantID, "err", err)
+	}
+}
+
+// RankedInsights is a user route: the caller's most central insights
+// (?rank=central, by PageRank) or the ones bridging separate clusters
+// (?rank=bridge), from the last analytics run, up to ?limit=.
+func (h *Handler) RankedInsights(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	rank := c.Query("rank")
+	if rank != "central" && rank != "bridge" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rank must be central or bridge"})
+		return
+	}
+	limit := defaultRankLimit
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > domain.MaxRankedInsights {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(domain.MaxRankedInsights)})
+			return
+		}
+	}
+
+	analytics, err := h.analytics.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to get graph analytics", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	ranked := analytics.Central
+	if rank == "bridge" {
+		ranked = analytics.Bridges
+	}
+	c.JSON(http.StatusOK, mapRankedInsightsToDTO(analytics, ranked[:min(len(ranked), limit)]))
+}
+
+// Clusters is a user route: the communities the last analytics run found
+// in the caller's graph, largest first.
+func (h *Handler) Clusters(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	analytics, err := h.analytics.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to get graph analytics", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapClustersToDTO(analytics))
+}
+
+// bindFilter reads ?types= and ?min_confidence=, writing a 400 and
+// returning false if either is invalid.
+func bindFilter(c *gin.Context) (domain.GraphFilter, bool) {
+	var filter domain.GraphFilter
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			filter.Types = append(filter.Types, domain.RelationType(strings.TrimSpace(t)))
+		}
+	}
+	if raw := c.Query("min_confidence"); raw != "" {
+		minConfidence, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrConfidenceOutOfRange.Error()})
+			return domain.GraphFilter{}, false
+		}
+		filter.MinConfidence = minConfidence
+	}
+	if err := filter.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return domain.GraphFilter{}, false
+	}
+	return filter, true
+}
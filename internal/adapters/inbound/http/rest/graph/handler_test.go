@@ -0,0 +1,320 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeService struct {
+	graph     domain.Graph
+	err       error
+	called    bool
+	gotTenant string
+	gotIDs    [2]string
+	gotDepth  int
+	gotFilter domain.GraphFilter
+	export    domain.GraphExport
+}
+
+func (f *fakeService) Neighborhood(_ context.Context, tenantID, insightID string, depth int, filter domain.GraphFilter) (domain.Graph, error) {
+	f.called = true
+	f.gotTenant, f.gotIDs, f.gotDepth, f.gotFilter = tenantID, [2]string{insightID}, depth, filter
+	return f.graph, f.err
+}
+
+func (f *fakeService) Path(_ context.Context, tenantID, fromID, toID string, filter domain.GraphFilter) (domain.Graph, error) {
+	f.called = true
+	f.gotTenant, f.gotIDs, f.gotFilter = tenantID, [2]string{fromID, toID}, filter
+	return f.graph, f.err
+}
+
+func (f *fakeService) Export(_ context.Context, tenantID, tag string) (domain.GraphExport, error) {
+	f.called = true
+	f.gotTenant, f.gotIDs = tenantID, [2]string{tag}
+	return f.export, f.err
+}
+
+func doRequest(h *Handler, target string, params gin.Params) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	c.Params = params
+	c.Set(auth.TenantIDKey, "t-1")
+
+	if params != nil {
+		h.Neighborhood(c)
+	} else {
+		h.Path(c)
+	}
+	return rec
+}
+
+func TestHandler_Neighborhood_ParsesQueryAndMapsTheGraph(t *testing.T) {
+	svc := &fakeService{graph: domain.Graph{
+		Nodes: []domain.GraphNode{{InsightID: "a", Text: "root"}, {InsightID: "b", Text: "next", Depth: 1}},
+		Edges: []domain.GraphEdge{{FromInsightID: "b", ToInsightID: "a", Type: domain.RelationSupports, Confidence: 0.8}},
+	}}
+
+	rec := doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/insights/a/graph?depth=2&types=supports,%20extends&min_confidence=0.5",
+		gin.Params{{Key: "insightID", Value: "a"}})
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+	if svc.gotTenant != "t-1" || svc.gotIDs[0] != "a" || svc.gotDepth != 2 {
+		t.Fatalf("service got tenant=%q insight=%q depth=%d", svc.gotTenant, svc.gotIDs[0], svc.gotDepth)
+	}
+	if len(svc.gotFilter.Types) != 2 || svc.gotFilter.Types[1] != domain.RelationExtends || svc.gotFilter.MinConfidence != 0.5 {
+		t.Fatalf("filter = %+v, want supports+extends at 0.5", svc.gotFilter)
+	}
+	var body NeighborhoodResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Nodes) != 2 || body.Nodes[1].Depth != 1 || len(body.Edges) != 1 || body.Edges[0].FromInsightID != "b" {
+		t.Fatalf("body = %+v, want the mapped graph", body)
+	}
+}
+
+func TestHandler_Neighborhood_DefaultsToOneHop(t *testing.T) {
+	svc := &fakeService{}
+
+	doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/insights/a/graph", gin.Params{{Key: "insightID", Value: "a"}})
+
+	if svc.gotDepth != 1 {
+		t.Fatalf("depth = %d, want 1", svc.gotDepth)
+	}
+}
+
+func TestHandler_Neighborhood_BadQuery_Rejected400(t *testing.T) {
+	for _, query := range []string{"depth=0", "depth=4", "depth=two", "types=cousin_of", "min_confidence=high", "min_confidence=1.5"} {
+		t.Run(query, func(t *testing.T) {
+			svc := &fakeService{}
+
+			rec := doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/insights/a/graph?"+query, gin.Params{{Key: "insightID", Value: "a"}})
+
+			if rec.Code != http.StatusBadRequest || svc.called {
+				t.Fatalf("status = %d called = %v, want 400 without reaching the service", rec.Code, svc.called)
+			}
+		})
+	}
+}
+
+func TestHandler_Neighborhood_UnknownInsight_Returns404(t *testing.T) {
+	svc := &fakeService{err: ports.ErrInsightNotFound}
+
+	rec := doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/insights/a/graph", gin.Params{{Key: "insightID", Value: "a"}})
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestHandler_Path_ReturnsThePath(t *testing.T) {
+	svc := &fakeService{graph: domain.Graph{
+		Nodes: []domain.GraphNode{{InsightID: "a"}, {InsightID: "b", Depth: 1}},
+		Edges: []domain.GraphEdge{{FromInsightID: "a", ToInsightID: "b", Type: domain.RelationExtends}},
+	}}
+
+	rec := doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/graph/path?from=a&to=b", nil)
+
+	if rec.Code != http.StatusOK || svc.gotIDs != [2]string{"a", "b"} || svc.gotTenant != "t-1" {
+		t.Fatalf("status = %d ids = %v tenant = %q, want 200 for t-1's a -> b", rec.Code, svc.gotIDs, svc.gotTenant)
+	}
+	var body PathResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Nodes) != 2 || len(body.Edges) != 1 || body.Edges[0].Type != "extends" {
+		t.Fatalf("body = %+v, want the mapped path", body)
+	}
+}
+
+func TestHandler_Path_MissingEndpoints_Rejected400(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/graph/path?from=a", nil)
+
+	if rec.Code != http.StatusBadRequest || svc.called {
+		t.Fatalf("status = %d called = %v, want 400 without reaching the service", rec.Code, svc.called)
+	}
+}
+
+func TestHandler_Path_NoPath_Returns404(t *testing.T) {
+	svc := &fakeService{err: domain.ErrNoPath}
+
+	rec := doRequest(NewHandler(svc, &fakeAnalytics{}), "/v1/graph/path?from=a&to=b", nil)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func doExport(h *Handler, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/graph/export?"+query, nil)
+	c.Set(auth.TenantIDKey, "t-1")
+	h.Export(c)
+	return rec
+}
+
+func TestHandler_Export_StreamsTheRequestedFormatAsADownload(t *testing.T) {
+	svc := &fakeService{export: domain.GraphExport{Insights: []domain.Insight{{ID: "i-1", Text: "hello"}}}}
+
+	rec := doExport(NewHandler(svc, &fakeAnalytics{}), "format=gexf&tag=focus")
+
+	if rec.Code != http.StatusOK || svc.gotTenant != "t-1" || svc.gotIDs[0] != "focus" {
+		t.Fatalf("status = %d tenant = %q tag = %q, want 200 for t-1's focus subgraph", rec.Code, svc.gotTenant, svc.gotIDs[0])
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/gexf+xml" {
+		t.Fatalf("Content-Type = %q, want application/gexf+xml", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="insights.gexf"` {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+	if !strings.Contains(rec.Body.String(), `<node id="i-1" label="hello">`) {
+		t.Fatalf("body = %s, want the node", rec.Body.String())
+	}
+}
+
+func TestHandler_Export_NormalizesTheTag(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doExport(NewHandler(svc, &fakeAnalytics{}), "format=graphml&tag=Deep%20Work")
+
+	if rec.Code != http.StatusOK || svc.gotIDs[0] != "deep-work" {
+		t.Fatalf("status = %d tag = %q, want 200 for deep-work", rec.Code, svc.gotIDs[0])
+	}
+}
+
+func TestHandler_Export_InvalidTag_Rejected400(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doExport(NewHandler(svc, &fakeAnalytics{}), "format=graphml&tag=%21%21%21")
+
+	if rec.Code != http.StatusBadRequest || svc.called {
+		t.Fatalf("status = %d called = %v, want 400 without reaching the service", rec.Code, svc.called)
+	}
+}
+
+func TestHandler_Export_UnknownFormat_Rejected400(t *testing.T) {
+	for _, query := range []string{"", "format=dot"} {
+		svc := &fakeService{}
+
+		rec := doExport(NewHandler(svc, &fakeAnalytics{}), query)
+
+		if rec.Code != http.StatusBadRequest || svc.called {
+			t.Fatalf("%q: status = %d called = %v, want 400 without reaching the service", query, rec.Code, svc.called)
+		}
+	}
+}
+
+type fakeAnalytics struct {
+	analytics domain.GraphAnalytics
+	err       error
+	gotTenant string
+}
+
+func (f *fakeAnalytics) Recompute(context.Context, string, time.Time) (domain.GraphAnalytics, bool, error) {
+	return f.analytics, false, f.err
+}
+
+func (f *fakeAnalytics) Get(_ context.Context, tenantID string) (domain.GraphAnalytics, error) {
+	f.gotTenant = tenantID
+	return f.analytics, f.err
+}
+
+func doAnalytics(h *Handler, target string, route gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	c.Set(auth.TenantIDKey, "t-1")
+	route(c)
+	return rec
+}
+
+func analyticsFixture() domain.GraphAnalytics {
+	return domain.GraphAnalytics{
+		TenantID:   "t-1",
+		ComputedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		Clusters:   []domain.GraphCluster{{ID: 1, Size: 2, InsightIDs: []string{"a", "b"}, Tags: []string{"focus"}}},
+		Central:    []domain.InsightRank{{InsightID: "a", Centrality: 0.5, ClusterID: 1}, {InsightID: "b", Centrality: 0.3, ClusterID: 1}, {InsightID: "x", Centrality: 0.2}},
+		Bridges:    []domain.InsightRank{{InsightID: "x", Bridging: 0.5}},
+	}
+}
+
+func TestHandler_RankedInsights_ListsTheRequestedRanking(t *testing.T) {
+	analytics := &fakeAnalytics{analytics: analyticsFixture()}
+	h := NewHandler(&fakeService{}, analytics)
+
+	rec := doAnalytics(h, "/v1/graph/insights?rank=central&limit=2", h.RankedInsights)
+
+	var body RankedInsightsResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || analytics.gotTenant != "t-1" || len(body.Insights) != 2 || body.Insights[0].InsightID != "a" || body.ComputedAt == nil {
+		t.Fatalf("status = %d body = %s, want t-1's two most central", rec.Code, rec.Body.String())
+	}
+
+	rec = doAnalytics(h, "/v1/graph/insights?rank=bridge", h.RankedInsights)
+
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Insights) != 1 || body.Insights[0].InsightID != "x" || body.Insights[0].Bridging != 0.5 {
+		t.Fatalf("body = %s, want the bridge x", rec.Body.String())
+	}
+}
+
+func TestHandler_RankedInsights_BadQuery_Rejected400(t *testing.T) {
+	for _, query := range []string{"", "rank=popular", "rank=central&limit=0", "rank=central&limit=51", "rank=bridge&limit=ten"} {
+		h := NewHandler(&fakeService{}, &fakeAnalytics{})
+
+		rec := doAnalytics(h, "/v1/graph/insights?"+query, h.RankedInsights)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%q: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestHandler_Clusters_NeverComputed_ReturnsEmpty(t *testing.T) {
+	h := NewHandler(&fakeService{}, &fakeAnalytics{analytics: domain.GraphAnalytics{TenantID: "t-1"}})
+
+	rec := doAnalytics(h, "/v1/graph/clusters", h.Clusters)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"computed_at":null,"clusters":[]}` {
+		t.Fatalf("status = %d body = %s, want an empty list", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandler_Clusters_MapsTheClusters(t *testing.T) {
+	h := NewHandler(&fakeService{}, &fakeAnalytics{analytics: analyticsFixture()})
+
+	rec := doAnalytics(h, "/v1/graph/clusters", h.Clusters)
+
+	var body ClustersResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || len(body.Clusters) != 1 || body.Clusters[0].Size != 2 || body.Clusters[0].Tags[0] != "focus" {
+		t.Fatalf("status = %d body = %s, want the one cluster", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandler_Clusters_ServiceFailure_Returns500(t *testing.T) {
+	h := NewHandler(&fakeService{}, &fakeAnalytics{err: errors.New("boom")})
+
+	rec := doAnalytics(h, "/v1/graph/clusters", h.Clusters)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func mapNeighborhoodToDTO(g domain.Graph) NeighborhoodResponseDTO {
+	return NeighborhoodResponseDTO{
+		Nodes:     mapNodesToDTO(g.Nodes),
+		Edges:     mapEdgesToDTO(g.Edges),
+		Truncated: g.Truncated,
+	}
+}
+
+func mapPathToDTO(g domain.Graph) PathResponseDTO {
+	return PathResponseDTO{
+		Nodes: mapNodesToDTO(g.Nodes),
+		Edges: mapEdgesToDTO(g.Edges),
+	}
+}
+
+func mapNodesToDTO(nodes []domain.GraphNode) []NodeDTO {
+	out := make([]NodeDTO, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, NodeDTO{InsightID: n.InsightID, Text: n.Text, Depth: n.Depth})
+	}
+	return out
+}
+
+func mapEdgesToDTO(edges []domain.GraphEdge) []EdgeDTO {
+	out := make([]EdgeDTO, 0, len(edges))
+	for _, e := range edges {
+		out = append(out, EdgeDTO{
+			FromInsightID: e.FromInsightID,
+			ToInsightID:   e.ToInsightID,
+			Type:          string(e.Type),
+			Confidence:    e.Confidence,
+			Rationale:     e.Rationale,
+		})
+	}
+	return out
+}
+
+func mapRankedInsightsToDTO(analytics domain.GraphAnalytics, ranked []domain.InsightRank) RankedInsightsResponseDTO {
+	out := RankedInsightsResponseDTO{ComputedAt: computedAt(analytics), Insights: make([]RankedInsightDTO, 0, len(ranked))}
+	for _, r := range ranked {
+		out.Insights = append(out.Insights, RankedInsightDTO{
+			InsightID:  r.InsightID,
+			Text:       r.Text,
+			ClusterID:  r.ClusterID,
+			Centrality: r.Centrality,
+			Bridging:   r.Bridging,
+		})
+	}
+	return out
+}
+
+func mapClustersToDTO(analytics domain.GraphAnalytics) ClustersResponseDTO {
+	out := ClustersResponseDTO{ComputedAt: computedAt(analytics), Clusters: make([]ClusterDTO, 0, len(analytics.Clusters))}
+	for _, c := range analytics.Clusters {
+		tags := c.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		out.Clusters = append(out.Clusters, ClusterDTO{ID: c.ID, Size: c.Size, InsightIDs: c.InsightIDs, Tags: tags})
+	}
+	return out
+}
+
+func computedAt(analytics domain.GraphAnalytics) *time.Time {
+	if analytics.ComputedAt.IsZero() {
+		return nil
+	}
+	return &analytics.ComputedAt
+}
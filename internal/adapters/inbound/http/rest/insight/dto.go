@@ -2,16 +2,41 @@ package insight
 
 import "time"
 
+// EnrichmentDTO's optional fields are omitted for insights enriched before
+// they were recorded.
 type EnrichmentDTO struct {
-	Tags []string `json:"tags"`
+	Tags          []string  `json:"tags"`
+	Summary       string    `json:"summary,omitempty"`
+	Concepts      []string  `json:"concepts,omitempty"`
+	Sentiment     string    `json:"sentiment,omitempty"`
+	Actionability float64   `json:"actionability"`
+	Question      string    `json:"question,omitempty"`
+	EnrichedAt    time.Time `json:"enriched_at,omitzero"`
+	Fallback      bool      `json:"fallback,omitempty"`
+	Model         string    `json:"model,omitempty"`
+	PromptVersion string    `json:"prompt_version,omitempty"`
+	InputTokens   int64     `json:"input_tokens,omitempty"`
+	OutputTokens  int64     `json:"output_tokens,omitempty"`
+	LatencyMS     int64     `json:"latency_ms,omitempty"`
+}
+
+// SourceRefDTO is another import of the same passage, merged into this
+// insight instead of being stored on its own.
+type SourceRefDTO struct {
+	Source        string    `json:"source"`
+	InsightID     string    `json:"insight_id"`
+	HighlightedAt time.Time `json:"highlighted_at,omitzero"`
+	MergedAt      time.Time `json:"merged_at"`
 }
 
 type ResponseDTO struct {
-	ID         string         `json:"id"`
-	Source     string         `json:"source"`
-	Text       string         `json:"text"`
-	Notes      string         `json:"notes,omitempty"`
-	Enrichment *EnrichmentDTO `json:"enrichment,omitempty"`
+	ID            string         `json:"id"`
+	Source        string         `json:"source"`
+	Text          string         `json:"text"`
+	Notes         string         `json:"notes,omitempty"`
+	Language      string         `json:"language,omitempty"`
+	Enrichment    *EnrichmentDTO `json:"enrichment,omitempty"`
+	MergedSources []SourceRefDTO `json:"merged_sources,omitempty"`
 }
 
 type ListInsightsResponseDTO struct {
@@ -24,8 +49,11 @@ type CreateInsightRequestDTO struct {
 }
 
 type CreateInsightResponseDTO struct {
-	Inserted bool        `json:"inserted"`
-	Insight  ResponseDTO `json:"insight"`
+	Inserted bool `json:"inserted"`
+	// MergedInto is set when the text duplicated an existing insight and
+	// was merged into it rather than stored.
+	MergedInto string      `json:"merged_into,omitempty"`
+	Insight    ResponseDTO `json:"insight"`
 }
 
 type TagScoreComponentsDTO struct {
@@ -35,12 +63,18 @@ type TagScoreComponentsDTO struct {
 	Density   float64 `json:"density"`
 }
 
+// TagResponseDTO's trend_slope is the tag's score change per week over
+// the last four weeks of snapshots; trending and fading flag a slope
+// steep enough to matter. All three are zero until there's history.
 type TagResponseDTO struct {
 	Tag             string                `json:"tag"`
 	InsightCount    int                   `json:"insight_count"`
 	LastInsightAt   time.Time             `json:"last_insight_at"`
 	Score           float64               `json:"score"`
 	ScoreComponents TagScoreComponentsDTO `json:"score_components"`
+	TrendSlope      float64               `json:"trend_slope"`
+	Trending        bool                  `json:"trending"`
+	Fading          bool                  `json:"fading"`
 }
 
 type ListTagsResponseDTO struct {
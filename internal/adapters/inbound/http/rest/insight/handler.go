@@ -71,7 +71,8 @@ func (h *Handler) Create(c *gin.Context) {
 		status = http.StatusCreated
 	}
 	c.JSON(status, CreateInsightResponseDTO{
-		Inserted: res.Inserted,
-		Insight:  mapInsightToDTO(insight),
+		Inserted:   res.Inserted,
+		MergedInto: res.MergedInto,
+		Insight:    mapInsightToDTO(insight),
 	})
 }
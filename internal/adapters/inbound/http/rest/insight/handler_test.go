@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -26,6 +27,14 @@ func (f *fakeService) Process(_ context.Context, _ domain.Insight) (appinsight.R
 	return appinsight.Result{}, nil
 }
 
+func (f *fakeService) ProcessBatch(_ context.Context, insights []domain.Insight) []appinsight.BatchResult {
+	return make([]appinsight.BatchResult, len(insights))
+}
+
+func (f *fakeService) Reenrich(_ context.Context, i domain.Insight) (domain.Insight, error) {
+	return i, nil
+}
+
 func (f *fakeService) ListByTenantID(_ context.Context, _, tag string) ([]domain.Insight, error) {
 	f.listCalled = true
 	f.gotTag = tag
@@ -128,7 +137,7 @@ func TestHandler_ListByTenantID_ResponseShapeIdentical_WithAndWithoutFilter(t *t
 	if withoutFilter.TenantID != withFilter.TenantID {
 		t.Fatalf("tenant_id differs: %q vs %q", withoutFilter.TenantID, withFilter.TenantID)
 	}
-	if len(withoutFilter.Items) != len(withFilter.Items) || withoutFilter.Items[0] != withFilter.Items[0] {
+	if len(withoutFilter.Items) != len(withFilter.Items) || !reflect.DeepEqual(withoutFilter.Items[0], withFilter.Items[0]) {
 		t.Fatalf("item shape differs: %+v vs %+v", withoutFilter.Items, withFilter.Items)
 	}
 }
@@ -13,15 +13,37 @@ func newID() string {
 
 func mapInsightToDTO(i domain.Insight) ResponseDTO {
 	dto := ResponseDTO{
-		ID:     i.ID,
-		Source: i.Source,
-		Text:   i.Text,
-		Notes:  i.Notes,
+		ID:       i.ID,
+		Source:   i.Source,
+		Text:     i.Text,
+		Notes:    i.Notes,
+		Language: string(i.Language),
+	}
+
+	for _, ref := range i.MergedSources {
+		dto.MergedSources = append(dto.MergedSources, SourceRefDTO{
+			Source:        ref.Source,
+			InsightID:     ref.InsightID,
+			HighlightedAt: ref.HighlightedAt,
+			MergedAt:      ref.MergedAt,
+		})
 	}
 
 	if i.Enrichment != nil {
 		dto.Enrichment = &EnrichmentDTO{
-			Tags: i.Enrichment.Tags,
+			Tags:          i.Enrichment.Tags,
+			Summary:       i.Enrichment.Summary,
+			Concepts:      i.Enrichment.Concepts,
+			Sentiment:     string(i.Enrichment.Sentiment),
+			Actionability: i.Enrichment.Actionability,
+			Question:      i.Enrichment.Question,
+			EnrichedAt:    i.Enrichment.EnrichedAt,
+			Fallback:      i.Enrichment.Fallback,
+			Model:         i.Enrichment.Model,
+			PromptVersion: i.Enrichment.PromptVersion,
+			InputTokens:   i.Enrichment.InputTokens,
+			OutputTokens:  i.Enrichment.OutputTokens,
+			LatencyMS:     i.Enrichment.Latency.Milliseconds(),
 		}
 	}
 
@@ -50,6 +72,9 @@ func mapTagsToDTO(tenantID string, tags []domain.TagSummary) ListTagsResponseDTO
 				Freshness: t.ScoreComponents.Freshness,
 				Density:   t.ScoreComponents.Density,
 			},
+			TrendSlope: t.Trend.Slope,
+			Trending:   t.Trend.Trending,
+			Fading:     t.Trend.Fading,
 		}
 	}
 	return ListTagsResponseDTO{TenantID: tenantID, Items: items}
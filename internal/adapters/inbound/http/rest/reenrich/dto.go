@@ -0,0 +1,28 @@
+package reenrich
+
+import "time"
+
+// CreateReenrichJobRequestDTO is POST /v1/admin/reenrich's body.
+// EnrichedBefore is required for scope "before" and rejected otherwise;
+// Model and PromptVersion likewise, at least one of them, for scope
+// "version".
+type CreateReenrichJobRequestDTO struct {
+	Scope          string     `json:"scope"`
+	EnrichedBefore *time.Time `json:"enriched_before"`
+	Model          string     `json:"model"`
+	PromptVersion  string     `json:"prompt_version"`
+}
+
+type JobDTO struct {
+	ID             string     `json:"id"`
+	Scope          string     `json:"scope"`
+	EnrichedBefore *time.Time `json:"enriched_before,omitempty"`
+	Model          string     `json:"model,omitempty"`
+	PromptVersion  string     `json:"prompt_version,omitempty"`
+	Status         string     `json:"status"`
+	Processed      int        `json:"processed"`
+	Enriched       int        `json:"enriched"`
+	Failed         int        `json:"failed"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,73 @@
+package reenrich
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appreenrich "github.com/marcogerstmann/insight-processing-platform/internal/application/reenrich"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Handler struct {
+	svc appreenrich.Service
+}
+
+func NewHandler(svc appreenrich.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Create is a user route: the tenant comes from the JWT, so "admin" here
+// means administering your own tenant's data, not anyone else's.
+//
+// Deliberately async, same as weeklyplan.Handler.Create: the job is
+// persisted as pending and the reenrich command pair works through it, so
+// the request returns 202 instead of blocking on hundreds of LLM calls —
+// and the REST Lambda never needs an OpenAI key.
+func (h *Handler) Create(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	var req CreateReenrichJobRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+
+	job := mapCreateRequestToDomain(tenantID, req)
+	if err := job.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.Request(c.Request.Context(), job); err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to request reenrich job",
+			"tenant_id", tenantID, "job_id", job.ID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, mapJobToDTO(job))
+}
+
+// Get is a user route: a job's progress so far, for polling after Create.
+func (h *Handler) Get(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	jobID := c.Param("jobID")
+
+	job, err := h.svc.Get(c.Request.Context(), tenantID, jobID)
+	if err != nil {
+		if errors.Is(err, ports.ErrReenrichJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "failed to get reenrich job",
+			"tenant_id", tenantID, "job_id", jobID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapJobToDTO(job))
+}
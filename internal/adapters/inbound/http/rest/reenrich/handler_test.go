@@ -0,0 +1,126 @@
+package reenrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeService struct {
+	requested []domain.ReenrichJob
+	getJob    domain.ReenrichJob
+	err       error
+}
+
+func (f *fakeService) Request(_ context.Context, job domain.ReenrichJob) error {
+	f.requested = append(f.requested, job)
+	return f.err
+}
+
+func (f *fakeService) Get(_ context.Context, _, _ string) (domain.ReenrichJob, error) {
+	return f.getJob, f.err
+}
+
+func (f *fakeService) Resume(_ context.Context, _ string) ([]domain.ReenrichJob, error) {
+	return nil, nil
+}
+
+func doCreateRequest(h *Handler, body string) (*httptest.ResponseRecorder, map[string]any) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/reenrich", bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set(auth.TenantIDKey, "t-1")
+
+	h.Create(c)
+
+	var respBody map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &respBody)
+	return rec, respBody
+}
+
+func TestHandler_Create_HappyPath_Returns202WithPendingJob(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec, body := doCreateRequest(h, `{"scope":"before","enriched_before":"2026-01-01T00:00:00Z"}`)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d, body=%v", rec.Code, http.StatusAccepted, body)
+	}
+	if len(svc.requested) != 1 || svc.requested[0].TenantID != "t-1" || svc.requested[0].EnrichedBefore.IsZero() {
+		t.Fatalf("requested = %+v, want one job for t-1 with a cutoff", svc.requested)
+	}
+	if body["status"] != "pending" || body["id"] == "" {
+		t.Fatalf("response body = %v, want non-empty id and status=pending", body)
+	}
+}
+
+func TestHandler_Create_VersionScope_CarriesModelAndPromptVersion(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec, body := doCreateRequest(h, `{"scope":"version","model":"gpt-4o-mini","prompt_version":"enrich-v2"}`)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d, body=%v", rec.Code, http.StatusAccepted, body)
+	}
+	if len(svc.requested) != 1 || svc.requested[0].Model != "gpt-4o-mini" || svc.requested[0].PromptVersion != "enrich-v2" {
+		t.Fatalf("requested = %+v, want the model and prompt version", svc.requested)
+	}
+	if body["prompt_version"] != "enrich-v2" {
+		t.Fatalf("response body = %v, want prompt_version echoed", body)
+	}
+}
+
+func TestHandler_Create_VersionScopeWithoutVersion_Rejected400(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec, _ := doCreateRequest(h, `{"scope":"version"}`)
+
+	if rec.Code != http.StatusBadRequest || len(svc.requested) != 0 {
+		t.Fatalf("status = %d, requested = %+v; want 400 and no job", rec.Code, svc.requested)
+	}
+}
+
+func TestHandler_Create_InvalidScope_Rejected400_NeverReachesService(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec, _ := doCreateRequest(h, `{"scope":"everything"}`)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if len(svc.requested) != 0 {
+		t.Fatalf("expected service not called, got %+v", svc.requested)
+	}
+}
+
+func TestHandler_Get_UnknownJob_Returns404(t *testing.T) {
+	h := NewHandler(&fakeService{err: ports.ErrReenrichJobNotFound})
+
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/reenrich/nope", nil)
+	c.Params = gin.Params{{Key: "jobID", Value: "nope"}}
+	c.Set(auth.TenantIDKey, "t-1")
+
+	h.Get(c)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
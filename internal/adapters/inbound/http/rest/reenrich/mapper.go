@@ -0,0 +1,50 @@
+package reenrich
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func newID() string {
+	return uuid.New().String()
+}
+
+func mapCreateRequestToDomain(tenantID string, req CreateReenrichJobRequestDTO) domain.ReenrichJob {
+	now := time.Now().UTC()
+	job := domain.ReenrichJob{
+		ID:            newID(),
+		TenantID:      tenantID,
+		Scope:         domain.ReenrichScope(req.Scope),
+		Model:         req.Model,
+		PromptVersion: req.PromptVersion,
+		Status:        domain.JobStatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.EnrichedBefore != nil {
+		job.EnrichedBefore = req.EnrichedBefore.UTC()
+	}
+	return job
+}
+
+func mapJobToDTO(job domain.ReenrichJob) JobDTO {
+	dto := JobDTO{
+		ID:            job.ID,
+		Scope:         string(job.Scope),
+		Model:         job.Model,
+		PromptVersion: job.PromptVersion,
+		Status:        string(job.Status),
+		Processed:     job.Processed,
+		Enriched:      job.Enriched,
+		Failed:        job.Failed,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+	if !job.EnrichedBefore.IsZero() {
+		before := job.EnrichedBefore
+		dto.EnrichedBefore = &before
+	}
+	return dto
+}
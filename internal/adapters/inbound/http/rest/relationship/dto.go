@@ -9,6 +9,13 @@ type CreateRelationshipRequestDTO struct {
 	Rationale   string  `json:"rationale"`
 }
 
+// UpdateRelationshipRequestDTO sets exactly one of Type (the corrected
+// relation type) and NotRelated.
+type UpdateRelationshipRequestDTO struct {
+	Type       string `json:"type,omitempty"`
+	NotRelated bool   `json:"not_related,omitempty"`
+}
+
 type ResponseDTO struct {
 	FromInsightID string    `json:"from_insight_id"`
 	ToInsightID   string    `json:"to_insight_id"`
@@ -30,3 +37,14 @@ type ListRelationshipsResponseDTO struct {
 	InsightID string              `json:"insight_id"`
 	Items     []RelatedInsightDTO `json:"items"`
 }
+
+type RejectedRelationshipDTO struct {
+	ResponseDTO
+	Reason     string    `json:"reason"`
+	Detail     string    `json:"detail"`
+	RejectedAt time.Time `json:"rejected_at"`
+}
+
+type ListRejectedResponseDTO struct {
+	Items []RejectedRelationshipDTO `json:"items"`
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
 	apprelationship "github.com/marcogerstmann/insight-processing-platform/internal/application/relationship"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
@@ -44,6 +45,12 @@ func (h *Handler) Create(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown insight"})
 			return
 		}
+		// 422, not 400: the edge is well-formed, the tenant's policy just
+		// won't store it. The agent skips these instead of failing its run.
+		if errors.Is(err, domain.ErrRelationshipRejected) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		slog.ErrorContext(c.Request.Context(), "failed to persist relationship",
 			"tenant_id", tenantID, "from_insight_id", fromInsightID, "to_insight_id", req.ToInsightID, "err", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
@@ -70,3 +77,83 @@ func (h *Handler) ListByInsightID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mapRelatedInsightsToDTO(insightID, related))
 }
+
+// ListRejected is an agent-only route, scoped by the URL's tenant like
+// Create: the edges the tenant's relationship policy turned away or
+// evicted.
+func (h *Handler) ListRejected(c *gin.Context) {
+	tenantID := c.Param("tenantID")
+
+	rejected, err := h.svc.ListRejected(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to list rejected relationships", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapRejectedToDTO(rejected))
+}
+
+// Update is a user route (tenant from the JWT, as in ListByInsightID) for
+// correcting one edge: either a new type, or not_related to reject it
+// outright — the same as Delete.
+func (h *Handler) Update(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	insightID := c.Param("insightID")
+	otherInsightID := c.Param("otherInsightID")
+
+	var req UpdateRelationshipRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+	if req.NotRelated == (req.Type != "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "set exactly one of type and not_related"})
+		return
+	}
+	if req.NotRelated {
+		h.remove(c, tenantID, insightID, otherInsightID)
+		return
+	}
+
+	relType := domain.RelationType(req.Type)
+	if !relType.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrUnknownRelationType.Error()})
+		return
+	}
+
+	rel, err := h.svc.Retype(c.Request.Context(), tenantID, insightID, otherInsightID, relType)
+	if err != nil {
+		if errors.Is(err, ports.ErrRelationshipNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "relationship not found"})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "failed to retype relationship",
+			"tenant_id", tenantID, "insight_id", insightID, "other_insight_id", otherInsightID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapRelationshipToDTO(rel))
+}
+
+// Delete is a user route that rejects one edge: it's removed, and the
+// agent won't post the pair again.
+func (h *Handler) Delete(c *gin.Context) {
+	h.remove(c, c.GetString(auth.TenantIDKey), c.Param("insightID"), c.Param("otherInsightID"))
+}
+
+func (h *Handler) remove(c *gin.Context, tenantID, insightID, otherInsightID string) {
+	if err := h.svc.Remove(c.Request.Context(), tenantID, insightID, otherInsightID); err != nil {
+		if errors.Is(err, ports.ErrRelationshipNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "relationship not found"})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "failed to remove relationship",
+			"tenant_id", tenantID, "insight_id", insightID, "other_insight_id", otherInsightID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.AbortWithStatus(http.StatusNoContent)
+}
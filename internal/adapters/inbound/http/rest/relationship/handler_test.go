@@ -22,6 +22,9 @@ type fakeService struct {
 	listRelated   []domain.RelatedInsight
 	listTenantID  string
 	listInsightID string
+	rejected      []domain.RejectedRelationship
+	removed       [3]string
+	retyped       domain.RelationType
 }
 
 func (f *fakeService) Put(_ context.Context, rel domain.Relationship) error {
@@ -36,6 +39,21 @@ func (f *fakeService) ListByInsightID(_ context.Context, tenantID, insightID str
 	return f.listRelated, f.err
 }
 
+func (f *fakeService) ListRejected(_ context.Context, tenantID string) ([]domain.RejectedRelationship, error) {
+	f.listTenantID = tenantID
+	return f.rejected, f.err
+}
+
+func (f *fakeService) Remove(_ context.Context, tenantID, insightID, otherInsightID string) error {
+	f.removed = [3]string{tenantID, insightID, otherInsightID}
+	return f.err
+}
+
+func (f *fakeService) Retype(_ context.Context, tenantID, insightID, otherInsightID string, relType domain.RelationType) (domain.Relationship, error) {
+	f.retyped = relType
+	return domain.Relationship{TenantID: tenantID, FromInsightID: insightID, ToInsightID: otherInsightID, Type: relType, Confidence: 1}, f.err
+}
+
 func doCreateRequest(h *Handler, tenantID, insightID string, body CreateRelationshipRequestDTO) (*httptest.ResponseRecorder, map[string]any) {
 	gin.SetMode(gin.TestMode)
 	rec := httptest.NewRecorder()
@@ -129,6 +147,21 @@ func TestHandler_Create_UnknownInsight_MapsToBadRequest(t *testing.T) {
 	}
 }
 
+func TestHandler_Create_RejectedByPolicy_Returns422(t *testing.T) {
+	svc := &fakeService{err: domain.ErrContradictoryEdge}
+	h := NewHandler(svc)
+
+	rec, body := doCreateRequest(h, "t-1", "i-1", CreateRelationshipRequestDTO{
+		ToInsightID: "i-2",
+		Type:        "contradicts",
+		Confidence:  0.9,
+	})
+
+	if rec.Code != http.StatusUnprocessableEntity || body["error"] != domain.ErrContradictoryEdge.Error() {
+		t.Fatalf("status = %d, body = %v; want 422 naming the policy rule", rec.Code, body)
+	}
+}
+
 func doListRequest(h *Handler, jwtTenantID, urlTenantID, insightID string) (*httptest.ResponseRecorder, ListRelationshipsResponseDTO) {
 	gin.SetMode(gin.TestMode)
 	rec := httptest.NewRecorder()
@@ -187,3 +220,120 @@ func TestHandler_ListByInsightID_UsesJWTTenant_NeverTheURLPathParam(t *testing.T
 		t.Fatalf("svc queried tenant %q, want the JWT tenant t-1 (not the URL's t-attacker)", svc.listTenantID)
 	}
 }
+
+func TestHandler_ListRejected_ScopesToURLTenant_ReturnsReasons(t *testing.T) {
+	svc := &fakeService{rejected: []domain.RejectedRelationship{{
+		Relationship: domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.3},
+		Reason:       domain.RejectBelowMinConfidence,
+		Detail:       "minimum for supports is 0.50",
+	}}}
+	h := NewHandler(svc)
+
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/tenants/t-1/relationships/rejected", nil)
+	c.Params = gin.Params{{Key: "tenantID", Value: "t-1"}}
+
+	h.ListRejected(c)
+
+	var body ListRejectedResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.listTenantID != "t-1" {
+		t.Fatalf("status = %d, tenant = %q; want 200 for t-1", rec.Code, svc.listTenantID)
+	}
+	if len(body.Items) != 1 || body.Items[0].Reason != "below_min_confidence" || body.Items[0].ToInsightID != "i-2" {
+		t.Fatalf("body.Items = %+v, want the mapped rejection", body.Items)
+	}
+}
+
+func doEdgeRequest(h *Handler, method, jwtTenantID, urlTenantID string, body any) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	var raw []byte
+	if body != nil {
+		raw, _ = json.Marshal(body)
+	}
+	c.Request = httptest.NewRequest(method, "/v1/tenants/"+urlTenantID+"/insights/i-1/relationships/i-2", bytes.NewReader(raw))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "tenantID", Value: urlTenantID}, {Key: "insightID", Value: "i-1"}, {Key: "otherInsightID", Value: "i-2"}}
+	c.Set(auth.TenantIDKey, jwtTenantID)
+
+	if method == http.MethodDelete {
+		h.Delete(c)
+	} else {
+		h.Update(c)
+	}
+	return rec
+}
+
+func TestHandler_Delete_RemovesForJWTTenant_Returns204(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec := doEdgeRequest(h, http.MethodDelete, "t-1", "t-attacker", nil)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if svc.removed != [3]string{"t-1", "i-1", "i-2"} {
+		t.Fatalf("removed = %v, want t-1's i-1 <-> i-2", svc.removed)
+	}
+}
+
+func TestHandler_Delete_NoEdge_Returns404(t *testing.T) {
+	h := NewHandler(&fakeService{err: ports.ErrRelationshipNotFound})
+
+	if rec := doEdgeRequest(h, http.MethodDelete, "t-1", "t-1", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandler_Update_Retype_ReturnsTheCorrectedEdge(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec := doEdgeRequest(h, http.MethodPatch, "t-1", "t-1", UpdateRelationshipRequestDTO{Type: "extends"})
+
+	var body ResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.retyped != domain.RelationExtends {
+		t.Fatalf("status = %d, retyped = %q; want 200 and extends", rec.Code, svc.retyped)
+	}
+	if body.Type != "extends" || body.Confidence != 1 {
+		t.Fatalf("body = %+v, want the retyped edge", body)
+	}
+}
+
+func TestHandler_Update_NotRelated_RemovesLikeDelete(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec := doEdgeRequest(h, http.MethodPatch, "t-1", "t-1", UpdateRelationshipRequestDTO{NotRelated: true})
+
+	if rec.Code != http.StatusNoContent || svc.removed != [3]string{"t-1", "i-1", "i-2"} {
+		t.Fatalf("status = %d, removed = %v; want 204 and the edge removed", rec.Code, svc.removed)
+	}
+}
+
+func TestHandler_Update_InvalidBody_Rejected400_NeverReachesService(t *testing.T) {
+	for name, body := range map[string]UpdateRelationshipRequestDTO{
+		"neither":      {},
+		"both":         {Type: "extends", NotRelated: true},
+		"unknown type": {Type: "not-a-real-type"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := &fakeService{}
+			rec := doEdgeRequest(NewHandler(svc), http.MethodPatch, "t-1", "t-1", body)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			if svc.retyped != "" || svc.removed != [3]string{} {
+				t.Fatalf("retyped = %q, removed = %v; want the service untouched", svc.retyped, svc.removed)
+			}
+		})
+	}
+}
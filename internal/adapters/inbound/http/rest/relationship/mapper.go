@@ -42,3 +42,16 @@ func mapRelatedInsightsToDTO(insightID string, related []domain.RelatedInsight)
 	}
 	return ListRelationshipsResponseDTO{InsightID: insightID, Items: items}
 }
+
+func mapRejectedToDTO(rejected []domain.RejectedRelationship) ListRejectedResponseDTO {
+	items := make([]RejectedRelationshipDTO, len(rejected))
+	for idx, r := range rejected {
+		items[idx] = RejectedRelationshipDTO{
+			ResponseDTO: mapRelationshipToDTO(r.Relationship),
+			Reason:      string(r.Reason),
+			Detail:      r.Detail,
+			RejectedAt:  r.RejectedAt,
+		}
+	}
+	return ListRejectedResponseDTO{Items: items}
+}
@@ -0,0 +1,39 @@
+package review
+
+import "time"
+
+// GradeRequestDTO's Grade is SM-2's 0-5: 0 a blank, 3 recalled with
+// effort, 5 recalled easily. A pointer so a missing grade isn't read as 0.
+type GradeRequestDTO struct {
+	Grade *int `json:"grade" binding:"required"`
+}
+
+// ScheduleDTO's LastReviewedAt is null for an insight never reviewed.
+type ScheduleDTO struct {
+	DueAt          time.Time  `json:"due_at"`
+	IntervalDays   int        `json:"interval_days"`
+	Repetitions    int        `json:"repetitions"`
+	EaseFactor     float64    `json:"ease_factor"`
+	Reviews        int        `json:"reviews"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at"`
+}
+
+type DueReviewDTO struct {
+	InsightID string      `json:"insight_id"`
+	Text      string      `json:"text"`
+	Source    string      `json:"source"`
+	Tags      []string    `json:"tags"`
+	New       bool        `json:"new"`
+	Priority  float64     `json:"priority"`
+	Schedule  ScheduleDTO `json:"schedule"`
+}
+
+type DueResponseDTO struct {
+	Items []DueReviewDTO `json:"items"`
+}
+
+type GradeResponseDTO struct {
+	InsightID string      `json:"insight_id"`
+	Grade     int         `json:"grade"`
+	Schedule  ScheduleDTO `json:"schedule"`
+}
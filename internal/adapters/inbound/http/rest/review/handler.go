@@ -0,0 +1,84 @@
+package review
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appreview "github.com/marcogerstmann/insight-processing-platform/internal/application/review"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	svc appreview.Service
+}
+
+func NewHandler(svc appreview.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Due is a user route: up to ?limit= of the caller's insights due for
+// review today, most pressing first. Insights never reviewed come back
+// with new: true and a schedule that starts from scratch.
+func (h *Handler) Due(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	limit := defaultLimit
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+			return
+		}
+	}
+
+	due, err := h.svc.Due(c.Request.Context(), tenantID, limit)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to list due reviews", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapDueToDTO(due))
+}
+
+// Grade is a user route recording a review of :insightID and returning
+// when it's next due. A grade racing another of the same insight is a
+// 409; the client can reload the schedule and grade again.
+func (h *Handler) Grade(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	insightID := c.Param("insightID")
+
+	var req GradeRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+
+	schedule, err := h.svc.Grade(c.Request.Context(), tenantID, insightID, *req.Grade)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReviewGrade):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, ports.ErrInsightNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, ports.ErrReviewConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			slog.ErrorContext(c.Request.Context(), "failed to grade review", "tenant_id", tenantID, "insight_id", insightID, "err", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mapGradeToDTO(schedule))
+}
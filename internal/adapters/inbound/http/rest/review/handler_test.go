@@ -0,0 +1,144 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeService struct {
+	due        []domain.DueReview
+	err        error
+	called     bool
+	gotTenant  string
+	gotLimit   int
+	gotInsight string
+	gotGrade   int
+}
+
+func (f *fakeService) Due(_ context.Context, tenantID string, limit int) ([]domain.DueReview, error) {
+	f.called, f.gotTenant, f.gotLimit = true, tenantID, limit
+	return f.due, f.err
+}
+
+func (f *fakeService) Grade(_ context.Context, tenantID, insightID string, grade int) (domain.ReviewSchedule, error) {
+	f.called, f.gotTenant, f.gotInsight, f.gotGrade = true, tenantID, insightID, grade
+	if f.err != nil {
+		return domain.ReviewSchedule{}, f.err
+	}
+	reviewedAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	return domain.ReviewSchedule{InsightID: insightID, IntervalDays: 1, DueAt: reviewedAt.AddDate(0, 0, 1), Reviews: 1, LastGrade: grade, LastReviewedAt: reviewedAt, EaseFactor: 2.5}, nil
+}
+
+func doRequest(method, target, body string, route gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "insightID", Value: "i-1"}}
+	c.Set(auth.TenantIDKey, "t-1")
+	route(c)
+	return rec
+}
+
+func TestHandler_Due_MapsTheQueue(t *testing.T) {
+	svc := &fakeService{due: []domain.DueReview{
+		{Insight: domain.Insight{ID: "i-1", Text: "work in sprints", Source: "readwise", Enrichment: &domain.Enrichment{Tags: []string{"focus"}}}, Priority: 1.4,
+			Schedule: domain.ReviewSchedule{IntervalDays: 6, Reviews: 2, LastReviewedAt: time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)}},
+		{Insight: domain.Insight{ID: "i-2", Text: "sleep more"}, New: true, Schedule: domain.NewReviewSchedule("t-1", "i-2")},
+	}}
+
+	rec := doRequest(http.MethodGet, "/v1/review/due?limit=5", "", NewHandler(svc).Due)
+
+	var body DueResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.gotTenant != "t-1" || svc.gotLimit != 5 || len(body.Items) != 2 {
+		t.Fatalf("status = %d body = %s, want t-1's two items at limit 5", rec.Code, rec.Body.String())
+	}
+	first := body.Items[0]
+	if first.Text != "work in sprints" || first.Tags[0] != "focus" || first.Schedule.IntervalDays != 6 || first.Schedule.LastReviewedAt == nil || first.New {
+		t.Fatalf("first = %+v", first)
+	}
+	if !strings.Contains(rec.Body.String(), `"tags":[],"new":true`) || !strings.Contains(rec.Body.String(), `"last_reviewed_at":null`) {
+		t.Fatalf("body = %s, want the new insight with empty tags and no last review", rec.Body.String())
+	}
+}
+
+func TestHandler_Due_DefaultsAndBoundsTheLimit(t *testing.T) {
+	svc := &fakeService{}
+	if rec := doRequest(http.MethodGet, "/v1/review/due", "", NewHandler(svc).Due); rec.Code != http.StatusOK || svc.gotLimit != defaultLimit {
+		t.Fatalf("status = %d limit = %d, want the default", rec.Code, svc.gotLimit)
+	}
+	if !strings.Contains(doRequest(http.MethodGet, "/v1/review/due", "", NewHandler(svc).Due).Body.String(), `"items":[]`) {
+		t.Fatalf("want an empty list, not null")
+	}
+
+	for _, limit := range []string{"0", "101", "many"} {
+		svc := &fakeService{}
+		rec := doRequest(http.MethodGet, "/v1/review/due?limit="+limit, "", NewHandler(svc).Due)
+		if rec.Code != http.StatusBadRequest || svc.called {
+			t.Fatalf("limit=%s: status = %d, called = %v; want 400 without calling the service", limit, rec.Code, svc.called)
+		}
+	}
+}
+
+func TestHandler_Due_ServiceFailure_Returns500(t *testing.T) {
+	rec := doRequest(http.MethodGet, "/v1/review/due", "", NewHandler(&fakeService{err: errors.New("boom")}).Due)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
+
+func TestHandler_Grade_ReturnsTheNewSchedule(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doRequest(http.MethodPost, "/v1/review/i-1", `{"grade":0}`, NewHandler(svc).Grade)
+
+	var body GradeResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusOK || svc.gotInsight != "i-1" || svc.gotGrade != 0 {
+		t.Fatalf("status = %d body = %s, want i-1 graded 0", rec.Code, rec.Body.String())
+	}
+	if body.InsightID != "i-1" || body.Schedule.IntervalDays != 1 || body.Schedule.Reviews != 1 {
+		t.Fatalf("body = %+v", body)
+	}
+}
+
+func TestHandler_Grade_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		err  error
+		want int
+	}{
+		{"missing grade", `{}`, nil, http.StatusBadRequest},
+		{"malformed", `{"grade":`, nil, http.StatusBadRequest},
+		{"out of range", `{"grade":7}`, domain.ErrInvalidReviewGrade, http.StatusBadRequest},
+		{"unknown insight", `{"grade":3}`, ports.ErrInsightNotFound, http.StatusNotFound},
+		{"graded concurrently", `{"grade":3}`, fmt.Errorf("save review: %w", ports.ErrReviewConflict), http.StatusConflict},
+		{"failure", `{"grade":3}`, errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doRequest(http.MethodPost, "/v1/review/i-1", tc.body, NewHandler(&fakeService{err: tc.err}).Grade)
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d body = %s, want %d", rec.Code, rec.Body.String(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package review
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapDueToDTO(due []domain.DueReview) DueResponseDTO {
+	out := DueResponseDTO{Items: make([]DueReviewDTO, 0, len(due))}
+	for _, d := range due {
+		tags := []string{}
+		if d.Insight.Enrichment != nil && d.Insight.Enrichment.Tags != nil {
+			tags = d.Insight.Enrichment.Tags
+		}
+		out.Items = append(out.Items, DueReviewDTO{
+			InsightID: d.Insight.ID,
+			Text:      d.Insight.Text,
+			Source:    d.Insight.Source,
+			Tags:      tags,
+			New:       d.New,
+			Priority:  d.Priority,
+			Schedule:  mapScheduleToDTO(d.Schedule),
+		})
+	}
+	return out
+}
+
+func mapGradeToDTO(s domain.ReviewSchedule) GradeResponseDTO {
+	return GradeResponseDTO{InsightID: s.InsightID, Grade: s.LastGrade, Schedule: mapScheduleToDTO(s)}
+}
+
+func mapScheduleToDTO(s domain.ReviewSchedule) ScheduleDTO {
+	dto := ScheduleDTO{
+		DueAt:        s.DueAt,
+		IntervalDays: s.IntervalDays,
+		Repetitions:  s.Repetitions,
+		EaseFactor:   s.EaseFactor,
+		Reviews:      s.Reviews,
+	}
+	if !s.LastReviewedAt.IsZero() {
+		reviewedAt := s.LastReviewedAt
+		dto.LastReviewedAt = &reviewedAt
+	}
+	return dto
+}
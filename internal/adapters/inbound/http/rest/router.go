@@ -3,17 +3,27 @@ package rest
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	restcontradiction "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/contradiction"
+	restdigest "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/digest"
+	restduplicate "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/duplicate"
+	restgraph "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/graph"
 	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/insight"
 	restraindrop "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/raindrop"
 	restreadwise "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/readwise"
+	restreenrich "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/reenrich"
 	restrelationship "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/relationship"
+	restreview "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/review"
+	restsettings "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/settings"
+	resttaghistory "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/taghistory"
+	resttagmap "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/tagmap"
+	restusage "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/usage"
 	restweeklyplan "github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/weeklyplan"
 )
 
 // NewRouter builds the REST engine. allowedOrigins enables browser CORS for
 // those origins; pass nil in environments where CORS is handled upstream (AWS
 // API Gateway), and the Vite dev origin from the local runner.
-func NewRouter(insightHandler *insight.Handler, readwiseHandler *restreadwise.Handler, raindropHandler *restraindrop.Handler, relationshipHandler *restrelationship.Handler, weeklyPlanHandler *restweeklyplan.Handler, authValidator *auth.CognitoValidator, allowedOrigins []string) *gin.Engine {
+func NewRouter(insightHandler *insight.Handler, readwiseHandler *restreadwise.Handler, raindropHandler *restraindrop.Handler, relationshipHandler *restrelationship.Handler, weeklyPlanHandler *restweeklyplan.Handler, reenrichHandler *restreenrich.Handler, usageHandler *restusage.Handler, duplicateHandler *restduplicate.Handler, settingsHandler *restsettings.Handler, graphHandler *restgraph.Handler, contradictionHandler *restcontradiction.Handler, tagMapHandler *resttagmap.Handler, tagHistoryHandler *resttaghistory.Handler, reviewHandler *restreview.Handler, digestHandler *restdigest.Handler, authValidator *auth.CognitoValidator, allowedOrigins []string) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Recovery())
 
@@ -34,6 +44,25 @@ func NewRouter(insightHandler *insight.Handler, readwiseHandler *restreadwise.Ha
 		v1.GET("/insights", auth.RequireUser(), insightHandler.ListByTenantID)
 		v1.POST("/insights", auth.RequireUser(), insightHandler.Create)
 		v1.GET("/tags", auth.RequireUser(), insightHandler.ListTags)
+		v1.GET("/tags/:tag/related", auth.RequireUser(), tagMapHandler.Related)
+		v1.GET("/tags/:tag/history", auth.RequireUser(), tagHistoryHandler.History)
+		// Multi-hop reads over the relationship graph (see graph.Service).
+		v1.GET("/insights/:insightID/graph", auth.RequireUser(), graphHandler.Neighborhood)
+		v1.GET("/graph/path", auth.RequireUser(), graphHandler.Path)
+		v1.GET("/graph/export", auth.RequireUser(), graphHandler.Export)
+		// Clusters and rankings, recomputed on KnowledgeUpdated (see
+		// graphanalytics.Service).
+		v1.GET("/graph/insights", auth.RequireUser(), graphHandler.RankedInsights)
+		v1.GET("/graph/clusters", auth.RequireUser(), graphHandler.Clusters)
+		v1.GET("/contradictions", auth.RequireUser(), contradictionHandler.List)
+		v1.PUT("/contradictions/:insightID/:otherInsightID/resolution", auth.RequireUser(), contradictionHandler.Resolve)
+		v1.DELETE("/contradictions/:insightID/:otherInsightID/resolution", auth.RequireUser(), contradictionHandler.Reopen)
+		// Spaced-repetition review of the caller's insights (see
+		// review.Service).
+		v1.GET("/review/due", auth.RequireUser(), reviewHandler.Due)
+		v1.POST("/review/:insightID", auth.RequireUser(), reviewHandler.Grade)
+		// Past digests, generated on a schedule (see digest.Service).
+		v1.GET("/digests", auth.RequireUser(), digestHandler.List)
 		v1.POST("/readwise/import", auth.RequireUser(), readwiseHandler.Import)
 		v1.POST("/raindrop/import", auth.RequireUser(), raindropHandler.Import)
 
@@ -45,12 +74,22 @@ func NewRouter(insightHandler *insight.Handler, readwiseHandler *restreadwise.Ha
 		// but tenant scoping comes from the JWT like every other user
 		// route — see ListByInsightID's doc comment.
 		v1.GET("/tenants/:tenantID/insights/:insightID/relationships", auth.RequireUser(), relationshipHandler.ListByInsightID)
+		// User routes: the user's verdict on one edge, which the agent then
+		// never posts again. Tenant from the JWT, as above.
+		v1.PATCH("/tenants/:tenantID/insights/:insightID/relationships/:otherInsightID", auth.RequireUser(), relationshipHandler.Update)
+		v1.DELETE("/tenants/:tenantID/insights/:insightID/relationships/:otherInsightID", auth.RequireUser(), relationshipHandler.Delete)
+		// Agent-only: the edges the tenant's relationship policy turned
+		// away or evicted, so the agent can learn from them.
+		v1.GET("/tenants/:tenantID/relationships/rejected", auth.RequireScope(auth.ScopeAgentWrite), relationshipHandler.ListRejected)
 
 		// User route (PLAN 1, IPP-103): tenant scoping comes from the JWT,
 		// same as ListByInsightID above — see Handler.Create's doc comment.
 		v1.POST("/tenants/:tenantID/weekly-plans", auth.RequireUser(), weeklyPlanHandler.Create)
 		v1.GET("/tenants/:tenantID/weekly-plans", auth.RequireUser(), weeklyPlanHandler.List)
 		v1.GET("/tenants/:tenantID/weekly-plans/:planID", auth.RequireUser(), weeklyPlanHandler.Get)
+		// User route: progress on one of a ready plan's actions, scoped by
+		// the JWT like Get above.
+		v1.PATCH("/tenants/:tenantID/weekly-plans/:planID/actions/:index", auth.RequireUser(), weeklyPlanHandler.UpdateAction)
 
 		// Agent-only (PLAN 4, IPP-106): the planning worker's machine token
 		// carries no tenant, same reasoning as the relationship write route
@@ -59,6 +98,21 @@ func NewRouter(insightHandler *insight.Handler, readwiseHandler *restreadwise.Ha
 		// Agent-only (PLAN 5, IPP-107): the redelivery pre-check, same
 		// URL-scoped trust boundary as SubmitResult above.
 		v1.GET("/tenants/:tenantID/weekly-plans/:planID/status", auth.RequireScope(auth.ScopeAgentWrite), weeklyPlanHandler.Status)
+
+		// User routes: "admin" over the caller's own tenant only, scoped by
+		// the JWT like every other user route — see reenrich.Handler.Create.
+		v1.POST("/admin/reenrich", auth.RequireUser(), reenrichHandler.Create)
+		v1.GET("/admin/reenrich/:jobID", auth.RequireUser(), reenrichHandler.Get)
+		// The caller's own LLM spend against its budget (see budget.Service).
+		v1.GET("/usage", auth.RequireUser(), usageHandler.Get)
+		// Pairs ingest flagged as the same passage (see duplicate.Service).
+		v1.GET("/duplicates", auth.RequireUser(), duplicateHandler.List)
+		// The caller's own tenant configuration, e.g. its tag language.
+		v1.GET("/settings", auth.RequireUser(), settingsHandler.Get)
+		v1.PUT("/settings", auth.RequireUser(), settingsHandler.Update)
+		v1.PUT("/settings/relationship-policy", auth.RequireUser(), settingsHandler.UpdateRelationshipPolicy)
+		v1.PUT("/settings/relevance", auth.RequireUser(), settingsHandler.UpdateTagRelevance)
+		v1.POST("/settings/relevance/preview", auth.RequireUser(), settingsHandler.PreviewTagRelevance)
 	}
 
 	return r
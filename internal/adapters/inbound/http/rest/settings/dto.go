@@ -0,0 +1,61 @@
+package settings
+
+import "time"
+
+// ResponseDTO's TagLanguage and TagRelevance are the ones in effect: the
+// defaults when the tenant hasn't chosen its own.
+type ResponseDTO struct {
+	TenantID           string                `json:"tenant_id"`
+	TagLanguage        string                `json:"tag_language"`
+	RelationshipPolicy RelationshipPolicyDTO `json:"relationship_policy"`
+	TagRelevance       TagRelevanceDTO       `json:"tag_relevance"`
+	UpdatedAt          time.Time             `json:"updated_at,omitzero"`
+}
+
+type UpdateRequestDTO struct {
+	TagLanguage string `json:"tag_language"`
+}
+
+// RelationshipPolicyDTO is both the relationship_policy in ResponseDTO and
+// PUT /v1/settings/relationship-policy's body. MinConfidence is keyed by
+// relation type; a type left out has no minimum, and MaxEdgesPerInsight 0
+// means no cap.
+type RelationshipPolicyDTO struct {
+	MinConfidence      map[string]float64 `json:"min_confidence"`
+	MaxEdgesPerInsight int                `json:"max_edges_per_insight"`
+}
+
+// TagRelevanceDTO is both the tag_relevance in ResponseDTO and the body of
+// PUT /v1/settings/relevance and its preview. It replaces the config
+// wholesale: count, recency and freshness weights must sum to 1.
+type TagRelevanceDTO struct {
+	CountWeight       float64 `json:"count_weight"`
+	RecencyWeight     float64 `json:"recency_weight"`
+	FreshnessWeight   float64 `json:"freshness_weight"`
+	DensityWeight     float64 `json:"density_weight"`
+	HalfLifeDays      float64 `json:"half_life_days"`
+	CountSaturation   float64 `json:"count_saturation"`
+	DensitySaturation float64 `json:"density_saturation"`
+}
+
+type TagScoreComponentsDTO struct {
+	Count     float64 `json:"count"`
+	Recency   float64 `json:"recency"`
+	Freshness float64 `json:"freshness"`
+	Density   float64 `json:"density"`
+}
+
+// TagPreviewDTO is a tag as ranked under the proposed config. current_rank
+// is where it ranks under the saved one.
+type TagPreviewDTO struct {
+	Tag             string                `json:"tag"`
+	InsightCount    int                   `json:"insight_count"`
+	Score           float64               `json:"score"`
+	ScoreComponents TagScoreComponentsDTO `json:"score_components"`
+	Rank            int                   `json:"rank"`
+	CurrentRank     int                   `json:"current_rank"`
+}
+
+type TagPreviewResponseDTO struct {
+	Items []TagPreviewDTO `json:"items"`
+}
@@ -0,0 +1,142 @@
+package settings
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appsettings "github.com/marcogerstmann/insight-processing-platform/internal/application/settings"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type Handler struct {
+	svc appsettings.Service
+}
+
+func NewHandler(svc appsettings.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Get is a user route: the caller's own tenant's settings.
+func (h *Handler) Get(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	settings, err := h.svc.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to get tenant settings", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapSettingsToDTO(settings))
+}
+
+// Update is a user route that sets the caller's tag language. It affects
+// enrichments from now on; re-enrich to bring existing tags over.
+func (h *Handler) Update(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	var req UpdateRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+	tagLanguage, err := domain.ParseLanguage(req.TagLanguage)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.svc.SetTagLanguage(c.Request.Context(), tenantID, tagLanguage)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to update tenant settings", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapSettingsToDTO(settings))
+}
+
+// UpdateRelationshipPolicy is a user route that replaces the caller's
+// relationship policy wholesale: whatever the body leaves out is unset.
+func (h *Handler) UpdateRelationshipPolicy(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	var req RelationshipPolicyDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+	policy := mapRelationshipPolicyToDomain(req)
+	if err := policy.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.svc.SetRelationshipPolicy(c.Request.Context(), tenantID, policy)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to update relationship policy", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapSettingsToDTO(settings))
+}
+
+// UpdateTagRelevance is a user route that replaces how the caller's tags
+// are ranked. GET /v1/tags reflects it at once; nothing is recomputed.
+func (h *Handler) UpdateTagRelevance(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	config, ok := bindTagRelevance(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.svc.SetTagRelevance(c.Request.Context(), tenantID, config)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to update tag relevance", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapSettingsToDTO(settings))
+}
+
+// PreviewTagRelevance is a user route that ranks the caller's tags under
+// the body's config without saving it.
+func (h *Handler) PreviewTagRelevance(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	config, ok := bindTagRelevance(c)
+	if !ok {
+		return
+	}
+
+	previews, err := h.svc.PreviewTagRelevance(c.Request.Context(), tenantID, config)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to preview tag relevance", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapTagPreviewsToDTO(previews))
+}
+
+// bindTagRelevance reads and validates a TagRelevanceDTO body, answering
+// 400 itself if it can't.
+func bindTagRelevance(c *gin.Context) (domain.TagRelevanceConfig, bool) {
+	var req TagRelevanceDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return domain.TagRelevanceConfig{}, false
+	}
+	config := mapTagRelevanceToDomain(req)
+	if err := config.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return domain.TagRelevanceConfig{}, false
+	}
+	return config, true
+}
@@ -0,0 +1,181 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	appsettings "github.com/marcogerstmann/insight-processing-platform/internal/application/settings"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	appsettings.Service
+	settings       domain.TenantSettings
+	gotTenantID    string
+	gotTagLanguage domain.Language
+	gotPolicy      *domain.RelationshipPolicy
+	gotRelevance   *domain.TagRelevanceConfig
+	previews       []appsettings.TagPreview
+}
+
+func (f *fakeService) Get(_ context.Context, tenantID string) (domain.TenantSettings, error) {
+	f.gotTenantID = tenantID
+	return f.settings, nil
+}
+
+func (f *fakeService) SetTagLanguage(_ context.Context, tenantID string, tagLanguage domain.Language) (domain.TenantSettings, error) {
+	f.gotTenantID = tenantID
+	f.gotTagLanguage = tagLanguage
+	return domain.TenantSettings{TenantID: tenantID, TagLanguage: tagLanguage}, nil
+}
+
+func (f *fakeService) SetRelationshipPolicy(_ context.Context, tenantID string, policy domain.RelationshipPolicy) (domain.TenantSettings, error) {
+	f.gotTenantID = tenantID
+	f.gotPolicy = &policy
+	return domain.TenantSettings{TenantID: tenantID, RelationshipPolicy: policy}, nil
+}
+
+func (f *fakeService) SetTagRelevance(_ context.Context, tenantID string, config domain.TagRelevanceConfig) (domain.TenantSettings, error) {
+	f.gotTenantID = tenantID
+	f.gotRelevance = &config
+	return domain.TenantSettings{TenantID: tenantID, TagRelevance: config}, nil
+}
+
+func (f *fakeService) PreviewTagRelevance(_ context.Context, tenantID string, config domain.TagRelevanceConfig) ([]appsettings.TagPreview, error) {
+	f.gotTenantID = tenantID
+	f.gotRelevance = &config
+	return f.previews, nil
+}
+
+func do(handler gin.HandlerFunc, method, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(method, "/v1/settings", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set(auth.TenantIDKey, "t-1")
+	handler(c)
+	return rec
+}
+
+func TestHandler_Get_UnsetTagLanguage_ReportsTheDefault(t *testing.T) {
+	svc := &fakeService{settings: domain.TenantSettings{TenantID: "t-1"}}
+
+	rec := do(NewHandler(svc).Get, http.MethodGet, "")
+
+	var body ResponseDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || svc.gotTenantID != "t-1" || body.TagLanguage != string(domain.DefaultTagLanguage) {
+		t.Fatalf("status %d, tenant %q, body %+v; want t-1's default tag language", rec.Code, svc.gotTenantID, body)
+	}
+}
+
+func TestHandler_Update_SetsTheParsedLanguage(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := do(NewHandler(svc).Update, http.MethodPut, `{"tag_language":"DE"}`)
+
+	if rec.Code != http.StatusOK || svc.gotTenantID != "t-1" || svc.gotTagLanguage != "de" {
+		t.Fatalf("status %d, tenant %q, language %q; want t-1 set to de", rec.Code, svc.gotTenantID, svc.gotTagLanguage)
+	}
+}
+
+func TestHandler_Update_UnsupportedLanguage_400(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := do(NewHandler(svc).Update, http.MethodPut, `{"tag_language":"klingon"}`)
+
+	if rec.Code != http.StatusBadRequest || svc.gotTenantID != "" {
+		t.Fatalf("status %d, service called for %q; want a 400 without a write", rec.Code, svc.gotTenantID)
+	}
+}
+
+func TestHandler_UpdateRelationshipPolicy_SetsThePolicy(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := do(NewHandler(svc).UpdateRelationshipPolicy, http.MethodPut, `{"min_confidence":{"contradicts":0.8},"max_edges_per_insight":10}`)
+
+	var body ResponseDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || svc.gotPolicy == nil || svc.gotPolicy.MinConfidence[domain.RelationContradicts] != 0.8 || svc.gotPolicy.MaxEdgesPerInsight != 10 {
+		t.Fatalf("status %d, policy %+v; want contradicts >= 0.8, 10 edges", rec.Code, svc.gotPolicy)
+	}
+	if body.RelationshipPolicy.MinConfidence["contradicts"] != 0.8 {
+		t.Fatalf("body %+v, want the policy echoed", body)
+	}
+}
+
+func TestHandler_UpdateRelationshipPolicy_UnknownRelationType_400(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := do(NewHandler(svc).UpdateRelationshipPolicy, http.MethodPut, `{"min_confidence":{"refutes":0.5}}`)
+
+	if rec.Code != http.StatusBadRequest || svc.gotPolicy != nil {
+		t.Fatalf("status %d, policy %+v; want a 400 without a write", rec.Code, svc.gotPolicy)
+	}
+}
+
+const slowReaderBody = `{"count_weight":0.5,"recency_weight":0.25,"freshness_weight":0.25,"density_weight":0.2,"half_life_days":30,"count_saturation":5,"density_saturation":2}`
+
+func TestHandler_Get_ReportsTheDefaultTagRelevance(t *testing.T) {
+	rec := do(NewHandler(&fakeService{settings: domain.TenantSettings{TenantID: "t-1"}}).Get, http.MethodGet, "")
+
+	var body ResponseDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.TagRelevance.HalfLifeDays != 7 || body.TagRelevance.CountWeight != 0.3 {
+		t.Fatalf("tag_relevance = %+v, want the defaults", body.TagRelevance)
+	}
+}
+
+func TestHandler_UpdateTagRelevance_SetsTheConfig(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := do(NewHandler(svc).UpdateTagRelevance, http.MethodPut, slowReaderBody)
+
+	if rec.Code != http.StatusOK || svc.gotRelevance == nil || svc.gotRelevance.HalfLife != 30*24*time.Hour || svc.gotRelevance.CountWeight != 0.5 {
+		t.Fatalf("status %d, config %+v; want a 30-day half-life with count at 0.5", rec.Code, svc.gotRelevance)
+	}
+	var body ResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.TagRelevance.HalfLifeDays != 30 {
+		t.Fatalf("body %+v, want the config echoed", body)
+	}
+}
+
+func TestHandler_UpdateTagRelevance_WeightsNotSummingToOne_400(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := do(NewHandler(svc).UpdateTagRelevance, http.MethodPut, `{"count_weight":0.5,"recency_weight":0.5,"freshness_weight":0.5,"half_life_days":7,"count_saturation":5,"density_saturation":2}`)
+
+	if rec.Code != http.StatusBadRequest || svc.gotRelevance != nil {
+		t.Fatalf("status %d, config %+v; want a 400 without a write", rec.Code, svc.gotRelevance)
+	}
+}
+
+func TestHandler_PreviewTagRelevance_ReturnsTheReranking(t *testing.T) {
+	svc := &fakeService{previews: []appsettings.TagPreview{{Tag: domain.TagSummary{Tag: "habits", Score: 0.7}, Rank: 1, CurrentRank: 3}}}
+
+	rec := do(NewHandler(svc).PreviewTagRelevance, http.MethodPost, slowReaderBody)
+
+	var body TagPreviewResponseDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || svc.gotRelevance == nil || len(body.Items) != 1 || body.Items[0].Tag != "habits" || body.Items[0].CurrentRank != 3 {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,81 @@
+package settings
+
+import (
+	"time"
+
+	appsettings "github.com/marcogerstmann/insight-processing-platform/internal/application/settings"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func mapSettingsToDTO(s domain.TenantSettings) ResponseDTO {
+	return ResponseDTO{
+		TenantID:           s.TenantID,
+		TagLanguage:        string(s.EffectiveTagLanguage()),
+		RelationshipPolicy: mapRelationshipPolicyToDTO(s.RelationshipPolicy),
+		TagRelevance:       mapTagRelevanceToDTO(s.EffectiveTagRelevance()),
+		UpdatedAt:          s.UpdatedAt,
+	}
+}
+
+func mapRelationshipPolicyToDTO(p domain.RelationshipPolicy) RelationshipPolicyDTO {
+	minConfidence := make(map[string]float64, len(p.MinConfidence))
+	for relType, min := range p.MinConfidence {
+		minConfidence[string(relType)] = min
+	}
+	return RelationshipPolicyDTO{MinConfidence: minConfidence, MaxEdgesPerInsight: p.MaxEdgesPerInsight}
+}
+
+func mapRelationshipPolicyToDomain(req RelationshipPolicyDTO) domain.RelationshipPolicy {
+	var minConfidence map[domain.RelationType]float64
+	if len(req.MinConfidence) > 0 {
+		minConfidence = make(map[domain.RelationType]float64, len(req.MinConfidence))
+		for relType, min := range req.MinConfidence {
+			minConfidence[domain.RelationType(relType)] = min
+		}
+	}
+	return domain.RelationshipPolicy{MinConfidence: minConfidence, MaxEdgesPerInsight: req.MaxEdgesPerInsight}
+}
+
+func mapTagRelevanceToDTO(c domain.TagRelevanceConfig) TagRelevanceDTO {
+	return TagRelevanceDTO{
+		CountWeight:       c.CountWeight,
+		RecencyWeight:     c.RecencyWeight,
+		FreshnessWeight:   c.FreshnessWeight,
+		DensityWeight:     c.DensityWeight,
+		HalfLifeDays:      c.HalfLife.Hours() / 24,
+		CountSaturation:   c.CountSaturation,
+		DensitySaturation: c.DensitySaturation,
+	}
+}
+
+func mapTagRelevanceToDomain(req TagRelevanceDTO) domain.TagRelevanceConfig {
+	return domain.TagRelevanceConfig{
+		CountWeight:       req.CountWeight,
+		RecencyWeight:     req.RecencyWeight,
+		FreshnessWeight:   req.FreshnessWeight,
+		DensityWeight:     req.DensityWeight,
+		HalfLife:          time.Duration(req.HalfLifeDays * float64(24*time.Hour)),
+		CountSaturation:   req.CountSaturation,
+		DensitySaturation: req.DensitySaturation,
+	}
+}
+
+func mapTagPreviewsToDTO(previews []appsettings.TagPreview) TagPreviewResponseDTO {
+	out := TagPreviewResponseDTO{Items: make([]TagPreviewDTO, len(previews))}
+	for i, p := range previews {
+		out.Items[i] = TagPreviewDTO{
+			Tag:          p.Tag.Tag,
+			InsightCount: p.Tag.InsightCount,
+			Score:        p.Tag.Score,
+			ScoreComponents: TagScoreComponentsDTO{
+				Count:     p.Tag.ScoreComponents.Count,
+				Recency:   p.Tag.ScoreComponents.Recency,
+				Freshness: p.Tag.ScoreComponents.Freshness,
+				Density:   p.Tag.ScoreComponents.Density,
+			},
+			Rank:        p.Rank,
+			CurrentRank: p.CurrentRank,
+		}
+	}
+	return out
+}
@@ -0,0 +1,22 @@
+package taghistory
+
+import "time"
+
+type TagScoreComponentsDTO struct {
+	Count     float64 `json:"count"`
+	Recency   float64 `json:"recency"`
+	Freshness float64 `json:"freshness"`
+	Density   float64 `json:"density"`
+}
+
+type TagHistoryPointDTO struct {
+	TakenAt         time.Time             `json:"taken_at"`
+	InsightCount    int                   `json:"insight_count"`
+	Score           float64               `json:"score"`
+	ScoreComponents TagScoreComponentsDTO `json:"score_components"`
+}
+
+type TagHistoryResponseDTO struct {
+	Tag   string               `json:"tag"`
+	Items []TagHistoryPointDTO `json:"items"`
+}
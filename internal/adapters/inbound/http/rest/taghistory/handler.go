@@ -0,0 +1,60 @@
+package taghistory
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	apptaghistory "github.com/marcogerstmann/insight-processing-platform/internal/application/taghistory"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+const (
+	defaultDays = 90
+	// maxDays is as far back as snapshots are kept.
+	maxDays = int(apptaghistory.SnapshotRetention / (24 * time.Hour))
+)
+
+type Handler struct {
+	svc apptaghistory.Service
+	now func() time.Time
+}
+
+func NewHandler(svc apptaghistory.Service) *Handler {
+	return &Handler{svc: svc, now: time.Now}
+}
+
+// History is a user route: :tag's relevance over the last ?days= of
+// snapshots, oldest first. :tag is normalized as in tagmap.Handler.Related.
+// A tag no snapshot in that span carries has an empty series, not a 404:
+// it may just be newer than the last snapshot.
+func (h *Handler) History(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	tag, ok := domain.NormalizeTag(c.Param("tag"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag"})
+		return
+	}
+	days := defaultDays
+	if raw := c.Query("days"); raw != "" {
+		var err error
+		if days, err = strconv.Atoi(raw); err != nil || days < 1 || days > maxDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and " + strconv.Itoa(maxDays)})
+			return
+		}
+	}
+
+	since := h.now().AddDate(0, 0, -days)
+	points, err := h.svc.History(c.Request.Context(), tenantID, string(tag), since)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to read tag history", "tenant_id", tenantID, "tag", tag, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapHistoryToDTO(string(tag), points))
+}
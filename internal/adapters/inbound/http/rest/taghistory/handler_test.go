@@ -0,0 +1,99 @@
+package taghistory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	points    []domain.TagHistoryPoint
+	err       error
+	called    bool
+	gotTenant string
+	gotTag    string
+	gotSince  time.Time
+}
+
+func (f *fakeService) Snapshot(context.Context, string) (domain.TagSnapshot, error) {
+	return domain.TagSnapshot{}, nil
+}
+
+func (f *fakeService) History(_ context.Context, tenantID, tag string, since time.Time) ([]domain.TagHistoryPoint, error) {
+	f.called, f.gotTenant, f.gotTag, f.gotSince = true, tenantID, tag, since
+	return f.points, f.err
+}
+
+var now = time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+func doRequest(svc *fakeService, tag, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	h := NewHandler(svc)
+	h.now = func() time.Time { return now }
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/tags/"+url.PathEscape(tag)+"/history?"+query, nil)
+	c.Params = gin.Params{{Key: "tag", Value: tag}}
+	c.Set(auth.TenantIDKey, "t-1")
+	h.History(c)
+	return rec
+}
+
+func TestHandler_History_ReturnsTheSeries(t *testing.T) {
+	takenAt := now.AddDate(0, 0, -1)
+	svc := &fakeService{points: []domain.TagHistoryPoint{{TakenAt: takenAt, InsightCount: 4, Score: 0.6, ScoreComponents: domain.TagScoreComponents{Recency: 0.9}}}}
+
+	rec := doRequest(svc, "Deep Work", "days=7")
+
+	if rec.Code != http.StatusOK || svc.gotTenant != "t-1" || svc.gotTag != "deep-work" || !svc.gotSince.Equal(now.AddDate(0, 0, -7)) {
+		t.Fatalf("status = %d tenant = %q tag = %q since = %v", rec.Code, svc.gotTenant, svc.gotTag, svc.gotSince)
+	}
+	var body TagHistoryResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.Tag != "deep-work" || len(body.Items) != 1 || !body.Items[0].TakenAt.Equal(takenAt) || body.Items[0].ScoreComponents.Recency != 0.9 {
+		t.Fatalf("body = %s", rec.Body.String())
+	}
+}
+
+func TestHandler_History_DefaultsToNinetyDays_EmptyIsAnEmptyList(t *testing.T) {
+	svc := &fakeService{}
+
+	rec := doRequest(svc, "focus", "")
+
+	if rec.Code != http.StatusOK || !svc.gotSince.Equal(now.AddDate(0, 0, -defaultDays)) {
+		t.Fatalf("status = %d since = %v", rec.Code, svc.gotSince)
+	}
+	if rec.Body.String() != `{"tag":"focus","items":[]}` {
+		t.Fatalf("body = %s, want an empty list", rec.Body.String())
+	}
+}
+
+func TestHandler_History_BadRequest(t *testing.T) {
+	for _, tc := range []struct{ tag, query string }{{"!!!", ""}, {"focus", "days=0"}, {"focus", "days=367"}, {"focus", "days=week"}} {
+		svc := &fakeService{}
+
+		rec := doRequest(svc, tc.tag, tc.query)
+
+		if rec.Code != http.StatusBadRequest || svc.called {
+			t.Fatalf("%+v: status = %d called = %v, want 400 without reaching the service", tc, rec.Code, svc.called)
+		}
+	}
+}
+
+func TestHandler_History_ServiceError(t *testing.T) {
+	rec := doRequest(&fakeService{err: errors.New("boom")}, "focus", "")
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
@@ -0,0 +1,21 @@
+package taghistory
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapHistoryToDTO(tag string, points []domain.TagHistoryPoint) TagHistoryResponseDTO {
+	out := TagHistoryResponseDTO{Tag: tag, Items: make([]TagHistoryPointDTO, len(points))}
+	for i, p := range points {
+		out.Items[i] = TagHistoryPointDTO{
+			TakenAt:      p.TakenAt,
+			InsightCount: p.InsightCount,
+			Score:        p.Score,
+			ScoreComponents: TagScoreComponentsDTO{
+				Count:     p.ScoreComponents.Count,
+				Recency:   p.ScoreComponents.Recency,
+				Freshness: p.ScoreComponents.Freshness,
+				Density:   p.ScoreComponents.Density,
+			},
+		}
+	}
+	return out
+}
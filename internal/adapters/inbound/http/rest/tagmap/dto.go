@@ -0,0 +1,22 @@
+package tagmap
+
+// RelatedTagDTO scores one tag's association with the requested one:
+// through shared insights (co_occurrence_*) and through relationship
+// edges between their insights (edge_*). Lift is 1 for no association;
+// PMI is its log2. score, a normalized PMI in [-1,1], is what the list is
+// ordered by.
+type RelatedTagDTO struct {
+	Tag              string  `json:"tag"`
+	CoOccurrences    int     `json:"co_occurrences"`
+	CoOccurrenceLift float64 `json:"co_occurrence_lift"`
+	CoOccurrencePMI  float64 `json:"co_occurrence_pmi"`
+	Edges            int     `json:"edges"`
+	EdgeLift         float64 `json:"edge_lift"`
+	EdgePMI          float64 `json:"edge_pmi"`
+	Score            float64 `json:"score"`
+}
+
+type RelatedTagsResponseDTO struct {
+	Tag   string          `json:"tag"`
+	Items []RelatedTagDTO `json:"items"`
+}
@@ -0,0 +1,61 @@
+package tagmap
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	apptagmap "github.com/marcogerstmann/insight-processing-platform/internal/application/tagmap"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	svc apptagmap.Service
+}
+
+func NewHandler(svc apptagmap.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Related is a user route: the tags associated with :tag in the caller's
+// tenant, up to ?limit=. :tag is normalized the way enrichment normalizes
+// tags, so "Deep Work" finds deep-work.
+func (h *Handler) Related(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	tag, ok := domain.NormalizeTag(c.Param("tag"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag"})
+		return
+	}
+	limit := defaultLimit
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+			return
+		}
+	}
+
+	related, err := h.svc.Related(c.Request.Context(), tenantID, string(tag), limit)
+	if err != nil {
+		if errors.Is(err, ports.ErrUnknownTag) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "failed to relate tags", "tenant_id", tenantID, "tag", tag, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapRelatedTagsToDTO(string(tag), related))
+}
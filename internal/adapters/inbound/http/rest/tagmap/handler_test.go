@@ -0,0 +1,89 @@
+package tagmap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeService struct {
+	related   []domain.RelatedTag
+	err       error
+	called    bool
+	gotTenant string
+	gotTag    string
+	gotLimit  int
+}
+
+func (f *fakeService) Related(_ context.Context, tenantID, tag string, limit int) ([]domain.RelatedTag, error) {
+	f.called, f.gotTenant, f.gotTag, f.gotLimit = true, tenantID, tag, limit
+	return f.related, f.err
+}
+
+func doRequest(h *Handler, tag, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/tags/"+url.PathEscape(tag)+"/related?"+query, nil)
+	c.Params = gin.Params{{Key: "tag", Value: tag}}
+	c.Set(auth.TenantIDKey, "t-1")
+	h.Related(c)
+	return rec
+}
+
+func TestHandler_Related_NormalizesTheTagAndMapsTheScores(t *testing.T) {
+	svc := &fakeService{related: []domain.RelatedTag{{Tag: "trust", CoOccurrences: 2, CoOccurrenceLift: 1.5, CoOccurrencePMI: 0.58, Score: 0.4}}}
+
+	rec := doRequest(NewHandler(svc), "Deep Work", "limit=5")
+
+	if rec.Code != http.StatusOK || svc.gotTenant != "t-1" || svc.gotTag != "deep-work" || svc.gotLimit != 5 {
+		t.Fatalf("status = %d tenant = %q tag = %q limit = %d", rec.Code, svc.gotTenant, svc.gotTag, svc.gotLimit)
+	}
+	var body RelatedTagsResponseDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.Tag != "deep-work" || len(body.Items) != 1 || body.Items[0].Tag != "trust" || body.Items[0].CoOccurrenceLift != 1.5 {
+		t.Fatalf("body = %s, want trust's scores", rec.Body.String())
+	}
+}
+
+func TestHandler_Related_DefaultLimit(t *testing.T) {
+	svc := &fakeService{}
+
+	doRequest(NewHandler(svc), "trust", "")
+
+	if svc.gotLimit != defaultLimit {
+		t.Fatalf("limit = %d, want %d", svc.gotLimit, defaultLimit)
+	}
+}
+
+func TestHandler_Related_BadRequest(t *testing.T) {
+	for _, tc := range []struct{ tag, query string }{{"!!!", ""}, {"trust", "limit=0"}, {"trust", "limit=101"}, {"trust", "limit=some"}} {
+		svc := &fakeService{}
+
+		rec := doRequest(NewHandler(svc), tc.tag, tc.query)
+
+		if rec.Code != http.StatusBadRequest || svc.called {
+			t.Fatalf("%+v: status = %d called = %v, want 400 without reaching the service", tc, rec.Code, svc.called)
+		}
+	}
+}
+
+func TestHandler_Related_Errors(t *testing.T) {
+	for err, want := range map[error]int{ports.ErrUnknownTag: http.StatusNotFound, errors.New("boom"): http.StatusInternalServerError} {
+		rec := doRequest(NewHandler(&fakeService{err: err}), "trust", "")
+
+		if rec.Code != want {
+			t.Fatalf("%v: status = %d, want %d", err, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package tagmap
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapRelatedTagsToDTO(tag string, related []domain.RelatedTag) RelatedTagsResponseDTO {
+	out := RelatedTagsResponseDTO{Tag: tag, Items: make([]RelatedTagDTO, 0, len(related))}
+	for _, r := range related {
+		out.Items = append(out.Items, RelatedTagDTO{
+			Tag:              r.Tag,
+			CoOccurrences:    r.CoOccurrences,
+			CoOccurrenceLift: r.CoOccurrenceLift,
+			CoOccurrencePMI:  r.CoOccurrencePMI,
+			Edges:            r.Edges,
+			EdgeLift:         r.EdgeLift,
+			EdgePMI:          r.EdgePMI,
+			Score:            r.Score,
+		})
+	}
+	return out
+}
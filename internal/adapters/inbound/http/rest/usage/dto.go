@@ -0,0 +1,23 @@
+package usage
+
+// PeriodDTO is one period's consumption against its limits. A limit of 0
+// means none is configured.
+type PeriodDTO struct {
+	Period       string  `json:"period"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	Tokens       int64   `json:"tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	TokenLimit   int64   `json:"token_limit"`
+	CostLimitUSD float64 `json:"cost_limit_usd"`
+}
+
+type ResponseDTO struct {
+	TenantID string `json:"tenant_id"`
+	// Exhausted is true while enrichment is degraded to the keyword
+	// fallback for this tenant.
+	Exhausted bool      `json:"exhausted"`
+	Day       PeriodDTO `json:"day"`
+	Month     PeriodDTO `json:"month"`
+}
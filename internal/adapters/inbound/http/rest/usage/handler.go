@@ -0,0 +1,34 @@
+package usage
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+)
+
+type Handler struct {
+	svc budget.Service
+}
+
+func NewHandler(svc budget.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Get is a user route: the caller's own tenant's LLM consumption for the
+// current UTC day and month.
+func (h *Handler) Get(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+
+	report, err := h.svc.Report(c.Request.Context(), tenantID)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "failed to report llm usage", "tenant_id", tenantID, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapReportToDTO(report))
+}
@@ -0,0 +1,73 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/inbound/http/rest/auth"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/budget"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	budget.Service
+	report      domain.UsageReport
+	err         error
+	gotTenantID string
+}
+
+func (f *fakeService) Report(_ context.Context, tenantID string) (domain.UsageReport, error) {
+	f.gotTenantID = tenantID
+	return f.report, f.err
+}
+
+func doGet(h *Handler) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/usage", nil)
+	c.Set(auth.TenantIDKey, "t-1")
+	h.Get(c)
+	return rec
+}
+
+func TestHandler_Get_ReportsUsageInDollarsForTheJWTTenant(t *testing.T) {
+	svc := &fakeService{report: domain.UsageReport{
+		TenantID:   "t-1",
+		Day:        "2026-03-01",
+		DayUsage:   domain.LLMUsage{Calls: 2, InputTokens: 100, OutputTokens: 20, CostMicros: 1_500_000},
+		Month:      "2026-03",
+		MonthUsage: domain.LLMUsage{Calls: 9},
+		Limits:     domain.BudgetLimits{DailyCostMicros: 2_000_000},
+	}}
+
+	rec := doGet(NewHandler(svc))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if svc.gotTenantID != "t-1" {
+		t.Fatalf("tenant = %q, want the JWT's", svc.gotTenantID)
+	}
+	var body ResponseDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Day.Tokens != 120 || body.Day.CostUSD != 1.5 || body.Day.CostLimitUSD != 2 || body.Month.Calls != 9 {
+		t.Fatalf("body = %+v", body)
+	}
+}
+
+func TestHandler_Get_ServiceError_Returns500(t *testing.T) {
+	rec := doGet(NewHandler(&fakeService{err: errors.New("boom")}))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
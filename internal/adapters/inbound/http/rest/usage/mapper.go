@@ -0,0 +1,29 @@
+package usage
+
+import "github.com/marcogerstmann/insight-processing-platform/internal/domain"
+
+func mapReportToDTO(r domain.UsageReport) ResponseDTO {
+	return ResponseDTO{
+		TenantID:  r.TenantID,
+		Exhausted: r.Exhausted,
+		Day:       mapPeriodToDTO(r.Day, r.DayUsage, r.Limits.DailyTokens, r.Limits.DailyCostMicros),
+		Month:     mapPeriodToDTO(r.Month, r.MonthUsage, r.Limits.MonthlyTokens, r.Limits.MonthlyCostMicros),
+	}
+}
+
+func mapPeriodToDTO(period string, u domain.LLMUsage, tokenLimit, costLimitMicros int64) PeriodDTO {
+	return PeriodDTO{
+		Period:       period,
+		Calls:        u.Calls,
+		InputTokens:  u.InputTokens,
+		OutputTokens: u.OutputTokens,
+		Tokens:       u.Tokens(),
+		CostUSD:      microsToUSD(u.CostMicros),
+		TokenLimit:   tokenLimit,
+		CostLimitUSD: microsToUSD(costLimitMicros),
+	}
+}
+
+func microsToUSD(micros int64) float64 {
+	return float64(micros) / 1e6
+}
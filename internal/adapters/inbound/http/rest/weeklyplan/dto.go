@@ -45,16 +45,21 @@ type ResolvedActionDTO struct {
 	Title              string               `json:"title"`
 	Why                string               `json:"why"`
 	SupportingInsights []ResolvedInsightDTO `json:"supporting_insights"`
+	Status             string               `json:"status"`
+	StartedAt          *time.Time           `json:"started_at"`
+	CompletedAt        *time.Time           `json:"completed_at"`
+	Reflection         string               `json:"reflection,omitempty"`
 }
 
 type PlanDetailDTO struct {
-	ID            string              `json:"id"`
-	Tag           string              `json:"tag"`
-	FocusSentence string              `json:"focus_sentence"`
-	Status        string              `json:"status"`
-	CreatedAt     time.Time           `json:"created_at"`
-	FailureReason string              `json:"failure_reason,omitempty"`
-	Actions       []ResolvedActionDTO `json:"actions"`
+	ID                string              `json:"id"`
+	Tag               string              `json:"tag"`
+	FocusSentence     string              `json:"focus_sentence"`
+	Status            string              `json:"status"`
+	CreatedAt         time.Time           `json:"created_at"`
+	FailureReason     string              `json:"failure_reason,omitempty"`
+	CompletionPercent int                 `json:"completion_percent"`
+	Actions           []ResolvedActionDTO `json:"actions"`
 }
 
 type PlanListItemDTO struct {
@@ -63,8 +68,29 @@ type PlanListItemDTO struct {
 	FocusSentence string    `json:"focus_sentence"`
 	Status        string    `json:"status"`
 	CreatedAt     time.Time `json:"created_at"`
+	// CompletionPercent is domain.WeeklyPlan.CompletionPercent, so the
+	// list can show progress without drilling into each plan.
+	CompletionPercent int `json:"completion_percent"`
 }
 
 type ListPlansResponseDTO struct {
 	Items []PlanListItemDTO `json:"items"`
 }
+
+// UpdateActionRequestDTO is PATCH .../actions/:index's body: either field
+// may be left out to keep it as it is; an empty reflection clears it.
+type UpdateActionRequestDTO struct {
+	Status     *string `json:"status"`
+	Reflection *string `json:"reflection"`
+}
+
+// ActionProgressDTO is PATCH .../actions/:index's response: the action's
+// progress as stored.
+type ActionProgressDTO struct {
+	Index       int        `json:"index"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	Reflection  string     `json:"reflection,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -171,3 +172,47 @@ func (h *Handler) List(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mapPlansToListDTO(plans))
 }
+
+// UpdateAction is a user route (see router.go): the tenant comes from the
+// JWT, plan and action index from the URL. Progress is tracked only once
+// the plan is ready — a pending or failed plan has no actions to track —
+// so that is a 409, where an index past the plan's actions is a 404. An
+// action another request moved on concurrently is a 409 too: the client
+// re-reads it and decides again.
+func (h *Handler) UpdateAction(c *gin.Context) {
+	tenantID := c.GetString(auth.TenantIDKey)
+	planID := c.Param("planID")
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "action index must be an integer"})
+		return
+	}
+
+	var req UpdateActionRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request_body"})
+		return
+	}
+
+	action, err := h.svc.UpdateAction(c.Request.Context(), tenantID, planID, index, mapUpdateActionRequestToDomain(req))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmptyActionUpdate),
+			errors.Is(err, domain.ErrUnknownActionStatus),
+			errors.Is(err, domain.ErrReflectionTooLong):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, ports.ErrPlanNotFound), errors.Is(err, domain.ErrActionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found"})
+		case errors.Is(err, domain.ErrPlanNotReady), errors.Is(err, ports.ErrActionChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			slog.ErrorContext(c.Request.Context(), "failed to update weekly plan action",
+				"tenant_id", tenantID, "plan_id", planID, "index", index, "err", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_server_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mapActionProgressToDTO(index, action))
+}
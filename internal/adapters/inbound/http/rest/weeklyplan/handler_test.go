@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -36,6 +38,12 @@ type fakeService struct {
 
 	setFailedCalled bool
 	setFailedReason string
+
+	updateActionCalled bool
+	updateActionTenant string
+	updateActionIndex  int
+	updateActionGot    domain.ActionUpdate
+	updateActionResult domain.Action
 }
 
 func (f *fakeService) Submit(_ context.Context, plan domain.WeeklyPlan) error {
@@ -72,6 +80,14 @@ func (f *fakeService) SetFailed(_ context.Context, _, _, reason string) error {
 	return f.err
 }
 
+func (f *fakeService) UpdateAction(_ context.Context, tenantID, _ string, index int, update domain.ActionUpdate) (domain.Action, error) {
+	f.updateActionCalled = true
+	f.updateActionTenant = tenantID
+	f.updateActionIndex = index
+	f.updateActionGot = update
+	return f.updateActionResult, f.err
+}
+
 func doCreateRequest(h *Handler, tenantID string, body CreateWeeklyPlanRequestDTO) (*httptest.ResponseRecorder, map[string]any) {
 	gin.SetMode(gin.TestMode)
 	rec := httptest.NewRecorder()
@@ -415,3 +431,112 @@ func TestHandler_List_UsesJWTTenant_NeverTheURLPathParam(t *testing.T) {
 		t.Fatalf("svc queried tenant %q, want the JWT tenant t-1 (not the URL's t-attacker)", svc.listCalledWith)
 	}
 }
+
+func TestHandler_List_IncludesCompletionPercent(t *testing.T) {
+	svc := &fakeService{listPlans: []domain.WeeklyPlan{{
+		ID:     "p-1",
+		Status: domain.PlanStatusReady,
+		Actions: []domain.Action{
+			{Status: domain.ActionDone},
+			{Status: domain.ActionSkipped},
+			{Status: domain.ActionTodo},
+		},
+	}}}
+	h := NewHandler(svc)
+
+	_, body := doListRequest(h, "t-1", "t-1")
+
+	if len(body.Items) != 1 || body.Items[0].CompletionPercent != 50 {
+		t.Fatalf("body.Items = %+v, want 50%% (one done of two not skipped)", body.Items)
+	}
+}
+
+func doUpdateActionRequest(h *Handler, jwtTenantID, urlTenantID, planID, index, body string) (*httptest.ResponseRecorder, ActionProgressDTO) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	c.Request = httptest.NewRequest(http.MethodPatch, "/v1/tenants/"+urlTenantID+"/weekly-plans/"+planID+"/actions/"+index, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "tenantID", Value: urlTenantID}, {Key: "planID", Value: planID}, {Key: "index", Value: index}}
+	c.Set(auth.TenantIDKey, jwtTenantID)
+
+	h.UpdateAction(c)
+
+	var dto ActionProgressDTO
+	_ = json.Unmarshal(rec.Body.Bytes(), &dto)
+	return rec, dto
+}
+
+func TestHandler_UpdateAction_HappyPath_ReturnsTheStoredProgress(t *testing.T) {
+	completedAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := &fakeService{updateActionResult: domain.Action{
+		Title:       "Ship it",
+		Status:      domain.ActionDone,
+		StartedAt:   completedAt.Add(-time.Hour),
+		CompletedAt: completedAt,
+		Reflection:  "done",
+	}}
+	h := NewHandler(svc)
+
+	rec, body := doUpdateActionRequest(h, "t-1", "t-attacker", "p-1", "2", `{"status":"done","reflection":"done"}`)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if svc.updateActionTenant != "t-1" || svc.updateActionIndex != 2 {
+		t.Fatalf("svc called with tenant %q index %d, want the JWT tenant t-1 and index 2", svc.updateActionTenant, svc.updateActionIndex)
+	}
+	if got := svc.updateActionGot; got.Status == nil || *got.Status != domain.ActionDone || got.Reflection == nil || *got.Reflection != "done" {
+		t.Fatalf("svc got update %+v, want status done and the reflection", got)
+	}
+	if body.Index != 2 || body.Status != "done" || body.CompletedAt == nil || !body.CompletedAt.Equal(completedAt) {
+		t.Fatalf("body = %+v, want index 2 done at %v", body, completedAt)
+	}
+}
+
+func TestHandler_UpdateAction_ReopenedAction_RendersCompletedAtAsNull(t *testing.T) {
+	svc := &fakeService{updateActionResult: domain.Action{Status: domain.ActionInProgress, StartedAt: time.Now()}}
+	h := NewHandler(svc)
+
+	rec, _ := doUpdateActionRequest(h, "t-1", "t-1", "p-1", "0", `{"status":"in_progress"}`)
+
+	if !strings.Contains(rec.Body.String(), `"completed_at":null`) {
+		t.Fatalf("body = %s, want completed_at null", rec.Body.String())
+	}
+}
+
+func TestHandler_UpdateAction_NonIntegerIndex_Rejected400_NeverReachesService(t *testing.T) {
+	svc := &fakeService{}
+	h := NewHandler(svc)
+
+	rec, _ := doUpdateActionRequest(h, "t-1", "t-1", "p-1", "first", `{"status":"done"}`)
+
+	if rec.Code != http.StatusBadRequest || svc.updateActionCalled {
+		t.Fatalf("status = %d, called = %v; want 400 and no service call", rec.Code, svc.updateActionCalled)
+	}
+}
+
+func TestHandler_UpdateAction_MapsErrors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrEmptyActionUpdate, http.StatusBadRequest},
+		{domain.ErrUnknownActionStatus, http.StatusBadRequest},
+		{domain.ErrReflectionTooLong, http.StatusBadRequest},
+		{ports.ErrPlanNotFound, http.StatusNotFound},
+		{domain.ErrActionNotFound, http.StatusNotFound},
+		{domain.ErrPlanNotReady, http.StatusConflict},
+		{ports.ErrActionChanged, http.StatusConflict},
+		{errors.New("dynamo down"), http.StatusInternalServerError},
+	} {
+		h := NewHandler(&fakeService{err: tc.err})
+
+		rec, _ := doUpdateActionRequest(h, "t-1", "t-1", "p-1", "0", `{"status":"done"}`)
+
+		if rec.Code != tc.want {
+			t.Errorf("%v: status = %d, want %d", tc.err, rec.Code, tc.want)
+		}
+	}
+}
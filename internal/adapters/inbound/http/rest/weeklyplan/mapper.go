@@ -45,17 +45,26 @@ func mapPlanDetailToDTO(detail domain.PlanDetail) PlanDetailDTO {
 		for j, s := range a.SupportingInsights {
 			supporting[j] = ResolvedInsightDTO{InsightID: s.InsightID, Text: s.Text}
 		}
-		actions[i] = ResolvedActionDTO{Title: a.Title, Why: a.Why, SupportingInsights: supporting}
+		actions[i] = ResolvedActionDTO{
+			Title:              a.Title,
+			Why:                a.Why,
+			SupportingInsights: supporting,
+			Status:             string(a.Status),
+			StartedAt:          optionalTime(a.StartedAt),
+			CompletedAt:        optionalTime(a.CompletedAt),
+			Reflection:         a.Reflection,
+		}
 	}
 
 	return PlanDetailDTO{
-		ID:            detail.Plan.ID,
-		Tag:           detail.Plan.Tag,
-		FocusSentence: detail.Plan.FocusSentence,
-		Status:        string(detail.Plan.Status),
-		CreatedAt:     detail.Plan.CreatedAt,
-		FailureReason: detail.Plan.FailureReason,
-		Actions:       actions,
+		ID:                detail.Plan.ID,
+		Tag:               detail.Plan.Tag,
+		FocusSentence:     detail.Plan.FocusSentence,
+		Status:            string(detail.Plan.Status),
+		CreatedAt:         detail.Plan.CreatedAt,
+		FailureReason:     detail.Plan.FailureReason,
+		CompletionPercent: detail.Plan.CompletionPercent(),
+		Actions:           actions,
 	}
 }
 
@@ -63,12 +72,42 @@ func mapPlansToListDTO(plans []domain.WeeklyPlan) ListPlansResponseDTO {
 	items := make([]PlanListItemDTO, len(plans))
 	for i, p := range plans {
 		items[i] = PlanListItemDTO{
-			ID:            p.ID,
-			Tag:           p.Tag,
-			FocusSentence: p.FocusSentence,
-			Status:        string(p.Status),
-			CreatedAt:     p.CreatedAt,
+			ID:                p.ID,
+			Tag:               p.Tag,
+			FocusSentence:     p.FocusSentence,
+			Status:            string(p.Status),
+			CreatedAt:         p.CreatedAt,
+			CompletionPercent: p.CompletionPercent(),
 		}
 	}
 	return ListPlansResponseDTO{Items: items}
 }
+
+func mapUpdateActionRequestToDomain(req UpdateActionRequestDTO) domain.ActionUpdate {
+	var update domain.ActionUpdate
+	if req.Status != nil {
+		status := domain.ActionStatus(*req.Status)
+		update.Status = &status
+	}
+	update.Reflection = req.Reflection
+	return update
+}
+
+func mapActionProgressToDTO(index int, a domain.Action) ActionProgressDTO {
+	return ActionProgressDTO{
+		Index:       index,
+		Title:       a.Title,
+		Status:      string(a.Status),
+		StartedAt:   optionalTime(a.StartedAt),
+		CompletedAt: optionalTime(a.CompletedAt),
+		Reflection:  a.Reflection,
+	}
+}
+
+// optionalTime is t, or nil while it's unset so it renders as null.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,36 @@
+// Package tagstat handles a direct invocation of the tag stat rebuild
+// Lambda (see application/tagstat and terraform/envs/dev/tagstat-rebuild.tf).
+// Nothing schedules it: a rebuild races ingestion, so it's run by hand,
+// after a deploy that changes the aggregates or when they look off.
+package tagstat
+
+import (
+	"context"
+	"log/slog"
+
+	apptagstat "github.com/marcogerstmann/insight-processing-platform/internal/application/tagstat"
+)
+
+// Handler rebuilds a single tenant's aggregates, the deployment's default
+// one, as schedule/tagsnapshot snapshots one.
+type Handler struct {
+	svc      apptagstat.Service
+	tenantID string
+}
+
+func NewHandler(svc apptagstat.Service, tenantID string) *Handler {
+	return &Handler{svc: svc, tenantID: tenantID}
+}
+
+// Rebuild recomputes tenantID's aggregates. A failure is returned so the
+// invocation is recorded as failed; running it again is the retry.
+func (h *Handler) Rebuild(ctx context.Context) error {
+	stats, err := h.svc.Rebuild(ctx, h.tenantID)
+	if err != nil {
+		slog.ErrorContext(ctx, "tag stat rebuild failed", "tenant_id", h.tenantID, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "tag stats rebuilt", "tenant_id", h.tenantID, "tags", len(stats))
+	return nil
+}
@@ -0,0 +1,38 @@
+package tagstat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	err       error
+	gotTenant string
+}
+
+func (f *fakeService) Rebuild(_ context.Context, tenantID string) ([]domain.TagStat, error) {
+	f.gotTenant = tenantID
+	return []domain.TagStat{{Tag: "focus"}}, f.err
+}
+
+func TestRebuild_Success(t *testing.T) {
+	svc := &fakeService{}
+
+	if err := NewHandler(svc, "tenant-1").Rebuild(context.Background()); err != nil {
+		t.Fatalf("Rebuild returned error: %v", err)
+	}
+	if svc.gotTenant != "tenant-1" {
+		t.Fatalf("rebuilt %q, want tenant-1", svc.gotTenant)
+	}
+}
+
+func TestRebuild_ServiceError_Propagates(t *testing.T) {
+	err := NewHandler(&fakeService{err: errors.New("boom")}, "tenant-1").Rebuild(context.Background())
+
+	if err == nil {
+		t.Fatal("expected the service error to fail the invocation")
+	}
+}
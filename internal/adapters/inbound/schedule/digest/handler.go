@@ -0,0 +1,55 @@
+// Package digest handles the scheduled triggers for digests (EventBridge
+// Scheduler invokes the Lambda directly, once a day and once a week — see
+// terraform/envs/dev/digest.tf), as schedule/tagsnapshot does for tag
+// snapshots.
+package digest
+
+import (
+	"context"
+	"log/slog"
+
+	appdigest "github.com/marcogerstmann/insight-processing-platform/internal/application/digest"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// Event is the schedule's input: which digest to generate.
+type Event struct {
+	Period string `json:"period"`
+}
+
+// Handler generates a single tenant's digest, the deployment's default
+// one, for the same reason tagsnapshot.Handler snapshots only that tenant.
+type Handler struct {
+	svc      appdigest.Service
+	tenantID string
+}
+
+func NewHandler(svc appdigest.Service, tenantID string) *Handler {
+	return &Handler{svc: svc, tenantID: tenantID}
+}
+
+// Generate builds event's digest; an empty period means daily. A failure
+// is returned so the invocation is recorded as failed; the schedule's
+// next run picks up from the last digest that was stored.
+func (h *Handler) Generate(ctx context.Context, event Event) error {
+	if event.Period == "" {
+		event.Period = string(domain.DigestDaily)
+	}
+	period, err := domain.ParseDigestPeriod(event.Period)
+	if err != nil {
+		slog.ErrorContext(ctx, "digest skipped", "tenant_id", h.tenantID, "period", event.Period, "err", err)
+		return err
+	}
+
+	digest, err := h.svc.Generate(ctx, h.tenantID, period)
+	if err != nil {
+		slog.ErrorContext(ctx, "digest failed", "tenant_id", h.tenantID, "period", period, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "digest complete",
+		"tenant_id", h.tenantID, "period", period, "since", digest.Since,
+		"insights", digest.InsightCount, "relationships", digest.RelationshipCount,
+		"tag_moves", len(digest.TagMoves), "plans", len(digest.Plans))
+	return nil
+}
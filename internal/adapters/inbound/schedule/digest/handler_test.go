@@ -0,0 +1,64 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	err       error
+	gotTenant string
+	gotPeriod domain.DigestPeriod
+}
+
+func (f *fakeService) Generate(_ context.Context, tenantID string, period domain.DigestPeriod) (domain.Digest, error) {
+	f.gotTenant, f.gotPeriod = tenantID, period
+	return domain.Digest{TenantID: tenantID, Period: period}, f.err
+}
+
+func (f *fakeService) List(context.Context, string, int) ([]domain.Digest, error) {
+	return nil, nil
+}
+
+func TestGenerate_Success(t *testing.T) {
+	svc := &fakeService{}
+
+	if err := NewHandler(svc, "tenant-1").Generate(context.Background(), Event{Period: "weekly"}); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if svc.gotTenant != "tenant-1" || svc.gotPeriod != domain.DigestWeekly {
+		t.Fatalf("generated %q for %q, want weekly for tenant-1", svc.gotPeriod, svc.gotTenant)
+	}
+}
+
+func TestGenerate_EmptyPeriod_IsDaily(t *testing.T) {
+	svc := &fakeService{}
+
+	if err := NewHandler(svc, "tenant-1").Generate(context.Background(), Event{}); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if svc.gotPeriod != domain.DigestDaily {
+		t.Fatalf("generated %q, want daily", svc.gotPeriod)
+	}
+}
+
+func TestGenerate_UnknownPeriod_Fails(t *testing.T) {
+	svc := &fakeService{}
+
+	err := NewHandler(svc, "tenant-1").Generate(context.Background(), Event{Period: "hourly"})
+
+	if !errors.Is(err, domain.ErrInvalidDigestPeriod) || svc.gotTenant != "" {
+		t.Fatalf("err = %v, generated for %q; want ErrInvalidDigestPeriod and nothing generated", err, svc.gotTenant)
+	}
+}
+
+func TestGenerate_ServiceError_Propagates(t *testing.T) {
+	err := NewHandler(&fakeService{err: errors.New("boom")}, "tenant-1").Generate(context.Background(), Event{})
+
+	if err == nil {
+		t.Fatal("expected the service error to fail the invocation")
+	}
+}
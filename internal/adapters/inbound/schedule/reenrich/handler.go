@@ -0,0 +1,46 @@
+// Package reenrich handles the scheduled trigger that works through a
+// tenant's pending re-enrichment jobs (see application/reenrich). Same
+// shape as schedule/raindrop: EventBridge Scheduler invokes the Lambda
+// directly, and each invocation is one bounded run.
+package reenrich
+
+import (
+	"context"
+	"log/slog"
+
+	appreenrich "github.com/marcogerstmann/insight-processing-platform/internal/application/reenrich"
+)
+
+type Handler struct {
+	svc      appreenrich.Service
+	tenantID string
+}
+
+func NewHandler(svc appreenrich.Service, tenantID string) *Handler {
+	return &Handler{svc: svc, tenantID: tenantID}
+}
+
+// Run resumes tenantID's pending jobs. An error is returned so the
+// invocation is recorded as failed; whatever progress was made before it
+// is already saved on the job.
+func (h *Handler) Run(ctx context.Context) error {
+	jobs, err := h.svc.Resume(ctx, h.tenantID)
+	for _, job := range jobs {
+		slog.InfoContext(ctx, "reenrich job progress",
+			"tenant_id", h.tenantID,
+			"job_id", job.ID,
+			"scope", job.Scope,
+			"status", job.Status,
+			"processed", job.Processed,
+			"enriched", job.Enriched,
+			"failed", job.Failed,
+		)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "reenrich run failed", "tenant_id", h.tenantID, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "reenrich run complete", "tenant_id", h.tenantID, "jobs", len(jobs))
+	return nil
+}
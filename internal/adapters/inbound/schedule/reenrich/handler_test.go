@@ -0,0 +1,75 @@
+package reenrich
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// fakeService's Resume stands in for a run: it returns jobs, or, once ctx
+// is done, the one job as far as it got (its progress already saved, as
+// application/reenrich does) along with ctx's error.
+type fakeService struct {
+	jobs      []domain.ReenrichJob
+	err       error
+	gotTenant string
+}
+
+func (f *fakeService) Request(context.Context, domain.ReenrichJob) error { return nil }
+
+func (f *fakeService) Get(context.Context, string, string) (domain.ReenrichJob, error) {
+	return domain.ReenrichJob{}, nil
+}
+
+func (f *fakeService) Resume(ctx context.Context, tenantID string) ([]domain.ReenrichJob, error) {
+	f.gotTenant = tenantID
+	if err := ctx.Err(); err != nil {
+		return f.jobs[:1], err
+	}
+	return f.jobs, f.err
+}
+
+func TestRun_NoRunnableJob(t *testing.T) {
+	svc := &fakeService{}
+
+	if err := NewHandler(svc, "tenant-1").Run(context.Background()); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if svc.gotTenant != "tenant-1" {
+		t.Fatalf("resumed %q, want tenant-1", svc.gotTenant)
+	}
+}
+
+func TestRun_ResumeError_Propagates(t *testing.T) {
+	svc := &fakeService{
+		jobs: []domain.ReenrichJob{{ID: "job-1", Status: domain.JobStatusPending, Processed: 3}},
+		err:  errors.New("save job job-1 progress: throttled"),
+	}
+
+	err := NewHandler(svc, "tenant-1").Run(context.Background())
+
+	if !errors.Is(err, svc.err) {
+		t.Fatalf("err = %v, want the resume error so the invocation is recorded as failed", err)
+	}
+}
+
+func TestRun_DeadlineMidRun_FailsWithTheProgressSaved(t *testing.T) {
+	svc := &fakeService{jobs: []domain.ReenrichJob{
+		{ID: "job-1", Status: domain.JobStatusPending, Cursor: "i-7", Processed: 7},
+		{ID: "job-2", Status: domain.JobStatusPending},
+	}}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err := NewHandler(svc, "tenant-1").Run(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the deadline, so the next scheduled run resumes job-1 from its cursor", err)
+	}
+	if svc.gotTenant != "tenant-1" {
+		t.Fatalf("resumed %q, want tenant-1", svc.gotTenant)
+	}
+}
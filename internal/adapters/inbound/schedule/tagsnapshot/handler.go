@@ -0,0 +1,37 @@
+// Package tagsnapshot handles the scheduled trigger for tag relevance
+// snapshots (EventBridge Scheduler invokes the Lambda directly — see
+// terraform/envs/dev/tag-snapshot.tf), as schedule/raindrop does for
+// Raindrop polls.
+package tagsnapshot
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/taghistory"
+)
+
+// Handler snapshots a single tenant's tags, the deployment's default one:
+// there's no tenant registry to walk, the same limit raindrop polling has.
+type Handler struct {
+	svc      taghistory.Service
+	tenantID string
+}
+
+func NewHandler(svc taghistory.Service, tenantID string) *Handler {
+	return &Handler{svc: svc, tenantID: tenantID}
+}
+
+// Snapshot records tenantID's tag relevance. A failure is returned so the
+// invocation is recorded as failed; the schedule's next run is the retry.
+func (h *Handler) Snapshot(ctx context.Context) error {
+	snapshot, err := h.svc.Snapshot(ctx, h.tenantID)
+	if err != nil {
+		slog.ErrorContext(ctx, "tag snapshot failed", "tenant_id", h.tenantID, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "tag snapshot complete",
+		"tenant_id", h.tenantID, "taken_at", snapshot.TakenAt, "tags", len(snapshot.Tags))
+	return nil
+}
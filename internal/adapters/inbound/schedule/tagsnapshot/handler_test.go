@@ -0,0 +1,43 @@
+package tagsnapshot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeService struct {
+	err       error
+	gotTenant string
+}
+
+func (f *fakeService) Snapshot(_ context.Context, tenantID string) (domain.TagSnapshot, error) {
+	f.gotTenant = tenantID
+	return domain.TagSnapshot{TenantID: tenantID}, f.err
+}
+
+func (f *fakeService) History(context.Context, string, string, time.Time) ([]domain.TagHistoryPoint, error) {
+	return nil, nil
+}
+
+func TestSnapshot_Success(t *testing.T) {
+	svc := &fakeService{}
+
+	if err := NewHandler(svc, "tenant-1").Snapshot(context.Background()); err != nil {
+		t.Fatalf("Snapshot returned error: %v", err)
+	}
+	if svc.gotTenant != "tenant-1" {
+		t.Fatalf("snapshotted %q, want tenant-1", svc.gotTenant)
+	}
+}
+
+func TestSnapshot_ServiceError_Propagates(t *testing.T) {
+	err := NewHandler(&fakeService{err: errors.New("boom")}, "tenant-1").Snapshot(context.Background())
+
+	if err == nil {
+		t.Fatal("expected the service error to fail the invocation")
+	}
+}
@@ -0,0 +1,116 @@
+// Package graphanalytics consumes KnowledgeUpdated from the domain events
+// bus (its own event-subscription queue) and recomputes the graph
+// analytics of every tenant whose graph changed.
+//
+// The debounce is the event source mapping's batching window: a run of
+// edge writes lands in one batch, and a batch costs one recompute per
+// tenant however many events it carries. Events that arrive after a
+// recompute that already covered them (a redelivery, or a batch cut in
+// two) are skipped by Recompute itself.
+package graphanalytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	appgraphanalytics "github.com/marcogerstmann/insight-processing-platform/internal/application/graphanalytics"
+)
+
+// eventBridgeMessage is the part of an EventBridge event, as delivered to
+// SQS, that this handler reads: the domain.DomainEvent envelope sits in
+// "detail".
+type eventBridgeMessage struct {
+	Detail struct {
+		TenantID   string    `json:"tenant_id"`
+		OccurredAt time.Time `json:"occurred_at"`
+	} `json:"detail"`
+}
+
+type Handler struct {
+	svc appgraphanalytics.Service
+}
+
+func NewHandler(svc appgraphanalytics.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// tenantBatch is one tenant's share of a batch: its records, and the
+// latest change among them.
+type tenantBatch struct {
+	records   []events.SQSMessage
+	changedAt time.Time
+}
+
+// Handle recomputes once per tenant in the batch. A failed recompute
+// reports that tenant's records for redelivery (ReportBatchItemFailures,
+// ADR-009), as does a record that can't be read, which the queue's
+// redrive policy moves to the DLQ once it's out of retries. The returned
+// error is always nil.
+func (h *Handler) Handle(ctx context.Context, e events.SQSEvent) (events.SQSEventResponse, error) {
+	var resp events.SQSEventResponse
+	retry := func(rec events.SQSMessage) {
+		resp.BatchItemFailures = append(resp.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: rec.MessageId})
+	}
+
+	var order []string
+	tenants := map[string]*tenantBatch{}
+	for _, rec := range e.Records {
+		tenantID, occurredAt, err := decode(rec)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to read knowledge update", "message_id", rec.MessageId, "err", err)
+			retry(rec)
+			continue
+		}
+		batch, ok := tenants[tenantID]
+		if !ok {
+			batch = &tenantBatch{}
+			tenants[tenantID] = batch
+			order = append(order, tenantID)
+		}
+		batch.records = append(batch.records, rec)
+		if occurredAt.After(batch.changedAt) {
+			batch.changedAt = occurredAt
+		}
+	}
+
+	for _, tenantID := range order {
+		batch := tenants[tenantID]
+		analytics, recomputed, err := h.svc.Recompute(ctx, tenantID, batch.changedAt)
+		if err != nil {
+			slog.ErrorContext(ctx, "graph analytics recompute failed (transient, retrying)",
+				"tenant_id", tenantID,
+				"events", len(batch.records),
+				"err", err,
+			)
+			for _, rec := range batch.records {
+				retry(rec)
+			}
+			continue
+		}
+		slog.InfoContext(ctx, "graph analytics up to date",
+			"tenant_id", tenantID,
+			"events", len(batch.records),
+			"recomputed", recomputed,
+			"clusters", len(analytics.Clusters),
+			"computed_at", analytics.ComputedAt,
+		)
+	}
+	return resp, nil
+}
+
+func decode(rec events.SQSMessage) (tenantID string, occurredAt time.Time, err error) {
+	var msg eventBridgeMessage
+	if err := json.Unmarshal([]byte(rec.Body), &msg); err != nil {
+		return "", time.Time{}, fmt.Errorf("decode event: %w", err)
+	}
+	if msg.Detail.TenantID == "" {
+		return "", time.Time{}, errors.New("event has no tenant_id")
+	}
+	return msg.Detail.TenantID, msg.Detail.OccurredAt, nil
+}
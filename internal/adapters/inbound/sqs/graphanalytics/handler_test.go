@@ -0,0 +1,79 @@
+package graphanalytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type spyService struct {
+	calls   map[string]time.Time
+	errByID map[string]error
+}
+
+func (s *spyService) Recompute(_ context.Context, tenantID string, changedAt time.Time) (domain.GraphAnalytics, bool, error) {
+	if _, ok := s.calls[tenantID]; ok {
+		panic("recomputed " + tenantID + " twice in one batch")
+	}
+	s.calls[tenantID] = changedAt
+	return domain.GraphAnalytics{TenantID: tenantID}, true, s.errByID[tenantID]
+}
+
+func (s *spyService) Get(context.Context, string) (domain.GraphAnalytics, error) {
+	return domain.GraphAnalytics{}, nil
+}
+
+func knowledgeUpdated(id, tenantID, occurredAt string) events.SQSMessage {
+	return events.SQSMessage{
+		MessageId: id,
+		Body: `{"detail-type":"KnowledgeUpdated","source":"ipp.core","detail":{"event_type":"KnowledgeUpdated","version":1,` +
+			`"tenant_id":"` + tenantID + `","occurred_at":"` + occurredAt + `","payload":{"change":"upserted"}}}`,
+	}
+}
+
+func TestHandler_Handle_RecomputesEachTenantOnceAsOfItsLatestChange(t *testing.T) {
+	svc := &spyService{calls: map[string]time.Time{}}
+	h := NewHandler(svc)
+
+	resp, err := h.Handle(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		knowledgeUpdated("m-1", "t-1", "2026-03-01T10:00:00Z"),
+		knowledgeUpdated("m-2", "t-2", "2026-03-01T10:00:05Z"),
+		knowledgeUpdated("m-3", "t-1", "2026-03-01T10:00:09Z"),
+		knowledgeUpdated("m-4", "t-1", "2026-03-01T10:00:03Z"),
+	}})
+
+	if err != nil || len(resp.BatchItemFailures) != 0 {
+		t.Fatalf("resp = %+v, err = %v; want the whole batch handled", resp, err)
+	}
+	if len(svc.calls) != 2 {
+		t.Fatalf("recomputed %v, want t-1 and t-2 once each", svc.calls)
+	}
+	if want := time.Date(2026, 3, 1, 10, 0, 9, 0, time.UTC); !svc.calls["t-1"].Equal(want) {
+		t.Fatalf("t-1 changedAt = %v, want the latest event's %v", svc.calls["t-1"], want)
+	}
+}
+
+func TestHandler_Handle_FailedTenantRetriesOnlyItsRecords(t *testing.T) {
+	svc := &spyService{calls: map[string]time.Time{}, errByID: map[string]error{"t-2": errors.New("throttled")}}
+	h := NewHandler(svc)
+
+	resp, _ := h.Handle(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		knowledgeUpdated("m-1", "t-1", "2026-03-01T10:00:00Z"),
+		knowledgeUpdated("m-2", "t-2", "2026-03-01T10:00:00Z"),
+		knowledgeUpdated("m-3", "t-2", "2026-03-01T10:00:01Z"),
+		{MessageId: "m-4", Body: "not json"},
+	}})
+
+	var failed []string
+	for _, f := range resp.BatchItemFailures {
+		failed = append(failed, f.ItemIdentifier)
+	}
+	if len(failed) != 3 || failed[0] != "m-4" || failed[1] != "m-2" || failed[2] != "m-3" {
+		t.Fatalf("failures = %v, want the unreadable m-4 and t-2's m-2, m-3", failed)
+	}
+}
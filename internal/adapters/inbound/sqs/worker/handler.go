@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/marcogerstmann/insight-processing-platform/internal/apperr"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	port "github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
@@ -20,7 +21,22 @@ func NewHandler(svc insight.Service, dlq port.DLQPublisher) *Handler {
 	return &Handler{svc: svc, dlq: dlq}
 }
 
-func (h *Handler) Handle(ctx context.Context, e events.SQSEvent) error {
+// Handle processes the batch as one insight.Service.ProcessBatch call, so
+// a bulk import's highlights are enriched together. It reports transient
+// failures per record (ReportBatchItemFailures, ADR-009): SQS redelivers
+// just those, and the rest of the batch is deleted. Permanent failures go
+// to the DLQ and count as handled. The returned error is always nil.
+func (h *Handler) Handle(ctx context.Context, e events.SQSEvent) (events.SQSEventResponse, error) {
+	var resp events.SQSEventResponse
+	retry := func(rec events.SQSMessage) {
+		resp.BatchItemFailures = append(resp.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: rec.MessageId})
+	}
+
+	var (
+		recs     []events.SQSMessage
+		evs      []domain.IngestEvent
+		insights []domain.Insight
+	)
 	for _, rec := range e.Records {
 		ev, err := mapRecordToDomain(rec)
 		if err != nil {
@@ -32,23 +48,32 @@ func (h *Handler) Handle(ctx context.Context, e events.SQSEvent) error {
 				"message_id", rec.MessageId,
 				"err", err,
 			)
-			return err
+			retry(rec)
+			continue
 		}
+		recs = append(recs, rec)
+		evs = append(evs, ev)
+		insights = append(insights, mapIngestEventToInsight(ev))
+	}
+	if len(insights) == 0 {
+		return resp, nil
+	}
 
-		i := mapIngestEventToInsight(ev)
-		res, err := h.svc.Process(ctx, i)
-		if err != nil {
-			if errors.As(err, &apperr.PermanentError{}) {
-				h.routeToDLQ(ctx, rec, err)
+	for i, res := range h.svc.ProcessBatch(ctx, insights) {
+		rec, ev := recs[i], evs[i]
+		if res.Err != nil {
+			if errors.As(res.Err, &apperr.PermanentError{}) {
+				h.routeToDLQ(ctx, rec, res.Err)
 				continue
 			}
 			slog.ErrorContext(ctx, "worker processing failed (transient, retrying)",
 				"message_id", rec.MessageId,
 				"tenant_id", ev.TenantID,
 				"highlight_id", ev.Highlight.ID,
-				"err", err,
+				"err", res.Err,
 			)
-			return err
+			retry(rec)
+			continue
 		}
 
 		slog.InfoContext(ctx, "worker processed message",
@@ -56,10 +81,11 @@ func (h *Handler) Handle(ctx context.Context, e events.SQSEvent) error {
 			"tenant_id", ev.TenantID,
 			"highlight_id", ev.Highlight.ID,
 			"inserted", res.Inserted,
+			"merged_into", res.MergedInto,
 		)
 	}
 
-	return nil
+	return resp, nil
 }
 
 func (h *Handler) routeToDLQ(ctx context.Context, rec events.SQSMessage, err error) {
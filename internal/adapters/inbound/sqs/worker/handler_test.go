@@ -14,6 +14,7 @@ import (
 
 type spyService struct {
 	processed []domain.Insight
+	batches   []int
 	errByID   map[string]error
 }
 
@@ -25,6 +26,19 @@ func (s *spyService) Process(_ context.Context, i domain.Insight) (insight.Resul
 	return insight.Result{Inserted: true}, nil
 }
 
+func (s *spyService) ProcessBatch(ctx context.Context, insights []domain.Insight) []insight.BatchResult {
+	s.batches = append(s.batches, len(insights))
+	out := make([]insight.BatchResult, len(insights))
+	for i, in := range insights {
+		out[i].Result, out[i].Err = s.Process(ctx, in)
+	}
+	return out
+}
+
+func (s *spyService) Reenrich(_ context.Context, i domain.Insight) (domain.Insight, error) {
+	return i, nil
+}
+
 func (s *spyService) ListByTenantID(_ context.Context, _, _ string) ([]domain.Insight, error) {
 	return nil, nil
 }
@@ -80,11 +94,11 @@ func TestHandler_Handle_ValidRecord_ProcessesAndSkipsDLQ(t *testing.T) {
 	dlq := &spyDLQ{}
 	h := NewHandler(svc, dlq)
 
-	err := h.Handle(context.Background(), events.SQSEvent{
+	resp, err := h.Handle(context.Background(), events.SQSEvent{
 		Records: []events.SQSMessage{record("m-1", "idk-1", validBody(t, "hl-1"))},
 	})
-	if err != nil {
-		t.Fatalf("unexpected err: %v", err)
+	if err != nil || len(resp.BatchItemFailures) != 0 {
+		t.Fatalf("unexpected err: %v (failures %v)", err, resp.BatchItemFailures)
 	}
 
 	if len(svc.processed) != 1 {
@@ -139,8 +153,9 @@ func TestHandler_Handle_PermanentMappingError_RoutesToDLQ_NoRetry(t *testing.T)
 
 			// No error returned: a poison message must not trigger redelivery,
 			// it is unfixable and burns the retry budget for nothing (ADR-009).
-			if err := h.Handle(context.Background(), events.SQSEvent{Records: []events.SQSMessage{rec}}); err != nil {
-				t.Fatalf("expected no error so SQS deletes the message, got %v", err)
+			resp, err := h.Handle(context.Background(), events.SQSEvent{Records: []events.SQSMessage{rec}})
+			if err != nil || len(resp.BatchItemFailures) != 0 {
+				t.Fatalf("expected no failure reported so SQS deletes the message, got %v, %v", resp.BatchItemFailures, err)
 			}
 			if len(svc.processed) != 0 {
 				t.Fatalf("expected the service never to see a malformed record, got %v", svc.processed)
@@ -161,11 +176,11 @@ func TestHandler_Handle_PermanentServiceError_RoutesToDLQ_NoRetry(t *testing.T)
 	dlq := &spyDLQ{}
 	h := NewHandler(svc, dlq)
 
-	err := h.Handle(context.Background(), events.SQSEvent{
+	resp, err := h.Handle(context.Background(), events.SQSEvent{
 		Records: []events.SQSMessage{record("m-1", "idk-1", validBody(t, "hl-1"))},
 	})
-	if err != nil {
-		t.Fatalf("expected no error for a permanent failure, got %v", err)
+	if err != nil || len(resp.BatchItemFailures) != 0 {
+		t.Fatalf("expected no error for a permanent failure, got %v (failures %v)", err, resp.BatchItemFailures)
 	}
 	if len(dlq.sentIDs) != 1 || dlq.sentIDs[0] != "m-1" {
 		t.Fatalf("expected m-1 routed to DLQ, got %v", dlq.sentIDs)
@@ -175,19 +190,22 @@ func TestHandler_Handle_PermanentServiceError_RoutesToDLQ_NoRetry(t *testing.T)
 	}
 }
 
-func TestHandler_Handle_TransientServiceError_ReturnsError_SkipsDLQ(t *testing.T) {
+func TestHandler_Handle_TransientServiceError_ReportsItemFailure_SkipsDLQ(t *testing.T) {
 	transient := errors.New("dynamodb throttled")
 	svc := &spyService{errByID: map[string]error{"idk-1": transient}}
 	dlq := &spyDLQ{}
 	h := NewHandler(svc, dlq)
 
-	err := h.Handle(context.Background(), events.SQSEvent{
+	resp, err := h.Handle(context.Background(), events.SQSEvent{
 		Records: []events.SQSMessage{record("m-1", "idk-1", validBody(t, "hl-1"))},
 	})
-	// Returning the error is what preserves at-least-once delivery: SQS keeps
-	// the message invisible and redelivers it.
-	if !errors.Is(err, transient) {
-		t.Fatalf("expected the transient error returned so SQS redelivers, got %v", err)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	// Reporting the record is what preserves at-least-once delivery: SQS
+	// keeps the message and redelivers it.
+	if len(resp.BatchItemFailures) != 1 || resp.BatchItemFailures[0].ItemIdentifier != "m-1" {
+		t.Fatalf("expected m-1 reported for redelivery, got %v", resp.BatchItemFailures)
 	}
 	if len(dlq.sentIDs) != 0 {
 		t.Fatalf("expected a retryable failure never to reach the DLQ, got %v", dlq.sentIDs)
@@ -199,14 +217,14 @@ func TestHandler_Handle_PoisonRecordDoesNotBlockTheRestOfTheBatch(t *testing.T)
 	dlq := &spyDLQ{}
 	h := NewHandler(svc, dlq)
 
-	err := h.Handle(context.Background(), events.SQSEvent{
+	resp, err := h.Handle(context.Background(), events.SQSEvent{
 		Records: []events.SQSMessage{
 			record("m-bad", "idk-bad", "{not json"),
 			record("m-good", "idk-good", validBody(t, "hl-2")),
 		},
 	})
-	if err != nil {
-		t.Fatalf("unexpected err: %v", err)
+	if err != nil || len(resp.BatchItemFailures) != 0 {
+		t.Fatalf("unexpected err: %v (failures %v)", err, resp.BatchItemFailures)
 	}
 
 	if len(svc.processed) != 1 || svc.processed[0].ID != "idk-good" {
@@ -224,42 +242,45 @@ func TestHandler_Handle_DLQSendFailure_IsSwallowedAsDegradedPath(t *testing.T) {
 
 	// ADR-009: a failed DLQ send is logged and dropped; the record then falls
 	// back to ordinary redrive rather than failing the batch.
-	err := h.Handle(context.Background(), events.SQSEvent{
+	resp, err := h.Handle(context.Background(), events.SQSEvent{
 		Records: []events.SQSMessage{record("m-bad", "idk-bad", "{not json")},
 	})
-	if err != nil {
-		t.Fatalf("expected a failed DLQ send to be swallowed, got %v", err)
+	if err != nil || len(resp.BatchItemFailures) != 0 {
+		t.Fatalf("expected a failed DLQ send to be swallowed, got %v (failures %v)", err, resp.BatchItemFailures)
 	}
 	if len(dlq.sentIDs) != 1 {
 		t.Fatalf("expected one DLQ attempt, got %v", dlq.sentIDs)
 	}
 }
 
-// A transient failure fails the whole batch, so records after it are never
-// reached and records before it are reprocessed on redelivery. That is only
-// safe because batch_size = 1 (ADR-007/ADR-009); this test fails the day
-// someone raises it without switching to ReportBatchItemFailures.
-func TestHandler_Handle_TransientError_AbortsRemainderOfBatch(t *testing.T) {
+// A transient failure is reported for its own record only: the records
+// around it are processed, and deleted, instead of being redelivered with
+// it (ADR-009). The batch reaches the service as one call, which is what
+// lets enrichment batch a bulk import's highlights.
+func TestHandler_Handle_TransientError_ReportsOnlyThatRecord(t *testing.T) {
 	transient := errors.New("dynamodb throttled")
 	svc := &spyService{errByID: map[string]error{"idk-2": transient}}
 	dlq := &spyDLQ{}
 	h := NewHandler(svc, dlq)
 
-	err := h.Handle(context.Background(), events.SQSEvent{
+	resp, err := h.Handle(context.Background(), events.SQSEvent{
 		Records: []events.SQSMessage{
 			record("m-1", "idk-1", validBody(t, "hl-1")),
 			record("m-2", "idk-2", validBody(t, "hl-2")),
 			record("m-3", "idk-3", validBody(t, "hl-3")),
 		},
 	})
-	if !errors.Is(err, transient) {
-		t.Fatalf("expected the transient error returned, got %v", err)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
 	}
 
-	if len(svc.processed) != 2 {
-		t.Fatalf("expected the batch to abort at the failing record, got %v", svc.processed)
+	if len(svc.batches) != 1 || svc.batches[0] != 3 {
+		t.Fatalf("expected one ProcessBatch call of 3, got %v", svc.batches)
+	}
+	if len(svc.processed) != 3 {
+		t.Fatalf("expected every record processed, got %v", svc.processed)
 	}
-	if svc.processed[1].ID != "idk-2" {
-		t.Fatalf("expected idk-2 to be the last attempted record, got %q", svc.processed[1].ID)
+	if len(resp.BatchItemFailures) != 1 || resp.BatchItemFailures[0].ItemIdentifier != "m-2" {
+		t.Fatalf("expected only m-2 reported for redelivery, got %v", resp.BatchItemFailures)
 	}
 }
@@ -0,0 +1,266 @@
+// Package anthropic is the enrichment adapter for Anthropic's Messages API,
+// selectable with LLM_PROVIDER=anthropic (see llmprovider).
+//
+// ADR-018 made OpenAI the default provider, and that hasn't changed; this
+// exists for its reversal trigger — wanting a model OpenAI doesn't serve —
+// without a redeploy of anything but configuration. Plain net/http rather
+// than a vendor SDK, the same as the readwise adapter: it's one endpoint.
+//
+// There are no structured outputs here, so the shape guarantee comes from a
+// forced tool call instead: enrichment.Schema is the tool's input_schema and
+// tool_choice names it, so the model can only answer by calling it.
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/enrichment"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+const (
+	defaultBaseURL = "https://api.anthropic.com"
+	apiVersion     = "2023-06-01"
+
+	// enrichModel is Anthropic's smallest current model; the same judgement
+	// as the OpenAI adapter's — tagging is an easy extraction task.
+	enrichModel = "claude-haiku-4-5"
+
+	// baseBackoff doubles per retry unless the API says how long to wait.
+	baseBackoff = 500 * time.Millisecond
+	maxBackoff  = 8 * time.Second
+)
+
+var _ ports.BatchEnrichmentClient = (*Client)(nil)
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+	bounds     enrichment.Bounds
+}
+
+func NewClient(apiKey string) *Client {
+	return newClient(defaultBaseURL, apiKey, enrichModel, enrichment.DefaultBounds)
+}
+
+func newClient(baseURL, apiKey, model string, bounds enrichment.Bounds) *Client {
+	return &Client{
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		model:      model,
+		bounds:     bounds,
+	}
+}
+
+type messagesRequest struct {
+	Model      string     `json:"model"`
+	MaxTokens  int64      `json:"max_tokens"`
+	System     string     `json:"system"`
+	Messages   []message  `json:"messages"`
+	Tools      []tool     `json:"tools"`
+	ToolChoice toolChoice `json:"tool_choice"`
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type tool struct {
+	Name        string         `json:"name"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type toolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type messagesResponse struct {
+	Model   string         `json:"model"`
+	Content []contentBlock `json:"content"`
+	Usage   struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+type contentBlock struct {
+	Type  string          `json:"type"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+func (c *Client) Version() string {
+	return enrichment.Version(c.model)
+}
+
+// Enrich bounds the whole call, retries included, by bounds.Timeout — the
+// same budget the OpenAI SDK applies through its request context.
+func (c *Client) Enrich(ctx context.Context, text string, tagLanguage domain.Language) (domain.Enrichment, error) {
+	input, usage, err := c.callTool(ctx, enrichment.SystemPromptFor(tagLanguage), text,
+		enrichment.SchemaName, enrichment.Schema, c.bounds.MaxTokens)
+	if err != nil {
+		return domain.Enrichment{}, err
+	}
+
+	var out enrichment.Output
+	if err := json.Unmarshal(input, &out); err != nil {
+		return domain.Enrichment{}, usage.Billed(fmt.Errorf("unmarshal tool input: %w", err))
+	}
+	return out.ToDomain(usage), nil
+}
+
+// EnrichBatch forces a call to a tool whose input_schema is
+// enrichment.BatchSchema, with the output cap scaled to the batch.
+func (c *Client) EnrichBatch(ctx context.Context, texts []string, tagLanguage domain.Language) ([]domain.Enrichment, error) {
+	user, err := enrichment.BatchUserMessage(texts)
+	if err != nil {
+		return nil, err
+	}
+	input, usage, err := c.callTool(ctx, enrichment.BatchSystemPromptFor(tagLanguage), user,
+		enrichment.BatchSchemaName, enrichment.BatchSchema, c.bounds.BatchMaxTokens(len(texts)))
+	if err != nil {
+		return nil, err
+	}
+
+	var out enrichment.BatchOutput
+	if err := json.Unmarshal(input, &out); err != nil {
+		return nil, usage.Billed(fmt.Errorf("%w: unmarshal tool input: %w", ports.ErrMalformedBatch, err))
+	}
+	enrichments, err := out.ToDomain(len(texts), usage)
+	if err != nil {
+		return nil, usage.Billed(err)
+	}
+	return enrichments, nil
+}
+
+// callTool makes one call forcing the tool named name and returns that
+// tool call's raw input with what the call cost. A response without the
+// tool call is still billed.
+func (c *Client) callTool(ctx context.Context, system, user, name string, schema map[string]any, maxTokens int64) (json.RawMessage, enrichment.Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.bounds.Timeout)
+	defer cancel()
+
+	body, err := json.Marshal(messagesRequest{
+		Model:      c.model,
+		MaxTokens:  maxTokens,
+		System:     system,
+		Messages:   []message{{Role: "user", Content: user}},
+		Tools:      []tool{{Name: name, InputSchema: schema}},
+		ToolChoice: toolChoice{Type: "tool", Name: name},
+	})
+	if err != nil {
+		return nil, enrichment.Usage{}, err
+	}
+
+	start := time.Now()
+	resp, err := c.send(ctx, body)
+	if err != nil {
+		return nil, enrichment.Usage{}, err
+	}
+
+	usage := enrichment.Usage{
+		Model:        resp.Model,
+		InputTokens:  resp.Usage.InputTokens,
+		OutputTokens: resp.Usage.OutputTokens,
+		Latency:      time.Since(start),
+	}
+	enrichment.LogComplete(ctx, "anthropic", usage)
+
+	for _, block := range resp.Content {
+		if block.Type == "tool_use" && block.Name == name {
+			return block.Input, usage, nil
+		}
+	}
+	return nil, usage, usage.Billed(errors.New("no tool_use block in response"))
+}
+
+// send posts body, retrying rate limits, overload and server errors up to
+// bounds.MaxRetries times. Anything else is the request's fault and would
+// fail the same way again.
+func (c *Client) send(ctx context.Context, body []byte) (messagesResponse, error) {
+	for attempt := 0; ; attempt++ {
+		resp, retryAfter, err := c.post(ctx, body)
+		if err == nil {
+			return resp, nil
+		}
+		if retryAfter < 0 || attempt >= c.bounds.MaxRetries {
+			return messagesResponse{}, err
+		}
+
+		wait := retryAfter
+		if wait == 0 {
+			wait = min(baseBackoff<<attempt, maxBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return messagesResponse{}, fmt.Errorf("%w (after %w)", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// post makes one attempt. On failure retryAfter says whether to retry: -1
+// never, 0 with backoff, otherwise after the delay the API asked for.
+func (c *Client) post(ctx context.Context, body []byte) (resp messagesResponse, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return messagesResponse{}, -1, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Anthropic-Version", apiVersion)
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return messagesResponse{}, -1, err
+		}
+		// A connection error, not an answer: worth another go.
+		return messagesResponse{}, 0, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	switch {
+	case httpResp.StatusCode == http.StatusOK:
+	case httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode >= http.StatusInternalServerError:
+		_, _ = io.Copy(io.Discard, httpResp.Body)
+		return messagesResponse{}, parseRetryAfter(httpResp.Header.Get("Retry-After")),
+			fmt.Errorf("anthropic: status %d", httpResp.StatusCode)
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1<<10))
+		return messagesResponse{}, -1, fmt.Errorf("anthropic: status %d: %s", httpResp.StatusCode, msg)
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return messagesResponse{}, -1, fmt.Errorf("decode response: %w", err)
+	}
+	return resp, 0, nil
+}
+
+// parseRetryAfter reads a Retry-After header in seconds, or 0 (back off by
+// the default schedule) when it's absent, unparseable or unreasonably long.
+// "0" is honoured as "retry now", as a delay too short to matter.
+func parseRetryAfter(v string) time.Duration {
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs < 0 || secs >= 60 {
+		return 0
+	}
+	if secs == 0 {
+		return time.Millisecond
+	}
+	return time.Duration(secs) * time.Second
+}
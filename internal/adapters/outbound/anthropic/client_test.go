@@ -0,0 +1,83 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/enrichment"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/enrichment/enrichmenttest"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+func writeJSON(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// messageJSON answers with a tool_use block when content is JSON, and with
+// a plain text block — a model that ignored the tool — when it isn't.
+func messageJSON(name, content string, usage enrichment.Usage) map[string]any {
+	block := map[string]any{"type": "text", "text": content}
+	if json.Valid([]byte(content)) {
+		block = map[string]any{"type": "tool_use", "id": "toolu_test", "name": name, "input": json.RawMessage(content)}
+	}
+	return map[string]any{
+		"id":          "msg_test",
+		"type":        "message",
+		"role":        "assistant",
+		"model":       usage.Model,
+		"stop_reason": "tool_use",
+		"content":     []map[string]any{block},
+		"usage":       map[string]any{"input_tokens": usage.InputTokens, "output_tokens": usage.OutputTokens},
+	}
+}
+
+func TestClient_Conformance(t *testing.T) {
+	enrichmenttest.Run(t, enrichmenttest.Provider{
+		NewClient: func(baseURL string, bounds enrichment.Bounds) ports.EnrichmentClient {
+			return newClient(baseURL, "test-key", enrichModel, bounds)
+		},
+		Respond: func(w http.ResponseWriter, name, content string, usage enrichment.Usage) {
+			writeJSON(w, messageJSON(name, content, usage))
+		},
+		MaxTokens: func(body map[string]any) float64 {
+			n, _ := body["max_tokens"].(float64)
+			return n
+		},
+	})
+}
+
+func TestEnrich_ForcesTheSchemaToolAndAuthenticates(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("path = %q, want /v1/messages", r.URL.Path)
+		}
+		if r.Header.Get("X-Api-Key") != "test-key" || r.Header.Get("Anthropic-Version") != apiVersion {
+			t.Errorf("unexpected auth headers: %v", r.Header)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		writeJSON(w, messageJSON(enrichment.SchemaName, `{"tags":["stoicism"]}`, enrichment.Usage{Model: enrichModel}))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newClient(srv.URL, "test-key", enrichModel, enrichment.DefaultBounds)
+	if _, err := c.Enrich(context.Background(), "text", "en"); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+
+	// tool_choice is what makes the tool_use scan safe — without it the
+	// model may answer in prose and never call the tool.
+	choice, _ := body["tool_choice"].(map[string]any)
+	if choice["type"] != "tool" || choice["name"] != enrichment.SchemaName {
+		t.Errorf("tool_choice = %v, want the schema tool forced", body["tool_choice"])
+	}
+	if body["model"] != enrichModel {
+		t.Errorf("model = %v, want %q", body["model"], enrichModel)
+	}
+}
@@ -0,0 +1,100 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.ContradictionResolutionRepository = (*InsightAdapter)(nil)
+
+// dynamoContradictionResolutionItem is written once per pair, keyed in
+// domain.ContradictionPair order (sk = CONTRADICTION#<a>#<b>): it's only
+// ever listed whole, never looked up from one insight's side.
+type dynamoContradictionResolutionItem struct {
+	PK             string    `dynamodbav:"pk"`
+	SK             string    `dynamodbav:"sk"`
+	TenantID       string    `dynamodbav:"tenant_id"`
+	InsightID      string    `dynamodbav:"insight_id"`
+	OtherInsightID string    `dynamodbav:"other_insight_id"`
+	Note           string    `dynamodbav:"note,omitempty"`
+	ResolvedAt     time.Time `dynamodbav:"resolved_at"`
+}
+
+func contradictionSK(insightID, otherInsightID string) string {
+	a, b := domain.ContradictionPair(insightID, otherInsightID)
+	return "CONTRADICTION#" + a + "#" + b
+}
+
+func (r *InsightAdapter) SaveContradictionResolution(ctx context.Context, resolution domain.ContradictionResolution) error {
+	a, b := domain.ContradictionPair(resolution.InsightID, resolution.OtherInsightID)
+	av, err := attributevalue.MarshalMap(dynamoContradictionResolutionItem{
+		PK:             pk(resolution.TenantID),
+		SK:             contradictionSK(a, b),
+		TenantID:       resolution.TenantID,
+		InsightID:      a,
+		OtherInsightID: b,
+		Note:           resolution.Note,
+		ResolvedAt:     resolution.ResolvedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+func (r *InsightAdapter) DeleteContradictionResolution(ctx context.Context, tenantID, insightID, otherInsightID string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: contradictionSK(insightID, otherInsightID)},
+		},
+	})
+	return err
+}
+
+func (r *InsightAdapter) ListContradictionResolutions(ctx context.Context, tenantID string) ([]domain.ContradictionResolution, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: "CONTRADICTION#"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolutions := make([]domain.ContradictionResolution, 0, len(out.Items))
+	for _, dynItem := range out.Items {
+		var item dynamoContradictionResolutionItem
+		if err := attributevalue.UnmarshalMap(dynItem, &item); err != nil {
+			return nil, err
+		}
+		resolutions = append(resolutions, domain.ContradictionResolution{
+			TenantID:       item.TenantID,
+			InsightID:      item.InsightID,
+			OtherInsightID: item.OtherInsightID,
+			Note:           item.Note,
+			ResolvedAt:     item.ResolvedAt,
+		})
+	}
+	return resolutions, nil
+}
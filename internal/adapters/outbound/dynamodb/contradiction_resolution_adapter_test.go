@@ -0,0 +1,38 @@
+package dynamodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_ContradictionResolutions_KeyedByPairEitherWay(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	if err := a.SaveContradictionResolution(ctx, domain.ContradictionResolution{TenantID: "t-1", InsightID: "z", OtherInsightID: "a", Note: "first", ResolvedAt: now}); err != nil {
+		t.Fatalf("SaveContradictionResolution: %v", err)
+	}
+	// Resolving the same pair from the other side replaces the note.
+	if err := a.SaveContradictionResolution(ctx, domain.ContradictionResolution{TenantID: "t-1", InsightID: "a", OtherInsightID: "z", Note: "second", ResolvedAt: now}); err != nil {
+		t.Fatalf("SaveContradictionResolution: %v", err)
+	}
+
+	got, err := a.ListContradictionResolutions(ctx, "t-1")
+	if err != nil || len(got) != 1 || got[0].Note != "second" || got[0].InsightID != "a" || got[0].OtherInsightID != "z" || !got[0].ResolvedAt.Equal(now) {
+		t.Fatalf("got %+v, %v; want one resolution for a-z", got, err)
+	}
+	if other, _ := a.ListContradictionResolutions(ctx, "t-2"); len(other) != 0 {
+		t.Fatalf("t-2 listed t-1's resolutions: %+v", other)
+	}
+
+	if err := a.DeleteContradictionResolution(ctx, "t-1", "z", "a"); err != nil {
+		t.Fatalf("DeleteContradictionResolution: %v", err)
+	}
+	if got, _ := a.ListContradictionResolutions(ctx, "t-1"); len(got) != 0 {
+		t.Fatalf("got %+v after reopening, want none", got)
+	}
+}
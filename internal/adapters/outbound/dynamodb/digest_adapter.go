@@ -0,0 +1,367 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.DigestRepository = (*InsightAdapter)(nil)
+
+const (
+	digestSKPrefix = "DIGEST#"
+	// digestNotifiedSKPrefix doesn't start with digestSKPrefix, so the
+	// pointers stay out of ListDigests' query.
+	digestNotifiedSKPrefix = "DIGESTNOTIFIED#"
+)
+
+type dynamoDigestInsight struct {
+	InsightID string   `dynamodbav:"insight_id"`
+	Source    string   `dynamodbav:"source"`
+	Excerpt   string   `dynamodbav:"excerpt"`
+	Tags      []string `dynamodbav:"tags,omitempty"`
+}
+
+type dynamoDigestRelationship struct {
+	FromInsightID string  `dynamodbav:"from_insight_id"`
+	FromExcerpt   string  `dynamodbav:"from_excerpt"`
+	ToInsightID   string  `dynamodbav:"to_insight_id"`
+	ToExcerpt     string  `dynamodbav:"to_excerpt"`
+	Type          string  `dynamodbav:"type"`
+	Confidence    float64 `dynamodbav:"confidence"`
+}
+
+type dynamoTagMove struct {
+	Tag  string  `dynamodbav:"tag"`
+	From float64 `dynamodbav:"from"`
+	To   float64 `dynamodbav:"to"`
+}
+
+type dynamoDigestPlan struct {
+	PlanID            string `dynamodbav:"plan_id"`
+	Tag               string `dynamodbav:"tag"`
+	FocusSentence     string `dynamodbav:"focus_sentence"`
+	Status            string `dynamodbav:"status"`
+	Actions           int    `dynamodbav:"actions"`
+	Requested         bool   `dynamodbav:"requested"`
+	Resolved          bool   `dynamodbav:"resolved"`
+	ActionsStarted    int    `dynamodbav:"actions_started"`
+	ActionsFinished   int    `dynamodbav:"actions_finished"`
+	CompletionPercent int    `dynamodbav:"completion_percent"`
+}
+
+// dynamoDigestItem is one digest, lists and renderings alike (pk =
+// TENANT#<id>, sk = DIGEST#<generatedAt>#<period>); domain.MaxDigestEntries
+// and the excerpts keep it well under the item size limit. expires_at is
+// the table's TTL attribute, as on dynamoTagSnapshotItem.
+type dynamoDigestItem struct {
+	PK                string                     `dynamodbav:"pk"`
+	SK                string                     `dynamodbav:"sk"`
+	Period            string                     `dynamodbav:"period"`
+	Since             time.Time                  `dynamodbav:"since"`
+	GeneratedAt       time.Time                  `dynamodbav:"generated_at"`
+	Notified          bool                       `dynamodbav:"notified"`
+	InsightCount      int                        `dynamodbav:"insight_count"`
+	Insights          []dynamoDigestInsight      `dynamodbav:"insights"`
+	RelationshipCount int                        `dynamodbav:"relationship_count"`
+	Relationships     []dynamoDigestRelationship `dynamodbav:"relationships"`
+	TagMoves          []dynamoTagMove            `dynamodbav:"tag_moves"`
+	Plans             []dynamoDigestPlan         `dynamodbav:"plans"`
+	Markdown          string                     `dynamodbav:"markdown"`
+	HTML              string                     `dynamodbav:"html"`
+	ExpiresAt         int64                      `dynamodbav:"expires_at"`
+}
+
+// digestSK leads with the fixed-width second-precision timestamp, as
+// tagSnapshotSK does, so a tenant's digests sort by time; the period
+// keeps a daily and a weekly digest generated in the same second apart.
+func digestSK(generatedAt time.Time, period domain.DigestPeriod) string {
+	return digestSKPrefix + generatedAt.UTC().Format(time.RFC3339) + "#" + string(period)
+}
+
+func digestNotifiedSK(period domain.DigestPeriod) string {
+	return digestNotifiedSKPrefix + string(period)
+}
+
+// dynamoDigestNotifiedItem points at the latest notified digest of a
+// period (pk = TENANT#<id>, sk = DIGESTNOTIFIED#<period>), so
+// LastNotifiedAt is one GetItem however many digests the tenant has. It
+// has no TTL: it's one small item per period, and a tenant whose digests
+// have all expired still picks up where the last one left off.
+type dynamoDigestNotifiedItem struct {
+	PK          string    `dynamodbav:"pk"`
+	SK          string    `dynamodbav:"sk"`
+	GeneratedAt time.Time `dynamodbav:"generated_at"`
+}
+
+// ListInsightsIngestedSince reads every insight and filters on created_at
+// in Go, as ListReviewSchedules filters on due_at: once a day, over a
+// personal library.
+func (r *InsightAdapter) ListInsightsIngestedSince(ctx context.Context, tenantID string, since time.Time) ([]domain.Insight, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: "INSIGHT#"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var insights []domain.Insight
+	for _, av := range out.Items {
+		var item dynamoInsightItem
+		if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+			return nil, err
+		}
+		if !item.CreatedAt.After(since) {
+			continue
+		}
+		insight, err := unmarshalInsight(av)
+		if err != nil {
+			return nil, err
+		}
+		insights = append(insights, insight)
+	}
+	return insights, nil
+}
+
+func (r *InsightAdapter) SaveDigest(ctx context.Context, digest domain.Digest, expiresAt time.Time) error {
+	item := dynamoDigestItem{
+		PK:                pk(digest.TenantID),
+		SK:                digestSK(digest.GeneratedAt, digest.Period),
+		Period:            string(digest.Period),
+		Since:             digest.Since,
+		GeneratedAt:       digest.GeneratedAt,
+		Notified:          digest.Notified,
+		InsightCount:      digest.InsightCount,
+		Insights:          make([]dynamoDigestInsight, len(digest.Insights)),
+		RelationshipCount: digest.RelationshipCount,
+		Relationships:     make([]dynamoDigestRelationship, len(digest.Relationships)),
+		TagMoves:          make([]dynamoTagMove, len(digest.TagMoves)),
+		Plans:             make([]dynamoDigestPlan, len(digest.Plans)),
+		Markdown:          digest.Markdown,
+		HTML:              digest.HTML,
+		ExpiresAt:         expiresAt.Unix(),
+	}
+	for i, in := range digest.Insights {
+		item.Insights[i] = dynamoDigestInsight{InsightID: in.InsightID, Source: in.Source, Excerpt: in.Excerpt, Tags: in.Tags}
+	}
+	for i, rel := range digest.Relationships {
+		item.Relationships[i] = dynamoDigestRelationship{
+			FromInsightID: rel.FromInsightID,
+			FromExcerpt:   rel.FromExcerpt,
+			ToInsightID:   rel.ToInsightID,
+			ToExcerpt:     rel.ToExcerpt,
+			Type:          string(rel.Type),
+			Confidence:    rel.Confidence,
+		}
+	}
+	for i, m := range digest.TagMoves {
+		item.TagMoves[i] = dynamoTagMove{Tag: m.Tag, From: m.From, To: m.To}
+	}
+	for i, p := range digest.Plans {
+		item.Plans[i] = dynamoDigestPlan{
+			PlanID:            p.PlanID,
+			Tag:               p.Tag,
+			FocusSentence:     p.FocusSentence,
+			Status:            string(p.Status),
+			Actions:           p.Actions,
+			Requested:         p.Requested,
+			Resolved:          p.Resolved,
+			ActionsStarted:    p.ActionsStarted,
+			ActionsFinished:   p.ActionsFinished,
+			CompletionPercent: p.CompletionPercent,
+		}
+	}
+
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	if _, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	}); err != nil {
+		return err
+	}
+	if !digest.Notified {
+		return nil
+	}
+
+	// Generate only marks its newest digest notified, so the pointer is
+	// overwritten unconditionally.
+	pointer, err := attributevalue.MarshalMap(dynamoDigestNotifiedItem{
+		PK:          pk(digest.TenantID),
+		SK:          digestNotifiedSK(digest.Period),
+		GeneratedAt: digest.GeneratedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      pointer,
+	})
+	return err
+}
+
+// ListDigests reads newest first and stops paging once it has limit
+// digests, so a tenant's growing history costs GET /v1/digests nothing.
+func (r *InsightAdapter) ListDigests(ctx context.Context, tenantID string, limit int) ([]domain.Digest, error) {
+	digests := []domain.Digest{}
+	if limit <= 0 {
+		return digests, nil
+	}
+	err := r.eachDigestNewestFirst(ctx, tenantID, func(d domain.Digest) bool {
+		digests = append(digests, d)
+		return len(digests) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// LastNotifiedAt reads the period's DIGESTNOTIFIED# pointer. Digests
+// notified before the pointer existed have none, so without it the
+// tenant's digests are paged newest first until one of the period that
+// was notified turns up.
+func (r *InsightAdapter) LastNotifiedAt(ctx context.Context, tenantID string, period domain.DigestPeriod) (time.Time, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: digestNotifiedSK(period)},
+		},
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if out.Item != nil {
+		var item dynamoDigestNotifiedItem
+		if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+			return time.Time{}, false, err
+		}
+		return item.GeneratedAt, true, nil
+	}
+
+	var at time.Time
+	var found bool
+	err = r.eachDigestNewestFirst(ctx, tenantID, func(d domain.Digest) bool {
+		if d.Period == period && d.Notified {
+			at, found = d.GeneratedAt, true
+		}
+		return !found
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return at, found, nil
+}
+
+// eachDigestNewestFirst calls visit with tenantID's unexpired digests,
+// newest first — the SK leads with generatedAt — following
+// LastEvaluatedKey until visit returns false or the digests run out. It
+// skips expired digests itself, as ListTagSnapshots does.
+func (r *InsightAdapter) eachDigestNewestFirst(ctx context.Context, tenantID string, visit func(domain.Digest) bool) error {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: digestSKPrefix},
+		},
+		ScanIndexForward: aws.Bool(false),
+	}
+
+	now := r.now().Unix()
+	for {
+		out, err := r.client.Query(ctx, input)
+		if err != nil {
+			return err
+		}
+		for _, av := range out.Items {
+			var item dynamoDigestItem
+			if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+				return err
+			}
+			if now >= item.ExpiresAt {
+				continue
+			}
+			if !visit(item.toDomain(tenantID)) {
+				return nil
+			}
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func (i dynamoDigestItem) toDomain(tenantID string) domain.Digest {
+	digest := domain.Digest{
+		TenantID:          tenantID,
+		Period:            domain.DigestPeriod(i.Period),
+		Since:             i.Since,
+		GeneratedAt:       i.GeneratedAt,
+		Notified:          i.Notified,
+		InsightCount:      i.InsightCount,
+		Insights:          make([]domain.DigestInsight, len(i.Insights)),
+		RelationshipCount: i.RelationshipCount,
+		Relationships:     make([]domain.DigestRelationship, len(i.Relationships)),
+		TagMoves:          make([]domain.TagMove, len(i.TagMoves)),
+		Plans:             make([]domain.DigestPlan, len(i.Plans)),
+		Markdown:          i.Markdown,
+		HTML:              i.HTML,
+	}
+	for n, in := range i.Insights {
+		digest.Insights[n] = domain.DigestInsight{InsightID: in.InsightID, Source: in.Source, Excerpt: in.Excerpt, Tags: in.Tags}
+	}
+	for n, rel := range i.Relationships {
+		digest.Relationships[n] = domain.DigestRelationship{
+			FromInsightID: rel.FromInsightID,
+			FromExcerpt:   rel.FromExcerpt,
+			ToInsightID:   rel.ToInsightID,
+			ToExcerpt:     rel.ToExcerpt,
+			Type:          domain.RelationType(rel.Type),
+			Confidence:    rel.Confidence,
+		}
+	}
+	for n, m := range i.TagMoves {
+		digest.TagMoves[n] = domain.TagMove{Tag: m.Tag, From: m.From, To: m.To}
+	}
+	for n, p := range i.Plans {
+		digest.Plans[n] = domain.DigestPlan{
+			PlanID:            p.PlanID,
+			Tag:               p.Tag,
+			FocusSentence:     p.FocusSentence,
+			Status:            domain.PlanStatus(p.Status),
+			Actions:           p.Actions,
+			Requested:         p.Requested,
+			Resolved:          p.Resolved,
+			ActionsStarted:    p.ActionsStarted,
+			ActionsFinished:   p.ActionsFinished,
+			CompletionPercent: p.CompletionPercent,
+		}
+	}
+	return digest
+}
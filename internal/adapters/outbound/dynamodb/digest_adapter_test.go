@@ -0,0 +1,186 @@
+package dynamodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_ListInsightsIngestedSince(t *testing.T) {
+	ctx := context.Background()
+	since := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), since)
+
+	// Highlighted long ago but ingested after since, and the reverse: only
+	// ingestion time counts.
+	a.now = func() time.Time { return since.Add(-time.Hour) }
+	if _, err := a.CreateIfAbsent(ctx, domain.Insight{ID: "old", TenantID: "t-1", Text: "old", HighlightedAt: since.Add(time.Hour)}); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+	a.now = func() time.Time { return since.Add(time.Hour) }
+	if _, err := a.CreateIfAbsent(ctx, domain.Insight{ID: "new", TenantID: "t-1", Text: "new", HighlightedAt: since.AddDate(-1, 0, 0)}); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+
+	got, err := a.ListInsightsIngestedSince(ctx, "t-1", since)
+	if err != nil {
+		t.Fatalf("ListInsightsIngestedSince: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "new" {
+		t.Fatalf("ListInsightsIngestedSince = %+v, want only new", got)
+	}
+}
+
+func TestInsightAdapter_Digests_RoundTripNewestFirstAndExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	weekly := domain.Digest{
+		TenantID:          "t-1",
+		Period:            domain.DigestWeekly,
+		Since:             now.AddDate(0, 0, -7),
+		GeneratedAt:       now.Add(-time.Hour),
+		Notified:          true,
+		InsightCount:      3,
+		Insights:          []domain.DigestInsight{{InsightID: "i-1", Source: "readwise", Excerpt: "text", Tags: []string{"focus"}}},
+		RelationshipCount: 1,
+		Relationships:     []domain.DigestRelationship{{FromInsightID: "i-1", FromExcerpt: "a", ToInsightID: "i-2", ToExcerpt: "b", Type: domain.RelationSupports, Confidence: 0.8}},
+		TagMoves:          []domain.TagMove{{Tag: "focus", From: 0.2, To: 0.5}},
+		Plans:             []domain.DigestPlan{{PlanID: "p-1", Tag: "focus", FocusSentence: "ship", Status: domain.PlanStatusReady, Actions: 3, Resolved: true, ActionsStarted: 2, ActionsFinished: 1, CompletionPercent: 33}},
+		Markdown:          "# Weekly",
+		HTML:              "<h1>Weekly</h1>",
+	}
+	daily := domain.Digest{
+		TenantID:      "t-1",
+		Period:        domain.DigestDaily,
+		Since:         now.AddDate(0, 0, -1),
+		GeneratedAt:   now,
+		Insights:      []domain.DigestInsight{},
+		Relationships: []domain.DigestRelationship{},
+		TagMoves:      []domain.TagMove{},
+		Plans:         []domain.DigestPlan{},
+	}
+	expired := daily
+	expired.GeneratedAt = now.AddDate(-2, 0, 0)
+
+	for _, d := range []struct {
+		digest    domain.Digest
+		expiresAt time.Time
+	}{{weekly, now.AddDate(1, 0, 0)}, {daily, now.AddDate(1, 0, 0)}, {expired, now.Add(-time.Second)}} {
+		if err := a.SaveDigest(ctx, d.digest, d.expiresAt); err != nil {
+			t.Fatalf("SaveDigest: %v", err)
+		}
+	}
+
+	got, err := a.ListDigests(ctx, "t-1", 10)
+	if err != nil {
+		t.Fatalf("ListDigests: %v", err)
+	}
+	if want := []domain.Digest{daily, weekly}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListDigests = %+v, want %+v", got, want)
+	}
+	if other, _ := a.ListDigests(ctx, "t-2", 10); len(other) != 0 {
+		t.Fatalf("t-2 sees %+v", other)
+	}
+}
+
+func TestInsightAdapter_ListDigests_PagesNewestFirstUpToLimit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	for days := range 5 {
+		expiresAt := now.AddDate(1, 0, 0)
+		if days == 1 {
+			expiresAt = now.Add(-time.Second)
+		}
+		d := domain.Digest{TenantID: "t-1", Period: domain.DigestDaily, GeneratedAt: now.AddDate(0, 0, -days)}
+		if err := a.SaveDigest(ctx, d, expiresAt); err != nil {
+			t.Fatalf("SaveDigest: %v", err)
+		}
+	}
+	f.queryPageSize = 1
+
+	got, err := a.ListDigests(ctx, "t-1", 3)
+	if err != nil {
+		t.Fatalf("ListDigests: %v", err)
+	}
+	var generated []time.Time
+	for _, d := range got {
+		generated = append(generated, d.GeneratedAt)
+	}
+	if want := []time.Time{now, now.AddDate(0, 0, -2), now.AddDate(0, 0, -3)}; !reflect.DeepEqual(generated, want) {
+		t.Fatalf("ListDigests generated at %v, want the three newest unexpired %v", generated, want)
+	}
+}
+
+func TestInsightAdapter_LastNotifiedAt_ReadsThePointerPerPeriod(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	if _, ok, err := a.LastNotifiedAt(ctx, "t-1", domain.DigestDaily); err != nil || ok {
+		t.Fatalf("LastNotifiedAt = %v, %v before any digest; want none", ok, err)
+	}
+
+	notified := domain.Digest{TenantID: "t-1", Period: domain.DigestDaily, GeneratedAt: now.AddDate(0, 0, -1)}
+	if err := a.SaveDigest(ctx, notified, now.AddDate(1, 0, 0)); err != nil {
+		t.Fatalf("SaveDigest: %v", err)
+	}
+	notified.Notified = true
+	if err := a.SaveDigest(ctx, notified, now.AddDate(1, 0, 0)); err != nil {
+		t.Fatalf("SaveDigest: %v", err)
+	}
+	// A newer digest whose notification failed doesn't move the pointer.
+	unsent := domain.Digest{TenantID: "t-1", Period: domain.DigestDaily, GeneratedAt: now}
+	if err := a.SaveDigest(ctx, unsent, now.AddDate(1, 0, 0)); err != nil {
+		t.Fatalf("SaveDigest: %v", err)
+	}
+
+	at, ok, err := a.LastNotifiedAt(ctx, "t-1", domain.DigestDaily)
+	if err != nil || !ok || !at.Equal(notified.GeneratedAt) {
+		t.Fatalf("LastNotifiedAt = %v, %v, %v; want the notified digest's %v", at, ok, err, notified.GeneratedAt)
+	}
+	if _, ok, err := a.LastNotifiedAt(ctx, "t-1", domain.DigestWeekly); err != nil || ok {
+		t.Fatalf("weekly LastNotifiedAt = %v, %v; want none", ok, err)
+	}
+	if digests, _ := a.ListDigests(ctx, "t-1", 10); len(digests) != 2 {
+		t.Fatalf("ListDigests = %+v, want the two digests without the pointer", digests)
+	}
+}
+
+func TestInsightAdapter_LastNotifiedAt_WithoutPointer_PagesBackToTheNotifiedDigest(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	old := domain.Digest{TenantID: "t-1", Period: domain.DigestWeekly, GeneratedAt: now.AddDate(0, 0, -30), Notified: true}
+	if err := a.SaveDigest(ctx, old, now.AddDate(1, 0, 0)); err != nil {
+		t.Fatalf("SaveDigest: %v", err)
+	}
+	previous := domain.Digest{TenantID: "t-1", Period: domain.DigestWeekly, GeneratedAt: now.AddDate(0, 0, -7), Notified: true}
+	if err := a.SaveDigest(ctx, previous, now.AddDate(1, 0, 0)); err != nil {
+		t.Fatalf("SaveDigest: %v", err)
+	}
+	for days := range 6 {
+		daily := domain.Digest{TenantID: "t-1", Period: domain.DigestDaily, GeneratedAt: now.AddDate(0, 0, -days), Notified: true}
+		if err := a.SaveDigest(ctx, daily, now.AddDate(1, 0, 0)); err != nil {
+			t.Fatalf("SaveDigest: %v", err)
+		}
+	}
+	// Digests notified before the pointer existed have none.
+	delete(f.items, pk("t-1")+"|"+digestNotifiedSK(domain.DigestWeekly))
+	f.queryPageSize = 1
+
+	at, ok, err := a.LastNotifiedAt(ctx, "t-1", domain.DigestWeekly)
+	if err != nil || !ok || !at.Equal(previous.GeneratedAt) {
+		t.Fatalf("LastNotifiedAt = %v, %v, %v; want the latest notified weekly digest's %v", at, ok, err, previous.GeneratedAt)
+	}
+}
@@ -0,0 +1,60 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+func TestInsightAdapter_AddMergedSource_RecordsEachRefOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+	if _, err := a.CreateIfAbsent(ctx, domain.Insight{ID: "i-1", TenantID: "t-1", Source: "readwise", Text: "hello"}); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+
+	ref := domain.SourceRef{Source: "raindrop", InsightID: "i-2", MergedAt: now}
+	for range 2 {
+		if err := a.AddMergedSource(ctx, "t-1", "i-1", ref); err != nil {
+			t.Fatalf("AddMergedSource: %v", err)
+		}
+	}
+
+	insights, err := a.ListByTenantID(ctx, "t-1", "")
+	if err != nil {
+		t.Fatalf("ListByTenantID: %v", err)
+	}
+	if len(insights) != 1 || len(insights[0].MergedSources) != 1 || insights[0].MergedSources[0].Source != "raindrop" {
+		t.Fatalf("insights = %+v, want i-1 with the one raindrop ref", insights)
+	}
+
+	if err := a.AddMergedSource(ctx, "t-1", "missing", ref); !errors.Is(err, ports.ErrInsightNotFound) {
+		t.Fatalf("err = %v, want ErrInsightNotFound", err)
+	}
+}
+
+func TestInsightAdapter_ListDuplicates_MostRecentFirst(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	for i, id := range []string{"i-2", "i-3"} {
+		d := domain.Duplicate{TenantID: "t-1", InsightID: id, DuplicateOfID: "i-1", Similarity: 0.9, DetectedAt: now.Add(time.Duration(i) * time.Hour)}
+		if err := a.SaveDuplicate(ctx, d); err != nil {
+			t.Fatalf("SaveDuplicate: %v", err)
+		}
+	}
+
+	got, err := a.ListDuplicates(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListDuplicates: %v", err)
+	}
+	if len(got) != 2 || got[0].InsightID != "i-3" || got[1].DuplicateOfID != "i-1" {
+		t.Fatalf("duplicates = %+v, want i-3 then i-2", got)
+	}
+}
@@ -0,0 +1,73 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.EnrichmentCache = (*InsightAdapter)(nil)
+
+// dynamoEnrichmentCacheItem's expires_at is the table's TTL attribute
+// (terraform/modules/dynamodb/main.tf), in epoch seconds as TTL requires.
+type dynamoEnrichmentCacheItem struct {
+	PK         string               `dynamodbav:"pk"`
+	SK         string               `dynamodbav:"sk"`
+	Enrichment dynamoEnrichmentItem `dynamodbav:"enrichment"`
+	ExpiresAt  int64                `dynamodbav:"expires_at"`
+}
+
+func enrichmentCacheSK(key string) string {
+	return "ENRICHCACHE#" + key
+}
+
+// GetCachedEnrichment checks expires_at itself: TTL deletion runs in the
+// background, up to days late, so an expired item can still be read.
+func (r *InsightAdapter) GetCachedEnrichment(ctx context.Context, tenantID, key string) (domain.Enrichment, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: enrichmentCacheSK(key)},
+		},
+	})
+	if err != nil {
+		return domain.Enrichment{}, false, err
+	}
+	if out.Item == nil {
+		return domain.Enrichment{}, false, nil
+	}
+
+	var item dynamoEnrichmentCacheItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.Enrichment{}, false, err
+	}
+	if r.now().Unix() >= item.ExpiresAt {
+		return domain.Enrichment{}, false, nil
+	}
+	return item.Enrichment.toDomain(), true, nil
+}
+
+func (r *InsightAdapter) PutCachedEnrichment(ctx context.Context, tenantID, key string, enrichment domain.Enrichment, expiresAt time.Time) error {
+	av, err := attributevalue.MarshalMap(dynamoEnrichmentCacheItem{
+		PK:         pk(tenantID),
+		SK:         enrichmentCacheSK(key),
+		Enrichment: toDynamoEnrichment(enrichment),
+		ExpiresAt:  expiresAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
@@ -0,0 +1,33 @@
+package dynamodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_CachedEnrichment_RoundTripsUntilExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	want := domain.Enrichment{Tags: []string{"psychology"}, Summary: "Habits compound.", Model: "m", PromptVersion: "p", InputTokens: 42}
+	if err := a.PutCachedEnrichment(ctx, "t-1", "k", want, now.Add(time.Hour)); err != nil {
+		t.Fatalf("PutCachedEnrichment: %v", err)
+	}
+
+	got, ok, err := a.GetCachedEnrichment(ctx, "t-1", "k")
+	if err != nil || !ok || got.Summary != want.Summary || got.Tags[0] != "psychology" || got.InputTokens != 42 {
+		t.Fatalf("got %+v, %v, %v; want the stored enrichment", got, ok, err)
+	}
+	if _, ok, _ := a.GetCachedEnrichment(ctx, "t-2", "k"); ok {
+		t.Fatal("another tenant read t-1's cache entry")
+	}
+
+	a.now = func() time.Time { return now.Add(time.Hour) }
+	if _, ok, _ := a.GetCachedEnrichment(ctx, "t-1", "k"); ok {
+		t.Fatal("expired entry was returned; TTL deletion can lag, so the read must check")
+	}
+}
@@ -0,0 +1,117 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.GraphAnalyticsRepository = (*InsightAdapter)(nil)
+
+// graphStatsSK keys a tenant's one analytics item (pk = TENANT#<id>). The
+// domain.Max* caps, and ranked insights keeping only an excerpt of their
+// text, keep it far below DynamoDB's 400 KB item limit.
+const graphStatsSK = "GRAPHSTATS"
+
+type dynamoInsightRankItem struct {
+	InsightID  string  `dynamodbav:"insight_id"`
+	Text       string  `dynamodbav:"text"`
+	ClusterID  int     `dynamodbav:"cluster_id,omitempty"`
+	Centrality float64 `dynamodbav:"centrality"`
+	Bridging   float64 `dynamodbav:"bridging"`
+}
+
+type dynamoGraphClusterItem struct {
+	ID         int      `dynamodbav:"id"`
+	Size       int      `dynamodbav:"size"`
+	InsightIDs []string `dynamodbav:"insight_ids"`
+	Tags       []string `dynamodbav:"tags,omitempty"`
+}
+
+type dynamoGraphAnalyticsItem struct {
+	PK         string                   `dynamodbav:"pk"`
+	SK         string                   `dynamodbav:"sk"`
+	ComputedAt time.Time                `dynamodbav:"computed_at"`
+	Clusters   []dynamoGraphClusterItem `dynamodbav:"clusters,omitempty"`
+	Central    []dynamoInsightRankItem  `dynamodbav:"central,omitempty"`
+	Bridges    []dynamoInsightRankItem  `dynamodbav:"bridges,omitempty"`
+}
+
+func (r *InsightAdapter) GetGraphAnalytics(ctx context.Context, tenantID string) (domain.GraphAnalytics, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: graphStatsSK},
+		},
+	})
+	if err != nil {
+		return domain.GraphAnalytics{}, false, err
+	}
+	if out.Item == nil {
+		return domain.GraphAnalytics{}, false, nil
+	}
+
+	var item dynamoGraphAnalyticsItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.GraphAnalytics{}, false, err
+	}
+	analytics := domain.GraphAnalytics{
+		TenantID:   tenantID,
+		ComputedAt: item.ComputedAt,
+		Central:    ranksToDomain(item.Central),
+		Bridges:    ranksToDomain(item.Bridges),
+	}
+	for _, c := range item.Clusters {
+		analytics.Clusters = append(analytics.Clusters, domain.GraphCluster{ID: c.ID, Size: c.Size, InsightIDs: c.InsightIDs, Tags: c.Tags})
+	}
+	return analytics, true, nil
+}
+
+func (r *InsightAdapter) SaveGraphAnalytics(ctx context.Context, analytics domain.GraphAnalytics) error {
+	item := dynamoGraphAnalyticsItem{
+		PK:         pk(analytics.TenantID),
+		SK:         graphStatsSK,
+		ComputedAt: analytics.ComputedAt,
+		Central:    ranksFromDomain(analytics.Central),
+		Bridges:    ranksFromDomain(analytics.Bridges),
+	}
+	for _, c := range analytics.Clusters {
+		item.Clusters = append(item.Clusters, dynamoGraphClusterItem{ID: c.ID, Size: c.Size, InsightIDs: c.InsightIDs, Tags: c.Tags})
+	}
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+func ranksFromDomain(ranks []domain.InsightRank) []dynamoInsightRankItem {
+	out := make([]dynamoInsightRankItem, len(ranks))
+	for i, r := range ranks {
+		out[i] = dynamoInsightRankItem{InsightID: r.InsightID, Text: r.Text, ClusterID: r.ClusterID, Centrality: r.Centrality, Bridging: r.Bridging}
+	}
+	return out
+}
+
+func ranksToDomain(items []dynamoInsightRankItem) []domain.InsightRank {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]domain.InsightRank, len(items))
+	for i, r := range items {
+		out[i] = domain.InsightRank{InsightID: r.InsightID, Text: r.Text, ClusterID: r.ClusterID, Centrality: r.Centrality, Bridging: r.Bridging}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package dynamodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_GraphAnalytics_MissingThenRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	if _, found, err := a.GetGraphAnalytics(ctx, "t-1"); err != nil || found {
+		t.Fatalf("found = %v, err = %v; want nothing before the first save", found, err)
+	}
+
+	want := domain.GraphAnalytics{
+		TenantID:   "t-1",
+		ComputedAt: now,
+		Clusters:   []domain.GraphCluster{{ID: 1, Size: 3, InsightIDs: []string{"a", "b", "c"}, Tags: []string{"focus"}}},
+		Central:    []domain.InsightRank{{InsightID: "a", Text: "hello", ClusterID: 1, Centrality: 0.4}, {InsightID: "z", Centrality: 0.1}},
+		Bridges:    []domain.InsightRank{{InsightID: "a", Text: "hello", ClusterID: 1, Centrality: 0.4, Bridging: 0.5}},
+	}
+	if err := a.SaveGraphAnalytics(ctx, want); err != nil {
+		t.Fatalf("SaveGraphAnalytics: %v", err)
+	}
+	got, found, err := a.GetGraphAnalytics(ctx, "t-1")
+	if err != nil || !found || !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, %v, %v; want the saved analytics", got, found, err)
+	}
+	if _, found, _ := a.GetGraphAnalytics(ctx, "t-2"); found {
+		t.Fatalf("t-2 read t-1's analytics")
+	}
+}
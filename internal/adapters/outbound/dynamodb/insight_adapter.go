@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
 	"time"
@@ -20,8 +21,58 @@ import (
 // terraform/modules/dynamodb/main.tf (enable_tag_gsi = true).
 const tagIndexName = "gsi1"
 
+// dynamoEnrichmentItem stores Latency as whole milliseconds — the same
+// unit the adapters log it in, and plenty of precision for an LLM call.
 type dynamoEnrichmentItem struct {
-	Tags []string `dynamodbav:"tags"`
+	Tags          []string  `dynamodbav:"tags"`
+	Summary       string    `dynamodbav:"summary,omitempty"`
+	Concepts      []string  `dynamodbav:"concepts,omitempty"`
+	Sentiment     string    `dynamodbav:"sentiment,omitempty"`
+	Actionability float64   `dynamodbav:"actionability"`
+	Question      string    `dynamodbav:"question,omitempty"`
+	EnrichedAt    time.Time `dynamodbav:"enriched_at"`
+	Fallback      bool      `dynamodbav:"fallback,omitempty"`
+	Model         string    `dynamodbav:"model,omitempty"`
+	PromptVersion string    `dynamodbav:"prompt_version,omitempty"`
+	InputTokens   int64     `dynamodbav:"input_tokens,omitempty"`
+	OutputTokens  int64     `dynamodbav:"output_tokens,omitempty"`
+	LatencyMS     int64     `dynamodbav:"latency_ms,omitempty"`
+}
+
+func toDynamoEnrichment(e domain.Enrichment) dynamoEnrichmentItem {
+	return dynamoEnrichmentItem{
+		Tags:          e.Tags,
+		Summary:       e.Summary,
+		Concepts:      e.Concepts,
+		Sentiment:     string(e.Sentiment),
+		Actionability: e.Actionability,
+		Question:      e.Question,
+		EnrichedAt:    e.EnrichedAt,
+		Fallback:      e.Fallback,
+		Model:         e.Model,
+		PromptVersion: e.PromptVersion,
+		InputTokens:   e.InputTokens,
+		OutputTokens:  e.OutputTokens,
+		LatencyMS:     e.Latency.Milliseconds(),
+	}
+}
+
+func (item dynamoEnrichmentItem) toDomain() domain.Enrichment {
+	return domain.Enrichment{
+		Tags:          item.Tags,
+		Summary:       item.Summary,
+		Concepts:      item.Concepts,
+		Sentiment:     domain.Sentiment(item.Sentiment),
+		Actionability: item.Actionability,
+		Question:      item.Question,
+		EnrichedAt:    item.EnrichedAt,
+		Fallback:      item.Fallback,
+		Model:         item.Model,
+		PromptVersion: item.PromptVersion,
+		InputTokens:   item.InputTokens,
+		OutputTokens:  item.OutputTokens,
+		Latency:       time.Duration(item.LatencyMS) * time.Millisecond,
+	}
 }
 
 type dynamoInsightItem struct {
@@ -32,8 +83,10 @@ type dynamoInsightItem struct {
 	Source        string                `dynamodbav:"source"`
 	Text          string                `dynamodbav:"text"`
 	Notes         string                `dynamodbav:"notes"`
+	Language      string                `dynamodbav:"language,omitempty"`
 	Enrichment    *dynamoEnrichmentItem `dynamodbav:"enrichment,omitempty"`
 	HighlightedAt time.Time             `dynamodbav:"highlighted_at"`
+	MergedSources []dynamoSourceRefItem `dynamodbav:"merged_sources,omitempty"`
 	CreatedAt     time.Time             `dynamodbav:"created_at"`
 	UpdatedAt     time.Time             `dynamodbav:"updated_at"`
 }
@@ -75,6 +128,7 @@ type dynamoAPI interface {
 	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
 	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
 }
 
 type InsightAdapter struct {
@@ -112,15 +166,15 @@ func (r *InsightAdapter) CreateIfAbsent(ctx context.Context, insight domain.Insi
 		Source:        insight.Source,
 		Text:          insight.Text,
 		Notes:         insight.Notes,
+		Language:      string(insight.Language),
 		HighlightedAt: resolveHighlightedAt(insight, now),
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
 
 	if insight.Enrichment != nil {
-		item.Enrichment = &dynamoEnrichmentItem{
-			Tags: insight.Enrichment.Tags,
-		}
+		enrichment := toDynamoEnrichment(*insight.Enrichment)
+		item.Enrichment = &enrichment
 	}
 
 	av, err := attributevalue.MarshalMap(item)
@@ -195,45 +249,60 @@ func (r *InsightAdapter) listByTag(ctx context.Context, tenantID, tag string) ([
 
 	insights := make([]domain.Insight, 0, len(members))
 	for _, m := range members {
-		out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
-			TableName: aws.String(r.tableName),
-			Key: map[string]types.AttributeValue{
-				"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
-				"sk": &types.AttributeValueMemberS{Value: sk(m.InsightID)},
-			},
-		})
+		insight, found, err := r.GetByID(ctx, tenantID, m.InsightID)
 		if err != nil {
 			return nil, err
 		}
-		if out.Item == nil {
+		if !found {
 			// Orphaned membership (insight deleted after tagging); skip it.
 			continue
 		}
-		insight, err := unmarshalInsight(out.Item)
-		if err != nil {
-			return nil, err
-		}
 		insights = append(insights, insight)
 	}
 	return insights, nil
 }
 
+func (r *InsightAdapter) GetByID(ctx context.Context, tenantID, insightID string) (domain.Insight, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: sk(insightID)},
+		},
+	})
+	if err != nil {
+		return domain.Insight{}, false, err
+	}
+	if out.Item == nil {
+		return domain.Insight{}, false, nil
+	}
+	insight, err := unmarshalInsight(out.Item)
+	if err != nil {
+		return domain.Insight{}, false, err
+	}
+	return insight, true, nil
+}
+
 func unmarshalInsight(item map[string]types.AttributeValue) (domain.Insight, error) {
 	var dynItem dynamoInsightItem
 	if err := attributevalue.UnmarshalMap(item, &dynItem); err != nil {
 		return domain.Insight{}, err
 	}
 	insight := domain.Insight{
-		ID:       dynItem.ID,
-		TenantID: dynItem.TenantID,
-		Source:   dynItem.Source,
-		Text:     dynItem.Text,
-		Notes:    dynItem.Notes,
+		ID:            dynItem.ID,
+		TenantID:      dynItem.TenantID,
+		Source:        dynItem.Source,
+		Text:          dynItem.Text,
+		Notes:         dynItem.Notes,
+		Language:      domain.Language(dynItem.Language),
+		HighlightedAt: dynItem.HighlightedAt,
+	}
+	for _, ref := range dynItem.MergedSources {
+		insight.MergedSources = append(insight.MergedSources, ref.toDomain())
 	}
 	if dynItem.Enrichment != nil {
-		insight.Enrichment = &domain.Enrichment{
-			Tags: dynItem.Enrichment.Tags,
-		}
+		enrichment := dynItem.Enrichment.toDomain()
+		insight.Enrichment = &enrichment
 	}
 	return insight, nil
 }
@@ -276,86 +345,35 @@ func (r *InsightAdapter) ListByTag(ctx context.Context, tenantID, tag string) ([
 // ListTags returns every tag in the tenant's partition aggregated with its
 // insight count, most recent tagging time, and relevance score (including
 // the relationship-density component, REL 5/IPP-101), sorted by score
-// descending.
-//
-// Aggregates in Go over one query of the TAG# prefix, per the
-// story's implementation notes. Fine at personal scale (a few hundred
-// membership items); a materialized per-tag counter item is the upgrade
-// path if this ever gets slow.
+// descending. Scores are weighed by the tenant's own TagRelevanceConfig
+// (TenantSettings.EffectiveTagRelevance).
 func (r *InsightAdapter) ListTags(ctx context.Context, tenantID string) ([]domain.TagSummary, error) {
-	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(r.tableName),
-		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
-		ExpressionAttributeNames: map[string]string{
-			"#pk": "pk",
-			"#sk": "sk",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
-			":skPrefix": &types.AttributeValueMemberS{Value: "TAG#"},
-		},
-	})
+	settings, err := r.GetTenantSettings(ctx, tenantID)
 	if err != nil {
-		return nil, err
-	}
-
-	type aggregate struct {
-		count      int
-		lastAt     time.Time
-		taggedAt   []time.Time
-		insightIDs []string
-	}
-	aggregates := make(map[string]*aggregate)
-	var tagOrder []string
-
-	for _, item := range out.Items {
-		var dynItem dynamoTagMembershipItem
-		if err := attributevalue.UnmarshalMap(item, &dynItem); err != nil {
-			return nil, err
-		}
-		tag, ok := parseTagFromSK(dynItem.SK)
-		if !ok {
-			continue
-		}
-
-		a, exists := aggregates[tag]
-		if !exists {
-			a = &aggregate{}
-			aggregates[tag] = a
-			tagOrder = append(tagOrder, tag)
-		}
-		a.count++
-		a.taggedAt = append(a.taggedAt, dynItem.HighlightedAt)
-		a.insightIDs = append(a.insightIDs, dynItem.InsightID)
-		if dynItem.HighlightedAt.After(a.lastAt) {
-			a.lastAt = dynItem.HighlightedAt
-		}
+		return nil, fmt.Errorf("get tenant settings: %w", err)
 	}
+	return r.RankTags(ctx, tenantID, settings.EffectiveTagRelevance())
+}
 
-	var degree map[string]int
-	if len(tagOrder) > 0 {
-		degree, err = r.relationshipDegreeByInsight(ctx, tenantID)
-		if err != nil {
-			return nil, fmt.Errorf("relationship degree by insight: %w", err)
-		}
+// RankTags is ListTags under config.
+//
+// Reads only the tenant's TAGSTAT# aggregates (dynamoTagStatItem), one
+// item per tag, which syncTagMemberships and the relationship writes keep
+// current; never the memberships or edges themselves.
+func (r *InsightAdapter) RankTags(ctx context.Context, tenantID string, config domain.TagRelevanceConfig) ([]domain.TagSummary, error) {
+	stats, err := r.listTagStats(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list tag stats: %w", err)
 	}
 
 	now := r.now()
-	summaries := make([]domain.TagSummary, 0, len(tagOrder))
-	for _, tag := range tagOrder {
-		a := aggregates[tag]
-
-		var totalDegree int
-		for _, insightID := range a.insightIDs {
-			totalDegree += degree[insightID]
-		}
-		avgDegree := float64(totalDegree) / float64(a.count)
-
-		score, components := domain.TagRelevanceScoreWithDensity(a.taggedAt, now, avgDegree)
+	summaries := make([]domain.TagSummary, 0, len(stats))
+	for _, stat := range stats {
+		score, components := config.ScoreStat(stat, now)
 		summaries = append(summaries, domain.TagSummary{
-			Tag:             tag,
-			InsightCount:    a.count,
-			LastInsightAt:   a.lastAt,
+			Tag:             stat.Tag,
+			InsightCount:    stat.InsightCount,
+			LastInsightAt:   stat.LastHighlightedAt,
 			Score:           score,
 			ScoreComponents: components,
 		})
@@ -368,43 +386,6 @@ func (r *InsightAdapter) ListTags(ctx context.Context, tenantID string) ([]domai
 	return summaries, nil
 }
 
-// relationshipDegreeByInsight counts each insight's relationship edges (both
-// directions) with a single query over the tenant's REL# prefix: every edge
-// is stored once under each endpoint's own "REL#<insightID>#" sort key (see
-// RelationshipRepository.Put), so grouping by that prefix's owner segment
-// gives the degree directly — no per-insight fetch.
-func (r *InsightAdapter) relationshipDegreeByInsight(ctx context.Context, tenantID string) (map[string]int, error) {
-	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(r.tableName),
-		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
-		ExpressionAttributeNames: map[string]string{
-			"#pk": "pk",
-			"#sk": "sk",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
-			":skPrefix": &types.AttributeValueMemberS{Value: "REL#"},
-		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	degree := make(map[string]int, len(out.Items))
-	for _, item := range out.Items {
-		var dynItem dynamoRelationshipItem
-		if err := attributevalue.UnmarshalMap(item, &dynItem); err != nil {
-			return nil, err
-		}
-		owner, ok := parseRelOwnerFromSK(dynItem.SK)
-		if !ok {
-			continue
-		}
-		degree[owner]++
-	}
-	return degree, nil
-}
-
 // parseTagFromSK extracts the tag from a membership item's sort key
 // ("TAG#<tag>#INSIGHT#<insightID>"); tags are normalized (see
 // domain.NormalizeTag) so they never contain "#".
@@ -455,10 +436,16 @@ func (r *InsightAdapter) Update(ctx context.Context, insight domain.Insight) err
 		":updated_at": &types.AttributeValueMemberS{Value: now.Format(time.RFC3339Nano)},
 	}
 
+	// Only ever set, never cleared: an insight stored before languages were
+	// detected gets one the first time it's re-enriched.
+	if insight.Language != domain.LanguageUnknown {
+		updateExpr += ", #language = :language"
+		exprNames["#language"] = "language"
+		exprValues[":language"] = &types.AttributeValueMemberS{Value: string(insight.Language)}
+	}
+
 	if insight.Enrichment != nil {
-		enrichmentAV, err := attributevalue.MarshalMap(&dynamoEnrichmentItem{
-			Tags: insight.Enrichment.Tags,
-		})
+		enrichmentAV, err := attributevalue.MarshalMap(toDynamoEnrichment(*insight.Enrichment))
 		if err != nil {
 			return fmt.Errorf("marshal enrichment: %w", err)
 		}
@@ -520,6 +507,9 @@ func (r *InsightAdapter) currentTags(ctx context.Context, key map[string]types.A
 // tag set: tags no longer present are deleted, newly added tags get a fresh
 // membership item. Unchanged tags are left untouched so replaying the same
 // enrichment doesn't reset created_at/highlighted_at or write duplicates.
+// Each added or removed membership is counted in or out of its tag's
+// TAGSTAT# aggregate (dynamoTagStatItem), with the insight's current
+// relationship degree.
 //
 // now is our own wall-clock write time (audit trail, dynamoTagMembershipItem
 // .CreatedAt). highlightedAt is the source system's highlight-creation time,
@@ -530,6 +520,14 @@ func (r *InsightAdapter) syncTagMemberships(ctx context.Context, tenantID, insig
 	oldSet := toTagSet(oldTags)
 	newSet := toTagSet(newTags)
 
+	var degree int
+	if !maps.Equal(oldSet, newSet) {
+		var err error
+		if degree, err = r.relationshipDegree(ctx, tenantID, insightID); err != nil {
+			return fmt.Errorf("relationship degree: %w", err)
+		}
+	}
+
 	for tag := range oldSet {
 		if newSet[tag] {
 			continue
@@ -541,12 +539,27 @@ func (r *InsightAdapter) syncTagMemberships(ctx context.Context, tenantID, insig
 		if err != nil {
 			return err
 		}
-		if _, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
-			TableName: aws.String(r.tableName),
-			Key:       key,
-		}); err != nil {
+		out, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName:    aws.String(r.tableName),
+			Key:          key,
+			ReturnValues: types.ReturnValueAllOld,
+		})
+		if err != nil {
+			return err
+		}
+		if out.Attributes == nil {
+			continue
+		}
+		var removed dynamoTagMembershipItem
+		if err := attributevalue.UnmarshalMap(out.Attributes, &removed); err != nil {
 			return err
 		}
+		if err := r.addTagMembershipStat(ctx, tenantID, tag, removed.HighlightedAt, -1, degree); err != nil {
+			return fmt.Errorf("tag stat: %w", err)
+		}
+		if err := r.lowerTagLastHighlighted(ctx, tenantID, tag, removed.HighlightedAt); err != nil {
+			return fmt.Errorf("tag stat: %w", err)
+		}
 	}
 
 	for tag := range newSet {
@@ -572,6 +585,12 @@ func (r *InsightAdapter) syncTagMemberships(ctx context.Context, tenantID, insig
 		}); err != nil {
 			return err
 		}
+		if err := r.addTagMembershipStat(ctx, tenantID, tag, highlightedAt, 1, degree); err != nil {
+			return fmt.Errorf("tag stat: %w", err)
+		}
+		if err := r.raiseTagLastHighlighted(ctx, tenantID, tag, highlightedAt); err != nil {
+			return fmt.Errorf("tag stat: %w", err)
+		}
 	}
 
 	return nil
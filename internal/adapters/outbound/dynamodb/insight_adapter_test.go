@@ -2,11 +2,14 @@ package dynamodb
 
 import (
 	"context"
+	"maps"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 
@@ -20,6 +23,16 @@ import (
 type fakeDynamo struct {
 	items map[string]map[string]types.AttributeValue // key: pk|sk
 	index map[string]map[string]types.AttributeValue // key: gsi1pk|gsi1sk
+
+	// beforeTransact and transactErr inject failures into
+	// TransactWriteItems: a concurrent write landing just before it, or the
+	// whole call failing (throttled, conflicting) without applying anything.
+	beforeTransact func()
+	transactErr    error
+
+	// queryPageSize, if set, pages Query results as DynamoDB does past
+	// 1 MB: a LastEvaluatedKey to pass back as ExclusiveStartKey.
+	queryPageSize int
 }
 
 func newFakeDynamo() *fakeDynamo {
@@ -65,12 +78,21 @@ func (f *fakeDynamo) GetItem(_ context.Context, in *dynamodb.GetItemInput, _ ...
 
 func (f *fakeDynamo) UpdateItem(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
 	key := compositeKey(in.Key, "pk", "sk")
+	if addExpr, ok := strings.CutPrefix(*in.UpdateExpression, "ADD "); ok {
+		return f.add(key, in, addExpr)
+	}
+	if removeExpr, ok := strings.CutPrefix(*in.UpdateExpression, "REMOVE "); ok {
+		return f.add(key, in, " REMOVE "+removeExpr)
+	}
 	item, exists := f.items[key]
 	if !exists {
 		return nil, &types.ConditionalCheckFailedException{}
 	}
 	if in.ConditionExpression != nil &&
 		!conditionHolds(item, *in.ConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues) {
+		if in.ReturnValuesOnConditionCheckFailure == types.ReturnValuesOnConditionCheckFailureAllOld {
+			return nil, &types.ConditionalCheckFailedException{Item: maps.Clone(item)}
+		}
 		return nil, &types.ConditionalCheckFailedException{}
 	}
 
@@ -81,6 +103,15 @@ func (f *fakeDynamo) UpdateItem(_ context.Context, in *dynamodb.UpdateItemInput,
 	setExpr = strings.TrimPrefix(setExpr, "SET ")
 	for _, clause := range strings.Split(setExpr, ", ") {
 		parts := strings.SplitN(clause, " = ", 2)
+		// "#alias[n]" sets one element of an existing list attribute.
+		if alias, index, ok := strings.Cut(strings.TrimSuffix(parts[0], "]"), "["); ok {
+			n, _ := strconv.Atoi(index)
+			list := item[in.ExpressionAttributeNames[alias]].(*types.AttributeValueMemberL)
+			elems := append([]types.AttributeValue(nil), list.Value...)
+			elems[n] = in.ExpressionAttributeValues[parts[1]]
+			item[in.ExpressionAttributeNames[alias]] = &types.AttributeValueMemberL{Value: elems}
+			continue
+		}
 		attrName := in.ExpressionAttributeNames[parts[0]]
 		item[attrName] = in.ExpressionAttributeValues[parts[1]]
 	}
@@ -96,49 +127,241 @@ func (f *fakeDynamo) UpdateItem(_ context.Context, in *dynamodb.UpdateItemInput,
 	return &dynamodb.UpdateItemOutput{}, nil
 }
 
+// add fakes an ADD UpdateExpression on numbers ("#a :a, #b :b"),
+// optionally followed by a SET clause of plain "#a = :a" assignments and
+// then a REMOVE clause: like DynamoDB, it creates a missing item and
+// counts a missing attribute as zero.
+func (f *fakeDynamo) add(key string, in *dynamodb.UpdateItemInput, expr string) (*dynamodb.UpdateItemOutput, error) {
+	item, exists := f.items[key]
+	if in.ConditionExpression != nil &&
+		!conditionHolds(item, *in.ConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues) {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	if !exists {
+		item = map[string]types.AttributeValue{"pk": in.Key["pk"], "sk": in.Key["sk"]}
+		f.items[key] = item
+	}
+	addExpr, removeExpr, _ := strings.Cut(expr, " REMOVE ")
+	addExpr, setExpr, _ := strings.Cut(addExpr, " SET ")
+	for _, clause := range strings.Split(addExpr, ", ") {
+		alias, valueRef, ok := strings.Cut(clause, " ")
+		if !ok {
+			continue
+		}
+		attrName := in.ExpressionAttributeNames[alias]
+		var current int64
+		if n, ok := item[attrName].(*types.AttributeValueMemberN); ok {
+			current, _ = strconv.ParseInt(n.Value, 10, 64)
+		}
+		delta, _ := strconv.ParseInt(in.ExpressionAttributeValues[valueRef].(*types.AttributeValueMemberN).Value, 10, 64)
+		item[attrName] = &types.AttributeValueMemberN{Value: strconv.FormatInt(current+delta, 10)}
+	}
+	for _, clause := range strings.Split(setExpr, ", ") {
+		if alias, valueRef, ok := strings.Cut(clause, " = "); ok {
+			item[in.ExpressionAttributeNames[alias]] = in.ExpressionAttributeValues[valueRef]
+		}
+	}
+	for _, alias := range strings.Split(removeExpr, ", ") {
+		delete(item, in.ExpressionAttributeNames[alias])
+	}
+	if in.ReturnValues == types.ReturnValueAllNew {
+		return &dynamodb.UpdateItemOutput{Attributes: maps.Clone(item)}, nil
+	}
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
 // conditionHolds fakes just enough of DynamoDB's condition-expression
 // evaluation for the clauses InsightAdapter actually sends: one or more
-// `attribute_exists(#alias)` / `#alias = :value` clauses joined by " AND ".
+// `attribute_exists(path)` / `attribute_not_exists(path)` /
+// `path = :value` (string or number) / `path < :number` clauses joined by
+// " AND ", or alternatives of those joined by " OR " (no parentheses),
+// where a path is "#alias" or "#alias[n].#field" (see attributeAt).
 func conditionHolds(
 	item map[string]types.AttributeValue, expr string, names map[string]string, values map[string]types.AttributeValue,
+) bool {
+	for _, alternative := range strings.Split(expr, " OR ") {
+		if allClausesHold(item, alternative, names, values) {
+			return true
+		}
+	}
+	return false
+}
+
+func allClausesHold(
+	item map[string]types.AttributeValue, expr string, names map[string]string, values map[string]types.AttributeValue,
 ) bool {
 	for _, clause := range strings.Split(expr, " AND ") {
 		clause = strings.TrimSpace(clause)
 		if rest, ok := strings.CutPrefix(clause, "attribute_exists("); ok {
-			alias := strings.TrimSuffix(rest, ")")
-			if _, exists := item[names[alias]]; !exists {
+			if _, exists := attributeAt(item, strings.TrimSuffix(rest, ")"), names); !exists {
 				return false
 			}
 			continue
 		}
-
-		alias, valueRef, ok := strings.Cut(clause, " = ")
-		if !ok {
+		if rest, ok := strings.CutPrefix(clause, "attribute_not_exists("); ok {
+			if _, exists := attributeAt(item, strings.TrimSuffix(rest, ")"), names); exists {
+				return false
+			}
 			continue
 		}
-		want, ok := values[valueRef].(*types.AttributeValueMemberS)
+		if path, valueRef, ok := strings.Cut(clause, " < "); ok {
+			av, _ := attributeAt(item, path, names)
+			got, okGot := av.(*types.AttributeValueMemberN)
+			want, okWant := values[valueRef].(*types.AttributeValueMemberN)
+			if !okGot || !okWant {
+				return false
+			}
+			g, _ := strconv.ParseInt(got.Value, 10, 64)
+			w, _ := strconv.ParseInt(want.Value, 10, 64)
+			if g >= w {
+				return false
+			}
+			continue
+		}
+
+		path, valueRef, ok := strings.Cut(clause, " = ")
 		if !ok {
 			continue
 		}
-		got, ok := item[names[alias]].(*types.AttributeValueMemberS)
-		if !ok || got.Value != want.Value {
-			return false
+		av, _ := attributeAt(item, path, names)
+		switch want := values[valueRef].(type) {
+		case *types.AttributeValueMemberS:
+			got, ok := av.(*types.AttributeValueMemberS)
+			if !ok || got.Value != want.Value {
+				return false
+			}
+		case *types.AttributeValueMemberN:
+			got, ok := av.(*types.AttributeValueMemberN)
+			if !ok || got.Value != want.Value {
+				return false
+			}
 		}
 	}
 	return true
 }
 
+// attributeAt resolves a condition's path: "#alias", or "#alias[n].#field"
+// for a field of a list element of maps.
+func attributeAt(item map[string]types.AttributeValue, path string, names map[string]string) (types.AttributeValue, bool) {
+	head, field, nested := strings.Cut(path, ".")
+	alias, index, indexed := strings.Cut(strings.TrimSuffix(head, "]"), "[")
+	av, ok := item[names[alias]]
+	if !ok || !indexed {
+		return av, ok
+	}
+	list, ok := av.(*types.AttributeValueMemberL)
+	n, _ := strconv.Atoi(index)
+	if !ok || n >= len(list.Value) {
+		return nil, false
+	}
+	if !nested {
+		return list.Value[n], true
+	}
+	m, ok := list.Value[n].(*types.AttributeValueMemberM)
+	if !ok {
+		return nil, false
+	}
+	av, ok = m.Value[names[field]]
+	return av, ok
+}
+
 func (f *fakeDynamo) DeleteItem(_ context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
 	key := compositeKey(in.Key, "pk", "sk")
-	if item, ok := f.items[key]; ok {
+	item, ok := f.items[key]
+	if ok {
 		if _, ok := item["gsi1pk"]; ok {
 			delete(f.index, compositeKey(item, "gsi1pk", "gsi1sk"))
 		}
 	}
 	delete(f.items, key)
+	if ok && in.ReturnValues == types.ReturnValueAllOld {
+		return &dynamodb.DeleteItemOutput{Attributes: item}, nil
+	}
 	return &dynamodb.DeleteItemOutput{}, nil
 }
 
+// TransactWriteItems is all-or-nothing like the real thing: every
+// ConditionCheck is evaluated before any Put or Delete is applied.
+func (f *fakeDynamo) TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	if f.beforeTransact != nil {
+		f.beforeTransact()
+	}
+	if f.transactErr != nil {
+		return nil, f.transactErr
+	}
+
+	reasons := make([]types.CancellationReason, len(in.TransactItems))
+	canceled := false
+	for i, op := range in.TransactItems {
+		reasons[i].Code = aws.String("None")
+		if !f.transactConditionHolds(op) {
+			reasons[i].Code = aws.String("ConditionalCheckFailed")
+			canceled = true
+		}
+	}
+	if canceled {
+		return nil, &types.TransactionCanceledException{CancellationReasons: reasons}
+	}
+
+	for _, op := range in.TransactItems {
+		switch {
+		case op.Put != nil:
+			if _, err := f.PutItem(ctx, &dynamodb.PutItemInput{TableName: op.Put.TableName, Item: op.Put.Item}); err != nil {
+				return nil, err
+			}
+		case op.Delete != nil:
+			if _, err := f.DeleteItem(ctx, &dynamodb.DeleteItemInput{TableName: op.Delete.TableName, Key: op.Delete.Key}); err != nil {
+				return nil, err
+			}
+		case op.Update != nil:
+			if _, err := f.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                 op.Update.TableName,
+				Key:                       op.Update.Key,
+				UpdateExpression:          op.Update.UpdateExpression,
+				ExpressionAttributeNames:  op.Update.ExpressionAttributeNames,
+				ExpressionAttributeValues: op.Update.ExpressionAttributeValues,
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+// transactConditionHolds evaluates a transaction item's condition, if it
+// has one, against the item it targets: a ConditionCheck's target must
+// exist, while a Put's, Delete's or Update's is judged by the condition
+// alone (so attribute_not_exists holds for a missing one).
+func (f *fakeDynamo) transactConditionHolds(op types.TransactWriteItem) bool {
+	var (
+		key    map[string]types.AttributeValue
+		expr   *string
+		names  map[string]string
+		values map[string]types.AttributeValue
+	)
+	switch {
+	case op.ConditionCheck != nil:
+		if _, ok := f.items[compositeKey(op.ConditionCheck.Key, "pk", "sk")]; !ok {
+			return false
+		}
+		key, expr, names, values = op.ConditionCheck.Key, op.ConditionCheck.ConditionExpression,
+			op.ConditionCheck.ExpressionAttributeNames, op.ConditionCheck.ExpressionAttributeValues
+	case op.Put != nil:
+		key, expr, names, values = op.Put.Item, op.Put.ConditionExpression,
+			op.Put.ExpressionAttributeNames, op.Put.ExpressionAttributeValues
+	case op.Delete != nil:
+		key, expr, names, values = op.Delete.Key, op.Delete.ConditionExpression,
+			op.Delete.ExpressionAttributeNames, op.Delete.ExpressionAttributeValues
+	case op.Update != nil:
+		key, expr, names, values = op.Update.Key, op.Update.ConditionExpression,
+			op.Update.ExpressionAttributeNames, op.Update.ExpressionAttributeValues
+	}
+	if expr == nil {
+		return true
+	}
+	return conditionHolds(f.items[compositeKey(key, "pk", "sk")], *expr, names, values)
+}
+
 func (f *fakeDynamo) Query(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
 	source := f.items
 	pkAttr, skAttr := "pk", "sk"
@@ -148,10 +371,14 @@ func (f *fakeDynamo) Query(_ context.Context, in *dynamodb.QueryInput, _ ...func
 	}
 
 	pkVal := in.ExpressionAttributeValues[":pk"].(*types.AttributeValueMemberS).Value
-	var skPrefix string
+	var skPrefix, skFrom, skTo string
 	if v, ok := in.ExpressionAttributeValues[":skPrefix"]; ok {
 		skPrefix = v.(*types.AttributeValueMemberS).Value
 	}
+	if v, ok := in.ExpressionAttributeValues[":skFrom"]; ok {
+		skFrom = v.(*types.AttributeValueMemberS).Value
+		skTo = in.ExpressionAttributeValues[":skTo"].(*types.AttributeValueMemberS).Value
+	}
 
 	var matched []map[string]types.AttributeValue
 	for _, item := range source {
@@ -161,13 +388,33 @@ func (f *fakeDynamo) Query(_ context.Context, in *dynamodb.QueryInput, _ ...func
 		if skPrefix != "" && !strings.HasPrefix(strAttr(item, skAttr), skPrefix) {
 			continue
 		}
+		if skFrom != "" && (strAttr(item, skAttr) < skFrom || strAttr(item, skAttr) > skTo) {
+			continue
+		}
 		matched = append(matched, item)
 	}
+	// before reports whether sort key a comes first in the query's order.
+	before := func(a, b string) bool { return a < b }
+	if in.ScanIndexForward != nil && !*in.ScanIndexForward {
+		before = func(a, b string) bool { return a > b }
+	}
 	sort.Slice(matched, func(i, j int) bool {
-		return strAttr(matched[i], skAttr) < strAttr(matched[j], skAttr)
+		return before(strAttr(matched[i], skAttr), strAttr(matched[j], skAttr))
 	})
 
-	return &dynamodb.QueryOutput{Items: matched, Count: int32(len(matched))}, nil
+	if start, ok := in.ExclusiveStartKey[skAttr]; ok {
+		after := start.(*types.AttributeValueMemberS).Value
+		i := sort.Search(len(matched), func(i int) bool { return before(after, strAttr(matched[i], skAttr)) })
+		matched = matched[i:]
+	}
+	var last map[string]types.AttributeValue
+	if f.queryPageSize > 0 && len(matched) > f.queryPageSize {
+		matched = matched[:f.queryPageSize]
+		end := matched[len(matched)-1]
+		last = map[string]types.AttributeValue{pkAttr: end[pkAttr], skAttr: end[skAttr]}
+	}
+
+	return &dynamodb.QueryOutput{Items: matched, Count: int32(len(matched)), LastEvaluatedKey: last}, nil
 }
 
 func newTestAdapter(f *fakeDynamo, fixedNow time.Time) *InsightAdapter {
@@ -525,3 +772,120 @@ func TestInsightAdapter_ListTags_RelationshipsRaiseScore_VisibleInDensityCompone
 		t.Fatalf("after Score = %v, want > before Score = %v", after[0].Score, before[0].Score)
 	}
 }
+
+func TestInsightAdapter_ListByTenantID_RoundTripsHighlightedAtAndEnrichedAt(t *testing.T) {
+	// Re-enrichment (application/reenrich) feeds listed insights straight
+	// back into Update; a dropped HighlightedAt there would re-stamp every
+	// new tag membership with "now" and skew relevance scoring.
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	highlightedAt := now.Add(-30 * 24 * time.Hour)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	insight := domain.Insight{ID: "i-1", TenantID: "t-1", Source: "readwise", Text: "hello", HighlightedAt: highlightedAt}
+	if _, err := a.CreateIfAbsent(ctx, insight); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+	insight.Enrichment = &domain.Enrichment{Tags: []string{"a"}, EnrichedAt: now}
+	if err := a.Update(ctx, insight); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	insights, err := a.ListByTenantID(ctx, "t-1", "")
+	if err != nil {
+		t.Fatalf("ListByTenantID: %v", err)
+	}
+	if len(insights) != 1 || !insights[0].HighlightedAt.Equal(highlightedAt) {
+		t.Fatalf("ListByTenantID = %+v, want HighlightedAt=%v", insights, highlightedAt)
+	}
+	if insights[0].Enrichment == nil || !insights[0].Enrichment.EnrichedAt.Equal(now) {
+		t.Fatalf("Enrichment = %+v, want EnrichedAt=%v", insights[0].Enrichment, now)
+	}
+}
+
+func TestInsightAdapter_Update_RoundTripsEnrichmentFields(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	insight := domain.Insight{ID: "i-1", TenantID: "t-1", Source: "readwise", Text: "hello"}
+	if _, err := a.CreateIfAbsent(ctx, insight); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+	want := domain.Enrichment{
+		Tags:          []string{"a"},
+		Summary:       "One sentence.",
+		Concepts:      []string{"Seneca"},
+		Sentiment:     domain.SentimentNeutral,
+		Actionability: 0.4,
+		Question:      "Why?",
+		EnrichedAt:    now,
+		Model:         "gpt-test",
+		PromptVersion: "tags-v1",
+		InputTokens:   42,
+		OutputTokens:  7,
+		Latency:       1500 * time.Millisecond,
+	}
+	insight.Enrichment = &want
+	if err := a.Update(ctx, insight); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	insights, err := a.ListByTenantID(ctx, "t-1", "")
+	if err != nil {
+		t.Fatalf("ListByTenantID: %v", err)
+	}
+	got := insights[0].Enrichment
+	if got == nil || got.Summary != want.Summary || len(got.Concepts) != 1 || got.Sentiment != want.Sentiment ||
+		got.Actionability != want.Actionability || got.Question != want.Question || got.Model != want.Model || got.PromptVersion != want.PromptVersion ||
+		got.InputTokens != want.InputTokens || got.OutputTokens != want.OutputTokens || got.Latency != want.Latency {
+		t.Fatalf("Enrichment = %+v, want %+v", got, want)
+	}
+}
+
+func TestInsightAdapter_Language_SetOnCreateOrBackfilledByUpdate(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAdapter(newFakeDynamo(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	detected := domain.Insight{ID: "i-1", TenantID: "t-1", Source: "readwise", Text: "hallo", Language: "de"}
+	legacy := domain.Insight{ID: "i-2", TenantID: "t-1", Source: "readwise", Text: "hello"}
+	for _, in := range []domain.Insight{detected, legacy} {
+		if _, err := a.CreateIfAbsent(ctx, in); err != nil {
+			t.Fatalf("CreateIfAbsent: %v", err)
+		}
+	}
+	legacy.Language = "en"
+	legacy.Enrichment = &domain.Enrichment{Tags: []string{"a"}}
+	if err := a.Update(ctx, legacy); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	insights, err := a.ListByTenantID(ctx, "t-1", "")
+	if err != nil {
+		t.Fatalf("ListByTenantID: %v", err)
+	}
+	got := map[string]domain.Language{}
+	for _, in := range insights {
+		got[in.ID] = in.Language
+	}
+	if got["i-1"] != "de" || got["i-2"] != "en" {
+		t.Fatalf("languages = %v, want i-1=de (created) and i-2=en (backfilled)", got)
+	}
+}
+
+func TestInsightAdapter_GetByID_ScopedByTenant(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAdapter(newFakeDynamo(), time.Now())
+
+	if _, err := a.CreateIfAbsent(ctx, domain.Insight{ID: "i-1", TenantID: "t-1", Source: "readwise", Text: "hello"}); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+
+	got, found, err := a.GetByID(ctx, "t-1", "i-1")
+	if err != nil || !found || got.Text != "hello" || got.Source != "readwise" {
+		t.Fatalf("GetByID = %+v, found=%v, err=%v; want the stored insight", got, found, err)
+	}
+	if _, found, err := a.GetByID(ctx, "t-2", "i-1"); err != nil || found {
+		t.Fatalf("GetByID(other tenant) found=%v err=%v, want not found", found, err)
+	}
+}
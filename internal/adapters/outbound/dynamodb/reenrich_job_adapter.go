@@ -0,0 +1,187 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.ReenrichJobRepository = (*InsightAdapter)(nil)
+
+type dynamoReenrichJobItem struct {
+	PK             string    `dynamodbav:"pk"`
+	SK             string    `dynamodbav:"sk"`
+	TenantID       string    `dynamodbav:"tenant_id"`
+	ID             string    `dynamodbav:"id"`
+	Scope          string    `dynamodbav:"scope"`
+	EnrichedBefore time.Time `dynamodbav:"enriched_before"`
+	Model          string    `dynamodbav:"model,omitempty"`
+	PromptVersion  string    `dynamodbav:"prompt_version,omitempty"`
+	Status         string    `dynamodbav:"status"`
+	Cursor         string    `dynamodbav:"cursor"`
+	Processed      int       `dynamodbav:"processed"`
+	Enriched       int       `dynamodbav:"enriched"`
+	Failed         int       `dynamodbav:"failed"`
+	CreatedAt      time.Time `dynamodbav:"created_at"`
+	UpdatedAt      time.Time `dynamodbav:"updated_at"`
+}
+
+func (item dynamoReenrichJobItem) toDomain() domain.ReenrichJob {
+	return domain.ReenrichJob{
+		ID:             item.ID,
+		TenantID:       item.TenantID,
+		Scope:          domain.ReenrichScope(item.Scope),
+		EnrichedBefore: item.EnrichedBefore,
+		Model:          item.Model,
+		PromptVersion:  item.PromptVersion,
+		Status:         domain.JobStatus(item.Status),
+		Cursor:         item.Cursor,
+		Processed:      item.Processed,
+		Enriched:       item.Enriched,
+		Failed:         item.Failed,
+		CreatedAt:      item.CreatedAt,
+		UpdatedAt:      item.UpdatedAt,
+	}
+}
+
+func reenrichJobSK(jobID string) string {
+	return "REENRICH#" + jobID
+}
+
+// CreateReenrichJob persists job (pk = TENANT#<id>, sk = REENRICH#<jobID>).
+func (r *InsightAdapter) CreateReenrichJob(ctx context.Context, job domain.ReenrichJob) error {
+	item := dynamoReenrichJobItem{
+		PK:             pk(job.TenantID),
+		SK:             reenrichJobSK(job.ID),
+		TenantID:       job.TenantID,
+		ID:             job.ID,
+		Scope:          string(job.Scope),
+		EnrichedBefore: job.EnrichedBefore,
+		Model:          job.Model,
+		PromptVersion:  job.PromptVersion,
+		Status:         string(job.Status),
+		Cursor:         job.Cursor,
+		Processed:      job.Processed,
+		Enriched:       job.Enriched,
+		Failed:         job.Failed,
+		CreatedAt:      job.CreatedAt,
+		UpdatedAt:      job.CreatedAt,
+	}
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+// GetReenrichJob loads one tenant's job by id, or ErrReenrichJobNotFound.
+func (r *InsightAdapter) GetReenrichJob(ctx context.Context, tenantID, jobID string) (domain.ReenrichJob, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: reenrichJobSK(jobID)},
+		},
+	})
+	if err != nil {
+		return domain.ReenrichJob{}, err
+	}
+	if out.Item == nil {
+		return domain.ReenrichJob{}, ports.ErrReenrichJobNotFound
+	}
+
+	var item dynamoReenrichJobItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.ReenrichJob{}, err
+	}
+	return item.toDomain(), nil
+}
+
+// ListPendingReenrichJobs returns tenantID's pending jobs, oldest first.
+// Filtered and sorted in Go, same reasoning as ListPlansByTenantID: a
+// tenant has a handful of jobs at most, and sk (a UUID) carries no order.
+func (r *InsightAdapter) ListPendingReenrichJobs(ctx context.Context, tenantID string) ([]domain.ReenrichJob, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: "REENRICH#"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []domain.ReenrichJob
+	for _, raw := range out.Items {
+		var item dynamoReenrichJobItem
+		if err := attributevalue.UnmarshalMap(raw, &item); err != nil {
+			return nil, err
+		}
+		if domain.JobStatus(item.Status) != domain.JobStatusPending {
+			continue
+		}
+		jobs = append(jobs, item.toDomain())
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// SaveReenrichJobProgress overwrites the job's progress fields, conditional
+// on the job still existing so a stray save never recreates a deleted job
+// as a half-empty item.
+func (r *InsightAdapter) SaveReenrichJobProgress(ctx context.Context, job domain.ReenrichJob) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(job.TenantID)},
+			"sk": &types.AttributeValueMemberS{Value: reenrichJobSK(job.ID)},
+		},
+		ConditionExpression: aws.String("attribute_exists(#pk)"),
+		UpdateExpression: aws.String("SET #status = :status, #cursor = :cursor, #processed = :processed, " +
+			"#enriched = :enriched, #failed = :failed, #updated_at = :updated_at"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk":         "pk",
+			"#status":     "status",
+			"#cursor":     "cursor",
+			"#processed":  "processed",
+			"#enriched":   "enriched",
+			"#failed":     "failed",
+			"#updated_at": "updated_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status":     &types.AttributeValueMemberS{Value: string(job.Status)},
+			":cursor":     &types.AttributeValueMemberS{Value: job.Cursor},
+			":processed":  &types.AttributeValueMemberN{Value: strconv.Itoa(job.Processed)},
+			":enriched":   &types.AttributeValueMemberN{Value: strconv.Itoa(job.Enriched)},
+			":failed":     &types.AttributeValueMemberN{Value: strconv.Itoa(job.Failed)},
+			":updated_at": &types.AttributeValueMemberS{Value: job.UpdatedAt.UTC().Format(time.RFC3339Nano)},
+		},
+	})
+	if err != nil {
+		if _, ok := errors.AsType[*types.ConditionalCheckFailedException](err); ok {
+			return ports.ErrReenrichJobNotFound
+		}
+		return err
+	}
+	return nil
+}
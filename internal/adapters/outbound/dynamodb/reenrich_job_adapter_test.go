@@ -0,0 +1,73 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+func TestInsightAdapter_ReenrichJob_ProgressRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	job := domain.ReenrichJob{
+		ID: "job-1", TenantID: "t-1", Scope: domain.ReenrichVersion, PromptVersion: "enrich-v2",
+		Status: domain.JobStatusPending, CreatedAt: now,
+	}
+	if err := a.CreateReenrichJob(ctx, job); err != nil {
+		t.Fatalf("CreateReenrichJob: %v", err)
+	}
+
+	job.Cursor, job.Processed, job.Enriched, job.Failed = "i-7", 3, 2, 1
+	job.UpdatedAt = now.Add(time.Minute)
+	if err := a.SaveReenrichJobProgress(ctx, job); err != nil {
+		t.Fatalf("SaveReenrichJobProgress: %v", err)
+	}
+
+	got, err := a.GetReenrichJob(ctx, "t-1", "job-1")
+	if err != nil {
+		t.Fatalf("GetReenrichJob: %v", err)
+	}
+	if got.Cursor != "i-7" || got.Processed != 3 || got.Enriched != 2 || got.Failed != 1 ||
+		got.Scope != domain.ReenrichVersion || got.PromptVersion != "enrich-v2" {
+		t.Fatalf("GetReenrichJob = %+v, want saved progress", got)
+	}
+}
+
+func TestInsightAdapter_ListPendingReenrichJobs_SkipsDoneOldestFirst(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	for _, job := range []domain.ReenrichJob{
+		{ID: "b", TenantID: "t-1", Scope: domain.ReenrichAll, Status: domain.JobStatusPending, CreatedAt: now.Add(time.Hour)},
+		{ID: "c", TenantID: "t-1", Scope: domain.ReenrichAll, Status: domain.JobStatusDone, CreatedAt: now},
+		{ID: "a", TenantID: "t-1", Scope: domain.ReenrichAll, Status: domain.JobStatusPending, CreatedAt: now.Add(2 * time.Hour)},
+	} {
+		if err := a.CreateReenrichJob(ctx, job); err != nil {
+			t.Fatalf("CreateReenrichJob(%s): %v", job.ID, err)
+		}
+	}
+
+	pending, err := a.ListPendingReenrichJobs(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListPendingReenrichJobs: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != "b" || pending[1].ID != "a" {
+		t.Fatalf("pending = %+v, want [b a]", pending)
+	}
+}
+
+func TestInsightAdapter_SaveReenrichJobProgress_UnknownJob(t *testing.T) {
+	a := newTestAdapter(newFakeDynamo(), time.Now())
+
+	err := a.SaveReenrichJobProgress(context.Background(), domain.ReenrichJob{ID: "nope", TenantID: "t-1"})
+	if !errors.Is(err, ports.ErrReenrichJobNotFound) {
+		t.Fatalf("err = %v, want ErrReenrichJobNotFound", err)
+	}
+}
@@ -0,0 +1,121 @@
+package dynamodb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.RejectedRelationshipLog = (*InsightAdapter)(nil)
+
+// rejectedRelationshipSKPrefix doesn't start with "REL#", so the edge
+// queries (ListByInsightID, relationshipDegreeByInsight) never see log
+// entries.
+const rejectedRelationshipSKPrefix = "RELREJECT#"
+
+// dynamoRejectedRelationshipItem is one log entry (pk = TENANT#<id>,
+// sk = RELREJECT#<rejectedAt>#<from>#<to>). expires_at is the table's TTL
+// attribute, as on dynamoEnrichmentCacheItem.
+type dynamoRejectedRelationshipItem struct {
+	PK            string    `dynamodbav:"pk"`
+	SK            string    `dynamodbav:"sk"`
+	TenantID      string    `dynamodbav:"tenant_id"`
+	FromInsightID string    `dynamodbav:"from_insight_id"`
+	ToInsightID   string    `dynamodbav:"to_insight_id"`
+	Type          string    `dynamodbav:"type"`
+	Confidence    float64   `dynamodbav:"confidence"`
+	Rationale     string    `dynamodbav:"rationale"`
+	DiscoveredAt  time.Time `dynamodbav:"discovered_at"`
+	Reason        string    `dynamodbav:"reason"`
+	Detail        string    `dynamodbav:"detail"`
+	RejectedAt    time.Time `dynamodbav:"rejected_at"`
+	ExpiresAt     int64     `dynamodbav:"expires_at"`
+}
+
+func rejectedRelationshipSK(rejected domain.RejectedRelationship) string {
+	return rejectedRelationshipSKPrefix + rejected.RejectedAt.UTC().Format(time.RFC3339Nano) + "#" + rejected.FromInsightID + "#" + rejected.ToInsightID
+}
+
+func (r *InsightAdapter) RecordRejectedRelationship(ctx context.Context, rejected domain.RejectedRelationship, expiresAt time.Time) error {
+	av, err := attributevalue.MarshalMap(dynamoRejectedRelationshipItem{
+		PK:            pk(rejected.TenantID),
+		SK:            rejectedRelationshipSK(rejected),
+		TenantID:      rejected.TenantID,
+		FromInsightID: rejected.FromInsightID,
+		ToInsightID:   rejected.ToInsightID,
+		Type:          string(rejected.Type),
+		Confidence:    rejected.Confidence,
+		Rationale:     rejected.Rationale,
+		DiscoveredAt:  rejected.DiscoveredAt,
+		Reason:        string(rejected.Reason),
+		Detail:        rejected.Detail,
+		RejectedAt:    rejected.RejectedAt,
+		ExpiresAt:     expiresAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+// ListRejectedRelationships skips expired entries itself, for the same
+// reason GetCachedEnrichment does: TTL deletion can run days late.
+func (r *InsightAdapter) ListRejectedRelationships(ctx context.Context, tenantID string) ([]domain.RejectedRelationship, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: rejectedRelationshipSKPrefix},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.now().Unix()
+	rejected := make([]domain.RejectedRelationship, 0, len(out.Items))
+	for _, dynItem := range out.Items {
+		var item dynamoRejectedRelationshipItem
+		if err := attributevalue.UnmarshalMap(dynItem, &item); err != nil {
+			return nil, err
+		}
+		if now >= item.ExpiresAt {
+			continue
+		}
+		rejected = append(rejected, domain.RejectedRelationship{
+			Relationship: domain.Relationship{
+				TenantID:      item.TenantID,
+				FromInsightID: item.FromInsightID,
+				ToInsightID:   item.ToInsightID,
+				Type:          domain.RelationType(item.Type),
+				Confidence:    item.Confidence,
+				Rationale:     item.Rationale,
+				DiscoveredAt:  item.DiscoveredAt,
+			},
+			Reason:     domain.RejectReason(item.Reason),
+			Detail:     item.Detail,
+			RejectedAt: item.RejectedAt,
+		})
+	}
+	sort.SliceStable(rejected, func(i, j int) bool {
+		return rejected[i].RejectedAt.After(rejected[j].RejectedAt)
+	})
+	return rejected, nil
+}
@@ -2,7 +2,10 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -50,8 +53,8 @@ func relSK(fromInsightID, toInsightID string) string {
 // parseRelOwnerFromSK extracts the owning insight ID from a relationship
 // item's sort key ("REL#<ownerID>#<otherID>"): whichever insight this
 // particular copy of the edge is filed under (see relSKPrefix and Put).
-// Used by relationshipDegreeByInsight to count edges per insight from a
-// single tenant-wide query.
+// Used by RelationshipDegrees to count edges per insight from a single
+// tenant-wide query.
 func parseRelOwnerFromSK(sk string) (string, bool) {
 	rest, ok := strings.CutPrefix(sk, "REL#")
 	if !ok {
@@ -66,13 +69,19 @@ func relSKPrefix(insightID string) string {
 }
 
 // Put persists rel as two adjacency items sharing the tenant's partition,
-// after checking both insights exist. Both PutItems are unconditional
-// (deterministic sk = upsert), which is what makes a re-post of the same
-// edge idempotent rather than a duplicate.
+// in one TransactWriteItems that also checks both insights still exist:
+// either both directions are indexed or neither is. The insights are read
+// first anyway, for the denormalized text, so a missing one fails fast
+// without a transaction; the condition checks cover one deleted between
+// that read and the write. A re-post of the same edge overwrites it
+// (deterministic sk = upsert) rather than adding a duplicate.
 //
-// TRADE-OFF: the two PutItems aren't transactional, so a failure between
-// them can leave one direction indexed and not the other. Upgrade to
-// TransactWriteItems if that inconsistency ever surfaces in practice.
+// A new edge (not a re-post) adds one to the degree sum of every tag on
+// each end, in their TAGSTAT# aggregates, in the same transaction. The
+// puts are conditioned on the edge being new, or still there, as read,
+// so a concurrent Put or DeleteRelationship of the same pair can't make
+// it count twice or not at all; losing that race reads the edge again
+// and retries.
 func (r *InsightAdapter) Put(ctx context.Context, rel domain.Relationship) error {
 	fromInsight, err := r.getInsight(ctx, rel.TenantID, rel.FromInsightID)
 	if err != nil {
@@ -96,7 +105,6 @@ func (r *InsightAdapter) Put(ctx context.Context, rel domain.Relationship) error
 		Rationale:     rel.Rationale,
 		DiscoveredAt:  rel.DiscoveredAt,
 	}
-
 	edges := [2]struct {
 		sk          string
 		relatedText string
@@ -104,22 +112,187 @@ func (r *InsightAdapter) Put(ctx context.Context, rel domain.Relationship) error
 		{relSK(rel.FromInsightID, rel.ToInsightID), toInsight.Text},
 		{relSK(rel.ToInsightID, rel.FromInsightID), fromInsight.Text},
 	}
-	for _, edge := range edges {
-		item.SK = edge.sk
-		item.RelatedInsightText = edge.relatedText
-		av, err := attributevalue.MarshalMap(item)
+
+	for range maxEdgeWriteAttempts {
+		_, existed, err := r.GetRelationship(ctx, rel.TenantID, rel.FromInsightID, rel.ToInsightID)
 		if err != nil {
+			return fmt.Errorf("get relationship: %w", err)
+		}
+
+		transactItems := []types.TransactWriteItem{
+			r.insightExistsCheck(rel.TenantID, rel.FromInsightID),
+			r.insightExistsCheck(rel.TenantID, rel.ToInsightID),
+		}
+		for _, edge := range edges {
+			item.SK = edge.sk
+			item.RelatedInsightText = edge.relatedText
+			av, err := attributevalue.MarshalMap(item)
+			if err != nil {
+				return err
+			}
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Put: &types.Put{
+					TableName:                aws.String(r.tableName),
+					Item:                     av,
+					ConditionExpression:      aws.String(edgeCondition(existed)),
+					ExpressionAttributeNames: map[string]string{"#pk": "pk"},
+				},
+			})
+		}
+		if !existed {
+			transactItems = append(transactItems, r.tagDegreeUpdates(rel.TenantID, fromInsight, toInsight, 1)...)
+		}
+
+		_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+		switch {
+		case failedAt(err, 0, 1):
+			return ports.ErrInsightNotFound
+		case conditionCheckFailed(err):
+			continue
+		default:
 			return err
 		}
-		if _, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
-			TableName: aws.String(r.tableName),
-			Item:      av,
-		}); err != nil {
+	}
+	return errEdgeContended
+}
+
+// DeleteRelationship removes both adjacency items Put wrote for the pair,
+// in one transaction for the same reason Put uses one. An existing edge
+// comes back out of its ends' tag degree sums in that transaction too,
+// its deletes conditioned on it still being there, as Put's puts are.
+// Deleting a missing edge is a no-op that succeeds.
+func (r *InsightAdapter) DeleteRelationship(ctx context.Context, tenantID, insightID, otherInsightID string) error {
+	for range maxEdgeWriteAttempts {
+		_, existed, err := r.GetRelationship(ctx, tenantID, insightID, otherInsightID)
+		if err != nil {
+			return fmt.Errorf("get relationship: %w", err)
+		}
+		if !existed {
+			return nil
+		}
+		insight, err := r.getInsight(ctx, tenantID, insightID)
+		if err != nil {
+			return fmt.Errorf("get insight: %w", err)
+		}
+		other, err := r.getInsight(ctx, tenantID, otherInsightID)
+		if err != nil {
+			return fmt.Errorf("get other insight: %w", err)
+		}
+
+		var transactItems []types.TransactWriteItem
+		for _, edgeSK := range []string{relSK(insightID, otherInsightID), relSK(otherInsightID, insightID)} {
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Delete: &types.Delete{
+					TableName: aws.String(r.tableName),
+					Key: map[string]types.AttributeValue{
+						"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+						"sk": &types.AttributeValueMemberS{Value: edgeSK},
+					},
+					ConditionExpression:      aws.String(edgeCondition(true)),
+					ExpressionAttributeNames: map[string]string{"#pk": "pk"},
+				},
+			})
+		}
+		if insight != nil && other != nil {
+			transactItems = append(transactItems, r.tagDegreeUpdates(tenantID, insight, other, -1)...)
+		}
+
+		_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+		if !conditionCheckFailed(err) {
 			return err
 		}
 	}
+	return errEdgeContended
+}
+
+// maxEdgeWriteAttempts bounds Put's and DeleteRelationship's retries when
+// a concurrent write to the same pair keeps changing it under them.
+const maxEdgeWriteAttempts = 3
+
+var errEdgeContended = errors.New("relationship changed concurrently on every attempt")
+
+// edgeCondition is an edge write's condition on the adjacency item: still
+// there if it existed when read, still absent if it didn't.
+func edgeCondition(existed bool) string {
+	if existed {
+		return "attribute_exists(#pk)"
+	}
+	return "attribute_not_exists(#pk)"
+}
+
+// tagDegreeUpdates adds delta to the degree sum of every tag of both of
+// an edge's ends, one update per tag: a tag on both ends gets 2*delta,
+// as the edge counts in each end's degree. Merging them matters: a
+// transaction can't touch the same item twice.
+func (r *InsightAdapter) tagDegreeUpdates(tenantID string, from, to *domain.Insight, delta int) []types.TransactWriteItem {
+	deltas := map[string]int{}
+	for _, end := range []*domain.Insight{from, to} {
+		if end.Enrichment == nil {
+			continue
+		}
+		for tag := range toTagSet(end.Enrichment.Tags) {
+			deltas[tag] += delta
+		}
+	}
+
+	tags := slices.Sorted(maps.Keys(deltas))
+	updates := make([]types.TransactWriteItem, len(tags))
+	for i, tag := range tags {
+		updates[i] = types.TransactWriteItem{
+			Update: &types.Update{
+				TableName:                 aws.String(r.tableName),
+				Key:                       tagStatKey(tenantID, tag),
+				UpdateExpression:          aws.String("ADD #degree_sum :degree_sum"),
+				ExpressionAttributeNames:  map[string]string{"#degree_sum": "degree_sum"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":degree_sum": numberAV(int64(deltas[tag]))},
+			},
+		}
+	}
+	return updates
+}
+
+func (r *InsightAdapter) insightExistsCheck(tenantID, insightID string) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		ConditionCheck: &types.ConditionCheck{
+			TableName: aws.String(r.tableName),
+			Key: map[string]types.AttributeValue{
+				"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+				"sk": &types.AttributeValueMemberS{Value: sk(insightID)},
+			},
+			ConditionExpression:      aws.String("attribute_exists(#pk)"),
+			ExpressionAttributeNames: map[string]string{"#pk": "pk"},
+		},
+	}
+}
+
+// failedAt reports whether err is a transaction cancelled by the
+// condition of one of the items at indexes.
+func failedAt(err error, indexes ...int) bool {
+	canceled, ok := errors.AsType[*types.TransactionCanceledException](err)
+	if !ok {
+		return false
+	}
+	for _, i := range indexes {
+		if i < len(canceled.CancellationReasons) && aws.ToString(canceled.CancellationReasons[i].Code) == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
+}
 
-	return nil
+// conditionCheckFailed reports whether err is a transaction cancelled by
+// one of its condition expressions, as opposed to a conflict or throttle.
+func conditionCheckFailed(err error) bool {
+	canceled, ok := errors.AsType[*types.TransactionCanceledException](err)
+	if !ok {
+		return false
+	}
+	for _, reason := range canceled.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
 }
 
 // ListByInsightID returns insightID's edges — from either direction it was
@@ -162,6 +335,7 @@ func (r *InsightAdapter) ListByInsightID(ctx context.Context, tenantID, insightI
 			Type:       domain.RelationType(item.Type),
 			Confidence: item.Confidence,
 			Rationale:  item.Rationale,
+			Outgoing:   item.FromInsightID == insightID,
 		})
 	}
 
@@ -172,6 +346,89 @@ func (r *InsightAdapter) ListByInsightID(ctx context.Context, tenantID, insightI
 	return related, nil
 }
 
+// ListRelationships queries the tenant's REL# prefix page by page,
+// keeping only each edge's copy filed under its from side.
+func (r *InsightAdapter) ListRelationships(ctx context.Context, tenantID string) ([]domain.Relationship, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: "REL#"},
+		},
+	}
+
+	var rels []domain.Relationship
+	for {
+		out, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, dynItem := range out.Items {
+			var item dynamoRelationshipItem
+			if err := attributevalue.UnmarshalMap(dynItem, &item); err != nil {
+				return nil, err
+			}
+			if item.SK != relSK(item.FromInsightID, item.ToInsightID) {
+				continue
+			}
+			rels = append(rels, domain.Relationship{
+				TenantID:      item.TenantID,
+				FromInsightID: item.FromInsightID,
+				ToInsightID:   item.ToInsightID,
+				Type:          domain.RelationType(item.Type),
+				Confidence:    item.Confidence,
+				Rationale:     item.Rationale,
+				DiscoveredAt:  item.DiscoveredAt,
+			})
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+	if rels == nil {
+		rels = []domain.Relationship{}
+	}
+	return rels, nil
+}
+
+// GetRelationship reads insightID's copy of the edge, which carries the
+// original direction like both copies do.
+func (r *InsightAdapter) GetRelationship(ctx context.Context, tenantID, insightID, otherInsightID string) (domain.Relationship, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: relSK(insightID, otherInsightID)},
+		},
+	})
+	if err != nil {
+		return domain.Relationship{}, false, err
+	}
+	if out.Item == nil {
+		return domain.Relationship{}, false, nil
+	}
+
+	var item dynamoRelationshipItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.Relationship{}, false, err
+	}
+	return domain.Relationship{
+		TenantID:      item.TenantID,
+		FromInsightID: item.FromInsightID,
+		ToInsightID:   item.ToInsightID,
+		Type:          domain.RelationType(item.Type),
+		Confidence:    item.Confidence,
+		Rationale:     item.Rationale,
+		DiscoveredAt:  item.DiscoveredAt,
+	}, true, nil
+}
+
 func (r *InsightAdapter) getInsight(ctx context.Context, tenantID, insightID string) (*domain.Insight, error) {
 	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(r.tableName),
@@ -3,6 +3,7 @@ package dynamodb
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -152,11 +153,11 @@ func TestInsightAdapter_ListByInsightID_MergesBothDirections_SortedByConfidenceD
 	if len(related) != 2 {
 		t.Fatalf("ListByInsightID = %+v, want 2 related insights", related)
 	}
-	if related[0].InsightID != "i-3" || related[0].Confidence != 0.9 || related[0].Text != "three" {
-		t.Fatalf("related[0] = %+v, want i-3 confidence=0.9 text=three (sorted first)", related[0])
+	if related[0].InsightID != "i-3" || related[0].Confidence != 0.9 || related[0].Text != "three" || related[0].Outgoing {
+		t.Fatalf("related[0] = %+v, want incoming i-3 confidence=0.9 text=three (sorted first)", related[0])
 	}
-	if related[1].InsightID != "i-2" || related[1].Confidence != 0.6 || related[1].Text != "two" {
-		t.Fatalf("related[1] = %+v, want i-2 confidence=0.6 text=two", related[1])
+	if related[1].InsightID != "i-2" || related[1].Confidence != 0.6 || related[1].Text != "two" || !related[1].Outgoing {
+		t.Fatalf("related[1] = %+v, want outgoing i-2 confidence=0.6 text=two", related[1])
 	}
 }
 
@@ -177,3 +178,270 @@ func TestInsightAdapter_ListByInsightID_NoRelationships_ReturnsEmptyNotNil(t *te
 		t.Fatalf("ListByInsightID = %v, want empty", related)
 	}
 }
+
+func TestInsightAdapter_DeleteRelationship_RemovesBothDirections(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+
+	for _, id := range []string{"i-1", "i-2", "i-3"} {
+		if _, err := a.CreateIfAbsent(ctx, domain.Insight{ID: id, TenantID: "t-1", Text: id}); err != nil {
+			t.Fatalf("CreateIfAbsent(%s): %v", id, err)
+		}
+	}
+	for _, to := range []string{"i-2", "i-3"} {
+		if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: to, Type: domain.RelationSupports, Confidence: 0.9}); err != nil {
+			t.Fatalf("Put(i-1, %s): %v", to, err)
+		}
+	}
+
+	// Deleted from the to side: the pair, not the direction, names the edge.
+	if err := a.DeleteRelationship(ctx, "t-1", "i-2", "i-1"); err != nil {
+		t.Fatalf("DeleteRelationship: %v", err)
+	}
+
+	related, err := a.ListByInsightID(ctx, "t-1", "i-1")
+	if err != nil || len(related) != 1 || related[0].InsightID != "i-3" {
+		t.Fatalf("i-1's edges = %+v, %v; want only i-3", related, err)
+	}
+	if related, _ := a.ListByInsightID(ctx, "t-1", "i-2"); len(related) != 0 {
+		t.Fatalf("i-2's edges = %+v, want none", related)
+	}
+	if err := a.DeleteRelationship(ctx, "t-1", "i-1", "i-2"); err != nil {
+		t.Fatalf("second DeleteRelationship: %v, want a no-op", err)
+	}
+}
+
+func TestInsightAdapter_RejectedRelationships_NewestFirstSkipsExpiredAndEdges(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	a := newTestAdapter(f, now)
+
+	for _, id := range []string{"i-1", "i-2"} {
+		if _, err := a.CreateIfAbsent(ctx, domain.Insight{ID: id, TenantID: "t-1", Text: id}); err != nil {
+			t.Fatalf("CreateIfAbsent(%s): %v", id, err)
+		}
+	}
+	if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	entries := []struct {
+		reason     domain.RejectReason
+		rejectedAt time.Time
+		expiresAt  time.Time
+	}{
+		{domain.RejectEdgeCap, now.Add(-2 * time.Hour), now.Add(time.Hour)},
+		{domain.RejectContradictsEdge, now.Add(-time.Hour), now.Add(time.Hour)},
+		{domain.RejectBelowMinConfidence, now.Add(-48 * time.Hour), now.Add(-time.Hour)},
+	}
+	for _, e := range entries {
+		rejected := domain.RejectedRelationship{
+			Relationship: domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationContradicts, Confidence: 0.4},
+			Reason:       e.reason,
+			RejectedAt:   e.rejectedAt,
+		}
+		if err := a.RecordRejectedRelationship(ctx, rejected, e.expiresAt); err != nil {
+			t.Fatalf("RecordRejectedRelationship(%s): %v", e.reason, err)
+		}
+	}
+
+	got, err := a.ListRejectedRelationships(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListRejectedRelationships: %v", err)
+	}
+	if len(got) != 2 || got[0].Reason != domain.RejectContradictsEdge || got[1].Reason != domain.RejectEdgeCap {
+		t.Fatalf("got %+v; want the two unexpired entries, newest first", got)
+	}
+	if got[0].Type != domain.RelationContradicts || got[0].FromInsightID != "i-1" {
+		t.Fatalf("entry = %+v, want the rejected edge round-tripped", got[0])
+	}
+
+	// The log lives beside the edges but must not show up as one.
+	if related, _ := a.ListByInsightID(ctx, "t-1", "i-1"); len(related) != 1 {
+		t.Fatalf("i-1's edges = %+v, want only the stored one", related)
+	}
+}
+
+// relItems is every edge item in the fake, both directions, by sort key.
+func relItems(f *fakeDynamo) map[string]string {
+	out := map[string]string{}
+	for _, item := range f.items {
+		if sk := strAttr(item, "sk"); strings.HasPrefix(sk, "REL#") {
+			out[sk] = strAttr(item, "rationale")
+		}
+	}
+	return out
+}
+
+func seedInsights(t *testing.T, a *InsightAdapter, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if _, err := a.CreateIfAbsent(context.Background(), domain.Insight{ID: id, TenantID: "t-1", Text: id}); err != nil {
+			t.Fatalf("CreateIfAbsent(%s): %v", id, err)
+		}
+	}
+}
+
+func TestInsightAdapter_Put_InsightDeletedBeforeWrite_WritesNeitherDirection(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2")
+
+	// i-2 disappears after Put has read it but before the write lands.
+	f.beforeTransact = func() { delete(f.items, pk("t-1")+"|"+sk("i-2")) }
+
+	err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9})
+	if !errors.Is(err, ports.ErrInsightNotFound) {
+		t.Fatalf("Put err = %v, want ErrInsightNotFound", err)
+	}
+	if got := relItems(f); len(got) != 0 {
+		t.Fatalf("edge items = %v, want none", got)
+	}
+}
+
+func TestInsightAdapter_Put_TransactionFails_LeavesTheOldEdgeWhole(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2")
+
+	rel := domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.7, Rationale: "v1"}
+	if err := a.Put(ctx, rel); err != nil {
+		t.Fatalf("first Put: %v", err)
+	}
+
+	wantErr := errors.New("throttled")
+	f.transactErr = wantErr
+	rel.Rationale = "v2"
+	if err := a.Put(ctx, rel); !errors.Is(err, wantErr) || errors.Is(err, ports.ErrInsightNotFound) {
+		t.Fatalf("Put err = %v, want the transaction's own error", err)
+	}
+
+	if got := relItems(f); len(got) != 2 || got[relSK("i-1", "i-2")] != "v1" || got[relSK("i-2", "i-1")] != "v1" {
+		t.Fatalf("edge items = %v, want both directions still at v1", got)
+	}
+}
+
+func TestInsightAdapter_DeleteRelationship_TransactionFails_KeepsBothDirections(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2")
+
+	if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	f.transactErr = errors.New("conflict")
+	if err := a.DeleteRelationship(ctx, "t-1", "i-1", "i-2"); err == nil {
+		t.Fatalf("DeleteRelationship succeeded, want the injected failure")
+	}
+	if got := relItems(f); len(got) != 2 {
+		t.Fatalf("edge items = %v, want both directions intact", got)
+	}
+}
+
+func TestInsightAdapter_GetRelationship_FromEitherSide(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2")
+
+	rel := domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationExtends, Confidence: 0.7, Rationale: "builds on it"}
+	if err := a.Put(ctx, rel); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	for _, side := range [][2]string{{"i-1", "i-2"}, {"i-2", "i-1"}} {
+		got, found, err := a.GetRelationship(ctx, "t-1", side[0], side[1])
+		if err != nil || !found {
+			t.Fatalf("GetRelationship(%v) found=%v err=%v", side, found, err)
+		}
+		if got.FromInsightID != "i-1" || got.ToInsightID != "i-2" || got.Type != domain.RelationExtends || got.Rationale != "builds on it" {
+			t.Fatalf("GetRelationship(%v) = %+v, want the stored i-1 -> i-2 edge", side, got)
+		}
+	}
+	if _, found, err := a.GetRelationship(ctx, "t-1", "i-1", "i-3"); err != nil || found {
+		t.Fatalf("GetRelationship(missing) found=%v err=%v, want not found", found, err)
+	}
+}
+
+func TestInsightAdapter_RelationshipFeedback_RoundTripsFromEitherSideAndStaysOutOfEdges(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2")
+
+	decidedAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	feedback := domain.RelationshipFeedback{TenantID: "t-1", InsightID: "i-1", OtherInsightID: "i-2", Verdict: domain.VerdictRetyped, Type: domain.RelationSameTopic, DecidedAt: decidedAt}
+	if err := a.SaveRelationshipFeedback(ctx, feedback); err != nil {
+		t.Fatalf("SaveRelationshipFeedback: %v", err)
+	}
+
+	got, found, err := a.GetRelationshipFeedback(ctx, "t-1", "i-2", "i-1")
+	if err != nil || !found {
+		t.Fatalf("GetRelationshipFeedback found=%v err=%v", found, err)
+	}
+	if got.InsightID != "i-2" || got.OtherInsightID != "i-1" || got.Verdict != domain.VerdictRetyped || got.Type != domain.RelationSameTopic || !got.DecidedAt.Equal(decidedAt) {
+		t.Fatalf("got = %+v, want i-2's copy of the retyped verdict", got)
+	}
+	if related, _ := a.ListByInsightID(ctx, "t-1", "i-1"); len(related) != 0 {
+		t.Fatalf("i-1's edges = %+v, want verdicts kept out of edge queries", related)
+	}
+	if _, found, _ := a.GetRelationshipFeedback(ctx, "t-1", "i-1", "i-3"); found {
+		t.Fatal("expected no verdict for an unjudged pair")
+	}
+}
+
+func TestInsightAdapter_ListRelationships_EachEdgeOnceInItsDirection(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2", "i-3")
+
+	for _, rel := range []domain.Relationship{
+		{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9},
+		{TenantID: "t-1", FromInsightID: "i-3", ToInsightID: "i-1", Type: domain.RelationExtends, Confidence: 0.6},
+	} {
+		if err := a.Put(ctx, rel); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if err := a.SaveRelationshipFeedback(ctx, domain.RelationshipFeedback{TenantID: "t-1", InsightID: "i-2", OtherInsightID: "i-3", Verdict: domain.VerdictNotRelated}); err != nil {
+		t.Fatalf("SaveRelationshipFeedback: %v", err)
+	}
+
+	rels, err := a.ListRelationships(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListRelationships: %v", err)
+	}
+	got := map[string]domain.RelationType{}
+	for _, rel := range rels {
+		got[rel.FromInsightID+"->"+rel.ToInsightID] = rel.Type
+	}
+	if len(rels) != 2 || got["i-1->i-2"] != domain.RelationSupports || got["i-3->i-1"] != domain.RelationExtends {
+		t.Fatalf("ListRelationships = %+v, want i-1 -> i-2 and i-3 -> i-1 once each", rels)
+	}
+}
+
+func TestInsightAdapter_ListRelationships_ReadsEveryPage(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeDynamo()
+	a := newTestAdapter(f, time.Now())
+	seedInsights(t, a, "i-1", "i-2", "i-3", "i-4")
+
+	for _, to := range []string{"i-2", "i-3", "i-4"} {
+		if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: to, Type: domain.RelationSupports, Confidence: 0.9}); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	f.queryPageSize = 2
+
+	rels, err := a.ListRelationships(ctx, "t-1")
+	if err != nil || len(rels) != 3 {
+		t.Fatalf("ListRelationships = %+v, %v; want all 3 edges across pages", rels, err)
+	}
+}
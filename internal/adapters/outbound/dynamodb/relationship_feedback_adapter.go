@@ -0,0 +1,94 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.RelationshipFeedbackRepository = (*InsightAdapter)(nil)
+
+// dynamoRelationshipFeedbackItem is written twice per verdict, once under
+// each insight (sk = RELFEEDBACK#<insightID>#<otherInsightID>), the same
+// way an edge is: the AI service reads an insight's ruled-out pairs with
+// one begins_with query (services/ai's DynamoDbInsightReader). The prefix
+// doesn't start with "REL#", so edge queries never see verdicts.
+type dynamoRelationshipFeedbackItem struct {
+	PK             string    `dynamodbav:"pk"`
+	SK             string    `dynamodbav:"sk"`
+	TenantID       string    `dynamodbav:"tenant_id"`
+	InsightID      string    `dynamodbav:"insight_id"`
+	OtherInsightID string    `dynamodbav:"other_insight_id"`
+	Verdict        string    `dynamodbav:"verdict"`
+	Type           string    `dynamodbav:"type,omitempty"`
+	DecidedAt      time.Time `dynamodbav:"decided_at"`
+}
+
+func relFeedbackSK(insightID, otherInsightID string) string {
+	return "RELFEEDBACK#" + insightID + "#" + otherInsightID
+}
+
+// SaveRelationshipFeedback writes both copies in one transaction, as Put
+// does for an edge.
+func (r *InsightAdapter) SaveRelationshipFeedback(ctx context.Context, feedback domain.RelationshipFeedback) error {
+	var transactItems []types.TransactWriteItem
+	for _, side := range [2][2]string{
+		{feedback.InsightID, feedback.OtherInsightID},
+		{feedback.OtherInsightID, feedback.InsightID},
+	} {
+		av, err := attributevalue.MarshalMap(dynamoRelationshipFeedbackItem{
+			PK:             pk(feedback.TenantID),
+			SK:             relFeedbackSK(side[0], side[1]),
+			TenantID:       feedback.TenantID,
+			InsightID:      side[0],
+			OtherInsightID: side[1],
+			Verdict:        string(feedback.Verdict),
+			Type:           string(feedback.Type),
+			DecidedAt:      feedback.DecidedAt,
+		})
+		if err != nil {
+			return err
+		}
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Put: &types.Put{TableName: aws.String(r.tableName), Item: av},
+		})
+	}
+	_, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	return err
+}
+
+func (r *InsightAdapter) GetRelationshipFeedback(ctx context.Context, tenantID, insightID, otherInsightID string) (domain.RelationshipFeedback, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: relFeedbackSK(insightID, otherInsightID)},
+		},
+	})
+	if err != nil {
+		return domain.RelationshipFeedback{}, false, err
+	}
+	if out.Item == nil {
+		return domain.RelationshipFeedback{}, false, nil
+	}
+
+	var item dynamoRelationshipFeedbackItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.RelationshipFeedback{}, false, err
+	}
+	return domain.RelationshipFeedback{
+		TenantID:       item.TenantID,
+		InsightID:      item.InsightID,
+		OtherInsightID: item.OtherInsightID,
+		Verdict:        domain.RelationshipVerdict(item.Verdict),
+		Type:           domain.RelationType(item.Type),
+		DecidedAt:      item.DecidedAt,
+	}, true, nil
+}
@@ -0,0 +1,224 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.ReviewRepository = (*InsightAdapter)(nil)
+
+// reviewLogSKPrefix doesn't start with reviewSKPrefix, so schedule
+// queries never see the log.
+const (
+	reviewSKPrefix    = "REVIEW#"
+	reviewLogSKPrefix = "REVIEWLOG#"
+)
+
+// dynamoReviewItem is one insight's review schedule (pk = TENANT#<id>, sk
+// = REVIEW#<insightID>). The due list reads them all and filters by
+// due_at in Go, as ListTags once did with memberships; a tenant reviews
+// hundreds of insights, not millions.
+type dynamoReviewItem struct {
+	PK             string    `dynamodbav:"pk"`
+	SK             string    `dynamodbav:"sk"`
+	InsightID      string    `dynamodbav:"insight_id"`
+	Repetitions    int       `dynamodbav:"repetitions"`
+	EaseFactor     float64   `dynamodbav:"ease_factor"`
+	IntervalDays   int       `dynamodbav:"interval_days"`
+	DueAt          time.Time `dynamodbav:"due_at"`
+	Reviews        int       `dynamodbav:"reviews"`
+	LastGrade      int       `dynamodbav:"last_grade"`
+	LastReviewedAt time.Time `dynamodbav:"last_reviewed_at"`
+}
+
+// dynamoReviewLogItem is one review SaveReview recorded (pk =
+// TENANT#<id>, sk = REVIEWLOG#<insightID>#<review number>), kept so a tag
+// stat rebuild can count every review again, not just each insight's
+// latest.
+type dynamoReviewLogItem struct {
+	PK         string    `dynamodbav:"pk"`
+	SK         string    `dynamodbav:"sk"`
+	InsightID  string    `dynamodbav:"insight_id"`
+	ReviewedAt time.Time `dynamodbav:"reviewed_at"`
+}
+
+func reviewSK(insightID string) string {
+	return reviewSKPrefix + insightID
+}
+
+func reviewLogSK(insightID string, review int) string {
+	return fmt.Sprintf("%s%s#%06d", reviewLogSKPrefix, insightID, review)
+}
+
+func (r *InsightAdapter) GetReviewSchedule(ctx context.Context, tenantID, insightID string) (domain.ReviewSchedule, bool, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: reviewSK(insightID)},
+		},
+	})
+	if err != nil {
+		return domain.ReviewSchedule{}, false, err
+	}
+	if out.Item == nil {
+		return domain.ReviewSchedule{}, false, nil
+	}
+	var item dynamoReviewItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.ReviewSchedule{}, false, err
+	}
+	return item.toDomain(tenantID), true, nil
+}
+
+func (r *InsightAdapter) ListReviewSchedules(ctx context.Context, tenantID string) ([]domain.ReviewSchedule, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: reviewSKPrefix},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]domain.ReviewSchedule, 0, len(out.Items))
+	for _, av := range out.Items {
+		var item dynamoReviewItem
+		if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, item.toDomain(tenantID))
+	}
+	return schedules, nil
+}
+
+// ListReviewHistory reads the whole review log in one query of its
+// prefix.
+func (r *InsightAdapter) ListReviewHistory(ctx context.Context, tenantID string) (map[string][]time.Time, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: reviewLogSKPrefix},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string][]time.Time)
+	for _, av := range out.Items {
+		var item dynamoReviewLogItem
+		if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+			return nil, err
+		}
+		history[item.InsightID] = append(history[item.InsightID], item.ReviewedAt)
+	}
+	return history, nil
+}
+
+// SaveReview puts the schedule and its review log item, and ADDs the
+// review to its bucket on each tag's TAGSTAT# aggregate
+// (tagStatReviewAttrPrefix), all in one transaction. The schedule put is
+// conditioned on the stored one's review count, so two grades of the same
+// insight racing can't both count: the loser gets ErrReviewConflict.
+// Folding the aggregates' old buckets follows the transaction, which
+// can't return what it wrote.
+func (r *InsightAdapter) SaveReview(ctx context.Context, schedule domain.ReviewSchedule, tags []string) error {
+	av, err := attributevalue.MarshalMap(dynamoReviewItem{
+		PK:             pk(schedule.TenantID),
+		SK:             reviewSK(schedule.InsightID),
+		InsightID:      schedule.InsightID,
+		Repetitions:    schedule.Repetitions,
+		EaseFactor:     schedule.EaseFactor,
+		IntervalDays:   schedule.IntervalDays,
+		DueAt:          schedule.DueAt,
+		Reviews:        schedule.Reviews,
+		LastGrade:      schedule.LastGrade,
+		LastReviewedAt: schedule.LastReviewedAt,
+	})
+	if err != nil {
+		return err
+	}
+	logAV, err := attributevalue.MarshalMap(dynamoReviewLogItem{
+		PK:         pk(schedule.TenantID),
+		SK:         reviewLogSK(schedule.InsightID, schedule.Reviews),
+		InsightID:  schedule.InsightID,
+		ReviewedAt: schedule.LastReviewedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	put := &types.Put{
+		TableName:                aws.String(r.tableName),
+		Item:                     av,
+		ConditionExpression:      aws.String("attribute_not_exists(#pk)"),
+		ExpressionAttributeNames: map[string]string{"#pk": "pk"},
+	}
+	if schedule.Reviews > 1 {
+		put.ConditionExpression = aws.String("#reviews = :reviews")
+		put.ExpressionAttributeNames = map[string]string{"#reviews": "reviews"}
+		put.ExpressionAttributeValues = map[string]types.AttributeValue{":reviews": numberAV(int64(schedule.Reviews - 1))}
+	}
+	transactItems := []types.TransactWriteItem{
+		{Put: put},
+		{Put: &types.Put{TableName: aws.String(r.tableName), Item: logAV}},
+	}
+	tagSet := toTagSet(tags)
+	for _, tag := range slices.Sorted(maps.Keys(tagSet)) {
+		transactItems = append(transactItems, r.tagReviewUpdate(schedule.TenantID, tag, schedule.LastReviewedAt))
+	}
+
+	_, err = r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if failedAt(err, 0) {
+		return ports.ErrReviewConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	for tag := range tagSet {
+		if err := r.compactTagStatItem(ctx, schedule.TenantID, tag); err != nil {
+			return fmt.Errorf("tag stat: %w", err)
+		}
+	}
+	return nil
+}
+
+func (i dynamoReviewItem) toDomain(tenantID string) domain.ReviewSchedule {
+	return domain.ReviewSchedule{
+		TenantID:       tenantID,
+		InsightID:      i.InsightID,
+		Repetitions:    i.Repetitions,
+		EaseFactor:     i.EaseFactor,
+		IntervalDays:   i.IntervalDays,
+		DueAt:          i.DueAt,
+		Reviews:        i.Reviews,
+		LastGrade:      i.LastGrade,
+		LastReviewedAt: i.LastReviewedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+func TestInsightAdapter_ReviewSchedules_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	if _, found, err := a.GetReviewSchedule(ctx, "t-1", "i-1"); err != nil || found {
+		t.Fatalf("GetReviewSchedule = %v, %v; want not found", found, err)
+	}
+
+	schedule, err := domain.NewReviewSchedule("t-1", "i-1").Review(4, now)
+	if err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	if err := a.SaveReview(ctx, schedule, nil); err != nil {
+		t.Fatalf("SaveReview: %v", err)
+	}
+
+	got, found, err := a.GetReviewSchedule(ctx, "t-1", "i-1")
+	if err != nil || !found || !reflect.DeepEqual(got, schedule) {
+		t.Fatalf("GetReviewSchedule = %+v, %v, %v; want %+v", got, found, err, schedule)
+	}
+	all, err := a.ListReviewSchedules(ctx, "t-1")
+	if err != nil || len(all) != 1 || all[0].InsightID != "i-1" {
+		t.Fatalf("ListReviewSchedules = %+v, %v", all, err)
+	}
+	if other, _ := a.ListReviewSchedules(ctx, "t-2"); len(other) != 0 {
+		t.Fatalf("t-2 sees %+v", other)
+	}
+}
+
+func TestInsightAdapter_SaveReview_RaisesTagFreshness(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+	tagInsight(t, a, "i-1", now.AddDate(0, 0, -60), "focus")
+
+	before, err := a.ListTags(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	schedule, _ := domain.NewReviewSchedule("t-1", "i-1").Review(5, now)
+	if err := a.SaveReview(ctx, schedule, []string{"focus"}); err != nil {
+		t.Fatalf("SaveReview: %v", err)
+	}
+	after, err := a.ListTags(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+
+	if after[0].ScoreComponents.Freshness <= before[0].ScoreComponents.Freshness || after[0].Score <= before[0].Score {
+		t.Fatalf("after review = %+v, before = %+v; want Freshness and Score up", after[0], before[0])
+	}
+	if after[0].InsightCount != 1 || !after[0].LastInsightAt.Equal(before[0].LastInsightAt) {
+		t.Fatalf("after review = %+v, want count and last highlight untouched", after[0])
+	}
+}
+
+func TestInsightAdapter_SaveReview_LogsEveryReviewAndRejectsARace(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+	tagInsight(t, a, "i-1", now.AddDate(0, 0, -60), "focus")
+
+	first, _ := domain.NewReviewSchedule("t-1", "i-1").Review(4, now)
+	if err := a.SaveReview(ctx, first, []string{"focus"}); err != nil {
+		t.Fatalf("SaveReview: %v", err)
+	}
+	second, _ := first.Review(5, now.Add(time.Hour))
+	if err := a.SaveReview(ctx, second, []string{"focus"}); err != nil {
+		t.Fatalf("SaveReview (second): %v", err)
+	}
+
+	// A grade computed from the first schedule, racing the second, and a
+	// first review of an insight that already has one, both lose.
+	stale, _ := first.Review(3, now.Add(2*time.Hour))
+	fresh, _ := domain.NewReviewSchedule("t-1", "i-1").Review(3, now.Add(2*time.Hour))
+	for _, schedule := range []domain.ReviewSchedule{stale, fresh} {
+		if err := a.SaveReview(ctx, schedule, []string{"focus"}); !errors.Is(err, ports.ErrReviewConflict) {
+			t.Fatalf("SaveReview = %v, want ErrReviewConflict", err)
+		}
+	}
+
+	if got, _, _ := a.GetReviewSchedule(ctx, "t-1", "i-1"); !reflect.DeepEqual(got, second) {
+		t.Fatalf("schedule = %+v, want the second review's", got)
+	}
+	history, err := a.ListReviewHistory(ctx, "t-1")
+	if err != nil || len(history) != 1 || !reflect.DeepEqual(history["i-1"], []time.Time{now, now.Add(time.Hour)}) {
+		t.Fatalf("ListReviewHistory = %v, %v; want both saved reviews, in order", history, err)
+	}
+	reviewed := tagStatsByTag(t, a)["focus"].ReviewedBuckets
+	if len(reviewed) != 2 || reviewed[domain.TagStatHour(now)] != 1 || reviewed[domain.TagStatHour(now.Add(time.Hour))] != 1 {
+		t.Fatalf("focus reviewed buckets = %v, want one per saved review", reviewed)
+	}
+	if schedules, _ := a.ListReviewSchedules(ctx, "t-1"); len(schedules) != 1 {
+		t.Fatalf("ListReviewSchedules = %+v, want the log kept out of it", schedules)
+	}
+}
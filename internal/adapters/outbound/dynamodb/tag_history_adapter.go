@@ -0,0 +1,158 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.TagHistoryRepository = (*InsightAdapter)(nil)
+
+// tagSnapshotSKPrefix doesn't start with "TAG#", so ListTags' membership
+// query never sees snapshots.
+const tagSnapshotSKPrefix = "TAGHIST#"
+
+type dynamoTagSnapshotEntry struct {
+	Tag          string  `dynamodbav:"tag"`
+	InsightCount int     `dynamodbav:"insight_count"`
+	Score        float64 `dynamodbav:"score"`
+	Count        float64 `dynamodbav:"count"`
+	Recency      float64 `dynamodbav:"recency"`
+	Freshness    float64 `dynamodbav:"freshness"`
+	Density      float64 `dynamodbav:"density"`
+}
+
+// dynamoTagSnapshotItem is one snapshot, every tag in one item (pk =
+// TENANT#<id>, sk = TAGHIST#<takenAt>), so trend detection reads a few
+// items rather than one per tag per snapshot; domain.MaxSnapshotTags keeps
+// it under the item size limit. expires_at is the table's
+// TTL attribute, as on dynamoEnrichmentCacheItem. relevance is absent for
+// the defaults, as on dynamoTenantSettingsItem.
+type dynamoTagSnapshotItem struct {
+	PK        string                   `dynamodbav:"pk"`
+	SK        string                   `dynamodbav:"sk"`
+	TakenAt   time.Time                `dynamodbav:"taken_at"`
+	Relevance *dynamoTagRelevanceItem  `dynamodbav:"relevance,omitempty"`
+	Tags      []dynamoTagSnapshotEntry `dynamodbav:"tags"`
+	ExpiresAt int64                    `dynamodbav:"expires_at"`
+}
+
+// tagSnapshotSK uses second-precision RFC 3339, which unlike RFC3339Nano
+// is fixed-width, so sort keys order by time and the BETWEEN in
+// ListTagSnapshots holds.
+func tagSnapshotSK(takenAt time.Time) string {
+	return tagSnapshotSKPrefix + takenAt.UTC().Format(time.RFC3339)
+}
+
+func (r *InsightAdapter) SaveTagSnapshot(ctx context.Context, snapshot domain.TagSnapshot, expiresAt time.Time) error {
+	item := dynamoTagSnapshotItem{
+		PK:        pk(snapshot.TenantID),
+		SK:        tagSnapshotSK(snapshot.TakenAt),
+		TakenAt:   snapshot.TakenAt,
+		Relevance: tagRelevanceFromDomain(snapshot.Relevance),
+		Tags:      make([]dynamoTagSnapshotEntry, len(snapshot.Tags)),
+		ExpiresAt: expiresAt.Unix(),
+	}
+	for i, t := range snapshot.Tags {
+		item.Tags[i] = dynamoTagSnapshotEntry{
+			Tag:          t.Tag,
+			InsightCount: t.InsightCount,
+			Score:        t.Score,
+			Count:        t.ScoreComponents.Count,
+			Recency:      t.ScoreComponents.Recency,
+			Freshness:    t.ScoreComponents.Freshness,
+			Density:      t.ScoreComponents.Density,
+		}
+	}
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+// ListTagSnapshots skips expired snapshots itself, for the same reason
+// GetCachedEnrichment does: TTL deletion can run days late. It reads the
+// tenant's settings, as ListTags does, to leave out snapshots scored
+// under another config. A few dozen snapshots of a large tenant fill a
+// 1 MB page, so it follows LastEvaluatedKey as ListRelationships does.
+func (r *InsightAdapter) ListTagSnapshots(ctx context.Context, tenantID string, since time.Time) ([]domain.TagSnapshot, error) {
+	settings, err := r.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get tenant settings: %w", err)
+	}
+	relevance := settings.EffectiveTagRelevance()
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND #sk BETWEEN :skFrom AND :skTo"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":     &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skFrom": &types.AttributeValueMemberS{Value: tagSnapshotSK(since)},
+			// "~" sorts after every digit, so this bounds the prefix.
+			":skTo": &types.AttributeValueMemberS{Value: tagSnapshotSKPrefix + "~"},
+		},
+	}
+
+	now := r.now().Unix()
+	snapshots := []domain.TagSnapshot{}
+	for {
+		out, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, av := range out.Items {
+			var item dynamoTagSnapshotItem
+			if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+				return nil, err
+			}
+			if now >= item.ExpiresAt {
+				continue
+			}
+			snapshot := domain.TagSnapshot{
+				TenantID:  tenantID,
+				TakenAt:   item.TakenAt,
+				Relevance: tagRelevanceToDomain(item.Relevance),
+				Tags:      make([]domain.TagSummary, len(item.Tags)),
+			}
+			if !snapshot.ScoredUnder(relevance) {
+				continue
+			}
+			for i, t := range item.Tags {
+				snapshot.Tags[i] = domain.TagSummary{
+					Tag:          t.Tag,
+					InsightCount: t.InsightCount,
+					Score:        t.Score,
+					ScoreComponents: domain.TagScoreComponents{
+						Count:     t.Count,
+						Recency:   t.Recency,
+						Freshness: t.Freshness,
+						Density:   t.Density,
+					},
+				}
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,145 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_TagSnapshots_ListsSinceOldestFirst(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	snapshot := func(tenantID string, takenAt time.Time) domain.TagSnapshot {
+		return domain.TagSnapshot{TenantID: tenantID, TakenAt: takenAt, Tags: []domain.TagSummary{{
+			Tag: "focus", InsightCount: 3, Score: 0.5,
+			ScoreComponents: domain.TagScoreComponents{Count: 0.4, Recency: 0.6, Freshness: 0.5, Density: 0.1},
+		}}}
+	}
+	later := snapshot("t-1", now.AddDate(0, 0, -1))
+	earlier := snapshot("t-1", now.AddDate(0, 0, -3))
+	for _, s := range []domain.TagSnapshot{later, earlier, snapshot("t-1", now.AddDate(0, 0, -9)), snapshot("t-2", now)} {
+		if err := a.SaveTagSnapshot(ctx, s, now.AddDate(1, 0, 0)); err != nil {
+			t.Fatalf("SaveTagSnapshot: %v", err)
+		}
+	}
+	if err := a.SaveTagSnapshot(ctx, snapshot("t-1", now.AddDate(0, 0, -2)), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("SaveTagSnapshot (expired): %v", err)
+	}
+	// Snapshots share the tenant's partition with tag memberships; each
+	// query must only see its own.
+	insight := domain.Insight{ID: "i-1", TenantID: "t-1", Text: "hello"}
+	if _, err := a.CreateIfAbsent(ctx, insight); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+	insight.Enrichment = &domain.Enrichment{Tags: []string{"focus"}}
+	if err := a.Update(ctx, insight); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	// One snapshot a page, as a large tenant's fill 1 MB a few dozen at a
+	// time.
+	f.queryPageSize = 1
+	got, err := a.ListTagSnapshots(ctx, "t-1", now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatalf("ListTagSnapshots: %v", err)
+	}
+	if !reflect.DeepEqual(got, []domain.TagSnapshot{earlier, later}) {
+		t.Fatalf("got %+v, want the last week's two unexpired t-1 snapshots across pages, oldest first", got)
+	}
+	f.queryPageSize = 0
+
+	tags, err := a.ListTags(ctx, "t-1")
+	if err != nil || len(tags) != 1 {
+		t.Fatalf("ListTags = %+v, %v; snapshots leaked into the membership query", tags, err)
+	}
+}
+
+func TestInsightAdapter_ListTagSnapshots_OnlyUnderTheCurrentConfig(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+	custom := domain.DefaultTagRelevanceConfig()
+	custom.HalfLife = 30 * 24 * time.Hour
+
+	for i, relevance := range []domain.TagRelevanceConfig{{}, domain.DefaultTagRelevanceConfig(), custom} {
+		snapshot := domain.TagSnapshot{TenantID: "t-1", TakenAt: now.AddDate(0, 0, i-3), Relevance: relevance}
+		if err := a.SaveTagSnapshot(ctx, snapshot, now.AddDate(1, 0, 0)); err != nil {
+			t.Fatalf("SaveTagSnapshot: %v", err)
+		}
+	}
+
+	got, err := a.ListTagSnapshots(ctx, "t-1", now.AddDate(0, 0, -7))
+	if err != nil || len(got) != 2 {
+		t.Fatalf("ListTagSnapshots = %+v, %v; want the two taken under the defaults", got, err)
+	}
+
+	if err := a.SaveTenantSettings(ctx, domain.TenantSettings{TenantID: "t-1", TagRelevance: custom}); err != nil {
+		t.Fatalf("SaveTenantSettings: %v", err)
+	}
+	got, err = a.ListTagSnapshots(ctx, "t-1", now.AddDate(0, 0, -7))
+	if err != nil || len(got) != 1 || got[0].Relevance != custom {
+		t.Fatalf("ListTagSnapshots = %+v, %v; want only the one taken under the new config", got, err)
+	}
+}
+
+func TestInsightAdapter_SaveTagSnapshot_FullSnapshotFitsOneItem(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	// The worst case: every tag as long as NormalizeTag allows, every
+	// score needing all its digits.
+	snapshot := domain.TagSnapshot{TenantID: "t-1", TakenAt: now, Tags: make([]domain.TagSummary, domain.MaxSnapshotTags)}
+	for i := range snapshot.Tags {
+		third := 1.0 / 3
+		snapshot.Tags[i] = domain.TagSummary{
+			Tag: fmt.Sprintf("%040d", i), InsightCount: 100000, Score: third,
+			ScoreComponents: domain.TagScoreComponents{Count: third, Recency: third, Freshness: third, Density: third},
+		}
+	}
+	if err := a.SaveTagSnapshot(ctx, snapshot, now.AddDate(1, 0, 0)); err != nil {
+		t.Fatalf("SaveTagSnapshot: %v", err)
+	}
+
+	if size := itemSize(f.items[pk("t-1")+"|"+tagSnapshotSK(now)]); size > 400*1024*3/4 {
+		t.Fatalf("snapshot item is %d bytes, want well under DynamoDB's 400 KB", size)
+	}
+}
+
+// itemSize approximates DynamoDB's item size: attribute names plus values,
+// with the few bytes of overhead each list and map element costs.
+func itemSize(item map[string]types.AttributeValue) int {
+	size := 0
+	for name, av := range item {
+		size += len(name) + valueSize(av)
+	}
+	return size
+}
+
+func valueSize(av types.AttributeValue) int {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return len(v.Value)
+	case *types.AttributeValueMemberN:
+		return len(v.Value)/2 + 1
+	case *types.AttributeValueMemberL:
+		size := 3
+		for _, e := range v.Value {
+			size += 1 + valueSize(e)
+		}
+		return size
+	case *types.AttributeValueMemberM:
+		return 3 + itemSize(v.Value) + len(v.Value)
+	}
+	return 1
+}
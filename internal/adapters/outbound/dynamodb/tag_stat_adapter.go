@@ -0,0 +1,510 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.TagStatRepository = (*InsightAdapter)(nil)
+
+// tagStatSKPrefix doesn't start with "TAG#", so membership queries never
+// see the aggregates.
+const tagStatSKPrefix = "TAGSTAT#"
+
+// tagStatHighlightAttrPrefix names HighlightedBuckets' buckets: one
+// top-level number attribute per bucket, because ADD only reaches
+// top-level attributes. The name is the prefix, "d" for a day or "w" for
+// a week (nothing for an hour), and the hours from the epoch to the
+// bucket's start: "hl_493140", "hl_d493128". tagStatReviewAttrPrefix does
+// the same for ReviewedBuckets.
+const (
+	tagStatHighlightAttrPrefix = "hl_"
+	tagStatReviewAttrPrefix    = "rv_"
+)
+
+// maxTagStatFolds caps the buckets compactTagStat folds in one update,
+// keeping its expression well under DynamoDB's size limit; any left over
+// are folded by the next write.
+const maxTagStatFolds = 50
+
+// dynamoTagStatItem is one tag's domain.TagStat (pk = TENANT#<id>, sk =
+// TAGSTAT#<tag>), maintained with ADD so concurrent writers never lose
+// each other's changes. LastHighlightedAt is Unix nanoseconds, a number
+// so a conditional update can keep the later of two. HighlightedBuckets'
+// buckets sit beside these fields (tagStatHighlightAttrPrefix).
+//
+// Relationship edges and reviews update the aggregate in the transaction
+// that writes them. Membership updates run after the writes they follow,
+// so a failure in between leaves the aggregate off until the next rebuild
+// (cmd/tagstat-rebuild-lambda).
+type dynamoTagStatItem struct {
+	PK                string `dynamodbav:"pk"`
+	SK                string `dynamodbav:"sk"`
+	InsightCount      int    `dynamodbav:"insight_count"`
+	LastHighlightedAt int64  `dynamodbav:"last_highlighted_at"`
+	DegreeSum         int    `dynamodbav:"degree_sum"`
+}
+
+func tagStatSK(tag string) string {
+	return tagStatSKPrefix + tag
+}
+
+func tagStatBucketAttr(prefix string, bucket domain.TagStatBucket) string {
+	var width string
+	switch bucket.Width {
+	case 24 * time.Hour:
+		width = "d"
+	case 7 * 24 * time.Hour:
+		width = "w"
+	}
+	return prefix + width + strconv.FormatInt(bucket.Start.Unix()/3600, 10)
+}
+
+// parseTagStatBucketAttr reverses tagStatBucketAttr, reporting which
+// prefix name has; ok is false for any other attribute.
+func parseTagStatBucketAttr(name string) (prefix string, bucket domain.TagStatBucket, ok bool) {
+	var rest string
+	for _, prefix = range []string{tagStatHighlightAttrPrefix, tagStatReviewAttrPrefix} {
+		if rest, ok = strings.CutPrefix(name, prefix); ok {
+			break
+		}
+	}
+	if !ok {
+		return "", domain.TagStatBucket{}, false
+	}
+	bucket.Width = time.Hour
+	if hours, ok := strings.CutPrefix(rest, "d"); ok {
+		rest, bucket.Width = hours, 24*time.Hour
+	} else if hours, ok := strings.CutPrefix(rest, "w"); ok {
+		rest, bucket.Width = hours, 7*24*time.Hour
+	}
+	h, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil {
+		return "", domain.TagStatBucket{}, false
+	}
+	bucket.Start = time.Unix(h*3600, 0).UTC()
+	return prefix, bucket, true
+}
+
+func tagStatKey(tenantID, tag string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+		"sk": &types.AttributeValueMemberS{Value: tagStatSK(tag)},
+	}
+}
+
+// addTagMembershipStat counts one membership of tag in or out (delta 1
+// or -1): its insight, its highlight's bucket, and that insight's degree.
+func (r *InsightAdapter) addTagMembershipStat(ctx context.Context, tenantID, tag string, highlightedAt time.Time, delta, degree int) error {
+	out, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(r.tableName),
+		Key:              tagStatKey(tenantID, tag),
+		UpdateExpression: aws.String("ADD #insight_count :insight_count, #bucket :bucket, #degree_sum :degree_sum"),
+		ExpressionAttributeNames: map[string]string{
+			"#insight_count": "insight_count",
+			"#bucket":        tagStatBucketAttr(tagStatHighlightAttrPrefix, domain.TagStatBucketAt(highlightedAt, r.now())),
+			"#degree_sum":    "degree_sum",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":insight_count": numberAV(int64(delta)),
+			":bucket":        numberAV(int64(delta)),
+			":degree_sum":    numberAV(int64(delta * degree)),
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return err
+	}
+	return r.compactTagStat(ctx, tenantID, tag, out.Attributes)
+}
+
+// tagReviewUpdate counts one review of an insight carrying tag, at
+// reviewedAt, as part of SaveReview's transaction.
+func (r *InsightAdapter) tagReviewUpdate(tenantID, tag string, reviewedAt time.Time) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                 aws.String(r.tableName),
+			Key:                       tagStatKey(tenantID, tag),
+			UpdateExpression:          aws.String("ADD #bucket :bucket"),
+			ExpressionAttributeNames:  map[string]string{"#bucket": tagStatBucketAttr(tagStatReviewAttrPrefix, domain.TagStatBucketAt(reviewedAt, r.now()))},
+			ExpressionAttributeValues: map[string]types.AttributeValue{":bucket": numberAV(1)},
+		},
+	}
+}
+
+// compactTagStatItem reads tag's aggregate and compacts it, for writers
+// that couldn't have the update return it.
+func (r *InsightAdapter) compactTagStatItem(ctx context.Context, tenantID, tag string) error {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       tagStatKey(tenantID, tag),
+	})
+	if err != nil || out.Item == nil {
+		return err
+	}
+	return r.compactTagStat(ctx, tenantID, tag, out.Item)
+}
+
+// compactTagStat folds the buckets of item, tag's aggregate as just
+// written, that have grown old enough into the wider bucket containing
+// them (domain.TagStatBucket.FoldAt), so the item holds a bounded number
+// of them however long the tag lives. The update is conditioned on each
+// folded bucket still holding what item says; a concurrent write that
+// changed one just leaves the folding to the next write.
+func (r *InsightAdapter) compactTagStat(ctx context.Context, tenantID, tag string, item map[string]types.AttributeValue) error {
+	now := r.now()
+	var (
+		removes    []string
+		conditions []string
+		targets    = map[string]int64{}
+		names      = map[string]string{}
+		values     = map[string]types.AttributeValue{}
+	)
+	for _, name := range slices.Sorted(maps.Keys(item)) {
+		prefix, bucket, ok := parseTagStatBucketAttr(name)
+		if !ok {
+			continue
+		}
+		folded := bucket.FoldAt(now)
+		if folded == bucket {
+			continue
+		}
+		n, ok := item[name].(*types.AttributeValueMemberN)
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseInt(n.Value, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		alias := "#s" + strconv.Itoa(len(removes))
+		names[alias] = name
+		values[":s"+strconv.Itoa(len(removes))] = n
+		removes = append(removes, alias)
+		conditions = append(conditions, alias+" = :s"+strconv.Itoa(len(removes)-1))
+		targets[tagStatBucketAttr(prefix, folded)] += count
+		if len(removes) == maxTagStatFolds {
+			break
+		}
+	}
+	if len(removes) == 0 {
+		return nil
+	}
+
+	var adds []string
+	for i, name := range slices.Sorted(maps.Keys(targets)) {
+		if targets[name] == 0 {
+			continue
+		}
+		alias := "#t" + strconv.Itoa(i)
+		names[alias] = name
+		values[":t"+strconv.Itoa(i)] = numberAV(targets[name])
+		adds = append(adds, alias+" :t"+strconv.Itoa(i))
+	}
+	expr := "REMOVE " + strings.Join(removes, ", ")
+	if len(adds) > 0 {
+		expr = "ADD " + strings.Join(adds, ", ") + " " + expr
+	}
+
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.tableName),
+		Key:                       tagStatKey(tenantID, tag),
+		UpdateExpression:          aws.String(expr),
+		ConditionExpression:       aws.String(strings.Join(conditions, " AND ")),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	if _, ok := errors.AsType[*types.ConditionalCheckFailedException](err); ok {
+		return nil
+	}
+	return err
+}
+
+// raiseTagLastHighlighted sets tag's last_highlighted_at to at unless it's
+// already later. The condition failing is that, not an error.
+func (r *InsightAdapter) raiseTagLastHighlighted(ctx context.Context, tenantID, tag string, at time.Time) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.tableName),
+		Key:                       tagStatKey(tenantID, tag),
+		UpdateExpression:          aws.String("SET #last = :last"),
+		ConditionExpression:       aws.String("attribute_not_exists(#last) OR #last < :last"),
+		ExpressionAttributeNames:  map[string]string{"#last": "last_highlighted_at"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":last": numberAV(at.UnixNano())},
+	})
+	if _, ok := errors.AsType[*types.ConditionalCheckFailedException](err); ok {
+		return nil
+	}
+	return err
+}
+
+// lowerTagLastHighlighted recomputes tag's last_highlighted_at from its
+// remaining memberships after one highlighted at removed is gone, which
+// only changes anything if removed was the latest. With none left it's
+// left as is: the aggregate counts zero insights and isn't listed.
+func (r *InsightAdapter) lowerTagLastHighlighted(ctx context.Context, tenantID, tag string, removed time.Time) error {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       tagStatKey(tenantID, tag),
+	})
+	if err != nil || out.Item == nil {
+		return err
+	}
+	var item dynamoTagStatItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return err
+	}
+	if item.LastHighlightedAt > removed.UnixNano() {
+		return nil
+	}
+
+	members, err := r.ListByTag(ctx, tenantID, tag)
+	if err != nil {
+		return err
+	}
+	var last time.Time
+	for _, m := range members {
+		if m.HighlightedAt.After(last) {
+			last = m.HighlightedAt
+		}
+	}
+	if last.IsZero() {
+		return nil
+	}
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.tableName),
+		Key:                       tagStatKey(tenantID, tag),
+		UpdateExpression:          aws.String("SET #last = :last"),
+		ExpressionAttributeNames:  map[string]string{"#last": "last_highlighted_at"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":last": numberAV(last.UnixNano())},
+	})
+	return err
+}
+
+// relationshipDegree counts insightID's relationship edges, both
+// directions, from its own REL#<insightID># adjacency items.
+func (r *InsightAdapter) relationshipDegree(ctx context.Context, tenantID, insightID string) (int, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: relSKPrefix(insightID)},
+		},
+		Select: types.SelectCount,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(out.Count), nil
+}
+
+// listTagStats returns tenantID's aggregates, by tag, skipping any left
+// counting no insights.
+func (r *InsightAdapter) listTagStats(ctx context.Context, tenantID string) ([]domain.TagStat, error) {
+	now := r.now()
+	items, err := r.queryAllWithPrefix(ctx, tenantID, tagStatSKPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]domain.TagStat, 0, len(items))
+	for _, item := range items {
+		var dynItem dynamoTagStatItem
+		if err := attributevalue.UnmarshalMap(item, &dynItem); err != nil {
+			return nil, err
+		}
+		if dynItem.InsightCount <= 0 {
+			continue
+		}
+		stat := domain.TagStat{
+			Tag:                strings.TrimPrefix(dynItem.SK, tagStatSKPrefix),
+			InsightCount:       dynItem.InsightCount,
+			HighlightedBuckets: map[domain.TagStatBucket]int{},
+			ReviewedBuckets:    map[domain.TagStatBucket]int{},
+			DegreeSum:          dynItem.DegreeSum,
+		}
+		if dynItem.LastHighlightedAt != 0 {
+			stat.LastHighlightedAt = time.Unix(0, dynItem.LastHighlightedAt).UTC()
+		}
+		for name, av := range item {
+			prefix, bucket, ok := parseTagStatBucketAttr(name)
+			if !ok {
+				continue
+			}
+			var n int
+			if err := attributevalue.Unmarshal(av, &n); err != nil {
+				return nil, err
+			}
+			if prefix == tagStatReviewAttrPrefix {
+				stat.ReviewedBuckets[bucket] += n
+			} else {
+				stat.HighlightedBuckets[bucket] += n
+			}
+		}
+		// Buckets not yet folded by a write are folded here, so a count
+		// and its later removal from a wider bucket cancel.
+		stat.HighlightedBuckets = positiveTagStatBuckets(domain.FoldTagStatBuckets(stat.HighlightedBuckets, now))
+		stat.ReviewedBuckets = positiveTagStatBuckets(domain.FoldTagStatBuckets(stat.ReviewedBuckets, now))
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+func positiveTagStatBuckets(buckets map[domain.TagStatBucket]int) map[domain.TagStatBucket]int {
+	maps.DeleteFunc(buckets, func(_ domain.TagStatBucket, n int) bool { return n <= 0 })
+	return buckets
+}
+
+// queryAllWithPrefix reads every item in tenantID's partition whose sort
+// key starts with skPrefix, following LastEvaluatedKey as
+// ListRelationships does: TAGSTAT# items carry hundreds of bucket
+// attributes each, and a large tenant's memberships and edges run well
+// past one 1 MB page.
+func (r *InsightAdapter) queryAllWithPrefix(ctx context.Context, tenantID, skPrefix string) ([]map[string]types.AttributeValue, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("#pk = :pk AND begins_with(#sk, :skPrefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#pk": "pk",
+			"#sk": "sk",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk":       &types.AttributeValueMemberS{Value: pk(tenantID)},
+			":skPrefix": &types.AttributeValueMemberS{Value: skPrefix},
+		},
+	}
+
+	var items []map[string]types.AttributeValue
+	for {
+		out, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, out.Items...)
+		if len(out.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// ListAllTagMemberships reads every membership under the TAG# prefix.
+func (r *InsightAdapter) ListAllTagMemberships(ctx context.Context, tenantID string) (map[string][]domain.TagMembership, error) {
+	items, err := r.queryAllWithPrefix(ctx, tenantID, "TAG#")
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make(map[string][]domain.TagMembership)
+	for _, item := range items {
+		var dynItem dynamoTagMembershipItem
+		if err := attributevalue.UnmarshalMap(item, &dynItem); err != nil {
+			return nil, err
+		}
+		tag, ok := parseTagFromSK(dynItem.SK)
+		if !ok {
+			continue
+		}
+		memberships[tag] = append(memberships[tag], domain.TagMembership{
+			InsightID:     dynItem.InsightID,
+			CreatedAt:     dynItem.CreatedAt,
+			HighlightedAt: dynItem.HighlightedAt,
+		})
+	}
+	return memberships, nil
+}
+
+// RelationshipDegrees counts each insight's relationship edges (both
+// directions) from the tenant's REL# prefix alone: every edge
+// is stored once under each endpoint's own "REL#<insightID>#" sort key (see
+// RelationshipRepository.Put), so grouping by that prefix's owner segment
+// gives the degree directly — no per-insight fetch.
+func (r *InsightAdapter) RelationshipDegrees(ctx context.Context, tenantID string) (map[string]int, error) {
+	items, err := r.queryAllWithPrefix(ctx, tenantID, "REL#")
+	if err != nil {
+		return nil, err
+	}
+
+	degree := make(map[string]int, len(items))
+	for _, item := range items {
+		var dynItem dynamoRelationshipItem
+		if err := attributevalue.UnmarshalMap(item, &dynItem); err != nil {
+			return nil, err
+		}
+		owner, ok := parseRelOwnerFromSK(dynItem.SK)
+		if !ok {
+			continue
+		}
+		degree[owner]++
+	}
+	return degree, nil
+}
+
+// ReplaceTagStats overwrites each aggregate with a plain put, then deletes
+// those of tags no longer in stats. A membership or edge written while it
+// runs can be lost from the aggregates, so run it when nothing else is.
+func (r *InsightAdapter) ReplaceTagStats(ctx context.Context, tenantID string, stats []domain.TagStat) error {
+	now := r.now()
+	keep := make(map[string]bool, len(stats))
+	for _, stat := range stats {
+		keep[tagStatSK(stat.Tag)] = true
+		av, err := attributevalue.MarshalMap(dynamoTagStatItem{
+			PK:                pk(tenantID),
+			SK:                tagStatSK(stat.Tag),
+			InsightCount:      stat.InsightCount,
+			LastHighlightedAt: stat.LastHighlightedAt.UnixNano(),
+			DegreeSum:         stat.DegreeSum,
+		})
+		if err != nil {
+			return err
+		}
+		for bucket, n := range domain.FoldTagStatBuckets(stat.HighlightedBuckets, now) {
+			av[tagStatBucketAttr(tagStatHighlightAttrPrefix, bucket)] = numberAV(int64(n))
+		}
+		for bucket, n := range domain.FoldTagStatBuckets(stat.ReviewedBuckets, now) {
+			av[tagStatBucketAttr(tagStatReviewAttrPrefix, bucket)] = numberAV(int64(n))
+		}
+		if _, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(r.tableName),
+			Item:      av,
+		}); err != nil {
+			return err
+		}
+	}
+
+	existing, err := r.queryAllWithPrefix(ctx, tenantID, tagStatSKPrefix)
+	if err != nil {
+		return err
+	}
+	for _, item := range existing {
+		sk, ok := item["sk"].(*types.AttributeValueMemberS)
+		if !ok || keep[sk.Value] {
+			continue
+		}
+		if _, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(r.tableName),
+			Key:       map[string]types.AttributeValue{"pk": item["pk"], "sk": item["sk"]},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func tagInsight(t *testing.T, a *InsightAdapter, id string, highlightedAt time.Time, tags ...string) domain.Insight {
+	t.Helper()
+	ctx := context.Background()
+	insight := domain.Insight{ID: id, TenantID: "t-1", Source: "readwise", Text: "text " + id, HighlightedAt: highlightedAt}
+	if _, err := a.CreateIfAbsent(ctx, insight); err != nil {
+		t.Fatalf("CreateIfAbsent(%s): %v", id, err)
+	}
+	insight.Enrichment = &domain.Enrichment{Tags: tags}
+	if err := a.Update(ctx, insight); err != nil {
+		t.Fatalf("Update(%s): %v", id, err)
+	}
+	return insight
+}
+
+func tagStatsByTag(t *testing.T, a *InsightAdapter) map[string]domain.TagStat {
+	t.Helper()
+	stats, err := a.listTagStats(context.Background(), "t-1")
+	if err != nil {
+		t.Fatalf("listTagStats: %v", err)
+	}
+	byTag := map[string]domain.TagStat{}
+	for _, s := range stats {
+		byTag[s.Tag] = s
+	}
+	return byTag
+}
+
+func TestInsightAdapter_TagStats_FollowMembershipChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+	t1 := now.Add(-48 * time.Hour)
+	t2 := now.Add(-2 * time.Hour)
+
+	tagInsight(t, a, "i-1", t1, "focus")
+	i2 := tagInsight(t, a, "i-2", t2, "focus", "sleep")
+
+	focus := tagStatsByTag(t, a)["focus"]
+	if focus.InsightCount != 2 || !focus.LastHighlightedAt.Equal(t2) ||
+		focus.HighlightedBuckets[domain.TagStatHour(t1)] != 1 || focus.HighlightedBuckets[domain.TagStatHour(t2)] != 1 {
+		t.Fatalf("focus = %+v, want both insights, last %v", focus, t2)
+	}
+
+	// Re-enrichment drops focus from the latest insight: the count, its
+	// hour, and the last highlight all fall back to what's left.
+	i2.Enrichment = &domain.Enrichment{Tags: []string{"sleep"}}
+	if err := a.Update(ctx, i2); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	stats := tagStatsByTag(t, a)
+	focus = stats["focus"]
+	if focus.InsightCount != 1 || !focus.LastHighlightedAt.Equal(t1) || len(focus.HighlightedBuckets) != 1 {
+		t.Fatalf("focus = %+v, want only i-1, last %v", focus, t1)
+	}
+	if stats["sleep"].InsightCount != 1 {
+		t.Fatalf("sleep = %+v, want untouched", stats["sleep"])
+	}
+
+	// Replaying the same enrichment changes nothing.
+	if err := a.Update(ctx, i2); err != nil {
+		t.Fatalf("Update (replay): %v", err)
+	}
+	if got := tagStatsByTag(t, a); !reflect.DeepEqual(got, stats) {
+		t.Fatalf("after replay = %+v, want %+v", got, stats)
+	}
+}
+
+func TestInsightAdapter_TagStats_FollowRelationshipChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	tagInsight(t, a, "i-1", now, "focus")
+	tagInsight(t, a, "i-2", now, "focus", "sleep")
+
+	rel := domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}
+	for range 2 {
+		if err := a.Put(ctx, rel); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	stats := tagStatsByTag(t, a)
+	if stats["focus"].DegreeSum != 2 || stats["sleep"].DegreeSum != 1 {
+		t.Fatalf("stats = %+v, want focus 2 (both ends) and sleep 1, a re-post counting once", stats)
+	}
+
+	// An insight tagged after it's related brings its degree along.
+	i3 := tagInsight(t, a, "i-3", now)
+	if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-3", ToInsightID: "i-1", Type: domain.RelationSupports, Confidence: 0.8}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	i3.Enrichment = &domain.Enrichment{Tags: []string{"sleep"}}
+	if err := a.Update(ctx, i3); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := tagStatsByTag(t, a)["sleep"].DegreeSum; got != 2 {
+		t.Fatalf("sleep degree sum = %d, want 2", got)
+	}
+
+	if err := a.DeleteRelationship(ctx, "t-1", "i-2", "i-1"); err != nil {
+		t.Fatalf("DeleteRelationship: %v", err)
+	}
+	if err := a.DeleteRelationship(ctx, "t-1", "i-2", "i-1"); err != nil {
+		t.Fatalf("DeleteRelationship (again): %v", err)
+	}
+	stats = tagStatsByTag(t, a)
+	if stats["focus"].DegreeSum != 1 || stats["sleep"].DegreeSum != 1 {
+		t.Fatalf("stats = %+v, want only i-3's edge left, a repeat delete counting once", stats)
+	}
+}
+
+func TestInsightAdapter_TagStats_RelationshipRaceCountsTheEdgeOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	tagInsight(t, a, "i-1", now, "focus")
+	tagInsight(t, a, "i-2", now, "focus", "sleep")
+
+	// The same edge is posted concurrently: the other Put lands between
+	// this one's read and its transaction, which then retries as a re-post.
+	rel := domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}
+	f.beforeTransact = func() {
+		f.beforeTransact = nil
+		if err := a.Put(ctx, rel); err != nil {
+			t.Fatalf("concurrent Put: %v", err)
+		}
+	}
+	if err := a.Put(ctx, rel); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	stats := tagStatsByTag(t, a)
+	if stats["focus"].DegreeSum != 2 || stats["sleep"].DegreeSum != 1 {
+		t.Fatalf("stats = %+v, want the edge counted once", stats)
+	}
+
+	// A failed delete takes nothing off either.
+	f.transactErr = errors.New("throttled")
+	if err := a.DeleteRelationship(ctx, "t-1", "i-1", "i-2"); err == nil {
+		t.Fatalf("DeleteRelationship succeeded, want the injected failure")
+	}
+	if got := tagStatsByTag(t, a); !reflect.DeepEqual(got, stats) {
+		t.Fatalf("after failed delete = %+v, want %+v", got, stats)
+	}
+}
+
+func TestInsightAdapter_TagStats_FoldOldBuckets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	i1 := tagInsight(t, a, "i-1", now.Add(-time.Hour), "focus")
+	tagInsight(t, a, "i-2", now.Add(-3*time.Hour), "focus")
+
+	// Ten days on, the next write folds both hours into their day.
+	now = now.Add(10 * 24 * time.Hour)
+	a.now = func() time.Time { return now }
+	tagInsight(t, a, "i-3", now, "focus")
+
+	day := domain.TagStatBucket{Start: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Width: 24 * time.Hour}
+	item := f.items[compositeKey(tagStatKey("t-1", "focus"), "pk", "sk")]
+	for _, hour := range []time.Time{i1.HighlightedAt, now.Add(-10*24*time.Hour - 3*time.Hour)} {
+		if _, ok := item[tagStatBucketAttr(tagStatHighlightAttrPrefix, domain.TagStatHour(hour))]; ok {
+			t.Fatalf("item = %v, want the hour of %v folded away", item, hour)
+		}
+	}
+	focus := tagStatsByTag(t, a)["focus"]
+	if len(focus.HighlightedBuckets) != 2 || focus.HighlightedBuckets[day] != 2 || focus.HighlightedBuckets[domain.TagStatHour(now)] != 1 {
+		t.Fatalf("focus buckets = %v, want two in %v and i-3's hour", focus.HighlightedBuckets, day.Start)
+	}
+
+	// Untagging i-1 now takes it out of the day it was folded into.
+	i1.Enrichment = &domain.Enrichment{Tags: []string{"sleep"}}
+	if err := a.Update(ctx, i1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := tagStatsByTag(t, a)["focus"].HighlightedBuckets[day]; got != 1 {
+		t.Fatalf("day bucket = %d, want 1 left", got)
+	}
+}
+
+func TestInsightAdapter_ReplaceTagStats_RebuildMatchesIncrementalAndDropsStale(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	tagInsight(t, a, "i-1", now.Add(-72*time.Hour), "focus")
+	tagInsight(t, a, "i-2", now.Add(-90*time.Minute), "focus", "sleep")
+	if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	incremental := tagStatsByTag(t, a)
+
+	// Drift: a stale aggregate for a tag nothing carries, and a wrong count.
+	if err := a.addTagMembershipStat(ctx, "t-1", "gone", now, 1, 0); err != nil {
+		t.Fatalf("addTagMembershipStat: %v", err)
+	}
+	if err := a.addTagMembershipStat(ctx, "t-1", "focus", now, 1, 3); err != nil {
+		t.Fatalf("addTagMembershipStat: %v", err)
+	}
+
+	memberships, err := a.ListAllTagMemberships(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListAllTagMemberships: %v", err)
+	}
+	degrees, err := a.RelationshipDegrees(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("RelationshipDegrees: %v", err)
+	}
+	if err := a.ReplaceTagStats(ctx, "t-1", domain.BuildTagStats(memberships, degrees, nil)); err != nil {
+		t.Fatalf("ReplaceTagStats: %v", err)
+	}
+
+	if got := tagStatsByTag(t, a); !reflect.DeepEqual(got, incremental) {
+		t.Fatalf("rebuilt = %+v, want what the incremental updates kept: %+v", got, incremental)
+	}
+	if _, ok := f.items[compositeKey(tagStatKey("t-1", "gone"), "pk", "sk")]; ok {
+		t.Fatalf("stale aggregate for gone survived the rebuild")
+	}
+}
+
+func TestInsightAdapter_ListTags_ReadsOnlyTheAggregates(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	tagInsight(t, a, "i-1", now, "focus")
+	// A membership written behind the adapter's back, without its
+	// aggregate, isn't listed until a rebuild.
+	orphan, err := attributevalue.MarshalMap(dynamoTagMembershipItem{PK: pk("t-1"), SK: tagSK("orphan", "i-1"), InsightID: "i-1", HighlightedAt: now})
+	if err != nil {
+		t.Fatalf("MarshalMap: %v", err)
+	}
+	if _, err := f.PutItem(ctx, &dynamodb.PutItemInput{Item: orphan}); err != nil {
+		t.Fatalf("PutItem: %v", err)
+	}
+
+	tags, err := a.ListTags(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Tag != "focus" || tags[0].InsightCount != 1 || !tags[0].LastInsightAt.Equal(now) {
+		t.Fatalf("ListTags = %+v, want focus from its aggregate", tags)
+	}
+}
+
+func TestInsightAdapter_ReplaceTagStats_PagesThroughEveryAggregate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	tagInsight(t, a, "i-1", now.Add(-time.Hour), "alpha", "beta")
+	tagInsight(t, a, "i-2", now.Add(-time.Hour), "beta", "gamma")
+	if err := a.Put(ctx, domain.Relationship{TenantID: "t-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.9}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// Sorts after every live aggregate, so it's only seen past page one.
+	if err := a.addTagMembershipStat(ctx, "t-1", "zzz-gone", now, 1, 0); err != nil {
+		t.Fatalf("addTagMembershipStat: %v", err)
+	}
+	f.queryPageSize = 1
+
+	memberships, err := a.ListAllTagMemberships(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListAllTagMemberships: %v", err)
+	}
+	if len(memberships) != 3 {
+		t.Fatalf("memberships = %+v, want alpha, beta and gamma", memberships)
+	}
+	degrees, err := a.RelationshipDegrees(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("RelationshipDegrees: %v", err)
+	}
+	if degrees["i-1"] != 1 || degrees["i-2"] != 1 {
+		t.Fatalf("degrees = %+v, want one edge on each of i-1 and i-2", degrees)
+	}
+	if err := a.ReplaceTagStats(ctx, "t-1", domain.BuildTagStats(memberships, degrees, nil)); err != nil {
+		t.Fatalf("ReplaceTagStats: %v", err)
+	}
+
+	if _, ok := f.items[compositeKey(tagStatKey("t-1", "zzz-gone"), "pk", "sk")]; ok {
+		t.Fatalf("stale aggregate past the first page survived the rebuild")
+	}
+	tags, err := a.ListTags(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(tags) != 3 {
+		t.Fatalf("ListTags = %+v, want alpha, beta and gamma", tags)
+	}
+}
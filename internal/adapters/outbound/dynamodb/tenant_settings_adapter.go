@@ -0,0 +1,134 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var (
+	_ ports.TenantSettingsRepository = (*InsightAdapter)(nil)
+	_ ports.TagRanker                = (*InsightAdapter)(nil)
+)
+
+// settingsSK keys a tenant's one settings item (pk = TENANT#<id>).
+const settingsSK = "SETTINGS"
+
+type dynamoTenantSettingsItem struct {
+	PK                    string             `dynamodbav:"pk"`
+	SK                    string             `dynamodbav:"sk"`
+	TagLanguage           string             `dynamodbav:"tag_language,omitempty"`
+	RelationMinConfidence map[string]float64 `dynamodbav:"relation_min_confidence,omitempty"`
+	MaxEdgesPerInsight    int                `dynamodbav:"max_edges_per_insight,omitempty"`
+	// TagRelevance is absent while the tenant is on the defaults.
+	TagRelevance *dynamoTagRelevanceItem `dynamodbav:"tag_relevance,omitempty"`
+	UpdatedAt    time.Time               `dynamodbav:"updated_at"`
+}
+
+type dynamoTagRelevanceItem struct {
+	CountWeight       float64 `dynamodbav:"count_weight"`
+	RecencyWeight     float64 `dynamodbav:"recency_weight"`
+	FreshnessWeight   float64 `dynamodbav:"freshness_weight"`
+	DensityWeight     float64 `dynamodbav:"density_weight"`
+	HalfLifeSeconds   int64   `dynamodbav:"half_life_seconds"`
+	CountSaturation   float64 `dynamodbav:"count_saturation"`
+	DensitySaturation float64 `dynamodbav:"density_saturation"`
+}
+
+// tagRelevanceFromDomain is nil for the zero config, which stores nothing.
+func tagRelevanceFromDomain(t domain.TagRelevanceConfig) *dynamoTagRelevanceItem {
+	if t == (domain.TagRelevanceConfig{}) {
+		return nil
+	}
+	return &dynamoTagRelevanceItem{
+		CountWeight:       t.CountWeight,
+		RecencyWeight:     t.RecencyWeight,
+		FreshnessWeight:   t.FreshnessWeight,
+		DensityWeight:     t.DensityWeight,
+		HalfLifeSeconds:   int64(t.HalfLife / time.Second),
+		CountSaturation:   t.CountSaturation,
+		DensitySaturation: t.DensitySaturation,
+	}
+}
+
+func tagRelevanceToDomain(t *dynamoTagRelevanceItem) domain.TagRelevanceConfig {
+	if t == nil {
+		return domain.TagRelevanceConfig{}
+	}
+	return domain.TagRelevanceConfig{
+		CountWeight:       t.CountWeight,
+		RecencyWeight:     t.RecencyWeight,
+		FreshnessWeight:   t.FreshnessWeight,
+		DensityWeight:     t.DensityWeight,
+		HalfLife:          time.Duration(t.HalfLifeSeconds) * time.Second,
+		CountSaturation:   t.CountSaturation,
+		DensitySaturation: t.DensitySaturation,
+	}
+}
+
+func (r *InsightAdapter) GetTenantSettings(ctx context.Context, tenantID string) (domain.TenantSettings, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: settingsSK},
+		},
+	})
+	if err != nil {
+		return domain.TenantSettings{}, err
+	}
+	settings := domain.TenantSettings{TenantID: tenantID}
+	if out.Item == nil {
+		return settings, nil
+	}
+
+	var item dynamoTenantSettingsItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.TenantSettings{}, err
+	}
+	settings.TagLanguage = domain.Language(item.TagLanguage)
+	if len(item.RelationMinConfidence) > 0 {
+		settings.RelationshipPolicy.MinConfidence = make(map[domain.RelationType]float64, len(item.RelationMinConfidence))
+		for relType, min := range item.RelationMinConfidence {
+			settings.RelationshipPolicy.MinConfidence[domain.RelationType(relType)] = min
+		}
+	}
+	settings.RelationshipPolicy.MaxEdgesPerInsight = item.MaxEdgesPerInsight
+	settings.TagRelevance = tagRelevanceToDomain(item.TagRelevance)
+	settings.UpdatedAt = item.UpdatedAt
+	return settings, nil
+}
+
+func (r *InsightAdapter) SaveTenantSettings(ctx context.Context, settings domain.TenantSettings) error {
+	var minConfidence map[string]float64
+	if len(settings.RelationshipPolicy.MinConfidence) > 0 {
+		minConfidence = make(map[string]float64, len(settings.RelationshipPolicy.MinConfidence))
+		for relType, min := range settings.RelationshipPolicy.MinConfidence {
+			minConfidence[string(relType)] = min
+		}
+	}
+	av, err := attributevalue.MarshalMap(dynamoTenantSettingsItem{
+		PK:                    pk(settings.TenantID),
+		SK:                    settingsSK,
+		TagLanguage:           string(settings.TagLanguage),
+		RelationMinConfidence: minConfidence,
+		MaxEdgesPerInsight:    settings.RelationshipPolicy.MaxEdgesPerInsight,
+		TagRelevance:          tagRelevanceFromDomain(settings.TagRelevance),
+		UpdatedAt:             settings.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.tableName),
+		Item:      av,
+	})
+	return err
+}
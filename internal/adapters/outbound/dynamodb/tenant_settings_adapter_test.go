@@ -0,0 +1,100 @@
+package dynamodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_TenantSettings_DefaultsThenRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	got, err := a.GetTenantSettings(ctx, "t-1")
+	if err != nil || got.TenantID != "t-1" || got.TagLanguage != domain.LanguageUnknown {
+		t.Fatalf("got %+v, %v; want t-1's defaults", got, err)
+	}
+
+	if err := a.SaveTenantSettings(ctx, domain.TenantSettings{TenantID: "t-1", TagLanguage: "de", UpdatedAt: now}); err != nil {
+		t.Fatalf("SaveTenantSettings: %v", err)
+	}
+	got, err = a.GetTenantSettings(ctx, "t-1")
+	if err != nil || got.TagLanguage != "de" || !got.UpdatedAt.Equal(now) {
+		t.Fatalf("got %+v, %v; want the saved settings", got, err)
+	}
+	if other, _ := a.GetTenantSettings(ctx, "t-2"); other.TagLanguage != domain.LanguageUnknown {
+		t.Fatalf("t-2 read t-1's settings: %+v", other)
+	}
+}
+
+func TestInsightAdapter_TenantSettings_RelationshipPolicyRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAdapter(newFakeDynamo(), time.Now())
+
+	policy := domain.RelationshipPolicy{
+		MinConfidence:      map[domain.RelationType]float64{domain.RelationContradicts: 0.8},
+		MaxEdgesPerInsight: 5,
+	}
+	if err := a.SaveTenantSettings(ctx, domain.TenantSettings{TenantID: "t-1", RelationshipPolicy: policy}); err != nil {
+		t.Fatalf("SaveTenantSettings: %v", err)
+	}
+	got, err := a.GetTenantSettings(ctx, "t-1")
+	if err != nil || got.RelationshipPolicy.MaxEdgesPerInsight != 5 || got.RelationshipPolicy.MinConfidence[domain.RelationContradicts] != 0.8 || len(got.RelationshipPolicy.MinConfidence) != 1 {
+		t.Fatalf("got %+v, %v; want the saved policy", got.RelationshipPolicy, err)
+	}
+}
+
+func TestInsightAdapter_TenantSettings_TagRelevanceRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	a := newTestAdapter(newFakeDynamo(), time.Now())
+
+	config := domain.DefaultTagRelevanceConfig()
+	config.HalfLife = 30 * 24 * time.Hour
+	if err := a.SaveTenantSettings(ctx, domain.TenantSettings{TenantID: "t-1", TagRelevance: config}); err != nil {
+		t.Fatalf("SaveTenantSettings: %v", err)
+	}
+	got, err := a.GetTenantSettings(ctx, "t-1")
+	if err != nil || got.TagRelevance != config {
+		t.Fatalf("got %+v, %v; want the saved config", got.TagRelevance, err)
+	}
+}
+
+func TestInsightAdapter_ListTags_WeighsByTheTenantsConfig(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	insight := domain.Insight{ID: "i-1", TenantID: "t-1", Text: "hello", HighlightedAt: now.AddDate(0, 0, -30)}
+	if _, err := a.CreateIfAbsent(ctx, insight); err != nil {
+		t.Fatalf("CreateIfAbsent: %v", err)
+	}
+	insight.Enrichment = &domain.Enrichment{Tags: []string{"focus"}}
+	if err := a.Update(ctx, insight); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	before, err := a.ListTags(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+
+	slow := domain.DefaultTagRelevanceConfig()
+	slow.HalfLife = 30 * 24 * time.Hour
+	if err := a.SaveTenantSettings(ctx, domain.TenantSettings{TenantID: "t-1", TagRelevance: slow}); err != nil {
+		t.Fatalf("SaveTenantSettings: %v", err)
+	}
+	after, err := a.ListTags(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if after[0].ScoreComponents.Recency <= before[0].ScoreComponents.Recency {
+		t.Fatalf("recency %v -> %v; a 30-day half-life should fade a month-old insight less", before[0].ScoreComponents.Recency, after[0].ScoreComponents.Recency)
+	}
+
+	preview, err := a.RankTags(ctx, "t-1", domain.DefaultTagRelevanceConfig())
+	if err != nil || preview[0].Score != before[0].Score {
+		t.Fatalf("RankTags under the defaults = %+v, %v; want the original score %v", preview, err, before[0].Score)
+	}
+}
@@ -0,0 +1,125 @@
+package dynamodb
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var _ ports.UsageRepository = (*InsightAdapter)(nil)
+
+type dynamoUsageItem struct {
+	Calls        int64 `dynamodbav:"calls"`
+	InputTokens  int64 `dynamodbav:"input_tokens"`
+	OutputTokens int64 `dynamodbav:"output_tokens"`
+	CostMicros   int64 `dynamodbav:"cost_micros"`
+}
+
+// usageDaySK and usageMonthSK key a tenant's counters (pk = TENANT#<id>).
+// One item per period rather than per call: the budget check reads two
+// items, not a day's worth.
+func usageDaySK(at time.Time) string   { return "USAGE#DAY#" + domain.UsageDay(at) }
+func usageMonthSK(at time.Time) string { return "USAGE#MONTH#" + domain.UsageMonth(at) }
+
+// usageDayRetention is how long after its day starts a day's counters are
+// kept: the daily budget only ever reads today's, and a couple of days more
+// leaves yesterday's to look at. expires_at is the table's TTL attribute,
+// as on dynamoEnrichmentCacheItem; month counters have none.
+const usageDayRetention = 3 * 24 * time.Hour
+
+// usageDayExpiresAt is when at's day counters expire. UTC days start on a
+// multiple of 24h since the Unix epoch, so Truncate finds the start of
+// domain.UsageDay's day.
+func usageDayExpiresAt(at time.Time) int64 {
+	return at.UTC().Truncate(24 * time.Hour).Add(usageDayRetention).Unix()
+}
+
+// AddLLMUsage increments both of at's counters with ADD, which creates a
+// missing item and treats missing attributes as zero — no read first, and
+// concurrent workers never lose each other's increments. The day item's
+// expires_at is SET alongside; every write of the day sets the same value.
+func (r *InsightAdapter) AddLLMUsage(ctx context.Context, tenantID string, at time.Time, usage domain.LLMUsage) error {
+	const add = "ADD #calls :calls, #input_tokens :input_tokens, #output_tokens :output_tokens, #cost_micros :cost_micros"
+	for _, sk := range []string{usageDaySK(at), usageMonthSK(at)} {
+		in := &dynamodb.UpdateItemInput{
+			TableName: aws.String(r.tableName),
+			Key: map[string]types.AttributeValue{
+				"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+				"sk": &types.AttributeValueMemberS{Value: sk},
+			},
+			UpdateExpression: aws.String(add),
+			ExpressionAttributeNames: map[string]string{
+				"#calls":         "calls",
+				"#input_tokens":  "input_tokens",
+				"#output_tokens": "output_tokens",
+				"#cost_micros":   "cost_micros",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":calls":         numberAV(usage.Calls),
+				":input_tokens":  numberAV(usage.InputTokens),
+				":output_tokens": numberAV(usage.OutputTokens),
+				":cost_micros":   numberAV(usage.CostMicros),
+			},
+		}
+		if sk == usageDaySK(at) {
+			in.UpdateExpression = aws.String(add + " SET #expires_at = :expires_at")
+			in.ExpressionAttributeNames["#expires_at"] = "expires_at"
+			in.ExpressionAttributeValues[":expires_at"] = numberAV(usageDayExpiresAt(at))
+		}
+		if _, err := r.client.UpdateItem(ctx, in); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *InsightAdapter) GetLLMUsage(ctx context.Context, tenantID string, at time.Time) (domain.LLMUsage, domain.LLMUsage, error) {
+	day, err := r.getUsage(ctx, tenantID, usageDaySK(at))
+	if err != nil {
+		return domain.LLMUsage{}, domain.LLMUsage{}, err
+	}
+	month, err := r.getUsage(ctx, tenantID, usageMonthSK(at))
+	if err != nil {
+		return domain.LLMUsage{}, domain.LLMUsage{}, err
+	}
+	return day, month, nil
+}
+
+func (r *InsightAdapter) getUsage(ctx context.Context, tenantID, sk string) (domain.LLMUsage, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: sk},
+		},
+	})
+	if err != nil {
+		return domain.LLMUsage{}, err
+	}
+	if out.Item == nil {
+		return domain.LLMUsage{}, nil
+	}
+
+	var item dynamoUsageItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return domain.LLMUsage{}, err
+	}
+	return domain.LLMUsage{
+		Calls:        item.Calls,
+		InputTokens:  item.InputTokens,
+		OutputTokens: item.OutputTokens,
+		CostMicros:   item.CostMicros,
+	}, nil
+}
+
+func numberAV(n int64) *types.AttributeValueMemberN {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(n, 10)}
+}
@@ -0,0 +1,75 @@
+package dynamodb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestInsightAdapter_AddLLMUsage_AccumulatesPerDayAndMonth(t *testing.T) {
+	ctx := context.Background()
+	day1 := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	day2 := day1.Add(2 * time.Hour)
+	a := newTestAdapter(newFakeDynamo(), day1)
+
+	call := domain.LLMUsage{Calls: 1, InputTokens: 100, OutputTokens: 20, CostMicros: 50}
+	for _, at := range []time.Time{day1, day1, day2} {
+		if err := a.AddLLMUsage(ctx, "t-1", at, call); err != nil {
+			t.Fatalf("AddLLMUsage: %v", err)
+		}
+	}
+
+	day, month, err := a.GetLLMUsage(ctx, "t-1", day1)
+	if err != nil {
+		t.Fatalf("GetLLMUsage: %v", err)
+	}
+	if day.Calls != 2 || day.InputTokens != 200 || day.OutputTokens != 40 || day.CostMicros != 100 {
+		t.Fatalf("day = %+v, want two calls' worth", day)
+	}
+	if month.Calls != 3 || month.CostMicros != 150 {
+		t.Fatalf("month = %+v, want all three calls", month)
+	}
+}
+
+func TestInsightAdapter_AddLLMUsage_DayCountersExpire_MonthCountersDoNot(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, at)
+
+	if err := a.AddLLMUsage(ctx, "t-1", at, domain.LLMUsage{Calls: 1}); err != nil {
+		t.Fatalf("AddLLMUsage: %v", err)
+	}
+
+	day := f.items[pk("t-1")+"|"+usageDaySK(at)]
+	want := strconv.FormatInt(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC).Unix(), 10)
+	if got, ok := day["expires_at"].(*types.AttributeValueMemberN); !ok || got.Value != want {
+		t.Fatalf("day expires_at = %v, want %s, three days after the day starts", day["expires_at"], want)
+	}
+	if _, ok := f.items[pk("t-1")+"|"+usageMonthSK(at)]["expires_at"]; ok {
+		t.Fatal("month counters got an expires_at")
+	}
+}
+
+func TestInsightAdapter_GetLLMUsage_UnusedPeriodReadsZero_ScopedByTenant(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	if err := a.AddLLMUsage(ctx, "t-1", now, domain.LLMUsage{Calls: 1}); err != nil {
+		t.Fatalf("AddLLMUsage: %v", err)
+	}
+
+	day, month, err := a.GetLLMUsage(ctx, "t-2", now)
+	if err != nil {
+		t.Fatalf("GetLLMUsage: %v", err)
+	}
+	if day != (domain.LLMUsage{}) || month != (domain.LLMUsage{}) {
+		t.Fatalf("got day=%+v month=%+v, want zero for another tenant", day, month)
+	}
+}
@@ -18,12 +18,48 @@ import (
 
 var _ ports.WeeklyPlanRepository = (*InsightAdapter)(nil)
 
+// dynamoActionItem's progress fields are absent on actions stored before
+// they existed; a missing status reads as todo.
 type dynamoActionItem struct {
-	Title                string   `dynamodbav:"title"`
-	Why                  string   `dynamodbav:"why"`
-	SupportingInsightIDs []string `dynamodbav:"supporting_insight_ids"`
+	Title                string    `dynamodbav:"title"`
+	Why                  string    `dynamodbav:"why"`
+	SupportingInsightIDs []string  `dynamodbav:"supporting_insight_ids"`
+	Status               string    `dynamodbav:"status,omitempty"`
+	StartedAt            time.Time `dynamodbav:"started_at"`
+	CompletedAt          time.Time `dynamodbav:"completed_at"`
+	Reflection           string    `dynamodbav:"reflection,omitempty"`
 }
 
+func newDynamoActionItem(a domain.Action) dynamoActionItem {
+	return dynamoActionItem{
+		Title:                a.Title,
+		Why:                  a.Why,
+		SupportingInsightIDs: a.SupportingInsightIDs,
+		Status:               string(a.Status),
+		StartedAt:            a.StartedAt,
+		CompletedAt:          a.CompletedAt,
+		Reflection:           a.Reflection,
+	}
+}
+
+func (a dynamoActionItem) toDomain() domain.Action {
+	status := domain.ActionStatus(a.Status)
+	if status == "" {
+		status = domain.ActionTodo
+	}
+	return domain.Action{
+		Title:                a.Title,
+		Why:                  a.Why,
+		SupportingInsightIDs: a.SupportingInsightIDs,
+		Status:               status,
+		StartedAt:            a.StartedAt,
+		CompletedAt:          a.CompletedAt,
+		Reflection:           a.Reflection,
+	}
+}
+
+// dynamoWeeklyPlanItem's resolved_at is absent on plans resolved before it
+// was recorded, and reads as zero.
 type dynamoWeeklyPlanItem struct {
 	PK            string             `dynamodbav:"pk"`
 	SK            string             `dynamodbav:"sk"`
@@ -33,6 +69,7 @@ type dynamoWeeklyPlanItem struct {
 	FocusSentence string             `dynamodbav:"focus_sentence"`
 	Status        string             `dynamodbav:"status"`
 	CreatedAt     time.Time          `dynamodbav:"created_at"`
+	ResolvedAt    time.Time          `dynamodbav:"resolved_at"`
 	Actions       []dynamoActionItem `dynamodbav:"actions,omitempty"`
 	FailureReason string             `dynamodbav:"failure_reason,omitempty"`
 }
@@ -40,11 +77,7 @@ type dynamoWeeklyPlanItem struct {
 func (item dynamoWeeklyPlanItem) toDomain() domain.WeeklyPlan {
 	actions := make([]domain.Action, len(item.Actions))
 	for i, a := range item.Actions {
-		actions[i] = domain.Action{
-			Title:                a.Title,
-			Why:                  a.Why,
-			SupportingInsightIDs: a.SupportingInsightIDs,
-		}
+		actions[i] = a.toDomain()
 	}
 	return domain.WeeklyPlan{
 		ID:            item.ID,
@@ -53,6 +86,7 @@ func (item dynamoWeeklyPlanItem) toDomain() domain.WeeklyPlan {
 		FocusSentence: item.FocusSentence,
 		Status:        domain.PlanStatus(item.Status),
 		CreatedAt:     item.CreatedAt,
+		ResolvedAt:    item.ResolvedAt,
 		Actions:       actions,
 		FailureReason: item.FailureReason,
 	}
@@ -161,11 +195,14 @@ func (r *InsightAdapter) ListPlansByTenantID(ctx context.Context, tenantID strin
 func (r *InsightAdapter) SetReady(ctx context.Context, tenantID, planID string, actions []domain.Action) error {
 	items := make([]dynamoActionItem, len(actions))
 	for i, a := range actions {
-		items[i] = dynamoActionItem{
+		// Whatever progress the caller sent, a freshly drafted action
+		// starts untouched.
+		items[i] = newDynamoActionItem(domain.Action{
 			Title:                a.Title,
 			Why:                  a.Why,
 			SupportingInsightIDs: a.SupportingInsightIDs,
-		}
+			Status:               domain.ActionTodo,
+		})
 	}
 	actionsAV, err := attributevalue.MarshalList(items)
 	if err != nil {
@@ -175,7 +212,7 @@ func (r *InsightAdapter) SetReady(ctx context.Context, tenantID, planID string,
 	return r.setResult(ctx, tenantID, planID, map[string]types.AttributeValue{
 		":status":  &types.AttributeValueMemberS{Value: string(domain.PlanStatusReady)},
 		":actions": &types.AttributeValueMemberL{Value: actionsAV},
-	}, "SET #status = :status, #actions = :actions REMOVE #reason", map[string]string{
+	}, "SET #status = :status, #actions = :actions, #resolved = :resolved REMOVE #reason", map[string]string{
 		"#actions": "actions",
 		"#reason":  "failure_reason",
 	})
@@ -187,11 +224,12 @@ func (r *InsightAdapter) SetFailed(ctx context.Context, tenantID, planID, reason
 	return r.setResult(ctx, tenantID, planID, map[string]types.AttributeValue{
 		":status": &types.AttributeValueMemberS{Value: string(domain.PlanStatusFailed)},
 		":reason": &types.AttributeValueMemberS{Value: reason},
-	}, "SET #status = :status, #reason = :reason", map[string]string{
+	}, "SET #status = :status, #reason = :reason, #resolved = :resolved", map[string]string{
 		"#reason": "failure_reason",
 	})
 }
 
+// setResult stamps the update's :resolved with r.now, as resolved_at.
 func (r *InsightAdapter) setResult(
 	ctx context.Context,
 	tenantID, planID string,
@@ -199,13 +237,18 @@ func (r *InsightAdapter) setResult(
 	updateExpr string,
 	extraNames map[string]string,
 ) error {
-	names := map[string]string{"#pk": "pk", "#status": "status"}
+	resolvedAt, err := attributevalue.Marshal(r.now().UTC())
+	if err != nil {
+		return err
+	}
+	names := map[string]string{"#pk": "pk", "#status": "status", "#resolved": "resolved_at"}
 	for k, v := range extraNames {
 		names[k] = v
 	}
 	values[":pending"] = &types.AttributeValueMemberS{Value: string(domain.PlanStatusPending)}
+	values[":resolved"] = resolvedAt
 
-	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(r.tableName),
 		Key: map[string]types.AttributeValue{
 			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
@@ -225,6 +268,78 @@ func (r *InsightAdapter) setResult(
 	return nil
 }
 
+// UpdateAction SETs the one list element, so updates to different
+// actions of the same plan never overwrite each other, on the condition
+// that its status and timestamps are still before's: of two updates made
+// from the same read, the second fails rather than overwriting the first.
+// The index isn't checked here: the caller validated it against the plan
+// it loaded, and a ready plan's actions never change in number.
+func (r *InsightAdapter) UpdateAction(ctx context.Context, tenantID, planID string, index int, before, after domain.Action) error {
+	av, err := attributevalue.Marshal(newDynamoActionItem(after))
+	if err != nil {
+		return err
+	}
+	prev := newDynamoActionItem(before)
+	startedAt, err := attributevalue.Marshal(prev.StartedAt)
+	if err != nil {
+		return err
+	}
+	completedAt, err := attributevalue.Marshal(prev.CompletedAt)
+	if err != nil {
+		return err
+	}
+
+	action := fmt.Sprintf("#actions[%d]", index)
+	condition := "attribute_exists(#pk) AND #status = :ready AND " +
+		action + ".#astatus = :prevStatus AND " +
+		action + ".#started = :prevStarted AND " +
+		action + ".#completed = :prevCompleted"
+	if before.Status == domain.ActionTodo {
+		// An action stored before progress was tracked has no status
+		// (see dynamoActionItem) and reads as untouched todo. AND binds
+		// tighter than OR.
+		condition += " OR attribute_exists(#pk) AND #status = :ready AND attribute_not_exists(" + action + ".#astatus)"
+	}
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: pk(tenantID)},
+			"sk": &types.AttributeValueMemberS{Value: planSK(planID)},
+		},
+		ConditionExpression: aws.String(condition),
+		UpdateExpression:    aws.String(fmt.Sprintf("SET %s = :action", action)),
+		ExpressionAttributeNames: map[string]string{
+			"#pk":        "pk",
+			"#status":    "status",
+			"#actions":   "actions",
+			"#astatus":   "status",
+			"#started":   "started_at",
+			"#completed": "completed_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":ready":         &types.AttributeValueMemberS{Value: string(domain.PlanStatusReady)},
+			":action":        av,
+			":prevStatus":    &types.AttributeValueMemberS{Value: string(before.Status)},
+			":prevStarted":   startedAt,
+			":prevCompleted": completedAt,
+		},
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		if ccf, ok := errors.AsType[*types.ConditionalCheckFailedException](err); ok {
+			// The old item tells which half of the condition failed.
+			var item dynamoWeeklyPlanItem
+			if ccf.Item == nil || attributevalue.UnmarshalMap(ccf.Item, &item) != nil || item.Status != string(domain.PlanStatusReady) {
+				return domain.ErrPlanNotReady
+			}
+			return ports.ErrActionChanged
+		}
+		return err
+	}
+	return nil
+}
+
 // tagExists reuses tagSK's "TAG#<tag>#INSIGHT#" prefix (an empty insightID
 // yields exactly that prefix) to check whether any insight in the tenant
 // carries tag, without a dedicated tag-existence index.
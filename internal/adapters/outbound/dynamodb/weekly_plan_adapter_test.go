@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
@@ -95,6 +98,8 @@ func TestInsightAdapter_SetReady_HappyPath_PersistsActionsAndStatus(t *testing.T
 	actions := []domain.Action{
 		{Title: "Ship it", Why: "why", SupportingInsightIDs: []string{"i-1"}},
 	}
+	resolvedAt := now.Add(time.Minute)
+	a.now = func() time.Time { return resolvedAt }
 	if err := a.SetReady(ctx, "t-1", "p-1", actions); err != nil {
 		t.Fatalf("SetReady: %v", err)
 	}
@@ -103,8 +108,8 @@ func TestInsightAdapter_SetReady_HappyPath_PersistsActionsAndStatus(t *testing.T
 	if err != nil {
 		t.Fatalf("Get: %v", err)
 	}
-	if got.Status != domain.PlanStatusReady {
-		t.Fatalf("Status = %q, want ready", got.Status)
+	if got.Status != domain.PlanStatusReady || !got.ResolvedAt.Equal(resolvedAt) {
+		t.Fatalf("Status = %q resolved at %v, want ready at %v", got.Status, got.ResolvedAt, resolvedAt)
 	}
 	if len(got.Actions) != 1 || got.Actions[0].Title != "Ship it" || got.Actions[0].SupportingInsightIDs[0] != "i-1" {
 		t.Fatalf("Actions = %+v, want one Ship it action citing i-1", got.Actions)
@@ -152,6 +157,122 @@ func TestInsightAdapter_SetReady_AlreadyReady_ReturnsErrPlanNotPending(t *testin
 	}
 }
 
+func TestInsightAdapter_UpdateAction_ReplacesOnlyThatAction(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	seedTaggedInsight(t, ctx, a, "t-1", "golang")
+	plan := domain.WeeklyPlan{ID: "p-1", TenantID: "t-1", Tag: "golang", FocusSentence: "focus", Status: domain.PlanStatusPending, CreatedAt: now}
+	if err := a.Create(ctx, plan); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := a.UpdateAction(ctx, "t-1", "p-1", 0, domain.Action{}, domain.Action{Title: "early"}); !errors.Is(err, domain.ErrPlanNotReady) {
+		t.Fatalf("UpdateAction on a pending plan err = %v, want ErrPlanNotReady", err)
+	}
+
+	// Progress the agent sends along is dropped: every drafted action
+	// starts as todo.
+	if err := a.SetReady(ctx, "t-1", "p-1", []domain.Action{{Title: "first", Status: domain.ActionDone}, {Title: "second"}}); err != nil {
+		t.Fatalf("SetReady: %v", err)
+	}
+	got, _ := a.Get(ctx, "t-1", "p-1")
+	if got.Actions[0].Status != domain.ActionTodo || got.Actions[1].Status != domain.ActionTodo {
+		t.Fatalf("Actions = %+v, want both todo", got.Actions)
+	}
+
+	todo := got.Actions[1]
+	done := todo
+	done.Status, done.StartedAt, done.CompletedAt, done.Reflection = domain.ActionDone, now, now.Add(time.Hour), "went well"
+	if err := a.UpdateAction(ctx, "t-1", "p-1", 1, todo, done); err != nil {
+		t.Fatalf("UpdateAction: %v", err)
+	}
+
+	got, err := a.Get(ctx, "t-1", "p-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Actions[0].Title != "first" || got.Actions[0].Status != domain.ActionTodo {
+		t.Fatalf("Actions[0] = %+v, want untouched", got.Actions[0])
+	}
+	second := got.Actions[1]
+	if second.Title != "second" || second.Status != domain.ActionDone || !second.CompletedAt.Equal(now.Add(time.Hour)) || second.Reflection != "went well" {
+		t.Fatalf("Actions[1] = %+v, want done with its reflection", second)
+	}
+
+	if err := a.UpdateAction(ctx, "t-1", "p-missing", 0, todo, done); !errors.Is(err, domain.ErrPlanNotReady) {
+		t.Fatalf("UpdateAction on a missing plan err = %v, want ErrPlanNotReady", err)
+	}
+}
+
+func TestInsightAdapter_UpdateAction_ActionMovedSinceRead_ReturnsErrActionChanged(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestAdapter(newFakeDynamo(), now)
+
+	seedTaggedInsight(t, ctx, a, "t-1", "golang")
+	if err := a.Create(ctx, domain.WeeklyPlan{ID: "p-1", TenantID: "t-1", Tag: "golang", FocusSentence: "focus", Status: domain.PlanStatusPending, CreatedAt: now}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := a.SetReady(ctx, "t-1", "p-1", []domain.Action{{Title: "only"}}); err != nil {
+		t.Fatalf("SetReady: %v", err)
+	}
+	got, _ := a.Get(ctx, "t-1", "p-1")
+	read := got.Actions[0]
+
+	// Two requests read the same todo action: one finishes it, the
+	// other, a moment later, marks it in progress.
+	done := read
+	done.Status, done.StartedAt, done.CompletedAt = domain.ActionDone, now, now
+	if err := a.UpdateAction(ctx, "t-1", "p-1", 0, read, done); err != nil {
+		t.Fatalf("first UpdateAction: %v", err)
+	}
+	started := read
+	started.Status, started.StartedAt = domain.ActionInProgress, now.Add(time.Minute)
+	if err := a.UpdateAction(ctx, "t-1", "p-1", 0, read, started); !errors.Is(err, ports.ErrActionChanged) {
+		t.Fatalf("second UpdateAction err = %v, want ErrActionChanged", err)
+	}
+
+	got, _ = a.Get(ctx, "t-1", "p-1")
+	if got.Actions[0].Status != domain.ActionDone || !got.Actions[0].CompletedAt.Equal(now) {
+		t.Fatalf("Actions[0] = %+v, want the first update kept", got.Actions[0])
+	}
+}
+
+func TestInsightAdapter_UpdateAction_ActionWithoutProgressFields_ReadsAsTodo(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := newFakeDynamo()
+	a := newTestAdapter(f, now)
+
+	seedTaggedInsight(t, ctx, a, "t-1", "golang")
+	if err := a.Create(ctx, domain.WeeklyPlan{ID: "p-1", TenantID: "t-1", Tag: "golang", FocusSentence: "focus", Status: domain.PlanStatusPending, CreatedAt: now}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := a.SetReady(ctx, "t-1", "p-1", []domain.Action{{Title: "only"}}); err != nil {
+		t.Fatalf("SetReady: %v", err)
+	}
+	// Strip the action back to how it was stored before progress was
+	// tracked.
+	item := f.items[pk("t-1")+"|"+planSK("p-1")]
+	legacy, _ := attributevalue.MarshalMap(struct {
+		Title string `dynamodbav:"title"`
+	}{Title: "only"})
+	item["actions"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberM{Value: legacy}}}
+
+	got, _ := a.Get(ctx, "t-1", "p-1")
+	read := got.Actions[0]
+	started := read
+	started.Status, started.StartedAt = domain.ActionInProgress, now
+	if err := a.UpdateAction(ctx, "t-1", "p-1", 0, read, started); err != nil {
+		t.Fatalf("UpdateAction: %v", err)
+	}
+	got, _ = a.Get(ctx, "t-1", "p-1")
+	if got.Actions[0].Status != domain.ActionInProgress {
+		t.Fatalf("Actions[0] = %+v, want in progress", got.Actions[0])
+	}
+}
+
 func TestInsightAdapter_SetFailed_HappyPath_PersistsReasonAndStatus(t *testing.T) {
 	ctx := context.Background()
 	f := newFakeDynamo()
@@ -172,8 +293,8 @@ func TestInsightAdapter_SetFailed_HappyPath_PersistsReasonAndStatus(t *testing.T
 	if err != nil {
 		t.Fatalf("Get: %v", err)
 	}
-	if got.Status != domain.PlanStatusFailed || got.FailureReason != "llm timed out" {
-		t.Fatalf("got = %+v, want status=failed reason=%q", got, "llm timed out")
+	if got.Status != domain.PlanStatusFailed || got.FailureReason != "llm timed out" || !got.ResolvedAt.Equal(now) {
+		t.Fatalf("got = %+v, want status=failed reason=%q resolved now", got, "llm timed out")
 	}
 }
 
@@ -0,0 +1,108 @@
+package enrichment
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// BatchSchemaName names BatchSchema the way SchemaName names Schema.
+const BatchSchemaName = "extract_enrichments"
+
+// BatchSystemPromptFor is SystemPromptFor plus how highlights arrive and
+// how to answer for each. The per-highlight instructions are unchanged,
+// which is why batch answers carry the same PromptVersion.
+func BatchSystemPromptFor(tagLanguage domain.Language) string {
+	return SystemPromptFor(tagLanguage) + " You are given several highlights as a JSON array of {\"index\", \"text\"} objects. Treat each one on its own, exactly as if it were the only highlight, and answer with one item per highlight carrying its index."
+}
+
+// BatchSchema mirrors BatchOutput: Schema's object per highlight, plus the
+// index it answers.
+var BatchSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"items": map[string]any{
+			"type":  "array",
+			"items": batchItemSchema(),
+		},
+	},
+	"required":             []string{"items"},
+	"additionalProperties": false,
+}
+
+func batchItemSchema() map[string]any {
+	properties := maps.Clone(Schema["properties"].(map[string]any))
+	properties["index"] = map[string]any{
+		"type":        "integer",
+		"description": "The index of the highlight this item answers.",
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             append([]string{"index"}, Schema["required"].([]string)...),
+		"additionalProperties": false,
+	}
+}
+
+type batchInput struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+}
+
+// BatchUserMessage is the user turn carrying texts, indexed by position.
+func BatchUserMessage(texts []string) (string, error) {
+	in := make([]batchInput, len(texts))
+	for i, text := range texts {
+		in[i] = batchInput{Index: i, Text: text}
+	}
+	b, err := json.Marshal(in)
+	return string(b), err
+}
+
+// BatchMaxTokens scales b's per-highlight output cap to n highlights.
+func (b Bounds) BatchMaxTokens(n int) int64 {
+	return b.MaxTokens * int64(n)
+}
+
+// BatchOutput is the model's answer to a batch, as BatchSchema constrains
+// it — though not necessarily in order, or complete.
+type BatchOutput struct {
+	Items []BatchItem `json:"items"`
+}
+
+type BatchItem struct {
+	Index int `json:"index"`
+	Output
+}
+
+// ToDomain maps the answer back onto n inputs by index, or fails with
+// ports.ErrMalformedBatch unless every index in [0,n) is answered exactly
+// once. Token usage is split evenly, the remainder going to the first
+// item, so the items' sum is still what the call cost.
+func (o BatchOutput) ToDomain(n int, u Usage) ([]domain.Enrichment, error) {
+	if len(o.Items) != n {
+		return nil, fmt.Errorf("%w: %d items for %d highlights", ports.ErrMalformedBatch, len(o.Items), n)
+	}
+
+	out := make([]domain.Enrichment, n)
+	seen := make([]bool, n)
+	for _, item := range o.Items {
+		if item.Index < 0 || item.Index >= n || seen[item.Index] {
+			return nil, fmt.Errorf("%w: unexpected index %d", ports.ErrMalformedBatch, item.Index)
+		}
+		seen[item.Index] = true
+
+		share := u
+		share.InputTokens = u.InputTokens / int64(n)
+		share.OutputTokens = u.OutputTokens / int64(n)
+		if item.Index == 0 {
+			share.InputTokens += u.InputTokens % int64(n)
+			share.OutputTokens += u.OutputTokens % int64(n)
+		}
+		out[item.Index] = item.Output.ToDomain(share)
+	}
+	return out, nil
+}
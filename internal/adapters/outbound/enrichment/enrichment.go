@@ -0,0 +1,159 @@
+// Package enrichment is what every LLM enrichment adapter shares: the
+// prompt, the output schema, the shape the model answers in, and ADR-013's
+// bounds on a call. A provider adapter (openai, anthropic) only translates
+// these into its vendor's wire format — so switching provider can change
+// which model answers, never what it's asked.
+package enrichment
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// PromptVersion is recorded on every Enrichment any adapter produces. Bump
+// it with any change to SystemPrompt or Schema, so a re-enrichment can be
+// aimed at output produced by an older prompt.
+//
+// enrich-v3 added the tag language instruction (SystemPromptFor): a
+// ReenrichVersion job aimed at enrich-v2 brings older tags into the
+// tenant's tag language.
+const PromptVersion = "enrich-v3"
+
+// Version is what every adapter's Version returns for the model it
+// requests: the model alone doesn't pin the output, the prompt does too.
+func Version(model string) string {
+	return model + "/" + PromptVersion
+}
+
+// SchemaName names Schema wherever a provider wants one (OpenAI's
+// json_schema, Anthropic's tool name).
+const SchemaName = "extract_enrichment"
+
+// Bounds caps one enrichment call, per ADR-013. Stated as bounds rather
+// than as any SDK's defaults, so they survive a provider change unchanged.
+type Bounds struct {
+	MaxTokens  int64
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+// DefaultBounds are ADR-013's: 512 output tokens, 30s, 3 retries. The
+// worker's Lambda timeout (terraform/envs/dev/worker.tf) relies on them.
+var DefaultBounds = Bounds{
+	MaxTokens:  512,
+	Timeout:    30 * time.Second,
+	MaxRetries: 3,
+}
+
+// Schema mirrors Output.
+//
+// `additionalProperties: false` and every property listed in `required`
+// are what OpenAI's strict mode demands; Anthropic's tool input_schema
+// accepts the same document as-is.
+var Schema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"tags": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "3-5 tags for the highlight, spanning a range of altitudes rather than 5 synonyms of the same idea. Include at least one broad field tag (e.g. \"psychology\", \"business\") the highlight clearly belongs to, then 2-4 tags that narrow into its specific facets (e.g. \"delayed-gratification\", \"locus-of-control\"). Never a narrow one-off tied to this highlight's exact wording (e.g. \"the-5-second-rule\", \"chapter-3-morning-routine\").",
+		},
+		"summary": map[string]any{
+			"type":        "string",
+			"description": "The highlight's point restated in one plain sentence.",
+		},
+		"concepts": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "Up to 8 key concepts and named entities (people, books, frameworks) the highlight mentions, written as they'd appear in prose (e.g. \"Daniel Kahneman\", \"loss aversion\").",
+		},
+		"sentiment": map[string]any{
+			"type":        "string",
+			"enum":        []string{"positive", "neutral", "negative"},
+			"description": "The highlight's overall tone.",
+		},
+		"actionability": map[string]any{
+			"type":        "number",
+			"description": "From 0 to 1: how directly this is advice a reader could act on this week. 0 for pure description or theory, 1 for a concrete practice to adopt.",
+		},
+		"question": map[string]any{
+			"type":        "string",
+			"description": "One open question the highlight raises for the reader, phrased to them.",
+		},
+	},
+	"required":             []string{"tags", "summary", "concepts", "sentiment", "actionability", "question"},
+	"additionalProperties": false,
+}
+
+const SystemPrompt = "You are a reading analyst. Given a reading highlight, extract 3-5 tags spanning a range of altitudes: start with one broad field it belongs to (e.g. \"psychology\", \"business\"), then add 2-4 tags that narrow into its specific facets. Never produce 5 tags that are all just synonyms of one idea, and never a narrow one-off tied to this highlight's exact wording. Then summarize the highlight in one sentence, list its key concepts, judge its sentiment, score how actionable it is from 0 to 1, and pose one question it raises. Be direct and concise. No preamble, no filler."
+
+// SystemPromptFor is SystemPrompt plus which language to tag in. Tags are
+// the shared vocabulary, so they're translated; everything else stays in
+// the highlight's own language, as does the stored text.
+func SystemPromptFor(tagLanguage domain.Language) string {
+	if tagLanguage == domain.LanguageUnknown {
+		tagLanguage = domain.DefaultTagLanguage
+	}
+	return SystemPrompt + " Write every tag in " + tagLanguage.Name() + ", translating it if the highlight is in another language, so highlights on the same topic share tags whatever language they're in. Write the summary, concepts and question in the highlight's own language."
+}
+
+// Output is the model's answer, as Schema constrains it.
+type Output struct {
+	Tags          []string `json:"tags"`
+	Summary       string   `json:"summary"`
+	Concepts      []string `json:"concepts"`
+	Sentiment     string   `json:"sentiment"`
+	Actionability float64  `json:"actionability"`
+	Question      string   `json:"question"`
+}
+
+// Usage is what one call cost, in whichever terms the provider reports.
+type Usage struct {
+	Model        string
+	InputTokens  int64
+	OutputTokens int64
+	Latency      time.Duration
+}
+
+// Billed wraps err, a failure after the provider answered, with what the
+// call cost.
+func (u Usage) Billed(err error) error {
+	return &ports.BilledError{InputTokens: u.InputTokens, OutputTokens: u.OutputTokens, Err: err}
+}
+
+// ToDomain combines the model's answer with the call's provenance. It
+// doesn't normalize or validate — that's insight.Service's job, whichever
+// adapter answered.
+func (o Output) ToDomain(u Usage) domain.Enrichment {
+	return domain.Enrichment{
+		Tags:          o.Tags,
+		Summary:       o.Summary,
+		Concepts:      o.Concepts,
+		Sentiment:     domain.Sentiment(o.Sentiment),
+		Actionability: o.Actionability,
+		Question:      o.Question,
+		Model:         u.Model,
+		PromptVersion: PromptVersion,
+		InputTokens:   u.InputTokens,
+		OutputTokens:  u.OutputTokens,
+		Latency:       u.Latency,
+	}
+}
+
+// LogComplete logs one finished call. Field names are load-bearing across
+// both services — the Python adapters match them so one Logs Insights
+// query spans the pipeline (IPP-113), whichever provider answered. Rename
+// here only in lockstep with services/ai.
+func LogComplete(ctx context.Context, provider string, u Usage) {
+	slog.InfoContext(ctx, "llm enrich complete",
+		"provider", provider,
+		"model", u.Model,
+		"input_tokens", u.InputTokens,
+		"output_tokens", u.OutputTokens,
+		"duration_ms", u.Latency.Milliseconds(),
+	)
+}
@@ -0,0 +1,268 @@
+// Package enrichmenttest is the conformance suite every LLM enrichment
+// adapter runs against an httptest fake of its own API: the same answer
+// mapped the same way, the same prompt sent, and ADR-013's bounds honoured
+// whichever vendor is behind the port.
+package enrichmenttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/enrichment"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// Provider is what an adapter's test supplies: how to build the adapter
+// and how its API answers.
+type Provider struct {
+	// NewClient builds the adapter under test against a fake server at
+	// baseURL (no path), bounded by bounds.
+	NewClient func(baseURL string, bounds enrichment.Bounds) ports.EnrichmentClient
+
+	// Respond writes a successful API response in which the model answered
+	// content under schemaName — the raw JSON of an enrichment.Output (or
+	// BatchOutput), or, to simulate a model that ignored the schema, prose.
+	Respond func(w http.ResponseWriter, schemaName, content string, usage enrichment.Usage)
+
+	// MaxTokens reads the output token cap out of a decoded request body.
+	MaxTokens func(body map[string]any) float64
+}
+
+// fastRetry asks every client to retry without backing off: OpenAI's SDK
+// reads Retry-After-Ms, plain HTTP clients Retry-After (in seconds).
+func fastRetry(w http.ResponseWriter) {
+	w.Header().Set("Retry-After-Ms", "1")
+	w.Header().Set("Retry-After", "0")
+}
+
+// Run runs the suite against p.
+func Run(t *testing.T, p Provider) {
+	t.Helper()
+
+	newClient := func(t *testing.T, handler http.HandlerFunc, bounds enrichment.Bounds) ports.EnrichmentClient {
+		t.Helper()
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+		return p.NewClient(srv.URL, bounds)
+	}
+	bounds := enrichment.Bounds{MaxTokens: 256, Timeout: 5 * time.Second, MaxRetries: 0}
+
+	t.Run("maps the answer and the call's provenance", func(t *testing.T) {
+		want := enrichment.Output{
+			Tags:          []string{"psychology", "habit-formation"},
+			Summary:       "Habits compound.",
+			Concepts:      []string{"James Clear"},
+			Sentiment:     "positive",
+			Actionability: 0.9,
+			Question:      "Which habit first?",
+		}
+		content, _ := json.Marshal(want)
+		c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			p.Respond(w, enrichment.SchemaName, string(content), enrichment.Usage{Model: "test-model", InputTokens: 42, OutputTokens: 7})
+		}, bounds)
+
+		got, err := c.Enrich(context.Background(), "a highlight about habits", "en")
+		if err != nil {
+			t.Fatalf("Enrich: %v", err)
+		}
+		if len(got.Tags) != 2 || got.Tags[1] != "habit-formation" || got.Summary != want.Summary ||
+			len(got.Concepts) != 1 || got.Sentiment != "positive" || got.Actionability != 0.9 || got.Question != want.Question {
+			t.Errorf("enrichment = %+v, want the fields of %+v", got, want)
+		}
+		if got.Model != "test-model" || got.PromptVersion != enrichment.PromptVersion {
+			t.Errorf("provenance: model %q, prompt version %q", got.Model, got.PromptVersion)
+		}
+		if got.InputTokens != 42 || got.OutputTokens != 7 {
+			t.Errorf("token usage: %d in, %d out, want 42 in, 7 out", got.InputTokens, got.OutputTokens)
+		}
+	})
+
+	t.Run("versions its answers by the shared prompt", func(t *testing.T) {
+		c := newClient(t, func(http.ResponseWriter, *http.Request) {}, bounds)
+		if v := c.Version(); !strings.HasSuffix(v, "/"+enrichment.PromptVersion) {
+			t.Errorf("Version() = %q, want it to end in the prompt version", v)
+		}
+	})
+
+	t.Run("sends the shared prompt, the tag language, the text and the token cap", func(t *testing.T) {
+		var body map[string]any
+		var raw string
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			raw = string(b)
+			p.Respond(w, enrichment.SchemaName, `{"tags":[],"summary":"","concepts":[],"sentiment":"neutral","actionability":0,"question":""}`, enrichment.Usage{Model: "m"})
+		}, bounds)
+
+		if _, err := c.Enrich(context.Background(), "the highlight text", "de"); err != nil {
+			t.Fatalf("Enrich: %v", err)
+		}
+		if !strings.Contains(raw, "You are a reading analyst.") || !strings.Contains(raw, "the highlight text") {
+			t.Errorf("request doesn't carry the shared system prompt and the text: %s", raw)
+		}
+		if !strings.Contains(raw, "Write every tag in German") {
+			t.Errorf("request doesn't ask for tags in the tag language: %s", raw)
+		}
+		if got := p.MaxTokens(body); got != float64(bounds.MaxTokens) {
+			t.Errorf("token cap = %v, want %d", got, bounds.MaxTokens)
+		}
+	})
+
+	t.Run("errors when the model ignored the schema, with what the call cost", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			p.Respond(w, enrichment.SchemaName, "I'm afraid I can't do that.", enrichment.Usage{Model: "m", InputTokens: 40, OutputTokens: 9})
+		}, bounds)
+
+		_, err := c.Enrich(context.Background(), "text", "en")
+		if err == nil {
+			t.Fatal("expected an error when the answer is not schema JSON")
+		}
+		if billed, ok := errors.AsType[*ports.BilledError](err); !ok || billed.InputTokens != 40 || billed.OutputTokens != 9 {
+			t.Fatalf("err = %v, want a *ports.BilledError carrying 40 in, 9 out", err)
+		}
+	})
+
+	t.Run("retries server errors at most MaxRetries times", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			fastRetry(w)
+			w.WriteHeader(http.StatusInternalServerError)
+		}, enrichment.Bounds{MaxTokens: 256, Timeout: 5 * time.Second, MaxRetries: 2})
+
+		if _, err := c.Enrich(context.Background(), "text", "en"); err == nil {
+			t.Fatal("expected an error once retries are exhausted")
+		}
+		if got := calls.Load(); got != 3 {
+			t.Fatalf("requests = %d, want 3 (one call plus two retries)", got)
+		}
+	})
+
+	t.Run("doesn't retry a rejected request", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}, enrichment.Bounds{MaxTokens: 256, Timeout: 5 * time.Second, MaxRetries: 2})
+
+		if _, err := c.Enrich(context.Background(), "text", "en"); err == nil {
+			t.Fatal("expected an error for a 400")
+		}
+		if got := calls.Load(); got != 1 {
+			t.Fatalf("requests = %d, want 1", got)
+		}
+	})
+
+	t.Run("gives up at the timeout", func(t *testing.T) {
+		c := newClient(t, func(_ http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(500 * time.Millisecond):
+			}
+		}, enrichment.Bounds{MaxTokens: 256, Timeout: 50 * time.Millisecond, MaxRetries: 0})
+
+		start := time.Now()
+		if _, err := c.Enrich(context.Background(), "text", "en"); err == nil {
+			t.Fatal("expected an error when the server outlasts the timeout")
+		}
+		if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+			t.Fatalf("Enrich took %v, want it cut off near the 50ms timeout", elapsed)
+		}
+	})
+
+	if _, ok := newClient(t, func(http.ResponseWriter, *http.Request) {}, bounds).(ports.BatchEnrichmentClient); ok {
+		runBatch(t, p, func(t *testing.T, handler http.HandlerFunc) ports.BatchEnrichmentClient {
+			return newClient(t, handler, bounds).(ports.BatchEnrichmentClient)
+		}, bounds)
+	}
+}
+
+// runBatch is the part of the suite for adapters that also implement
+// ports.BatchEnrichmentClient.
+func runBatch(t *testing.T, p Provider, newClient func(*testing.T, http.HandlerFunc) ports.BatchEnrichmentClient, bounds enrichment.Bounds) {
+	t.Helper()
+
+	item := func(index int, summary string) enrichment.BatchItem {
+		return enrichment.BatchItem{Index: index, Output: enrichment.Output{
+			Tags: []string{"t"}, Summary: summary, Concepts: []string{}, Sentiment: "neutral",
+		}}
+	}
+
+	t.Run("batch maps items back by index and splits the usage", func(t *testing.T) {
+		var body map[string]any
+		var raw string
+		content, _ := json.Marshal(enrichment.BatchOutput{Items: []enrichment.BatchItem{
+			item(2, "third"), item(0, "first"), item(1, "second"),
+		}})
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			raw = string(b)
+			p.Respond(w, enrichment.BatchSchemaName, string(content), enrichment.Usage{Model: "test-model", InputTokens: 100, OutputTokens: 31})
+		})
+
+		got, err := c.EnrichBatch(context.Background(), []string{"one", "two", "three"}, "de")
+		if err != nil {
+			t.Fatalf("EnrichBatch: %v", err)
+		}
+		if len(got) != 3 || got[0].Summary != "first" || got[1].Summary != "second" || got[2].Summary != "third" {
+			t.Fatalf("enrichments = %+v, want them in input order", got)
+		}
+		var in, out int64
+		for _, e := range got {
+			if e.Model != "test-model" || e.PromptVersion != enrichment.PromptVersion {
+				t.Errorf("provenance: model %q, prompt version %q", e.Model, e.PromptVersion)
+			}
+			in += e.InputTokens
+			out += e.OutputTokens
+		}
+		if in != 100 || out != 31 {
+			t.Errorf("token usage sums to %d in, %d out, want 100 in, 31 out", in, out)
+		}
+		if !strings.Contains(raw, "You are a reading analyst.") || !strings.Contains(raw, "three") || !strings.Contains(raw, "Write every tag in German") {
+			t.Errorf("request doesn't carry the shared system prompt, the tag language and the texts: %s", raw)
+		}
+		if got := p.MaxTokens(body); got != float64(bounds.BatchMaxTokens(3)) {
+			t.Errorf("token cap = %v, want %d", got, bounds.BatchMaxTokens(3))
+		}
+	})
+
+	t.Run("batch is malformed when an index goes unanswered", func(t *testing.T) {
+		content, _ := json.Marshal(enrichment.BatchOutput{Items: []enrichment.BatchItem{
+			item(0, "first"), item(0, "first again"),
+		}})
+		c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			p.Respond(w, enrichment.BatchSchemaName, string(content), enrichment.Usage{Model: "m", InputTokens: 60, OutputTokens: 20})
+		})
+
+		_, err := c.EnrichBatch(context.Background(), []string{"one", "two"}, "en")
+		if !errors.Is(err, ports.ErrMalformedBatch) {
+			t.Fatalf("err = %v, want ErrMalformedBatch", err)
+		}
+		if billed, ok := errors.AsType[*ports.BilledError](err); !ok || billed.InputTokens != 60 || billed.OutputTokens != 20 {
+			t.Fatalf("err = %v, want a *ports.BilledError carrying the whole call's usage", err)
+		}
+	})
+
+	t.Run("batch is malformed when the model ignored the schema", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			p.Respond(w, enrichment.BatchSchemaName, "I'm afraid I can't do that.", enrichment.Usage{Model: "m"})
+		})
+
+		if _, err := c.EnrichBatch(context.Background(), []string{"one", "two"}, "en"); err == nil {
+			t.Fatal("expected an error when the answer is not schema JSON")
+		}
+	})
+}
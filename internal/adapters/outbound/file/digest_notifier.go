@@ -0,0 +1,36 @@
+// Package file delivers digests to the local filesystem, for running the
+// digest command without a mail server.
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// DigestNotifier writes each digest as a Markdown and an HTML file under
+// dir/<tenantID>/, named for its period and when it was generated.
+type DigestNotifier struct {
+	dir string
+}
+
+var _ ports.Notifier = (*DigestNotifier)(nil)
+
+func NewDigestNotifier(dir string) *DigestNotifier {
+	return &DigestNotifier{dir: dir}
+}
+
+func (n *DigestNotifier) Notify(_ context.Context, digest domain.Digest) error {
+	dir := filepath.Join(n.dir, digest.TenantID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	base := filepath.Join(dir, string(digest.Period)+"-"+digest.GeneratedAt.UTC().Format("20060102T150405Z"))
+	if err := os.WriteFile(base+".md", []byte(digest.Markdown), 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(base+".html", []byte(digest.HTML), 0o644)
+}
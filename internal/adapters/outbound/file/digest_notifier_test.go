@@ -0,0 +1,36 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestDigestNotifier_WritesBothRenderings(t *testing.T) {
+	dir := t.TempDir()
+	digest := domain.Digest{
+		TenantID:    "t-1",
+		Period:      domain.DigestWeekly,
+		GeneratedAt: time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC),
+		Markdown:    "# Weekly digest",
+		HTML:        "<h1>Weekly digest</h1>",
+	}
+
+	if err := NewDigestNotifier(dir).Notify(context.Background(), digest); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"weekly-20260310T093000Z.md":   digest.Markdown,
+		"weekly-20260310T093000Z.html": digest.HTML,
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, "t-1", name))
+		if err != nil || string(got) != want {
+			t.Fatalf("%s = %q, %v; want %q", name, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package llmprovider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BreakerFromEnv reads llm.NewBreaker's settings. Both are optional, and
+// unset leaves them zero so NewBreaker applies its defaults:
+//
+//	LLM_BREAKER_FAILURE_THRESHOLD  consecutive failures before it opens
+//	LLM_BREAKER_COOLDOWN           how long it stays open, e.g. "2m"
+func BreakerFromEnv() (threshold int, coolDown time.Duration, err error) {
+	if v := strings.TrimSpace(os.Getenv("LLM_BREAKER_FAILURE_THRESHOLD")); v != "" {
+		threshold, err = strconv.Atoi(v)
+		if err != nil || threshold < 0 {
+			return 0, 0, fmt.Errorf("invalid LLM_BREAKER_FAILURE_THRESHOLD %q", v)
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("LLM_BREAKER_COOLDOWN")); v != "" {
+		coolDown, err = time.ParseDuration(v)
+		if err != nil || coolDown < 0 {
+			return 0, 0, fmt.Errorf("invalid LLM_BREAKER_COOLDOWN %q", v)
+		}
+	}
+	return threshold, coolDown, nil
+}
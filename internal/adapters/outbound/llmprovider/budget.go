@@ -0,0 +1,73 @@
+package llmprovider
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// BudgetFromEnv reads the per-tenant limits and the configured model's
+// price for budget.NewService. Every variable is optional; an unset limit
+// is no limit, and an unset price makes every call cost $0, which disables
+// the dollar limits in effect:
+//
+//	LLM_BUDGET_DAILY_TOKENS    LLM_BUDGET_MONTHLY_TOKENS
+//	LLM_BUDGET_DAILY_USD       LLM_BUDGET_MONTHLY_USD
+//	LLM_PRICE_INPUT_PER_MTOK   LLM_PRICE_OUTPUT_PER_MTOK  (USD per million tokens)
+//
+// The price belongs to whichever LLM_PROVIDER is configured, so it's set
+// alongside it rather than looked up from a table that would go stale.
+func BudgetFromEnv() (domain.BudgetLimits, domain.ModelPrice, error) {
+	var limits domain.BudgetLimits
+	var price domain.ModelPrice
+	var err error
+
+	read := func(key string, parse func(string) error) {
+		v := strings.TrimSpace(os.Getenv(key))
+		if v == "" || err != nil {
+			return
+		}
+		if perr := parse(v); perr != nil {
+			err = fmt.Errorf("invalid %s %q: %w", key, v, perr)
+		}
+	}
+	tokens := func(dst *int64) func(string) error {
+		return func(v string) error {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err == nil && n < 0 {
+				err = fmt.Errorf("must not be negative")
+			}
+			*dst = n
+			return err
+		}
+	}
+	usd := func(dst *float64) func(string) error {
+		return func(v string) error {
+			f, err := strconv.ParseFloat(v, 64)
+			if err == nil && (f < 0 || math.IsInf(f, 0) || math.IsNaN(f)) {
+				err = fmt.Errorf("must be a non-negative amount")
+			}
+			*dst = f
+			return err
+		}
+	}
+
+	var dailyUSD, monthlyUSD float64
+	read("LLM_BUDGET_DAILY_TOKENS", tokens(&limits.DailyTokens))
+	read("LLM_BUDGET_MONTHLY_TOKENS", tokens(&limits.MonthlyTokens))
+	read("LLM_BUDGET_DAILY_USD", usd(&dailyUSD))
+	read("LLM_BUDGET_MONTHLY_USD", usd(&monthlyUSD))
+	read("LLM_PRICE_INPUT_PER_MTOK", usd(&price.InputPerMTok))
+	read("LLM_PRICE_OUTPUT_PER_MTOK", usd(&price.OutputPerMTok))
+	if err != nil {
+		return domain.BudgetLimits{}, domain.ModelPrice{}, err
+	}
+
+	limits.DailyCostMicros = int64(math.Round(dailyUSD * 1e6))
+	limits.MonthlyCostMicros = int64(math.Round(monthlyUSD * 1e6))
+	return limits, price, nil
+}
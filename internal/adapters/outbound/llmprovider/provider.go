@@ -0,0 +1,79 @@
+// Package llmprovider picks the enrichment adapter the composition roots
+// wire in, from configuration:
+//
+//	LLM_PROVIDER=openai     (default) OPENAI_API_KEY
+//	LLM_PROVIDER=anthropic  ANTHROPIC_API_KEY
+//	LLM_PROVIDER=local      LLM_BASE_URL (default Ollama's), LLM_MODEL,
+//	                        optional LLM_API_KEY
+//
+// It's the one piece of wiring every entrypoint that enriches repeats, so
+// it lives here rather than in four main()s (ADR-006 accepts duplicated
+// construction, not duplicated decisions). Keys resolve through
+// envutil.ResolveSecret, so each may be an ssm: path.
+package llmprovider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/anthropic"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/openai"
+	"github.com/marcogerstmann/insight-processing-platform/internal/envutil"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderLocal     = "local"
+
+	// defaultLocalBaseURL is Ollama's OpenAI-compatible endpoint.
+	defaultLocalBaseURL = "http://localhost:11434/v1"
+)
+
+// FromEnv builds the configured provider's client. It returns (nil, nil)
+// when the chosen provider has no key (or, for local, no model) — callers
+// decide whether that's "skip enrichment" or an error, as they do for any
+// optional secret. An unknown LLM_PROVIDER is always an error: a typo
+// shouldn't quietly switch enrichment off.
+func FromEnv(ctx context.Context, secrets ports.SecretProvider) (ports.EnrichmentClient, error) {
+	provider := strings.TrimSpace(os.Getenv("LLM_PROVIDER"))
+	if provider == "" {
+		provider = ProviderOpenAI
+	}
+
+	switch provider {
+	case ProviderOpenAI:
+		apiKey, err := envutil.ResolveSecret(ctx, "OPENAI_API_KEY", secrets)
+		if err != nil || apiKey == "" {
+			return nil, err
+		}
+		return openai.NewClient(apiKey), nil
+
+	case ProviderAnthropic:
+		apiKey, err := envutil.ResolveSecret(ctx, "ANTHROPIC_API_KEY", secrets)
+		if err != nil || apiKey == "" {
+			return nil, err
+		}
+		return anthropic.NewClient(apiKey), nil
+
+	case ProviderLocal:
+		model := strings.TrimSpace(os.Getenv("LLM_MODEL"))
+		if model == "" {
+			return nil, nil
+		}
+		baseURL := strings.TrimSpace(os.Getenv("LLM_BASE_URL"))
+		if baseURL == "" {
+			baseURL = defaultLocalBaseURL
+		}
+		apiKey, err := envutil.ResolveSecret(ctx, "LLM_API_KEY", secrets)
+		if err != nil {
+			return nil, err
+		}
+		return openai.NewCompatibleClient(baseURL, model, apiKey), nil
+	}
+
+	return nil, fmt.Errorf("unknown LLM_PROVIDER %q (want %s, %s or %s)", provider, ProviderOpenAI, ProviderAnthropic, ProviderLocal)
+}
@@ -0,0 +1,120 @@
+package llmprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/anthropic"
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/openai"
+)
+
+func TestFromEnv(t *testing.T) {
+	tests := map[string]struct {
+		env     map[string]string
+		wantNil bool
+		want    any
+		wantErr bool
+	}{
+		"defaults to openai": {
+			env:  map[string]string{"OPENAI_API_KEY": "k"},
+			want: &openai.Client{},
+		},
+		"openai without a key skips enrichment": {
+			env:     map[string]string{"LLM_PROVIDER": "openai"},
+			wantNil: true,
+		},
+		"anthropic": {
+			env:  map[string]string{"LLM_PROVIDER": "anthropic", "ANTHROPIC_API_KEY": "k"},
+			want: &anthropic.Client{},
+		},
+		"anthropic ignores an OpenAI key": {
+			env:     map[string]string{"LLM_PROVIDER": "anthropic", "OPENAI_API_KEY": "k"},
+			wantNil: true,
+		},
+		"local needs only a model": {
+			env:  map[string]string{"LLM_PROVIDER": "local", "LLM_MODEL": "llama3.2"},
+			want: &openai.Client{},
+		},
+		"local without a model skips enrichment": {
+			env:     map[string]string{"LLM_PROVIDER": "local"},
+			wantNil: true,
+		},
+		"unknown provider is an error": {
+			env:     map[string]string{"LLM_PROVIDER": "openia", "OPENAI_API_KEY": "k"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"LLM_PROVIDER", "OPENAI_API_KEY", "ANTHROPIC_API_KEY", "LLM_MODEL", "LLM_BASE_URL", "LLM_API_KEY"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			got, err := FromEnv(context.Background(), nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if tc.wantNil {
+				if got != nil {
+					t.Fatalf("got %T, want nil", got)
+				}
+				return
+			}
+			switch tc.want.(type) {
+			case *openai.Client:
+				if _, ok := got.(*openai.Client); !ok {
+					t.Fatalf("got %T, want *openai.Client", got)
+				}
+			case *anthropic.Client:
+				if _, ok := got.(*anthropic.Client); !ok {
+					t.Fatalf("got %T, want *anthropic.Client", got)
+				}
+			}
+		})
+	}
+}
+
+func TestBudgetFromEnv(t *testing.T) {
+	t.Setenv("LLM_BUDGET_DAILY_TOKENS", "200000")
+	t.Setenv("LLM_BUDGET_MONTHLY_TOKENS", "")
+	t.Setenv("LLM_BUDGET_DAILY_USD", "")
+	t.Setenv("LLM_BUDGET_MONTHLY_USD", "12.5")
+	t.Setenv("LLM_PRICE_INPUT_PER_MTOK", "0.1")
+	t.Setenv("LLM_PRICE_OUTPUT_PER_MTOK", "0.4")
+
+	limits, price, err := BudgetFromEnv()
+	if err != nil {
+		t.Fatalf("BudgetFromEnv: %v", err)
+	}
+	if limits.DailyTokens != 200000 || limits.MonthlyTokens != 0 || limits.MonthlyCostMicros != 12_500_000 {
+		t.Fatalf("limits = %+v", limits)
+	}
+	if price.InputPerMTok != 0.1 || price.OutputPerMTok != 0.4 {
+		t.Fatalf("price = %+v", price)
+	}
+
+	t.Setenv("LLM_BUDGET_DAILY_TOKENS", "-1")
+	if _, _, err := BudgetFromEnv(); err == nil {
+		t.Fatal("expected a negative limit to be rejected")
+	}
+}
+
+func TestBreakerFromEnv(t *testing.T) {
+	t.Setenv("LLM_BREAKER_FAILURE_THRESHOLD", "3")
+	t.Setenv("LLM_BREAKER_COOLDOWN", "90s")
+
+	threshold, coolDown, err := BreakerFromEnv()
+	if err != nil || threshold != 3 || coolDown != 90*time.Second {
+		t.Fatalf("got %d, %s, %v; want 3, 1m30s", threshold, coolDown, err)
+	}
+
+	t.Setenv("LLM_BREAKER_COOLDOWN", "soon")
+	if _, _, err := BreakerFromEnv(); err == nil {
+		t.Fatal("expected an unparseable cool-down to be rejected")
+	}
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// DuplicateNoopAdapter logs merges and keeps flagged pairs in process
+// memory; nothing outlives the process.
+type DuplicateNoopAdapter struct {
+	mu         sync.Mutex
+	duplicates []domain.Duplicate
+}
+
+var _ ports.DuplicateRepository = (*DuplicateNoopAdapter)(nil)
+
+func NewDuplicateNoopAdapter() *DuplicateNoopAdapter {
+	return &DuplicateNoopAdapter{}
+}
+
+func (a *DuplicateNoopAdapter) AddMergedSource(_ context.Context, tenantID, insightID string, ref domain.SourceRef) error {
+	slog.Info("noop duplicate repo: would merge source",
+		"tenant_id", tenantID,
+		"insight_id", insightID,
+		"merged_source", ref.Source,
+		"merged_insight_id", ref.InsightID,
+	)
+	return nil
+}
+
+func (a *DuplicateNoopAdapter) SaveDuplicate(_ context.Context, duplicate domain.Duplicate) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	slog.Info("noop duplicate repo: flagged duplicate",
+		"tenant_id", duplicate.TenantID,
+		"insight_id", duplicate.InsightID,
+		"duplicate_of_id", duplicate.DuplicateOfID,
+		"similarity", duplicate.Similarity,
+	)
+	a.duplicates = append(a.duplicates, duplicate)
+	return nil
+}
+
+func (a *DuplicateNoopAdapter) ListDuplicates(_ context.Context, tenantID string) ([]domain.Duplicate, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var out []domain.Duplicate
+	for i := len(a.duplicates) - 1; i >= 0; i-- {
+		if a.duplicates[i].TenantID == tenantID {
+			out = append(out, a.duplicates[i])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// EnrichmentCacheMemoryAdapter caches enrichments in process memory, honouring
+// their expiry; everything is lost when the process exits.
+type EnrichmentCacheMemoryAdapter struct {
+	mu      sync.Mutex
+	entries map[string]cachedEnrichment
+	now     func() time.Time
+}
+
+type cachedEnrichment struct {
+	enrichment domain.Enrichment
+	expiresAt  time.Time
+}
+
+var _ ports.EnrichmentCache = (*EnrichmentCacheMemoryAdapter)(nil)
+
+func NewEnrichmentCacheMemoryAdapter() *EnrichmentCacheMemoryAdapter {
+	return &EnrichmentCacheMemoryAdapter{
+		entries: make(map[string]cachedEnrichment),
+		now:     time.Now,
+	}
+}
+
+func (a *EnrichmentCacheMemoryAdapter) GetCachedEnrichment(_ context.Context, tenantID, key string) (domain.Enrichment, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := a.entries[tenantID+"|"+key]
+	if !ok || !a.now().Before(entry.expiresAt) {
+		return domain.Enrichment{}, false, nil
+	}
+	return entry.enrichment, true, nil
+}
+
+func (a *EnrichmentCacheMemoryAdapter) PutCachedEnrichment(_ context.Context, tenantID, key string, enrichment domain.Enrichment, expiresAt time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.entries[tenantID+"|"+key] = cachedEnrichment{enrichment: enrichment, expiresAt: expiresAt}
+	return nil
+}
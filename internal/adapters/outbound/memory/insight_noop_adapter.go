@@ -57,6 +57,11 @@ func (r *InsightNoopAdapter) Update(_ context.Context, insight domain.Insight) e
 	return nil
 }
 
+func (r *InsightNoopAdapter) GetByID(_ context.Context, tenantID, insightID string) (domain.Insight, bool, error) {
+	slog.Info("noop repo get insight", "tenantID", tenantID, "id", insightID)
+	return domain.Insight{}, false, nil
+}
+
 func (r *InsightNoopAdapter) ListByTenantID(_ context.Context, tenantID, tag string) ([]domain.Insight, error) {
 	slog.Info("noop repo list insights", "tenantID", tenantID, "tag", tag)
 	return []domain.Insight{}, nil
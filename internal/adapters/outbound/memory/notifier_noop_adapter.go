@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// NotifierNoopAdapter only logs: the digest itself is already stored and
+// listed by GET /v1/digests.
+type NotifierNoopAdapter struct{}
+
+var _ ports.Notifier = (*NotifierNoopAdapter)(nil)
+
+func NewNotifierNoopAdapter() *NotifierNoopAdapter {
+	return &NotifierNoopAdapter{}
+}
+
+func (a *NotifierNoopAdapter) Notify(_ context.Context, digest domain.Digest) error {
+	slog.Info("noop notifier: would send digest",
+		"tenant_id", digest.TenantID,
+		"period", digest.Period,
+		"generated_at", digest.GeneratedAt,
+	)
+	return nil
+}
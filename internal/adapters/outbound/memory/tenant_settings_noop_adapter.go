@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// TenantSettingsNoopAdapter keeps tenant settings in process memory; every
+// tenant starts on the defaults and is back on them after a restart.
+type TenantSettingsNoopAdapter struct {
+	mu       sync.Mutex
+	byTenant map[string]domain.TenantSettings
+}
+
+var _ ports.TenantSettingsRepository = (*TenantSettingsNoopAdapter)(nil)
+
+func NewTenantSettingsNoopAdapter() *TenantSettingsNoopAdapter {
+	return &TenantSettingsNoopAdapter{byTenant: make(map[string]domain.TenantSettings)}
+}
+
+func (a *TenantSettingsNoopAdapter) GetTenantSettings(_ context.Context, tenantID string) (domain.TenantSettings, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if settings, ok := a.byTenant[tenantID]; ok {
+		return settings, nil
+	}
+	return domain.TenantSettings{TenantID: tenantID}, nil
+}
+
+func (a *TenantSettingsNoopAdapter) SaveTenantSettings(_ context.Context, settings domain.TenantSettings) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.byTenant[settings.TenantID] = settings
+	return nil
+}
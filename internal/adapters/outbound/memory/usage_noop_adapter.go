@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// UsageNoopAdapter keeps LLM usage counters in process memory, so budgets
+// can be exercised locally; everything is lost when the process exits.
+type UsageNoopAdapter struct {
+	mu       sync.Mutex
+	counters map[string]domain.LLMUsage
+}
+
+var _ ports.UsageRepository = (*UsageNoopAdapter)(nil)
+
+func NewUsageNoopAdapter() *UsageNoopAdapter {
+	return &UsageNoopAdapter{
+		counters: make(map[string]domain.LLMUsage),
+	}
+}
+
+func (a *UsageNoopAdapter) AddLLMUsage(_ context.Context, tenantID string, at time.Time, usage domain.LLMUsage) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, key := range usageKeys(tenantID, at) {
+		c := a.counters[key]
+		c.Calls += usage.Calls
+		c.InputTokens += usage.InputTokens
+		c.OutputTokens += usage.OutputTokens
+		c.CostMicros += usage.CostMicros
+		a.counters[key] = c
+	}
+	return nil
+}
+
+func (a *UsageNoopAdapter) GetLLMUsage(_ context.Context, tenantID string, at time.Time) (domain.LLMUsage, domain.LLMUsage, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	keys := usageKeys(tenantID, at)
+	return a.counters[keys[0]], a.counters[keys[1]], nil
+}
+
+func usageKeys(tenantID string, at time.Time) [2]string {
+	return [2]string{
+		tenantID + "|" + domain.UsageDay(at),
+		tenantID + "|" + domain.UsageMonth(at),
+	}
+}
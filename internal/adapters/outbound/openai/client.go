@@ -1,5 +1,6 @@
-// Package openai is the enrichment adapter — one bounded LLM call that
-// turns a highlight into tags.
+// Package openai is the enrichment adapter for OpenAI's Chat Completions
+// API — and for anything else that speaks it, such as a local Ollama or
+// llama.cpp server (NewCompatibleClient).
 //
 // Replaces the Anthropic adapter (IPP-135). The provider changed, not the
 // architecture: this still satisfies ports.EnrichmentClient and nothing
@@ -7,10 +8,11 @@
 // serves the AI service's embeddings, which is the whole point — see
 // docs/adr/018-one-provider-for-model-capabilities.md.
 //
-// ADR-013's bounds are unchanged and deliberately visible here: a timeout
-// per call, capped retries, and a hard output-token ceiling. Enrichment
-// stays optional — the composition roots leave llm.Service nil when no key
-// resolves, so the pipeline runs without it.
+// The prompt, schema and ADR-013's bounds live in package enrichment, shared
+// with every other provider adapter; this package only speaks the wire
+// format. Enrichment stays optional — the composition roots leave
+// llm.Service nil when no provider is configured, so the pipeline runs
+// without it.
 package openai
 
 import (
@@ -18,120 +20,139 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log/slog"
 	"time"
 
 	sdk "github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/shared"
 
+	"github.com/marcogerstmann/insight-processing-platform/internal/adapters/outbound/enrichment"
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
-const (
-	// enrichModel is the cheapest current-generation model that supports
-	// structured outputs. Tagging one highlight is an easy extraction task
-	// and does not need a frontier model; changing that judgement is this
-	// one line.
-	enrichModel = "gpt-5.6-luna"
+// enrichModel is the cheapest current-generation model that supports
+// structured outputs. Tagging one highlight is an easy extraction task and
+// does not need a frontier model; changing that judgement is this one line.
+const enrichModel = "gpt-5.6-luna"
 
-	defaultMaxTokens = 512
-	defaultTimeout   = 30 * time.Second
-	schemaName       = "extract_enrichment"
-)
+var _ ports.BatchEnrichmentClient = (*Client)(nil)
+
+type Client struct {
+	client   sdk.Client
+	provider string
+	model    string
+	bounds   enrichment.Bounds
+}
 
-// enrichSchema mirrors enrichmentInput.
+func NewClient(apiKey string) *Client {
+	return newClient("openai", enrichModel, enrichment.DefaultBounds, option.WithAPIKey(apiKey))
+}
+
+// NewCompatibleClient points the same adapter at an OpenAI-compatible
+// server, typically a local Ollama (http://localhost:11434/v1) or llama.cpp
+// one, serving model. apiKey may be empty: local servers ignore it.
 //
-// Structured outputs replace the forced tool call the Anthropic adapter
-// used. Same guarantee — the model must return exactly this shape — but
-// one response field instead of a tool definition plus a scan of the
-// content blocks for the matching tool_use. `additionalProperties: false`
-// and every property listed in `required` are what `strict` demands.
-var enrichSchema = map[string]any{
-	"type": "object",
-	"properties": map[string]any{
-		"tags": map[string]any{
-			"type":        "array",
-			"items":       map[string]any{"type": "string"},
-			"description": "3-5 tags for the highlight, spanning a range of altitudes rather than 5 synonyms of the same idea. Include at least one broad field tag (e.g. \"psychology\", \"business\") the highlight clearly belongs to, then 2-4 tags that narrow into its specific facets (e.g. \"delayed-gratification\", \"locus-of-control\"). Never a narrow one-off tied to this highlight's exact wording (e.g. \"the-5-second-rule\", \"chapter-3-morning-routine\").",
-		},
-	},
-	"required":             []string{"tags"},
-	"additionalProperties": false,
+// The server must support structured outputs (response_format json_schema);
+// one that ignores it answers in prose, and every call fails to parse.
+func NewCompatibleClient(baseURL, model, apiKey string) *Client {
+	return newClient("local", model, enrichment.DefaultBounds, option.WithBaseURL(baseURL), option.WithAPIKey(apiKey))
 }
 
-const systemPrompt = "You are a tagging specialist. Given a reading highlight, extract 3-5 tags spanning a range of altitudes: start with one broad field it belongs to (e.g. \"psychology\", \"business\"), then add 2-4 tags that narrow into its specific facets. Never produce 5 tags that are all just synonyms of one idea, and never a narrow one-off tied to this highlight's exact wording. Be direct and concise. No preamble, no filler."
+func newClient(provider, model string, bounds enrichment.Bounds, opts ...option.RequestOption) *Client {
+	opts = append(opts, option.WithMaxRetries(bounds.MaxRetries))
+	return &Client{
+		client:   sdk.NewClient(opts...),
+		provider: provider,
+		model:    model,
+		bounds:   bounds,
+	}
+}
 
-type enrichmentInput struct {
-	Tags []string `json:"tags"`
+func (c *Client) Version() string {
+	return enrichment.Version(c.model)
 }
 
-type Client struct {
-	client    sdk.Client
-	model     string
-	maxTokens int64
-	timeout   time.Duration
+// Enrich uses structured outputs (`strict: true`), which replaced the forced
+// tool call the Anthropic adapter used: same guarantee that the model
+// returns exactly enrichment.Schema, but one response field instead of a
+// tool definition plus a scan of the content blocks.
+func (c *Client) Enrich(ctx context.Context, text string, tagLanguage domain.Language) (domain.Enrichment, error) {
+	content, usage, err := c.complete(ctx, enrichment.SystemPromptFor(tagLanguage), text,
+		enrichment.SchemaName, enrichment.Schema, c.bounds.MaxTokens)
+	if err != nil {
+		return domain.Enrichment{}, err
+	}
+
+	var out enrichment.Output
+	if err := json.Unmarshal([]byte(content), &out); err != nil {
+		return domain.Enrichment{}, usage.Billed(fmt.Errorf("unmarshal structured output: %w", err))
+	}
+	return out.ToDomain(usage), nil
 }
 
-func NewClient(apiKey string) *Client {
-	client := sdk.NewClient(
-		option.WithAPIKey(apiKey),
-		option.WithMaxRetries(3),
-	)
-	return &Client{
-		client:    client,
-		model:     enrichModel,
-		maxTokens: defaultMaxTokens,
-		timeout:   defaultTimeout,
+// EnrichBatch asks for every text in one completion, constrained to
+// enrichment.BatchSchema, with the output cap scaled to the batch.
+func (c *Client) EnrichBatch(ctx context.Context, texts []string, tagLanguage domain.Language) ([]domain.Enrichment, error) {
+	user, err := enrichment.BatchUserMessage(texts)
+	if err != nil {
+		return nil, err
+	}
+	content, usage, err := c.complete(ctx, enrichment.BatchSystemPromptFor(tagLanguage), user,
+		enrichment.BatchSchemaName, enrichment.BatchSchema, c.bounds.BatchMaxTokens(len(texts)))
+	if err != nil {
+		return nil, err
+	}
+
+	var out enrichment.BatchOutput
+	if err := json.Unmarshal([]byte(content), &out); err != nil {
+		return nil, usage.Billed(fmt.Errorf("%w: unmarshal structured output: %w", ports.ErrMalformedBatch, err))
 	}
+	enrichments, err := out.ToDomain(len(texts), usage)
+	if err != nil {
+		return nil, usage.Billed(err)
+	}
+	return enrichments, nil
 }
 
-func (c *Client) Enrich(ctx context.Context, text string) (domain.Enrichment, error) {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+// complete makes one structured-output call and returns the answer's raw
+// JSON with what it cost. An answer without content is still billed.
+func (c *Client) complete(ctx context.Context, system, user, schemaName string, schema map[string]any, maxTokens int64) (string, enrichment.Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.bounds.Timeout)
 	defer cancel()
 
 	start := time.Now()
 	completion, err := c.client.Chat.Completions.New(ctx, sdk.ChatCompletionNewParams{
 		Model:               c.model,
-		MaxCompletionTokens: sdk.Int(c.maxTokens),
+		MaxCompletionTokens: sdk.Int(maxTokens),
 		Messages: []sdk.ChatCompletionMessageParamUnion{
-			sdk.SystemMessage(systemPrompt),
-			sdk.UserMessage(text),
+			sdk.SystemMessage(system),
+			sdk.UserMessage(user),
 		},
 		ResponseFormat: sdk.ChatCompletionNewParamsResponseFormatUnion{
 			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
 				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
 					Name:   schemaName,
 					Strict: sdk.Bool(true),
-					Schema: enrichSchema,
+					Schema: schema,
 				},
 			},
 		},
 	})
 	if err != nil {
-		return domain.Enrichment{}, err
+		return "", enrichment.Usage{}, err
 	}
 
-	// Field names are load-bearing across both services — the Python
-	// adapters match them so one Logs Insights query spans the pipeline
-	// (IPP-113). Rename here only in lockstep with services/ai.
-	slog.InfoContext(ctx, "llm enrich complete",
-		"model", completion.Model,
-		"input_tokens", completion.Usage.PromptTokens,
-		"output_tokens", completion.Usage.CompletionTokens,
-		"duration_ms", time.Since(start).Milliseconds(),
-	)
-
-	if len(completion.Choices) == 0 {
-		return domain.Enrichment{}, errors.New("no choices in response")
+	usage := enrichment.Usage{
+		Model:        completion.Model,
+		InputTokens:  completion.Usage.PromptTokens,
+		OutputTokens: completion.Usage.CompletionTokens,
+		Latency:      time.Since(start),
 	}
+	enrichment.LogComplete(ctx, c.provider, usage)
 
-	var input enrichmentInput
-	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &input); err != nil {
-		return domain.Enrichment{}, fmt.Errorf("unmarshal structured output: %w", err)
+	if len(completion.Choices) == 0 {
+		return "", usage, usage.Billed(errors.New("no choices in response"))
 	}
-
-	return domain.Enrichment{
-		Tags: input.Tags,
-	}, nil
+	return completion.Choices[0].Message.Content, usage, nil
 }
@@ -0,0 +1,74 @@
+// Package smtp mails digests through a plain SMTP relay. Locally that's a
+// catch-all stub such as Mailpit (see docs/setup.md); there's no auth or
+// TLS to configure, so it isn't meant for a public relay.
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// DigestNotifier sends each digest as one multipart/alternative message:
+// the Markdown as its plain-text part, the HTML as its rich one.
+type DigestNotifier struct {
+	addr string
+	from string
+	to   []string
+	// send is smtp.SendMail, swapped out in tests.
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+var _ ports.Notifier = (*DigestNotifier)(nil)
+
+func NewDigestNotifier(addr, from string, to []string) *DigestNotifier {
+	return &DigestNotifier{addr: addr, from: from, to: to, send: smtp.SendMail}
+}
+
+func (n *DigestNotifier) Notify(_ context.Context, digest domain.Digest) error {
+	msg, err := n.message(digest)
+	if err != nil {
+		return err
+	}
+	return n.send(n.addr, nil, n.from, n.to, msg)
+}
+
+func (n *DigestNotifier) message(digest domain.Digest) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", digest.Markdown},
+		{"text/html; charset=utf-8", digest.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	title := "Daily"
+	if digest.Period == domain.DigestWeekly {
+		title = "Weekly"
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s digest, %s\r\n", title, digest.GeneratedAt.UTC().Format("2006-01-02"))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,46 @@
+package smtp
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+func TestDigestNotifier_SendsBothRenderings(t *testing.T) {
+	n := NewDigestNotifier("localhost:1025", "digest@ipp.local", []string{"me@example.com"})
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	n.send = func(addr string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		return nil
+	}
+
+	err := n.Notify(context.Background(), domain.Digest{
+		Period:      domain.DigestDaily,
+		GeneratedAt: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+		Markdown:    "# Daily digest",
+		HTML:        "<h1>Daily digest</h1>",
+	})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	if gotAddr != "localhost:1025" || len(gotTo) != 1 || gotTo[0] != "me@example.com" {
+		t.Fatalf("sent to %v via %s", gotTo, gotAddr)
+	}
+	for _, want := range []string{
+		"Subject: Daily digest, 2026-03-10\r\n",
+		"Content-Type: multipart/alternative; boundary=",
+		"Content-Type: text/plain; charset=utf-8\r\n\r\n# Daily digest",
+		"Content-Type: text/html; charset=utf-8\r\n\r\n<h1>Daily digest</h1>",
+	} {
+		if !strings.Contains(gotMsg, want) {
+			t.Fatalf("message missing %q:\n%s", want, gotMsg)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package budget enforces per-tenant LLM limits: tokens and dollars, per
+// UTC day and per month. llm.Service consults it through ports.Budget
+// before every call; GET /v1/usage reads it through Report.
+//
+// TRADE-OFF: Check and Record aren't one transaction, so concurrent
+// workers can each pass Check and overshoot a limit by roughly one call
+// apiece. The limits are a spend ceiling, not a billing system; a
+// conditional write per call would cost more than the overshoot.
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	ports.Budget
+
+	// Report is tenantID's usage for the current day and month, against
+	// the limits.
+	Report(ctx context.Context, tenantID string) (domain.UsageReport, error)
+}
+
+type service struct {
+	usage  ports.UsageRepository
+	limits domain.BudgetLimits
+	price  domain.ModelPrice
+	now    func() time.Time
+}
+
+// NewService prices every call at price. One price suffices: one provider
+// and model serves enrichment at a time (see llmprovider).
+func NewService(usage ports.UsageRepository, limits domain.BudgetLimits, price domain.ModelPrice) Service {
+	return &service{
+		usage:  usage,
+		limits: limits,
+		price:  price,
+		now:    time.Now,
+	}
+}
+
+var _ Service = (*service)(nil)
+
+func (s *service) Check(ctx context.Context, tenantID string) error {
+	if s.limits == (domain.BudgetLimits{}) {
+		return nil
+	}
+	day, month, err := s.usage.GetLLMUsage(ctx, tenantID, s.now())
+	if err != nil {
+		return fmt.Errorf("read usage: %w", err)
+	}
+	if s.limits.Exhausted(day, month) {
+		return ports.ErrBudgetExhausted
+	}
+	return nil
+}
+
+func (s *service) Record(ctx context.Context, tenantID string, inputTokens, outputTokens int64) error {
+	err := s.usage.AddLLMUsage(ctx, tenantID, s.now(), domain.LLMUsage{
+		Calls:        1,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		CostMicros:   s.price.CostMicros(inputTokens, outputTokens),
+	})
+	if err != nil {
+		return fmt.Errorf("record usage: %w", err)
+	}
+	return nil
+}
+
+func (s *service) Report(ctx context.Context, tenantID string) (domain.UsageReport, error) {
+	now := s.now()
+	day, month, err := s.usage.GetLLMUsage(ctx, tenantID, now)
+	if err != nil {
+		return domain.UsageReport{}, err
+	}
+	return domain.UsageReport{
+		TenantID:   tenantID,
+		Day:        domain.UsageDay(now),
+		DayUsage:   day,
+		Month:      domain.UsageMonth(now),
+		MonthUsage: month,
+		Limits:     s.limits,
+		Exhausted:  s.limits.Exhausted(day, month),
+	}, nil
+}
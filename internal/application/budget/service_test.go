@@ -0,0 +1,83 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var testNow = time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+
+// fakeUsage keeps one counter per tenant; every test runs on a single day.
+type fakeUsage struct {
+	byTenant map[string]domain.LLMUsage
+}
+
+func (f *fakeUsage) AddLLMUsage(_ context.Context, tenantID string, _ time.Time, usage domain.LLMUsage) error {
+	c := f.byTenant[tenantID]
+	c.Calls += usage.Calls
+	c.InputTokens += usage.InputTokens
+	c.OutputTokens += usage.OutputTokens
+	c.CostMicros += usage.CostMicros
+	f.byTenant[tenantID] = c
+	return nil
+}
+
+func (f *fakeUsage) GetLLMUsage(_ context.Context, tenantID string, _ time.Time) (domain.LLMUsage, domain.LLMUsage, error) {
+	return f.byTenant[tenantID], f.byTenant[tenantID], nil
+}
+
+func newTestService(limits domain.BudgetLimits) (*service, *fakeUsage) {
+	usage := &fakeUsage{byTenant: make(map[string]domain.LLMUsage)}
+	s := NewService(usage, limits, domain.ModelPrice{InputPerMTok: 1, OutputPerMTok: 4}).(*service)
+	s.now = func() time.Time { return testNow }
+	return s, usage
+}
+
+func TestService_Record_PricesTheCall(t *testing.T) {
+	svc, usage := newTestService(domain.BudgetLimits{})
+
+	if err := svc.Record(context.Background(), "t-1", 1000, 500); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	day, month, _ := usage.GetLLMUsage(context.Background(), "t-1", testNow)
+	want := domain.LLMUsage{Calls: 1, InputTokens: 1000, OutputTokens: 500, CostMicros: 3000}
+	if day != want || month != want {
+		t.Fatalf("day = %+v, month = %+v, want %+v", day, month, want)
+	}
+}
+
+func TestService_Check_DailyTokensSpent_ReturnsErrBudgetExhausted(t *testing.T) {
+	svc, _ := newTestService(domain.BudgetLimits{DailyTokens: 1500})
+
+	if err := svc.Check(context.Background(), "t-1"); err != nil {
+		t.Fatalf("Check before any call: %v", err)
+	}
+	_ = svc.Record(context.Background(), "t-1", 1000, 500)
+
+	if err := svc.Check(context.Background(), "t-1"); !errors.Is(err, ports.ErrBudgetExhausted) {
+		t.Fatalf("err = %v, want ErrBudgetExhausted", err)
+	}
+	if err := svc.Check(context.Background(), "t-2"); err != nil {
+		t.Fatalf("another tenant's budget was spent: %v", err)
+	}
+}
+
+func TestService_Report_ShowsUsageAgainstLimits(t *testing.T) {
+	limits := domain.BudgetLimits{MonthlyCostMicros: 2000}
+	svc, _ := newTestService(limits)
+	_ = svc.Record(context.Background(), "t-1", 1000, 500)
+
+	got, err := svc.Report(context.Background(), "t-1")
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if got.Day != "2026-03-14" || got.Month != "2026-03" || got.MonthUsage.CostMicros != 3000 || got.Limits != limits || !got.Exhausted {
+		t.Fatalf("report = %+v", got)
+	}
+}
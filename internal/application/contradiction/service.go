@@ -0,0 +1,137 @@
+// Package contradiction surfaces the contradicts edges in a tenant's graph
+// (GET /v1/contradictions) and lets the user mark each pair resolved with
+// a note once they've thought it through.
+package contradiction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// List returns tenantID's contradictions, grouped by shared tag (see
+	// domain.GroupContradictions), resolved ones included and marked.
+	List(ctx context.Context, tenantID string) ([]domain.ContradictionGroup, error)
+
+	// Resolve marks the contradiction between the two insights resolved,
+	// replacing an earlier note. ports.ErrContradictionNotFound if there's
+	// no contradicts edge between them.
+	Resolve(ctx context.Context, tenantID, insightID, otherInsightID, note string) (domain.ContradictionResolution, error)
+
+	// Reopen clears the pair's resolution. ports.ErrContradictionNotFound
+	// if there's no contradicts edge between them.
+	Reopen(ctx context.Context, tenantID, insightID, otherInsightID string) error
+}
+
+type service struct {
+	insights      ports.InsightRepository
+	relationships ports.RelationshipRepository
+	resolutions   ports.ContradictionResolutionRepository
+	now           func() time.Time
+}
+
+func NewService(insights ports.InsightRepository, relationships ports.RelationshipRepository, resolutions ports.ContradictionResolutionRepository) Service {
+	return &service{insights: insights, relationships: relationships, resolutions: resolutions, now: time.Now}
+}
+
+var _ Service = (*service)(nil)
+
+// List reads the whole graph at once, as graph.Service.Export does. A
+// resolution whose edge has since been removed or retyped is simply never
+// matched.
+func (s *service) List(ctx context.Context, tenantID string) ([]domain.ContradictionGroup, error) {
+	rels, err := s.relationships.ListRelationships(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list relationships: %w", err)
+	}
+	var contradicts []domain.Relationship
+	for _, rel := range rels {
+		if rel.Type == domain.RelationContradicts {
+			contradicts = append(contradicts, rel)
+		}
+	}
+	if len(contradicts) == 0 {
+		return []domain.ContradictionGroup{}, nil
+	}
+
+	insights, err := s.insights.ListByTenantID(ctx, tenantID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list insights: %w", err)
+	}
+	byID := make(map[string]domain.Insight, len(insights))
+	for _, insight := range insights {
+		byID[insight.ID] = insight
+	}
+	resolutions, err := s.resolutions.ListContradictionResolutions(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list contradiction resolutions: %w", err)
+	}
+	resolved := make(map[[2]string]domain.ContradictionResolution, len(resolutions))
+	for _, r := range resolutions {
+		a, b := domain.ContradictionPair(r.InsightID, r.OtherInsightID)
+		resolved[[2]string{a, b}] = r
+	}
+
+	contradictions := make([]domain.Contradiction, 0, len(contradicts))
+	for _, rel := range contradicts {
+		from, to := byID[rel.FromInsightID], byID[rel.ToInsightID]
+		c := domain.Contradiction{
+			Relationship: rel,
+			FromText:     from.Text,
+			ToText:       to.Text,
+			SharedTags:   domain.SharedTags(from, to),
+		}
+		a, b := domain.ContradictionPair(rel.FromInsightID, rel.ToInsightID)
+		if r, ok := resolved[[2]string{a, b}]; ok {
+			c.Resolution = &r
+		}
+		contradictions = append(contradictions, c)
+	}
+	return domain.GroupContradictions(contradictions), nil
+}
+
+func (s *service) Resolve(ctx context.Context, tenantID, insightID, otherInsightID, note string) (domain.ContradictionResolution, error) {
+	a, b := domain.ContradictionPair(insightID, otherInsightID)
+	resolution := domain.ContradictionResolution{
+		TenantID:       tenantID,
+		InsightID:      a,
+		OtherInsightID: b,
+		Note:           note,
+		ResolvedAt:     s.now().UTC(),
+	}
+	if err := resolution.Validate(); err != nil {
+		return domain.ContradictionResolution{}, err
+	}
+	if err := s.requireContradiction(ctx, tenantID, insightID, otherInsightID); err != nil {
+		return domain.ContradictionResolution{}, err
+	}
+	if err := s.resolutions.SaveContradictionResolution(ctx, resolution); err != nil {
+		return domain.ContradictionResolution{}, fmt.Errorf("save contradiction resolution: %w", err)
+	}
+	return resolution, nil
+}
+
+func (s *service) Reopen(ctx context.Context, tenantID, insightID, otherInsightID string) error {
+	if err := s.requireContradiction(ctx, tenantID, insightID, otherInsightID); err != nil {
+		return err
+	}
+	if err := s.resolutions.DeleteContradictionResolution(ctx, tenantID, insightID, otherInsightID); err != nil {
+		return fmt.Errorf("delete contradiction resolution: %w", err)
+	}
+	return nil
+}
+
+func (s *service) requireContradiction(ctx context.Context, tenantID, insightID, otherInsightID string) error {
+	rel, found, err := s.relationships.GetRelationship(ctx, tenantID, insightID, otherInsightID)
+	if err != nil {
+		return fmt.Errorf("get relationship: %w", err)
+	}
+	if !found || rel.Type != domain.RelationContradicts {
+		return ports.ErrContradictionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package contradiction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeInsights struct {
+	ports.InsightRepository
+	all []domain.Insight
+}
+
+func (f fakeInsights) ListByTenantID(context.Context, string, string) ([]domain.Insight, error) {
+	return f.all, nil
+}
+
+type fakeRelationships struct {
+	ports.RelationshipRepository
+	all []domain.Relationship
+}
+
+func (f fakeRelationships) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return f.all, nil
+}
+
+func (f fakeRelationships) GetRelationship(_ context.Context, _, insightID, otherInsightID string) (domain.Relationship, bool, error) {
+	for _, rel := range f.all {
+		if (rel.FromInsightID == insightID && rel.ToInsightID == otherInsightID) ||
+			(rel.FromInsightID == otherInsightID && rel.ToInsightID == insightID) {
+			return rel, true, nil
+		}
+	}
+	return domain.Relationship{}, false, nil
+}
+
+type fakeResolutions struct {
+	saved map[[2]string]domain.ContradictionResolution
+}
+
+func (f *fakeResolutions) SaveContradictionResolution(_ context.Context, r domain.ContradictionResolution) error {
+	f.saved[[2]string{r.InsightID, r.OtherInsightID}] = r
+	return nil
+}
+
+func (f *fakeResolutions) DeleteContradictionResolution(_ context.Context, _, insightID, otherInsightID string) error {
+	a, b := domain.ContradictionPair(insightID, otherInsightID)
+	delete(f.saved, [2]string{a, b})
+	return nil
+}
+
+func (f *fakeResolutions) ListContradictionResolutions(context.Context, string) ([]domain.ContradictionResolution, error) {
+	var out []domain.ContradictionResolution
+	for _, r := range f.saved {
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func tagged(id string, tags ...string) domain.Insight {
+	return domain.Insight{ID: id, Text: "text of " + id, Enrichment: &domain.Enrichment{Tags: tags}}
+}
+
+func newTestService() (*service, *fakeResolutions) {
+	insights := fakeInsights{all: []domain.Insight{tagged("a", "focus"), tagged("b", "focus"), tagged("c", "sleep")}}
+	rels := fakeRelationships{all: []domain.Relationship{
+		{FromInsightID: "b", ToInsightID: "a", Type: domain.RelationContradicts, Confidence: 0.8, Rationale: "opposite advice"},
+		{FromInsightID: "a", ToInsightID: "c", Type: domain.RelationContradicts, Confidence: 0.6},
+		{FromInsightID: "b", ToInsightID: "c", Type: domain.RelationSupports, Confidence: 0.9},
+	}}
+	resolutions := &fakeResolutions{saved: map[[2]string]domain.ContradictionResolution{}}
+	s := NewService(insights, rels, resolutions).(*service)
+	s.now = func() time.Time { return now }
+	return s, resolutions
+}
+
+func TestService_List_GroupsContradictsEdgesWithTextsAndResolutions(t *testing.T) {
+	s, _ := newTestService()
+	if _, err := s.Resolve(context.Background(), "t-1", "b", "a", "different contexts"); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	groups, err := s.List(context.Background(), "t-1")
+
+	if err != nil || len(groups) != 2 || groups[0].Tag != "focus" || groups[1].Tag != "" {
+		t.Fatalf("groups = %+v, %v; want focus then untagged", groups, err)
+	}
+	focus := groups[0].Contradictions[0]
+	if focus.FromText != "text of b" || focus.ToText != "text of a" || focus.Relationship.Rationale != "opposite advice" {
+		t.Fatalf("focus contradiction = %+v, want both texts and the rationale", focus)
+	}
+	if focus.Resolution == nil || focus.Resolution.Note != "different contexts" {
+		t.Fatalf("resolution = %+v, want the note", focus.Resolution)
+	}
+	if untagged := groups[1].Contradictions[0]; untagged.Resolution != nil || untagged.Relationship.ToInsightID != "c" {
+		t.Fatalf("untagged = %+v, want the open a-c contradiction", untagged)
+	}
+}
+
+func TestService_List_NoContradictions(t *testing.T) {
+	s := NewService(fakeInsights{}, fakeRelationships{}, &fakeResolutions{})
+
+	groups, err := s.List(context.Background(), "t-1")
+
+	if err != nil || groups == nil || len(groups) != 0 {
+		t.Fatalf("groups = %#v, %v; want an empty list", groups, err)
+	}
+}
+
+func TestService_Resolve_KeysThePairInOrder(t *testing.T) {
+	s, resolutions := newTestService()
+
+	got, err := s.Resolve(context.Background(), "t-1", "c", "a", "")
+
+	if err != nil || got.InsightID != "a" || got.OtherInsightID != "c" || !got.ResolvedAt.Equal(now) {
+		t.Fatalf("got %+v, %v; want a-c resolved now", got, err)
+	}
+	if _, ok := resolutions.saved[[2]string{"a", "c"}]; !ok {
+		t.Fatalf("saved = %v, want a-c", resolutions.saved)
+	}
+}
+
+func TestService_Resolve_NotAContradiction(t *testing.T) {
+	s, resolutions := newTestService()
+
+	for _, pair := range [][2]string{{"b", "c"}, {"a", "x"}} {
+		if _, err := s.Resolve(context.Background(), "t-1", pair[0], pair[1], ""); !errors.Is(err, ports.ErrContradictionNotFound) {
+			t.Fatalf("%v: err = %v, want ErrContradictionNotFound", pair, err)
+		}
+		if err := s.Reopen(context.Background(), "t-1", pair[0], pair[1]); !errors.Is(err, ports.ErrContradictionNotFound) {
+			t.Fatalf("%v: reopen err = %v, want ErrContradictionNotFound", pair, err)
+		}
+	}
+	if len(resolutions.saved) != 0 {
+		t.Fatalf("saved = %v, want nothing", resolutions.saved)
+	}
+}
+
+func TestService_Reopen_ClearsTheResolution(t *testing.T) {
+	s, resolutions := newTestService()
+	_, _ = s.Resolve(context.Background(), "t-1", "a", "b", "done")
+
+	if err := s.Reopen(context.Background(), "t-1", "b", "a"); err != nil || len(resolutions.saved) != 0 {
+		t.Fatalf("err = %v saved = %v, want the resolution gone", err, resolutions.saved)
+	}
+}
@@ -0,0 +1,55 @@
+package digest
+
+import (
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+var (
+	//go:embed templates/digest.md.tmpl
+	markdownSource string
+	//go:embed templates/digest.html.tmpl
+	htmlSource string
+)
+
+var templateFuncs = map[string]any{
+	"periodTitle": func(p domain.DigestPeriod) string {
+		if p == domain.DigestWeekly {
+			return "Weekly"
+		}
+		return "Daily"
+	},
+	"date":   func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"score":  func(f float64) string { return fmt.Sprintf("%.2f", f) },
+	"signed": func(f float64) string { return fmt.Sprintf("%+.2f", f) },
+	"join":   strings.Join,
+	// more is how many of total a capped list left out.
+	"more": func(total, shown int) int { return total - shown },
+}
+
+var (
+	markdownTemplate = texttemplate.Must(texttemplate.New("digest.md").Funcs(templateFuncs).Parse(markdownSource))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(templateFuncs).Parse(htmlSource))
+)
+
+// render fills in digest's Markdown and HTML from its lists. html/template
+// escapes the quoted insight text; the Markdown is left as written, since
+// highlights are prose a reader would paste there anyway.
+func render(digest *domain.Digest) error {
+	var md, html strings.Builder
+	if err := markdownTemplate.Execute(&md, digest); err != nil {
+		return fmt.Errorf("markdown: %w", err)
+	}
+	if err := htmlTemplate.Execute(&html, digest); err != nil {
+		return fmt.Errorf("html: %w", err)
+	}
+	digest.Markdown = md.String()
+	digest.HTML = html.String()
+	return nil
+}
@@ -0,0 +1,243 @@
+// Package digest sums up what changed in a tenant since its last digest:
+// new insights and relationships, the tags whose relevance moved most, and
+// the weekly plans that moved. The digest Lambda generates one on each
+// daily and weekly schedule, stores it, and hands it to a ports.Notifier;
+// GET /v1/digests lists them.
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// DigestRetention is how long a digest is kept, the same year
+// taghistory.SnapshotRetention keeps snapshots.
+const DigestRetention = 366 * 24 * time.Hour
+
+type Service interface {
+	// Generate assembles, renders and stores tenantID's period digest,
+	// covering the span since the previous one that was sent, then
+	// notifies and marks it Notified. A failed notification is returned
+	// and the digest stays stored unsent, so the next run covers its span
+	// again rather than only what came after it.
+	Generate(ctx context.Context, tenantID string, period domain.DigestPeriod) (domain.Digest, error)
+
+	// List returns up to limit of tenantID's digests, newest first.
+	List(ctx context.Context, tenantID string, limit int) ([]domain.Digest, error)
+}
+
+type service struct {
+	insights      ports.InsightRepository
+	relationships ports.RelationshipRepository
+	history       ports.TagHistoryRepository
+	plans         ports.WeeklyPlanRepository
+	digests       ports.DigestRepository
+	notifier      ports.Notifier
+	now           func() time.Time
+}
+
+func NewService(
+	insights ports.InsightRepository,
+	relationships ports.RelationshipRepository,
+	history ports.TagHistoryRepository,
+	plans ports.WeeklyPlanRepository,
+	digests ports.DigestRepository,
+	notifier ports.Notifier,
+) Service {
+	return &service{
+		insights:      insights,
+		relationships: relationships,
+		history:       history,
+		plans:         plans,
+		digests:       digests,
+		notifier:      notifier,
+		now:           time.Now,
+	}
+}
+
+var _ Service = (*service)(nil)
+
+// Generate truncates GeneratedAt to the second, the precision digests are
+// keyed at, as taghistory.Service.Snapshot does for snapshots.
+func (s *service) Generate(ctx context.Context, tenantID string, period domain.DigestPeriod) (domain.Digest, error) {
+	now := s.now().UTC().Truncate(time.Second)
+	since, err := s.since(ctx, tenantID, period, now)
+	if err != nil {
+		return domain.Digest{}, err
+	}
+	digest := domain.Digest{TenantID: tenantID, Period: period, Since: since, GeneratedAt: now}
+
+	if err := s.addInsights(ctx, &digest); err != nil {
+		return domain.Digest{}, err
+	}
+	if err := s.addRelationships(ctx, &digest); err != nil {
+		return domain.Digest{}, err
+	}
+	if err := s.addTagMoves(ctx, &digest); err != nil {
+		return domain.Digest{}, err
+	}
+	if err := s.addPlans(ctx, &digest); err != nil {
+		return domain.Digest{}, err
+	}
+	if err := render(&digest); err != nil {
+		return domain.Digest{}, fmt.Errorf("render digest: %w", err)
+	}
+
+	if err := s.digests.SaveDigest(ctx, digest, now.Add(DigestRetention)); err != nil {
+		return domain.Digest{}, fmt.Errorf("save digest: %w", err)
+	}
+	if err := s.notifier.Notify(ctx, digest); err != nil {
+		return digest, fmt.Errorf("notify: %w", err)
+	}
+	digest.Notified = true
+	if err := s.digests.SaveDigest(ctx, digest, now.Add(DigestRetention)); err != nil {
+		return digest, fmt.Errorf("mark digest notified: %w", err)
+	}
+	return digest, nil
+}
+
+func (s *service) List(ctx context.Context, tenantID string, limit int) ([]domain.Digest, error) {
+	digests, err := s.digests.ListDigests(ctx, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list digests: %w", err)
+	}
+	return digests, nil
+}
+
+// since is when the latest notified digest of period was generated, or
+// one period.Window before now if none was.
+func (s *service) since(ctx context.Context, tenantID string, period domain.DigestPeriod, now time.Time) (time.Time, error) {
+	at, ok, err := s.digests.LastNotifiedAt(ctx, tenantID, period)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("find last notified digest: %w", err)
+	}
+	if !ok {
+		return now.Add(-period.Window()), nil
+	}
+	return at, nil
+}
+
+// addInsights lists the most recently highlighted of the new insights;
+// ingestion order isn't on domain.Insight.
+func (s *service) addInsights(ctx context.Context, digest *domain.Digest) error {
+	insights, err := s.digests.ListInsightsIngestedSince(ctx, digest.TenantID, digest.Since)
+	if err != nil {
+		return fmt.Errorf("list new insights: %w", err)
+	}
+	sort.Slice(insights, func(i, j int) bool {
+		if !insights[i].HighlightedAt.Equal(insights[j].HighlightedAt) {
+			return insights[i].HighlightedAt.After(insights[j].HighlightedAt)
+		}
+		return insights[i].ID < insights[j].ID
+	})
+
+	digest.InsightCount = len(insights)
+	digest.Insights = make([]domain.DigestInsight, 0, min(len(insights), domain.MaxDigestEntries))
+	for _, insight := range insights[:min(len(insights), domain.MaxDigestEntries)] {
+		digest.Insights = append(digest.Insights, domain.NewDigestInsight(insight))
+	}
+	return nil
+}
+
+// addRelationships quotes both ends of the latest new edges, reading each
+// insight once however many of them it's on.
+func (s *service) addRelationships(ctx context.Context, digest *domain.Digest) error {
+	rels, err := s.relationships.ListRelationships(ctx, digest.TenantID)
+	if err != nil {
+		return fmt.Errorf("list relationships: %w", err)
+	}
+	var fresh []domain.Relationship
+	for _, rel := range rels {
+		if rel.DiscoveredAt.After(digest.Since) {
+			fresh = append(fresh, rel)
+		}
+	}
+	sort.Slice(fresh, func(i, j int) bool {
+		if !fresh[i].DiscoveredAt.Equal(fresh[j].DiscoveredAt) {
+			return fresh[i].DiscoveredAt.After(fresh[j].DiscoveredAt)
+		}
+		return fresh[i].FromInsightID+fresh[i].ToInsightID < fresh[j].FromInsightID+fresh[j].ToInsightID
+	})
+
+	excerpts := map[string]string{}
+	excerpt := func(insightID string) (string, error) {
+		if e, ok := excerpts[insightID]; ok {
+			return e, nil
+		}
+		insight, _, err := s.insights.GetByID(ctx, digest.TenantID, insightID)
+		if err != nil {
+			return "", fmt.Errorf("get insight %s: %w", insightID, err)
+		}
+		excerpts[insightID] = domain.DigestExcerpt(insight.Text)
+		return excerpts[insightID], nil
+	}
+
+	digest.RelationshipCount = len(fresh)
+	digest.Relationships = make([]domain.DigestRelationship, 0, min(len(fresh), domain.MaxDigestEntries))
+	for _, rel := range fresh[:min(len(fresh), domain.MaxDigestEntries)] {
+		from, err := excerpt(rel.FromInsightID)
+		if err != nil {
+			return err
+		}
+		to, err := excerpt(rel.ToInsightID)
+		if err != nil {
+			return err
+		}
+		digest.Relationships = append(digest.Relationships, domain.DigestRelationship{
+			FromInsightID: rel.FromInsightID,
+			FromExcerpt:   from,
+			ToInsightID:   rel.ToInsightID,
+			ToExcerpt:     to,
+			Type:          rel.Type,
+			Confidence:    rel.Confidence,
+		})
+	}
+	return nil
+}
+
+// addTagMoves measures from the snapshot nearest the start of the span
+// (domain.DigestBaseline), looking back up to a period before it in case
+// the snapshot schedule runs less often than the digest's. No snapshot, no
+// moves.
+func (s *service) addTagMoves(ctx context.Context, digest *domain.Digest) error {
+	snapshots, err := s.history.ListTagSnapshots(ctx, digest.TenantID, digest.Since.Add(-digest.Period.Window()))
+	if err != nil {
+		return fmt.Errorf("list tag snapshots: %w", err)
+	}
+	digest.TagMoves = []domain.TagMove{}
+	baseline, found := domain.DigestBaseline(snapshots, digest.Since)
+	if !found {
+		return nil
+	}
+
+	tags, err := s.insights.ListTags(ctx, digest.TenantID)
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+	digest.TagMoves = domain.TopTagMoves(tags, baseline, domain.MaxDigestTagMoves)
+	return nil
+}
+
+// addPlans lists the plans requested, resolved or worked on in the span,
+// as domain.NewDigestPlan judges it.
+func (s *service) addPlans(ctx context.Context, digest *domain.Digest) error {
+	plans, err := s.plans.ListPlansByTenantID(ctx, digest.TenantID)
+	if err != nil {
+		return fmt.Errorf("list weekly plans: %w", err)
+	}
+	digest.Plans = []domain.DigestPlan{}
+	for _, plan := range plans {
+		if len(digest.Plans) == domain.MaxDigestEntries {
+			break
+		}
+		if d, ok := domain.NewDigestPlan(plan, digest.Since); ok {
+			digest.Plans = append(digest.Plans, d)
+		}
+	}
+	return nil
+}
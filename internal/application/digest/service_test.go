@@ -0,0 +1,248 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+var now = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+type fakeInsights struct {
+	ports.InsightRepository
+	byID map[string]domain.Insight
+	tags []domain.TagSummary
+}
+
+func (f fakeInsights) GetByID(_ context.Context, _, insightID string) (domain.Insight, bool, error) {
+	insight, ok := f.byID[insightID]
+	return insight, ok, nil
+}
+
+func (f fakeInsights) ListTags(context.Context, string) ([]domain.TagSummary, error) {
+	return f.tags, nil
+}
+
+type fakeRelationships struct {
+	ports.RelationshipRepository
+	all []domain.Relationship
+}
+
+func (f fakeRelationships) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return f.all, nil
+}
+
+type fakeHistory struct {
+	ports.TagHistoryRepository
+	snapshots []domain.TagSnapshot
+	gotSince  time.Time
+}
+
+func (f *fakeHistory) ListTagSnapshots(_ context.Context, _ string, since time.Time) ([]domain.TagSnapshot, error) {
+	f.gotSince = since
+	return f.snapshots, nil
+}
+
+type fakePlans struct {
+	ports.WeeklyPlanRepository
+	all []domain.WeeklyPlan
+}
+
+func (f fakePlans) ListPlansByTenantID(context.Context, string) ([]domain.WeeklyPlan, error) {
+	return f.all, nil
+}
+
+type fakeDigests struct {
+	ingested []domain.Insight
+	gotSince time.Time
+	saved    []domain.Digest
+}
+
+func (f *fakeDigests) ListInsightsIngestedSince(_ context.Context, _ string, since time.Time) ([]domain.Insight, error) {
+	f.gotSince = since
+	return f.ingested, nil
+}
+
+// SaveDigest overwrites a digest of the same GeneratedAt and Period, as
+// the adapter's PutItem on the same key does.
+func (f *fakeDigests) SaveDigest(_ context.Context, digest domain.Digest, _ time.Time) error {
+	for i, d := range f.saved {
+		if d.GeneratedAt.Equal(digest.GeneratedAt) && d.Period == digest.Period {
+			f.saved[i] = digest
+			return nil
+		}
+	}
+	f.saved = append([]domain.Digest{digest}, f.saved...)
+	return nil
+}
+
+func (f *fakeDigests) ListDigests(_ context.Context, _ string, limit int) ([]domain.Digest, error) {
+	return f.saved[:min(len(f.saved), limit)], nil
+}
+
+func (f *fakeDigests) LastNotifiedAt(_ context.Context, _ string, period domain.DigestPeriod) (time.Time, bool, error) {
+	for _, d := range f.saved {
+		if d.Period == period && d.Notified {
+			return d.GeneratedAt, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+type fakeNotifier struct {
+	err      error
+	notified []domain.Digest
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, digest domain.Digest) error {
+	f.notified = append(f.notified, digest)
+	return f.err
+}
+
+func newTestService(digests *fakeDigests, history *fakeHistory, notifier *fakeNotifier) *service {
+	insights := fakeInsights{
+		byID: map[string]domain.Insight{
+			"a": {ID: "a", Text: "Deep work needs <long> blocks"},
+			"b": {ID: "b", Text: "Sleep is the best meditation"},
+		},
+		tags: []domain.TagSummary{{Tag: "focus", Score: 0.8}, {Tag: "sleep", Score: 0.3}},
+	}
+	rels := fakeRelationships{all: []domain.Relationship{
+		{FromInsightID: "a", ToInsightID: "b", Type: domain.RelationSupports, Confidence: 0.9, DiscoveredAt: now.Add(-time.Hour)},
+		{FromInsightID: "b", ToInsightID: "a", Type: domain.RelationExtends, Confidence: 0.7, DiscoveredAt: now.AddDate(0, 0, -3)},
+	}}
+	plans := fakePlans{all: []domain.WeeklyPlan{
+		{ID: "p-new", Tag: "focus", FocusSentence: "Protect mornings", Status: domain.PlanStatusReady, CreatedAt: now.Add(-2 * time.Hour), ResolvedAt: now.Add(-2 * time.Hour).Add(time.Minute), Actions: []domain.Action{{Title: "Block 9-11"}}},
+		{ID: "p-worked", Tag: "focus", FocusSentence: "Single-task", Status: domain.PlanStatusReady, CreatedAt: now.AddDate(0, 0, -5), ResolvedAt: now.AddDate(0, 0, -5), Actions: []domain.Action{
+			{Title: "Close the inbox", Status: domain.ActionDone, StartedAt: now.Add(-3 * time.Hour), CompletedAt: now.Add(-time.Hour)},
+			{Title: "One tab", Status: domain.ActionInProgress, StartedAt: now.AddDate(0, 0, -4)},
+		}},
+		{ID: "p-old", Tag: "sleep", FocusSentence: "Earlier nights", Status: domain.PlanStatusReady, CreatedAt: now.AddDate(0, 0, -5), ResolvedAt: now.AddDate(0, 0, -5)},
+	}}
+	svc := NewService(insights, rels, history, plans, digests, notifier).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestGenerate_FirstDigestCoversOneWindow(t *testing.T) {
+	ctx := context.Background()
+	digests := &fakeDigests{ingested: []domain.Insight{
+		{ID: "a", Source: "readwise", Text: "Deep work", HighlightedAt: now.AddDate(0, 0, -30), Enrichment: &domain.Enrichment{Tags: []string{"focus"}}},
+		{ID: "b", Source: "raindrop", Text: "Sleep", HighlightedAt: now.AddDate(0, 0, -1)},
+	}}
+	history := &fakeHistory{snapshots: []domain.TagSnapshot{{
+		TakenAt: now.AddDate(0, 0, -1).Add(-time.Hour),
+		Tags:    []domain.TagSummary{{Tag: "focus", Score: 0.5}, {Tag: "sleep", Score: 0.3}},
+	}}}
+	notifier := &fakeNotifier{}
+	svc := newTestService(digests, history, notifier)
+
+	digest, err := svc.Generate(ctx, "t-1", domain.DigestDaily)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if want := now.AddDate(0, 0, -1); !digest.Since.Equal(want) || !digests.gotSince.Equal(want) {
+		t.Fatalf("since = %v (asked %v), want %v", digest.Since, digests.gotSince, want)
+	}
+	if digest.InsightCount != 2 || digest.Insights[0].InsightID != "b" || digest.Insights[1].Tags[0] != "focus" {
+		t.Fatalf("insights = %+v, want both, most recently highlighted first", digest.Insights)
+	}
+	if digest.RelationshipCount != 1 || digest.Relationships[0].FromExcerpt != "Deep work needs <long> blocks" || digest.Relationships[0].ToExcerpt != "Sleep is the best meditation" {
+		t.Fatalf("relationships = %+v, want the edge discovered today, both ends quoted", digest.Relationships)
+	}
+	if len(digest.TagMoves) != 1 || digest.TagMoves[0].Tag != "focus" || digest.TagMoves[0].From != 0.5 {
+		t.Fatalf("tag moves = %+v, want focus up from 0.5, sleep unmoved", digest.TagMoves)
+	}
+	wantPlans := []domain.DigestPlan{
+		{PlanID: "p-new", Tag: "focus", FocusSentence: "Protect mornings", Status: domain.PlanStatusReady, Actions: 1, Requested: true, Resolved: true},
+		{PlanID: "p-worked", Tag: "focus", FocusSentence: "Single-task", Status: domain.PlanStatusReady, Actions: 2, ActionsStarted: 1, ActionsFinished: 1, CompletionPercent: 50},
+	}
+	if !reflect.DeepEqual(digest.Plans, wantPlans) {
+		t.Fatalf("plans = %+v, want p-new requested and resolved, p-worked's action finished, p-old untouched", digest.Plans)
+	}
+
+	if !strings.Contains(digest.Markdown, "# Daily digest") || !strings.Contains(digest.Markdown, "focus: 0.50 → 0.80 (+0.30)") ||
+		!strings.Contains(digest.Markdown, "_(requested, now ready, 1 actions, 0% complete)_") ||
+		!strings.Contains(digest.Markdown, "_(ready, 2 actions, 1 started, 1 finished, 50% complete)_") {
+		t.Fatalf("markdown = %q", digest.Markdown)
+	}
+	if !strings.Contains(digest.HTML, "Deep work needs &lt;long&gt; blocks") {
+		t.Fatalf("html = %q, want the quoted text escaped", digest.HTML)
+	}
+	if len(digests.saved) != 1 || !digests.saved[0].Notified || len(notifier.notified) != 1 || notifier.notified[0].Markdown != digest.Markdown {
+		t.Fatalf("saved %+v, notified %d; want the digest sent once and stored as notified", digests.saved, len(notifier.notified))
+	}
+}
+
+func TestGenerate_PicksUpFromThePreviousDigestOfItsPeriod(t *testing.T) {
+	ctx := context.Background()
+	previousDaily := now.Add(-20 * time.Hour)
+	digests := &fakeDigests{saved: []domain.Digest{
+		{Period: domain.DigestWeekly, GeneratedAt: now.Add(-time.Hour), Notified: true},
+		{Period: domain.DigestDaily, GeneratedAt: now.Add(-2 * time.Hour)},
+		{Period: domain.DigestDaily, GeneratedAt: previousDaily, Notified: true},
+	}}
+	history := &fakeHistory{}
+	svc := newTestService(digests, history, &fakeNotifier{})
+
+	digest, err := svc.Generate(ctx, "t-1", domain.DigestDaily)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if !digest.Since.Equal(previousDaily) {
+		t.Fatalf("since = %v, want the previous notified daily digest's %v", digest.Since, previousDaily)
+	}
+	if want := previousDaily.Add(-24 * time.Hour); !history.gotSince.Equal(want) {
+		t.Fatalf("snapshots since %v, want %v", history.gotSince, want)
+	}
+	if len(digest.TagMoves) != 0 || !strings.Contains(digest.Markdown, "No tag moved noticeably.") {
+		t.Fatalf("tag moves = %+v without any snapshot", digest.TagMoves)
+	}
+}
+
+func TestGenerate_NotifyFailureKeepsTheDigestAndTheRetryCoversItsSpan(t *testing.T) {
+	ctx := context.Background()
+	previous := domain.Digest{Period: domain.DigestWeekly, GeneratedAt: now.AddDate(0, 0, -3), Notified: true}
+	digests := &fakeDigests{saved: []domain.Digest{previous}}
+	notifier := &fakeNotifier{err: errors.New("smtp down")}
+	svc := newTestService(digests, &fakeHistory{}, notifier)
+
+	failed, err := svc.Generate(ctx, "t-1", domain.DigestWeekly)
+	if err == nil {
+		t.Fatal("expected the notify error")
+	}
+	if len(digests.saved) != 2 || digests.saved[0].Notified {
+		t.Fatalf("saved %+v, want the digest stored unsent", digests.saved)
+	}
+
+	notifier.err = nil
+	svc.now = func() time.Time { return now.Add(time.Hour) }
+	retried, err := svc.Generate(ctx, "t-1", domain.DigestWeekly)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !retried.Since.Equal(previous.GeneratedAt) || !failed.Since.Equal(previous.GeneratedAt) || !reflect.DeepEqual(retried.Plans, failed.Plans) {
+		t.Fatalf("retry since %v with plans %+v, want the failed digest's span since %v covered again", retried.Since, retried.Plans, previous.GeneratedAt)
+	}
+	if len(digests.saved) != 3 || !digests.saved[0].Notified {
+		t.Fatalf("saved %+v, want the retry stored as notified", digests.saved)
+	}
+}
+
+func TestList_CapsAtLimit(t *testing.T) {
+	digests := &fakeDigests{saved: []domain.Digest{{Period: domain.DigestDaily}, {Period: domain.DigestWeekly}}}
+	svc := newTestService(digests, &fakeHistory{}, &fakeNotifier{})
+
+	got, err := svc.List(context.Background(), "t-1", 1)
+	if err != nil || len(got) != 1 || got[0].Period != domain.DigestDaily {
+		t.Fatalf("List = %+v, %v; want the newest only", got, err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package duplicate finds a new insight's near-duplicate among the
+// tenant's existing ones. The idempotency key includes the source (see
+// ingest.buildIdempotencyKey), so the same passage imported from Readwise
+// and from Raindrop, or highlighted twice, arrives as two insights; this is
+// what notices, and the configured domain.DuplicatePolicy decides what
+// insight.Service.Process does about it.
+//
+// TRADE-OFF: Detect lists the tenant's whole corpus and signs every text
+// on each ingest. At personal scale that's one Query and microseconds of
+// hashing per insight; persisting signatures (or an LSH index) is the
+// upgrade path once corpora reach the tens of thousands.
+package duplicate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// Detection is Detect's answer. Match and Similarity are only set when
+// Found.
+type Detection struct {
+	Policy     domain.DuplicatePolicy
+	Found      bool
+	Match      domain.Insight
+	Similarity float64
+}
+
+type Service interface {
+	// Detect returns insight's closest existing insight at or above the
+	// threshold, ignoring insight itself (a redelivery finds its own
+	// earlier write). Under DuplicateOff it reads nothing.
+	Detect(ctx context.Context, insight domain.Insight) (Detection, error)
+
+	// Merge records insight as another source of detection.Match.
+	Merge(ctx context.Context, insight domain.Insight, detection Detection) error
+
+	// Flag records the stored insight and its match for review.
+	Flag(ctx context.Context, insight domain.Insight, detection Detection) error
+
+	// List returns tenantID's flagged pairs, most recent first.
+	List(ctx context.Context, tenantID string) ([]domain.Duplicate, error)
+}
+
+type service struct {
+	insights   ports.InsightRepository
+	duplicates ports.DuplicateRepository
+	policy     domain.DuplicatePolicy
+	threshold  float64
+	now        func() time.Time
+}
+
+// NewService detects at threshold (domain.DefaultDuplicateThreshold if not
+// within (0,1]).
+func NewService(insights ports.InsightRepository, duplicates ports.DuplicateRepository, policy domain.DuplicatePolicy, threshold float64) Service {
+	if threshold <= 0 || threshold > 1 {
+		threshold = domain.DefaultDuplicateThreshold
+	}
+	return &service{
+		insights:   insights,
+		duplicates: duplicates,
+		policy:     policy,
+		threshold:  threshold,
+		now:        time.Now,
+	}
+}
+
+var _ Service = (*service)(nil)
+
+func (s *service) Detect(ctx context.Context, insight domain.Insight) (Detection, error) {
+	detection := Detection{Policy: s.policy}
+	if s.policy == domain.DuplicateOff {
+		return detection, nil
+	}
+
+	corpus, err := s.insights.ListByTenantID(ctx, insight.TenantID, "")
+	if err != nil {
+		return Detection{}, fmt.Errorf("list insights: %w", err)
+	}
+
+	sig := domain.NewTextSignature(insight.Text)
+	for _, candidate := range corpus {
+		if candidate.ID == insight.ID {
+			continue
+		}
+		similarity := sig.Similarity(domain.NewTextSignature(candidate.Text))
+		if similarity >= s.threshold && similarity > detection.Similarity {
+			detection.Found = true
+			detection.Match = candidate
+			detection.Similarity = similarity
+		}
+	}
+	return detection, nil
+}
+
+func (s *service) Merge(ctx context.Context, insight domain.Insight, detection Detection) error {
+	return s.duplicates.AddMergedSource(ctx, insight.TenantID, detection.Match.ID, domain.SourceRef{
+		Source:        insight.Source,
+		InsightID:     insight.ID,
+		HighlightedAt: insight.HighlightedAt,
+		MergedAt:      s.now().UTC(),
+	})
+}
+
+func (s *service) Flag(ctx context.Context, insight domain.Insight, detection Detection) error {
+	return s.duplicates.SaveDuplicate(ctx, domain.Duplicate{
+		TenantID:        insight.TenantID,
+		InsightID:       insight.ID,
+		Source:          insight.Source,
+		Text:            insight.Text,
+		DuplicateOfID:   detection.Match.ID,
+		DuplicateOfText: detection.Match.Text,
+		Similarity:      detection.Similarity,
+		DetectedAt:      s.now().UTC(),
+	})
+}
+
+func (s *service) List(ctx context.Context, tenantID string) ([]domain.Duplicate, error) {
+	return s.duplicates.ListDuplicates(ctx, tenantID)
+}
@@ -0,0 +1,60 @@
+package duplicate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// fakeCorpus only answers ListByTenantID; nothing else is reached from
+// Detect.
+type fakeCorpus struct {
+	ports.InsightRepository
+	insights []domain.Insight
+	listed   bool
+}
+
+func (f *fakeCorpus) ListByTenantID(_ context.Context, _, _ string) ([]domain.Insight, error) {
+	f.listed = true
+	return f.insights, nil
+}
+
+const passage = "We are what we repeatedly do. Excellence, then, is not an act, but a habit."
+
+func TestService_Detect_FindsTheBestMatchButNeverItself(t *testing.T) {
+	corpus := &fakeCorpus{insights: []domain.Insight{
+		{ID: "self", Text: passage},
+		{ID: "near", Text: "We are what we repeatedly do. Excellence, then, is not an act, but a practice."},
+		{ID: "exact", Text: "we are what we repeatedly do -- excellence then is not an act but a habit"},
+		{ID: "other", Text: "Habits are the compound interest of self-improvement."},
+	}}
+	svc := NewService(corpus, nil, domain.DuplicateFlag, 0)
+
+	got, err := svc.Detect(context.Background(), domain.Insight{ID: "self", TenantID: "t-1", Text: passage})
+	if err != nil {
+		t.Fatalf("Detect: %v", err)
+	}
+	if !got.Found || got.Match.ID != "exact" || got.Similarity != 1 {
+		t.Fatalf("detection = %+v, want the exact match", got)
+	}
+}
+
+func TestService_Detect_NothingAboveThreshold_NotFound(t *testing.T) {
+	corpus := &fakeCorpus{insights: []domain.Insight{{ID: "other", Text: "Habits are the compound interest of self-improvement."}}}
+
+	got, err := NewService(corpus, nil, domain.DuplicateMerge, 0).Detect(context.Background(), domain.Insight{ID: "new", Text: passage})
+	if err != nil || got.Found {
+		t.Fatalf("detection = %+v, %v; want nothing found", got, err)
+	}
+}
+
+func TestService_Detect_PolicyOff_ReadsNothing(t *testing.T) {
+	corpus := &fakeCorpus{insights: []domain.Insight{{ID: "dup", Text: passage}}}
+
+	got, err := NewService(corpus, nil, domain.DuplicateOff, 0).Detect(context.Background(), domain.Insight{ID: "new", Text: passage})
+	if err != nil || got.Found || corpus.listed {
+		t.Fatalf("detection = %+v, %v, listed = %v; want no read at all", got, err, corpus.listed)
+	}
+}
@@ -0,0 +1,216 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// Neighborhood returns the insights within depth hops of insightID
+	// along edges filter follows, and the edges between them. Nodes stop
+	// at domain.MaxGraphNodes, nearest first; Graph.Truncated says so.
+	// ports.ErrInsightNotFound if there's no such insight.
+	Neighborhood(ctx context.Context, tenantID, insightID string, depth int, filter domain.GraphFilter) (domain.Graph, error)
+	// Path returns a shortest path from fromID to toID along edges filter
+	// follows, ignoring edge direction. domain.ErrNoPath if there's none
+	// within domain.MaxPathLength hops and domain.MaxGraphNodes visited.
+	Path(ctx context.Context, tenantID, fromID, toID string, filter domain.GraphFilter) (domain.Graph, error)
+	// Export returns all of tenantID's insights and the relationships
+	// between them, or only those tagged tag if it's non-empty. tag is
+	// normalized first; domain.ErrInvalidTag if it can't be.
+	Export(ctx context.Context, tenantID, tag string) (domain.GraphExport, error)
+}
+
+type service struct {
+	insights      ports.InsightRepository
+	relationships ports.RelationshipRepository
+}
+
+func NewService(insights ports.InsightRepository, relationships ports.RelationshipRepository) Service {
+	return &service{insights: insights, relationships: relationships}
+}
+
+var _ Service = (*service)(nil)
+
+// Neighborhood is a breadth-first search over the REL# adjacency items,
+// one ListByInsightID per node expanded. A node already seen is never
+// expanded again, which is all cycles need. The outermost ring isn't
+// expanded, so edges between two nodes at depth hops are left out.
+func (s *service) Neighborhood(ctx context.Context, tenantID, insightID string, depth int, filter domain.GraphFilter) (domain.Graph, error) {
+	if depth < 1 || depth > domain.MaxGraphDepth {
+		return domain.Graph{}, domain.ErrGraphDepthOutOfRange
+	}
+	if err := filter.Validate(); err != nil {
+		return domain.Graph{}, err
+	}
+	root, err := s.node(ctx, tenantID, insightID)
+	if err != nil {
+		return domain.Graph{}, err
+	}
+
+	graph := domain.Graph{Nodes: []domain.GraphNode{root}}
+	seen := map[string]bool{insightID: true}
+	// An edge shows up from both its ends; keep the first sighting.
+	seenEdges := map[[2]string]bool{}
+	frontier := []string{insightID}
+	for hop := 1; hop <= depth && len(frontier) > 0; hop++ {
+		var next []string
+		for _, id := range frontier {
+			edges, err := s.relationships.ListByInsightID(ctx, tenantID, id)
+			if err != nil {
+				return domain.Graph{}, fmt.Errorf("list edges of %s: %w", id, err)
+			}
+			for _, edge := range edges {
+				if !filter.Follows(edge) {
+					continue
+				}
+				if !seen[edge.InsightID] {
+					if len(graph.Nodes) >= domain.MaxGraphNodes {
+						graph.Truncated = true
+						continue
+					}
+					seen[edge.InsightID] = true
+					graph.Nodes = append(graph.Nodes, domain.GraphNode{InsightID: edge.InsightID, Text: edge.Text, Depth: hop})
+					next = append(next, edge.InsightID)
+				}
+				if key := pairKey(id, edge.InsightID); !seenEdges[key] {
+					seenEdges[key] = true
+					graph.Edges = append(graph.Edges, domain.GraphEdgeFrom(id, edge))
+				}
+			}
+		}
+		frontier = next
+	}
+	return graph, nil
+}
+
+// Path is a breadth-first search from fromID that stops at the first
+// sighting of toID, so the path has the fewest hops; among equally short
+// ones it prefers more confident edges, since ListByInsightID lists those
+// first.
+func (s *service) Path(ctx context.Context, tenantID, fromID, toID string, filter domain.GraphFilter) (domain.Graph, error) {
+	if err := filter.Validate(); err != nil {
+		return domain.Graph{}, err
+	}
+	from, err := s.node(ctx, tenantID, fromID)
+	if err != nil {
+		return domain.Graph{}, err
+	}
+	to, err := s.node(ctx, tenantID, toID)
+	if err != nil {
+		return domain.Graph{}, err
+	}
+	if fromID == toID {
+		return domain.Graph{Nodes: []domain.GraphNode{from}}, nil
+	}
+
+	// reached maps each visited node to how it was first reached.
+	reached := map[string]pathStep{fromID: {}}
+	frontier := []string{fromID}
+	for hop := 1; hop <= domain.MaxPathLength && len(frontier) > 0; hop++ {
+		var next []string
+		for _, id := range frontier {
+			edges, err := s.relationships.ListByInsightID(ctx, tenantID, id)
+			if err != nil {
+				return domain.Graph{}, fmt.Errorf("list edges of %s: %w", id, err)
+			}
+			for _, edge := range edges {
+				if _, ok := reached[edge.InsightID]; ok || !filter.Follows(edge) {
+					continue
+				}
+				if len(reached) >= domain.MaxGraphNodes {
+					return domain.Graph{}, domain.ErrNoPath
+				}
+				reached[edge.InsightID] = pathStep{prev: id, edge: domain.GraphEdgeFrom(id, edge), text: edge.Text}
+				if edge.InsightID == toID {
+					return walkBack(reached, from, to, hop), nil
+				}
+				next = append(next, edge.InsightID)
+			}
+		}
+		frontier = next
+	}
+	return domain.Graph{}, domain.ErrNoPath
+}
+
+// pathStep is how Path first reached a node: over edge, from prev.
+type pathStep struct {
+	prev string
+	edge domain.GraphEdge
+	text string
+}
+
+// walkBack follows reached's predecessors from to back to from, filling
+// the hops-long path in from the end.
+func walkBack(reached map[string]pathStep, from, to domain.GraphNode, hops int) domain.Graph {
+	graph := domain.Graph{
+		Nodes: make([]domain.GraphNode, hops+1),
+		Edges: make([]domain.GraphEdge, hops),
+	}
+	graph.Nodes[0] = from
+	graph.Nodes[hops] = domain.GraphNode{InsightID: to.InsightID, Text: to.Text, Depth: hops}
+	id := to.InsightID
+	for i := hops; i > 0; i-- {
+		st := reached[id]
+		graph.Edges[i-1] = st.edge
+		if i < hops {
+			graph.Nodes[i] = domain.GraphNode{InsightID: id, Text: st.text, Depth: i}
+		}
+		id = st.prev
+	}
+	return graph
+}
+
+// Export reads the whole graph at once, which is fine at personal scale;
+// a tag narrows the insights, and with them the edges kept.
+func (s *service) Export(ctx context.Context, tenantID, tag string) (domain.GraphExport, error) {
+	if tag != "" {
+		normalized, ok := domain.NormalizeTag(tag)
+		if !ok {
+			return domain.GraphExport{}, domain.ErrInvalidTag
+		}
+		tag = string(normalized)
+	}
+	insights, err := s.insights.ListByTenantID(ctx, tenantID, tag)
+	if err != nil {
+		return domain.GraphExport{}, fmt.Errorf("list insights: %w", err)
+	}
+	rels, err := s.relationships.ListRelationships(ctx, tenantID)
+	if err != nil {
+		return domain.GraphExport{}, fmt.Errorf("list relationships: %w", err)
+	}
+
+	included := make(map[string]bool, len(insights))
+	for _, insight := range insights {
+		included[insight.ID] = true
+	}
+	export := domain.GraphExport{Insights: insights, Relationships: make([]domain.Relationship, 0, len(rels))}
+	for _, rel := range rels {
+		if included[rel.FromInsightID] && included[rel.ToInsightID] {
+			export.Relationships = append(export.Relationships, rel)
+		}
+	}
+	return export, nil
+}
+
+func (s *service) node(ctx context.Context, tenantID, insightID string) (domain.GraphNode, error) {
+	insight, found, err := s.insights.GetByID(ctx, tenantID, insightID)
+	if err != nil {
+		return domain.GraphNode{}, fmt.Errorf("get insight %s: %w", insightID, err)
+	}
+	if !found {
+		return domain.GraphNode{}, ports.ErrInsightNotFound
+	}
+	return domain.GraphNode{InsightID: insight.ID, Text: insight.Text}, nil
+}
+
+// pairKey names an edge by its two insights in either order.
+func pairKey(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
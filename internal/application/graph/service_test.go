@@ -0,0 +1,294 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeInsights struct {
+	ports.InsightRepository
+	ids map[string]bool
+	// tagged answers ListByTenantID per tag.
+	tagged map[string][]domain.Insight
+}
+
+func (f fakeInsights) ListByTenantID(_ context.Context, _, tag string) ([]domain.Insight, error) {
+	return f.tagged[tag], nil
+}
+
+func (f fakeInsights) GetByID(_ context.Context, tenantID, insightID string) (domain.Insight, bool, error) {
+	if !f.ids[insightID] {
+		return domain.Insight{}, false, nil
+	}
+	return domain.Insight{ID: insightID, TenantID: tenantID, Text: "text of " + insightID}, true, nil
+}
+
+// fakeEdges answers ListByInsightID the way the dynamodb adapter does:
+// each edge under both its ends, most confident first.
+type fakeEdges struct {
+	ports.RelationshipRepository
+	adjacency map[string][]domain.RelatedInsight
+	listed    []string
+	all       []domain.Relationship
+}
+
+func (f *fakeEdges) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return f.all, nil
+}
+
+func (f *fakeEdges) ListByInsightID(_ context.Context, _, insightID string) ([]domain.RelatedInsight, error) {
+	f.listed = append(f.listed, insightID)
+	return f.adjacency[insightID], nil
+}
+
+func newFakes(rels ...domain.Relationship) (fakeInsights, *fakeEdges) {
+	insights := fakeInsights{ids: map[string]bool{}}
+	edges := &fakeEdges{adjacency: map[string][]domain.RelatedInsight{}, all: rels}
+	for _, rel := range rels {
+		insights.ids[rel.FromInsightID] = true
+		insights.ids[rel.ToInsightID] = true
+		edges.adjacency[rel.FromInsightID] = append(edges.adjacency[rel.FromInsightID], domain.RelatedInsight{
+			InsightID: rel.ToInsightID, Text: "text of " + rel.ToInsightID, Type: rel.Type, Confidence: rel.Confidence, Outgoing: true,
+		})
+		edges.adjacency[rel.ToInsightID] = append(edges.adjacency[rel.ToInsightID], domain.RelatedInsight{
+			InsightID: rel.FromInsightID, Text: "text of " + rel.FromInsightID, Type: rel.Type, Confidence: rel.Confidence,
+		})
+	}
+	for _, related := range edges.adjacency {
+		sort.SliceStable(related, func(i, j int) bool { return related[i].Confidence > related[j].Confidence })
+	}
+	return insights, edges
+}
+
+func edge(from, to string, relType domain.RelationType, confidence float64) domain.Relationship {
+	return domain.Relationship{TenantID: "t-1", FromInsightID: from, ToInsightID: to, Type: relType, Confidence: confidence}
+}
+
+func nodeIDs(g domain.Graph) []string {
+	ids := make([]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[i] = n.InsightID
+	}
+	return ids
+}
+
+func TestService_Neighborhood_WalksDepthHopsAndStopsOnCycles(t *testing.T) {
+	// a -> b -> c -> a is a cycle; d hangs off c, three hops from a via b
+	// but two via the cycle's closing edge.
+	insights, edges := newFakes(
+		edge("a", "b", domain.RelationSupports, 0.9),
+		edge("b", "c", domain.RelationExtends, 0.8),
+		edge("c", "a", domain.RelationSameTopic, 0.7),
+		edge("c", "d", domain.RelationExampleOf, 0.6),
+		edge("d", "e", domain.RelationSupports, 0.9),
+	)
+	svc := NewService(insights, edges)
+
+	got, err := svc.Neighborhood(context.Background(), "t-1", "a", 2, domain.GraphFilter{})
+	if err != nil {
+		t.Fatalf("Neighborhood: %v", err)
+	}
+
+	if fmt.Sprint(nodeIDs(got)) != "[a b c d]" || got.Truncated {
+		t.Fatalf("nodes = %v (truncated=%v), want [a b c d] untruncated", nodeIDs(got), got.Truncated)
+	}
+	depths := map[string]int{}
+	for _, n := range got.Nodes {
+		depths[n.InsightID] = n.Depth
+	}
+	if depths["a"] != 0 || depths["c"] != 1 || depths["d"] != 2 {
+		t.Fatalf("depths = %v, want a=0 c=1 d=2", depths)
+	}
+	if len(got.Edges) != 4 {
+		t.Fatalf("edges = %+v, want a-b, a-c, b-c, c-d once each", got.Edges)
+	}
+	for _, e := range got.Edges {
+		if e.FromInsightID == "a" && e.ToInsightID == "c" {
+			t.Fatalf("edge %+v lost its direction, want c -> a", e)
+		}
+	}
+	if fmt.Sprint(edges.listed) != "[a b c]" {
+		t.Fatalf("expanded %v, want each node within depth-1 hops once", edges.listed)
+	}
+}
+
+func TestService_Neighborhood_FilterByTypeAndConfidence(t *testing.T) {
+	insights, edges := newFakes(
+		edge("a", "b", domain.RelationSupports, 0.9),
+		edge("a", "c", domain.RelationSupports, 0.4),
+		edge("a", "d", domain.RelationContradicts, 0.95),
+	)
+	svc := NewService(insights, edges)
+
+	got, err := svc.Neighborhood(context.Background(), "t-1", "a", 1, domain.GraphFilter{
+		Types:         []domain.RelationType{domain.RelationSupports},
+		MinConfidence: 0.5,
+	})
+	if err != nil {
+		t.Fatalf("Neighborhood: %v", err)
+	}
+	if fmt.Sprint(nodeIDs(got)) != "[a b]" || len(got.Edges) != 1 {
+		t.Fatalf("nodes = %v edges = %+v, want only a -> b", nodeIDs(got), got.Edges)
+	}
+}
+
+func TestService_Neighborhood_NodeCap_Truncates(t *testing.T) {
+	var rels []domain.Relationship
+	for i := range domain.MaxGraphNodes + 5 {
+		rels = append(rels, edge("hub", fmt.Sprintf("n-%03d", i), domain.RelationSameTopic, 0.5))
+	}
+	insights, edges := newFakes(rels...)
+
+	got, err := NewService(insights, edges).Neighborhood(context.Background(), "t-1", "hub", 1, domain.GraphFilter{})
+	if err != nil {
+		t.Fatalf("Neighborhood: %v", err)
+	}
+	if len(got.Nodes) != domain.MaxGraphNodes || !got.Truncated {
+		t.Fatalf("nodes = %d truncated = %v, want %d and truncated", len(got.Nodes), got.Truncated, domain.MaxGraphNodes)
+	}
+	if len(got.Edges) != domain.MaxGraphNodes-1 {
+		t.Fatalf("edges = %d, want one per kept neighbor", len(got.Edges))
+	}
+}
+
+func TestService_Neighborhood_RejectsBadInput(t *testing.T) {
+	insights, edges := newFakes(edge("a", "b", domain.RelationSupports, 0.9))
+	svc := NewService(insights, edges)
+
+	for name, tc := range map[string]struct {
+		insightID string
+		depth     int
+		filter    domain.GraphFilter
+		want      error
+	}{
+		"depth 0":         {"a", 0, domain.GraphFilter{}, domain.ErrGraphDepthOutOfRange},
+		"depth too large": {"a", domain.MaxGraphDepth + 1, domain.GraphFilter{}, domain.ErrGraphDepthOutOfRange},
+		"unknown type":    {"a", 1, domain.GraphFilter{Types: []domain.RelationType{"nope"}}, domain.ErrUnknownRelationType},
+		"bad confidence":  {"a", 1, domain.GraphFilter{MinConfidence: 2}, domain.ErrConfidenceOutOfRange},
+		"missing insight": {"zzz", 1, domain.GraphFilter{}, ports.ErrInsightNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := svc.Neighborhood(context.Background(), "t-1", tc.insightID, tc.depth, tc.filter); !errors.Is(err, tc.want) {
+				t.Fatalf("err = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestService_Path_ShortestPathInOrderWithEdgeDirections(t *testing.T) {
+	// Two routes from a to e: a-b-c-d-e and the shorter a-x-e, whose second
+	// edge points back at x.
+	insights, edges := newFakes(
+		edge("a", "b", domain.RelationSupports, 0.9),
+		edge("b", "c", domain.RelationSupports, 0.9),
+		edge("c", "d", domain.RelationSupports, 0.9),
+		edge("d", "e", domain.RelationSupports, 0.9),
+		edge("a", "x", domain.RelationExtends, 0.5),
+		edge("e", "x", domain.RelationExampleOf, 0.5),
+	)
+	svc := NewService(insights, edges)
+
+	got, err := svc.Path(context.Background(), "t-1", "a", "e", domain.GraphFilter{})
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+
+	if fmt.Sprint(nodeIDs(got)) != "[a x e]" {
+		t.Fatalf("path = %v, want [a x e]", nodeIDs(got))
+	}
+	if got.Nodes[1].Text != "text of x" || got.Nodes[2].Depth != 2 {
+		t.Fatalf("nodes = %+v, want texts and hop counts filled in", got.Nodes)
+	}
+	if len(got.Edges) != 2 || got.Edges[0].Type != domain.RelationExtends || got.Edges[1].FromInsightID != "e" || got.Edges[1].ToInsightID != "x" {
+		t.Fatalf("edges = %+v, want a -> x then e -> x", got.Edges)
+	}
+}
+
+func TestService_Path_FilterCanRuleOutTheShortcut(t *testing.T) {
+	insights, edges := newFakes(
+		edge("a", "b", domain.RelationSupports, 0.9),
+		edge("b", "e", domain.RelationSupports, 0.9),
+		edge("a", "e", domain.RelationSameTopic, 0.3),
+	)
+	svc := NewService(insights, edges)
+
+	got, err := svc.Path(context.Background(), "t-1", "a", "e", domain.GraphFilter{MinConfidence: 0.5})
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if fmt.Sprint(nodeIDs(got)) != "[a b e]" {
+		t.Fatalf("path = %v, want [a b e]", nodeIDs(got))
+	}
+}
+
+func TestService_Path_NoPath(t *testing.T) {
+	insights, edges := newFakes(
+		edge("a", "b", domain.RelationSupports, 0.9),
+		edge("b", "a", domain.RelationSupports, 0.9),
+		edge("c", "d", domain.RelationSupports, 0.9),
+	)
+	svc := NewService(insights, edges)
+
+	if _, err := svc.Path(context.Background(), "t-1", "a", "d", domain.GraphFilter{}); !errors.Is(err, domain.ErrNoPath) {
+		t.Fatalf("err = %v, want ErrNoPath", err)
+	}
+	if _, err := svc.Path(context.Background(), "t-1", "a", "zzz", domain.GraphFilter{}); !errors.Is(err, ports.ErrInsightNotFound) {
+		t.Fatalf("err = %v, want ErrInsightNotFound", err)
+	}
+}
+
+func TestService_Path_TooLong_NoPath(t *testing.T) {
+	var rels []domain.Relationship
+	for i := range domain.MaxPathLength + 1 {
+		rels = append(rels, edge(fmt.Sprint(i), fmt.Sprint(i+1), domain.RelationExtends, 0.9))
+	}
+	insights, edges := newFakes(rels...)
+	svc := NewService(insights, edges)
+
+	last := fmt.Sprint(domain.MaxPathLength + 1)
+	if _, err := svc.Path(context.Background(), "t-1", "0", last, domain.GraphFilter{}); !errors.Is(err, domain.ErrNoPath) {
+		t.Fatalf("err = %v, want ErrNoPath past MaxPathLength", err)
+	}
+	got, err := svc.Path(context.Background(), "t-1", "0", fmt.Sprint(domain.MaxPathLength), domain.GraphFilter{})
+	if err != nil || len(got.Edges) != domain.MaxPathLength {
+		t.Fatalf("path = %+v, %v; want exactly MaxPathLength hops", got, err)
+	}
+}
+
+func TestService_Export_TagKeepsOnlyEdgesInsideTheSubgraph(t *testing.T) {
+	insights, edges := newFakes(
+		edge("a", "b", domain.RelationSupports, 0.9),
+		edge("b", "c", domain.RelationExtends, 0.8),
+	)
+	insights.tagged = map[string][]domain.Insight{
+		"":      {{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		"focus": {{ID: "a"}, {ID: "b"}},
+	}
+	svc := NewService(insights, edges)
+
+	all, err := svc.Export(context.Background(), "t-1", "")
+	if err != nil || len(all.Insights) != 3 || len(all.Relationships) != 2 {
+		t.Fatalf("Export(all) = %+v, %v; want 3 insights and 2 edges", all, err)
+	}
+
+	sub, err := svc.Export(context.Background(), "t-1", "focus")
+	if err != nil {
+		t.Fatalf("Export(focus): %v", err)
+	}
+	if len(sub.Insights) != 2 || len(sub.Relationships) != 1 || sub.Relationships[0].ToInsightID != "b" {
+		t.Fatalf("Export(focus) = %+v, want a, b and only a -> b", sub)
+	}
+
+	if raw, err := svc.Export(context.Background(), "t-1", " Focus "); err != nil || len(raw.Insights) != 2 {
+		t.Fatalf("Export(\" Focus \") = %+v, %v; want the tag normalized to focus", raw, err)
+	}
+	if _, err := svc.Export(context.Background(), "t-1", "!!!"); !errors.Is(err, domain.ErrInvalidTag) {
+		t.Fatalf("err = %v, want ErrInvalidTag", err)
+	}
+}
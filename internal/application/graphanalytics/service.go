@@ -0,0 +1,79 @@
+// Package graphanalytics finds a tenant's clusters, central insights and
+// bridge insights (domain.ComputeGraphAnalytics) and keeps the result, so
+// GET /v1/graph/clusters and /v1/graph/insights read one stored item
+// instead of walking the graph. The graph-analytics Lambda recomputes it
+// as KnowledgeUpdated events arrive.
+package graphanalytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// Recompute analyses tenantID's whole graph and saves the result,
+	// unless the saved one was computed after changedAt and so already
+	// reflects the change. recomputed says which.
+	Recompute(ctx context.Context, tenantID string, changedAt time.Time) (analytics domain.GraphAnalytics, recomputed bool, err error)
+
+	// Get returns tenantID's saved analytics, or the zero value (TenantID
+	// set, ComputedAt zero) if they've never been computed.
+	Get(ctx context.Context, tenantID string) (domain.GraphAnalytics, error)
+}
+
+type service struct {
+	insights      ports.InsightRepository
+	relationships ports.RelationshipRepository
+	repo          ports.GraphAnalyticsRepository
+	now           func() time.Time
+}
+
+func NewService(insights ports.InsightRepository, relationships ports.RelationshipRepository, repo ports.GraphAnalyticsRepository) Service {
+	return &service{insights: insights, relationships: relationships, repo: repo, now: time.Now}
+}
+
+var _ Service = (*service)(nil)
+
+// Recompute reads the whole graph at once, as graph.Service.Export does;
+// that's fine at personal scale, and the caller debounces so a burst of
+// changes costs one pass. ComputedAt is taken before the reads, so a change
+// landing while they run is never mistaken for one they saw.
+func (s *service) Recompute(ctx context.Context, tenantID string, changedAt time.Time) (domain.GraphAnalytics, bool, error) {
+	saved, found, err := s.repo.GetGraphAnalytics(ctx, tenantID)
+	if err != nil {
+		return domain.GraphAnalytics{}, false, fmt.Errorf("get graph analytics: %w", err)
+	}
+	if found && saved.ComputedAt.After(changedAt) {
+		return saved, false, nil
+	}
+
+	startedAt := s.now().UTC()
+	insights, err := s.insights.ListByTenantID(ctx, tenantID, "")
+	if err != nil {
+		return domain.GraphAnalytics{}, false, fmt.Errorf("list insights: %w", err)
+	}
+	rels, err := s.relationships.ListRelationships(ctx, tenantID)
+	if err != nil {
+		return domain.GraphAnalytics{}, false, fmt.Errorf("list relationships: %w", err)
+	}
+	analytics := domain.ComputeGraphAnalytics(tenantID, insights, rels, startedAt)
+	if err := s.repo.SaveGraphAnalytics(ctx, analytics); err != nil {
+		return domain.GraphAnalytics{}, false, fmt.Errorf("save graph analytics: %w", err)
+	}
+	return analytics, true, nil
+}
+
+func (s *service) Get(ctx context.Context, tenantID string) (domain.GraphAnalytics, error) {
+	analytics, found, err := s.repo.GetGraphAnalytics(ctx, tenantID)
+	if err != nil {
+		return domain.GraphAnalytics{}, err
+	}
+	if !found {
+		return domain.GraphAnalytics{TenantID: tenantID}, nil
+	}
+	return analytics, nil
+}
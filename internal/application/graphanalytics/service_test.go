@@ -0,0 +1,114 @@
+package graphanalytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeInsights struct {
+	ports.InsightRepository
+	all    []domain.Insight
+	listed int
+}
+
+func (f *fakeInsights) ListByTenantID(context.Context, string, string) ([]domain.Insight, error) {
+	f.listed++
+	return f.all, nil
+}
+
+type fakeRelationships struct {
+	ports.RelationshipRepository
+	all []domain.Relationship
+}
+
+func (f fakeRelationships) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return f.all, nil
+}
+
+type fakeRepo struct {
+	saved   map[string]domain.GraphAnalytics
+	saveErr error
+}
+
+func (f *fakeRepo) GetGraphAnalytics(_ context.Context, tenantID string) (domain.GraphAnalytics, bool, error) {
+	a, ok := f.saved[tenantID]
+	return a, ok, nil
+}
+
+func (f *fakeRepo) SaveGraphAnalytics(_ context.Context, a domain.GraphAnalytics) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.saved[a.TenantID] = a
+	return nil
+}
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(repo *fakeRepo) (*service, *fakeInsights) {
+	insights := &fakeInsights{all: []domain.Insight{{ID: "a"}, {ID: "b"}, {ID: "c"}}}
+	rels := fakeRelationships{all: []domain.Relationship{
+		{FromInsightID: "a", ToInsightID: "b", Type: domain.RelationSupports, Confidence: 0.9},
+	}}
+	s := NewService(insights, rels, repo).(*service)
+	s.now = func() time.Time { return now }
+	return s, insights
+}
+
+func TestService_Recompute_AnalysesAndSavesTheGraph(t *testing.T) {
+	repo := &fakeRepo{saved: map[string]domain.GraphAnalytics{}}
+	s, _ := newTestService(repo)
+
+	got, recomputed, err := s.Recompute(context.Background(), "t-1", now.Add(-time.Minute))
+
+	if err != nil || !recomputed {
+		t.Fatalf("recomputed = %v, err = %v; want a fresh computation", recomputed, err)
+	}
+	if !got.ComputedAt.Equal(now) || len(got.Clusters) != 1 || got.Clusters[0].Size != 2 || len(got.Central) != 3 {
+		t.Fatalf("got %+v, want a-b clustered and all three ranked", got)
+	}
+	if saved := repo.saved["t-1"]; !saved.ComputedAt.Equal(now) {
+		t.Fatalf("saved = %+v, want the result saved", saved)
+	}
+}
+
+func TestService_Recompute_SkipsWhenTheSavedResultIsNewerThanTheChange(t *testing.T) {
+	saved := domain.GraphAnalytics{TenantID: "t-1", ComputedAt: now.Add(-time.Minute)}
+	repo := &fakeRepo{saved: map[string]domain.GraphAnalytics{"t-1": saved}}
+	s, insights := newTestService(repo)
+
+	got, recomputed, err := s.Recompute(context.Background(), "t-1", now.Add(-2*time.Minute))
+
+	if err != nil || recomputed || insights.listed != 0 || !got.ComputedAt.Equal(saved.ComputedAt) {
+		t.Fatalf("recomputed = %v listed = %d err = %v; want the saved result without reading the graph", recomputed, insights.listed, err)
+	}
+
+	// A change after the saved computation does recompute.
+	if _, recomputed, _ := s.Recompute(context.Background(), "t-1", now.Add(-30*time.Second)); !recomputed {
+		t.Fatalf("a later change didn't recompute")
+	}
+}
+
+func TestService_Recompute_SaveFailure(t *testing.T) {
+	boom := errors.New("boom")
+	s, _ := newTestService(&fakeRepo{saved: map[string]domain.GraphAnalytics{}, saveErr: boom})
+
+	if _, _, err := s.Recompute(context.Background(), "t-1", now); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want boom", err)
+	}
+}
+
+func TestService_Get_NeverComputed(t *testing.T) {
+	s, _ := newTestService(&fakeRepo{saved: map[string]domain.GraphAnalytics{}})
+
+	got, err := s.Get(context.Background(), "t-1")
+
+	if err != nil || got.TenantID != "t-1" || !got.ComputedAt.IsZero() || got.Clusters != nil {
+		t.Fatalf("got %+v, %v; want t-1's empty analytics", got, err)
+	}
+}
@@ -5,46 +5,169 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/apperr"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/llm"
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
+var errNoLLM = errors.New("no LLM service configured")
+
 type Result struct {
 	Inserted bool
+	// MergedInto is the existing insight this one was folded into under
+	// domain.DuplicateMerge; Inserted is false then.
+	MergedInto string
+}
+
+// BatchResult is one insight's outcome from ProcessBatch: what Process
+// would have returned for it.
+type BatchResult struct {
+	Result
+	Err error
 }
 
 type Service interface {
 	Process(ctx context.Context, insight domain.Insight) (Result, error)
+
+	// ProcessBatch is Process for several insights at once — a worker's
+	// SQS batch — with their enrichments requested together per tenant
+	// (llm.Service.EnrichBatch). One insight's failure doesn't fail the
+	// others; results are in insights' order.
+	ProcessBatch(ctx context.Context, insights []domain.Insight) []BatchResult
+
+	// Reenrich re-runs enrichment for an insight that's already stored —
+	// application/reenrich's unit of work. Unlike Process, an LLM failure
+	// is returned rather than swallowed: the caller is the retry mechanism
+	// here, and needs to count it.
+	Reenrich(ctx context.Context, insight domain.Insight) (domain.Insight, error)
+
 	ListByTenantID(ctx context.Context, tenantID, tag string) ([]domain.Insight, error)
+	// ListTags ranks tenantID's tags by relevance, each with its Trend
+	// over the last domain.TagTrendWindow of snapshots.
 	ListTags(ctx context.Context, tenantID string) ([]domain.TagSummary, error)
 }
 
 type service struct {
-	repo   ports.InsightRepository
-	llm    *llm.Service
-	events ports.DomainEventPublisher
+	repo       ports.InsightRepository
+	llm        *llm.Service
+	events     ports.DomainEventPublisher
+	duplicates duplicate.Service
+	tagHistory ports.TagHistoryRepository
 }
 
-func NewService(repo ports.InsightRepository, llm *llm.Service, events ports.DomainEventPublisher) Service {
+// NewService builds the insight Service. llm, duplicates and tagHistory
+// may be nil: insights are then stored unenriched, or without duplicate
+// detection, and ListTags leaves every Trend unset.
+func NewService(repo ports.InsightRepository, llm *llm.Service, events ports.DomainEventPublisher, duplicates duplicate.Service, tagHistory ports.TagHistoryRepository) Service {
 	return &service{
-		repo:   repo,
-		llm:    llm,
-		events: events,
+		repo:       repo,
+		llm:        llm,
+		events:     events,
+		duplicates: duplicates,
+		tagHistory: tagHistory,
 	}
 }
 
 var _ Service = (*service)(nil)
 
 func (s *service) Process(ctx context.Context, insight domain.Insight) (Result, error) {
+	res := s.ProcessBatch(ctx, []domain.Insight{insight})[0]
+	return res.Result, res.Err
+}
+
+func (s *service) ProcessBatch(ctx context.Context, insights []domain.Insight) []BatchResult {
+	insights = slices.Clone(insights)
+	results := make([]BatchResult, len(insights))
+	var toEnrich []int
+	for i := range insights {
+		withLanguage(&insights[i])
+		results[i].Result, results[i].Err = s.store(ctx, insights[i])
+		if results[i].Err != nil || results[i].MergedInto != "" {
+			continue
+		}
+		if results[i].Inserted {
+			toEnrich = append(toEnrich, i)
+			continue
+		}
+		// A redelivery whose first attempt stored the insight but didn't
+		// get to save its enrichment (a timeout, say) enriches it now:
+		// nothing else ever will.
+		if s.llm != nil {
+			if existing, ok := s.unenriched(ctx, insights[i]); ok {
+				insights[i] = existing
+				toEnrich = append(toEnrich, i)
+			}
+		}
+	}
+	if len(toEnrich) == 0 {
+		return results
+	}
+
+	if s.llm == nil {
+		slog.WarnContext(ctx, "no LLM service configured, skipping enrichment")
+		return results
+	}
+
+	// Enrichment is soft-fail, as ever: an insight whose enrichment failed
+	// stays stored and Inserted. Only failing to save one fails it.
+	for _, group := range groupByTenant(insights, toEnrich) {
+		inputs := make([]string, len(group))
+		for j, i := range group {
+			inputs[j] = enrichmentInput(insights[i])
+		}
+
+		enrichments, errs := s.llm.EnrichBatch(ctx, insights[group[0]].TenantID, inputs)
+		for j, i := range group {
+			enrichment, err := enrichments[j], errs[j]
+			if err == nil {
+				enrichment, err = finishEnrichment(enrichment)
+			}
+			if err != nil {
+				slog.WarnContext(ctx, "enrichment failed, proceeding without enrichment",
+					"insight_id", insights[i].ID, "err", err)
+				continue
+			}
+			if _, err := s.saveEnrichment(ctx, insights[i], enrichment); err != nil {
+				results[i] = BatchResult{Err: err}
+			}
+		}
+	}
+	return results
+}
+
+// store is Process up to enrichment: duplicate handling, the idempotent
+// write and the created event.
+func (s *service) store(ctx context.Context, insight domain.Insight) (Result, error) {
 	if strings.TrimSpace(insight.ID) == "" {
 		return Result{}, apperr.PermanentError{Err: errors.New("missing id")}
 	}
 
+	// Detection runs before the write because a merge replaces it. A
+	// redelivered merge finds the same match and merges nothing new (see
+	// DuplicateRepository.AddMergedSource).
+	var dup duplicate.Detection
+	if s.duplicates != nil {
+		var err error
+		if dup, err = s.duplicates.Detect(ctx, insight); err != nil {
+			return Result{}, fmt.Errorf("detect duplicates: %w", err)
+		}
+		if dup.Found && dup.Policy == domain.DuplicateMerge {
+			if err := s.duplicates.Merge(ctx, insight, dup); err != nil {
+				return Result{}, fmt.Errorf("merge duplicate: %w", err)
+			}
+			slog.InfoContext(ctx, "merged duplicate insight",
+				"tenant_id", insight.TenantID, "insight_id", insight.ID,
+				"merged_into", dup.Match.ID, "similarity", dup.Similarity)
+			return Result{MergedInto: dup.Match.ID}, nil
+		}
+	}
+
 	inserted, err := s.repo.CreateIfAbsent(ctx, insight)
 	if err != nil {
 		return Result{}, err
@@ -53,6 +176,14 @@ func (s *service) Process(ctx context.Context, insight domain.Insight) (Result,
 		return Result{Inserted: false}, nil
 	}
 
+	// A flag is advisory, so failing to save one doesn't fail the ingest.
+	if dup.Found {
+		if err := s.duplicates.Flag(ctx, insight, dup); err != nil {
+			slog.WarnContext(ctx, "failed to flag duplicate insight",
+				"tenant_id", insight.TenantID, "insight_id", insight.ID, "err", err)
+		}
+	}
+
 	// TRADE-OFF: a publish failure here (or after Update below) returns a
 	// plain (transient) error so SQS redelivers — but on redelivery
 	// CreateIfAbsent finds the record already there and short-circuits
@@ -64,33 +195,109 @@ func (s *service) Process(ctx context.Context, insight domain.Insight) (Result,
 		return Result{}, err
 	}
 
+	return Result{Inserted: true}, nil
+}
+
+// unenriched is the stored copy of an insight CreateIfAbsent found already
+// there, if it's still waiting on its enrichment. Failing to read it only
+// leaves it so.
+func (s *service) unenriched(ctx context.Context, insight domain.Insight) (domain.Insight, bool) {
+	existing, found, err := s.repo.GetByID(ctx, insight.TenantID, insight.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read redelivered insight, skipping enrichment",
+			"tenant_id", insight.TenantID, "insight_id", insight.ID, "err", err)
+		return domain.Insight{}, false
+	}
+	if !found || existing.Enrichment != nil {
+		return domain.Insight{}, false
+	}
+	return existing, true
+}
+
+// groupByTenant splits the insights at indexes by tenant, keeping both the
+// tenants and each one's insights in order: a batch call is one tenant's,
+// since budget and cache are.
+func groupByTenant(insights []domain.Insight, indexes []int) [][]int {
+	var groups [][]int
+	byTenant := map[string]int{}
+	for _, i := range indexes {
+		g, ok := byTenant[insights[i].TenantID]
+		if !ok {
+			g = len(groups)
+			byTenant[insights[i].TenantID] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+func (s *service) Reenrich(ctx context.Context, insight domain.Insight) (domain.Insight, error) {
 	if s.llm == nil {
-		slog.WarnContext(ctx, "no LLM service configured, skipping enrichment")
-		return Result{Inserted: true}, nil
+		return domain.Insight{}, errNoLLM
 	}
 
-	enrichmentInput := insight.Text
-	if notes := strings.TrimSpace(insight.Notes); notes != "" {
-		enrichmentInput += "\n\nNotes: " + notes
+	withLanguage(&insight)
+	enrichment, err := s.enrich(ctx, insight)
+	if err != nil {
+		return domain.Insight{}, fmt.Errorf("enrich: %w", err)
 	}
+	return s.saveEnrichment(ctx, insight, enrichment)
+}
 
-	enrichment, err := s.llm.Enrich(ctx, enrichmentInput)
+// withLanguage detects insight's language unless it's already known, so
+// insights stored before detection existed get one when re-enriched.
+func withLanguage(insight *domain.Insight) {
+	if insight.Language == domain.LanguageUnknown {
+		insight.Language = domain.DetectLanguage(insight.Text)
+	}
+}
+
+// enrich asks the LLM for insight's enrichment, normalizes the result and
+// rejects it if it's still invalid.
+// It never writes anything — Process and Reenrich disagree on what an LLM
+// failure means, so each decides for itself.
+func (s *service) enrich(ctx context.Context, insight domain.Insight) (domain.Enrichment, error) {
+	enrichment, err := s.llm.Enrich(ctx, insight.TenantID, enrichmentInput(insight))
 	if err != nil {
-		slog.WarnContext(ctx, "enrichment failed, proceeding without enrichment", "err", err)
-		return Result{Inserted: true}, nil
+		return domain.Enrichment{}, err
+	}
+	return finishEnrichment(enrichment)
+}
+
+// enrichmentInput is what the LLM is asked about: the text, and the
+// reader's notes when there are any.
+func enrichmentInput(insight domain.Insight) string {
+	input := insight.Text
+	if notes := strings.TrimSpace(insight.Notes); notes != "" {
+		input += "\n\nNotes: " + notes
 	}
-	enrichment.Tags = domain.NormalizeTags(enrichment.Tags)
+	return input
+}
 
+// finishEnrichment normalizes an LLM answer and stamps it, or rejects it
+// if it's still invalid.
+func finishEnrichment(enrichment domain.Enrichment) (domain.Enrichment, error) {
+	enrichment = enrichment.Normalize()
+	if err := enrichment.Validate(); err != nil {
+		return domain.Enrichment{}, fmt.Errorf("invalid enrichment: %w", err)
+	}
+	enrichment.EnrichedAt = time.Now().UTC()
+	return enrichment, nil
+}
+
+// saveEnrichment persists enrichment onto insight (tag memberships
+// included, see InsightRepository.Update) and announces it.
+func (s *service) saveEnrichment(ctx context.Context, insight domain.Insight, enrichment domain.Enrichment) (domain.Insight, error) {
 	insight.Enrichment = &enrichment
 	if err := s.repo.Update(ctx, insight); err != nil {
-		return Result{}, err
+		return domain.Insight{}, err
 	}
 
 	if err := s.publish(ctx, domain.NewInsightEnrichedEvent(insight, time.Now())); err != nil {
-		return Result{}, err
+		return domain.Insight{}, err
 	}
-
-	return Result{Inserted: true}, nil
+	return insight, nil
 }
 
 func (s *service) publish(ctx context.Context, event domain.DomainEvent) error {
@@ -113,5 +320,16 @@ func (s *service) ListByTenantID(ctx context.Context, tenantID, tag string) ([]d
 }
 
 func (s *service) ListTags(ctx context.Context, tenantID string) ([]domain.TagSummary, error) {
-	return s.repo.ListTags(ctx, tenantID)
+	tags, err := s.repo.ListTags(ctx, tenantID)
+	if err != nil || s.tagHistory == nil {
+		return tags, err
+	}
+
+	now := time.Now()
+	snapshots, err := s.tagHistory.ListTagSnapshots(ctx, tenantID, now.Add(-domain.TagTrendWindow))
+	if err != nil {
+		return nil, fmt.Errorf("list tag snapshots: %w", err)
+	}
+	domain.AnnotateTagTrends(tags, snapshots, now)
+	return tags, nil
 }
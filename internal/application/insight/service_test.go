@@ -5,8 +5,10 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/apperr"
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/duplicate"
 	"github.com/marcogerstmann/insight-processing-platform/internal/application/llm"
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 )
@@ -22,7 +24,8 @@ type spyRepo struct {
 
 	putInserted bool
 	putErr      error
-	created     bool
+	created     map[string]bool
+	stored      map[string]domain.Insight
 
 	updateErr error
 
@@ -32,6 +35,8 @@ type spyRepo struct {
 	listByTenantIDInsights []domain.Insight
 	gotListTag             string
 	listCalled             bool
+
+	tags []domain.TagSummary
 }
 
 func (s *spyRepo) CreateIfAbsent(_ context.Context, insight domain.Insight) (bool, error) {
@@ -44,11 +49,15 @@ func (s *spyRepo) CreateIfAbsent(_ context.Context, insight domain.Insight) (boo
 	}
 	// Mimic a real repo's dedup: once inserted, later calls (e.g. an SQS
 	// redelivery) report the record already exists.
-	if s.created {
+	if s.created[insight.ID] {
 		return false, nil
 	}
 	if s.putInserted {
-		s.created = true
+		if s.created == nil {
+			s.created = map[string]bool{}
+		}
+		s.created[insight.ID] = true
+		s.save(insight)
 	}
 	return s.putInserted, nil
 }
@@ -58,7 +67,19 @@ func (s *spyRepo) Update(_ context.Context, insight domain.Insight) error {
 		s.log.add("repo.Update")
 	}
 	s.gotUpdateInsight = insight
-	return s.updateErr
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	s.save(insight)
+	return nil
+}
+
+// save keeps what a real repo would hand back from GetByID.
+func (s *spyRepo) save(insight domain.Insight) {
+	if s.stored == nil {
+		s.stored = map[string]domain.Insight{}
+	}
+	s.stored[insight.ID] = insight
 }
 
 func (s *spyRepo) ListByTenantID(_ context.Context, _, tag string) ([]domain.Insight, error) {
@@ -72,9 +93,17 @@ func (s *spyRepo) ListByTag(_ context.Context, _, _ string) ([]domain.TagMembers
 }
 
 func (s *spyRepo) ListTags(_ context.Context, _ string) ([]domain.TagSummary, error) {
+	if s.tags != nil {
+		return s.tags, nil
+	}
 	return []domain.TagSummary{}, nil
 }
 
+func (s *spyRepo) GetByID(_ context.Context, _, id string) (domain.Insight, bool, error) {
+	insight, ok := s.stored[id]
+	return insight, ok, nil
+}
+
 type spyEnrichmentClient struct {
 	log *callLog
 
@@ -83,7 +112,7 @@ type spyEnrichmentClient struct {
 	gotText      string
 }
 
-func (s *spyEnrichmentClient) Enrich(_ context.Context, text string) (domain.Enrichment, error) {
+func (s *spyEnrichmentClient) Enrich(_ context.Context, text string, _ domain.Language) (domain.Enrichment, error) {
 	if s.log != nil {
 		s.log.add("llm.Enrich")
 	}
@@ -94,6 +123,8 @@ func (s *spyEnrichmentClient) Enrich(_ context.Context, text string) (domain.Enr
 	return s.returnEnrich, nil
 }
 
+func (s *spyEnrichmentClient) Version() string { return "spy/v1" }
+
 type spyDomainEventPublisher struct {
 	log *callLog
 
@@ -128,7 +159,7 @@ func TestService_Process_HardGuard_EmptyID(t *testing.T) {
 	repo := &spyRepo{log: log}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	_, err := svc.Process(context.Background(), makeInsight(""))
 	if err == nil {
@@ -147,7 +178,7 @@ func TestService_Process_WhenNew_PutThenEnrichThenUpdate_StrictOrder(t *testing.
 	repo := &spyRepo{log: log, putInserted: true}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	_, err := svc.Process(context.Background(), makeInsight("idk-123"))
 	if err != nil {
@@ -170,7 +201,7 @@ func TestService_Process_WhenDuplicate_SkipsEnrichAndUpdate(t *testing.T) {
 	repo := &spyRepo{log: log, putInserted: false}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	res, err := svc.Process(context.Background(), makeInsight("idk-dup"))
 	if err != nil {
@@ -192,7 +223,7 @@ func TestService_Process_WhenRepoPutFails_ReturnsError_SkipsEnrichAndUpdate(t *t
 	repo := &spyRepo{log: log, putErr: putErr}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	_, err := svc.Process(context.Background(), makeInsight("idk-puterr"))
 	if err == nil {
@@ -213,7 +244,7 @@ func TestService_Process_WhenEnrichFails_SoftFail_InsightStillInserted(t *testin
 	repo := &spyRepo{log: log, putInserted: true}
 	spy := &spyEnrichmentClient{log: log, enrichErr: errors.New("enrich boom")}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	res, err := svc.Process(context.Background(), makeInsight("idk-enricherr"))
 	if err != nil {
@@ -237,13 +268,32 @@ func TestService_Process_WhenEnrichFails_SoftFail_InsightStillInserted(t *testin
 	}
 }
 
+func TestService_Process_OutOfRangeEnrichment_StillStoresItsTags(t *testing.T) {
+	repo := &spyRepo{putInserted: true}
+	spy := &spyEnrichmentClient{returnEnrich: domain.Enrichment{Tags: []string{"a"}, Actionability: 3, Sentiment: "mixed"}}
+	pub := &spyDomainEventPublisher{}
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
+
+	res, err := svc.Process(context.Background(), makeInsight("idk-out-of-range"))
+	if err != nil || !res.Inserted {
+		t.Fatalf("expected Inserted=true, got res=%+v err=%v", res, err)
+	}
+	got := repo.gotUpdateInsight.Enrichment
+	if got == nil || len(got.Tags) != 1 || got.Tags[0] != "a" {
+		t.Fatalf("expected the tags stored despite the bad fields, got %+v", got)
+	}
+	if got.Actionability != 1 || got.Sentiment != "" {
+		t.Fatalf("expected actionability clamped to 1 and the unknown sentiment dropped, got %v %q", got.Actionability, got.Sentiment)
+	}
+}
+
 func TestService_Process_WhenUpdateFails_ReturnsError_AfterPutAndEnrich(t *testing.T) {
 	log := &callLog{}
 	updateErr := errors.New("update boom")
 	repo := &spyRepo{log: log, putInserted: true, updateErr: updateErr}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	_, err := svc.Process(context.Background(), makeInsight("idk-updateerr"))
 	if err == nil {
@@ -271,7 +321,7 @@ func TestService_Process_NilEnricher_SkipsEnrichAndUpdate(t *testing.T) {
 	log := &callLog{}
 	repo := &spyRepo{log: log, putInserted: true}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, nil, pub)
+	svc := NewService(repo, nil, pub, nil, nil)
 
 	res, err := svc.Process(context.Background(), makeInsight("idk-nilenr"))
 	if err != nil {
@@ -297,7 +347,7 @@ func TestService_Process_PropagatesInsightToRepo(t *testing.T) {
 	repo := &spyRepo{log: log, putInserted: true}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	_, err := svc.Process(context.Background(), makeInsight("idk-prop"))
 	if err != nil {
@@ -317,7 +367,7 @@ func TestService_Process_EnrichmentInput_IncludesNotes(t *testing.T) {
 	repo := &spyRepo{log: log, putInserted: true}
 	spy := &spyEnrichmentClient{log: log}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	insight := makeInsight("idk-notes")
 	insight.Notes = "reminds me of stoicism"
@@ -342,7 +392,7 @@ func TestService_Process_UpdateReceivesEnrichmentFromLLM(t *testing.T) {
 		},
 	}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, llm.NewService(spy), pub)
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 	_, err := svc.Process(context.Background(), makeInsight("idk-enriched"))
 	if err != nil {
@@ -370,7 +420,7 @@ func TestService_Process_Redelivery_PublishesInsightCreatedOnlyOnce(t *testing.T
 	log := &callLog{}
 	repo := &spyRepo{log: log, putInserted: true}
 	pub := &spyDomainEventPublisher{log: log}
-	svc := NewService(repo, nil, pub)
+	svc := NewService(repo, nil, pub, nil, nil)
 
 	target := makeInsight("idk-once")
 	if _, err := svc.Process(context.Background(), target); err != nil {
@@ -391,6 +441,57 @@ func TestService_Process_Redelivery_PublishesInsightCreatedOnlyOnce(t *testing.T
 	}
 }
 
+func TestService_Process_RedeliveryOfUnenrichedInsight_EnrichesIt(t *testing.T) {
+	repo := &spyRepo{putInserted: true, updateErr: errors.New("timed out")}
+	spy := &spyEnrichmentClient{returnEnrich: domain.Enrichment{Model: "m", Tags: []string{"Learning"}}}
+	pub := &spyDomainEventPublisher{}
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
+
+	target := makeInsight("idk-unenriched")
+	if _, err := svc.Process(context.Background(), target); err == nil {
+		t.Fatalf("expected the first delivery to fail saving its enrichment")
+	}
+	repo.updateErr = nil
+	res, err := svc.Process(context.Background(), target)
+	if err != nil {
+		t.Fatalf("unexpected err on redelivery: %v", err)
+	}
+
+	if res.Inserted {
+		t.Fatalf("expected Inserted=false on redelivery")
+	}
+	if e := repo.stored[target.ID].Enrichment; e == nil || len(e.Tags) != 1 || e.Tags[0] != "learning" {
+		t.Fatalf("stored enrichment = %+v, want the redelivery to enrich it", e)
+	}
+	if last := pub.published[len(pub.published)-1]; last.EventType != domain.InsightEnriched {
+		t.Fatalf("last event = %s, want InsightEnriched", last.EventType)
+	}
+}
+
+func TestService_Process_RedeliveryOfEnrichedInsight_LeavesIt(t *testing.T) {
+	log := &callLog{}
+	repo := &spyRepo{putInserted: true}
+	spy := &spyEnrichmentClient{log: log, returnEnrich: domain.Enrichment{Model: "m"}}
+	svc := NewService(repo, llm.NewService(spy), &spyDomainEventPublisher{}, nil, nil)
+
+	target := makeInsight("idk-enriched")
+	for range 2 {
+		if _, err := svc.Process(context.Background(), target); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+
+	calls := 0
+	for _, e := range log.entries {
+		if e == "llm.Enrich" {
+			calls++
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("llm calls = %d, want the redelivery left alone", calls)
+	}
+}
+
 func TestService_Process_PublishFailureIsTransient(t *testing.T) {
 	t.Run("InsightCreated publish failure", func(t *testing.T) {
 		log := &callLog{}
@@ -398,7 +499,7 @@ func TestService_Process_PublishFailureIsTransient(t *testing.T) {
 		spy := &spyEnrichmentClient{log: log}
 		publishErr := errors.New("eventbridge boom")
 		pub := &spyDomainEventPublisher{log: log, failEventType: domain.InsightCreated, failErr: publishErr}
-		svc := NewService(repo, llm.NewService(spy), pub)
+		svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 		_, err := svc.Process(context.Background(), makeInsight("idk-pub-created-fail"))
 		if err == nil {
@@ -423,7 +524,7 @@ func TestService_Process_PublishFailureIsTransient(t *testing.T) {
 		spy := &spyEnrichmentClient{log: log}
 		publishErr := errors.New("eventbridge boom")
 		pub := &spyDomainEventPublisher{log: log, failEventType: domain.InsightEnriched, failErr: publishErr}
-		svc := NewService(repo, llm.NewService(spy), pub)
+		svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
 
 		_, err := svc.Process(context.Background(), makeInsight("idk-pub-enriched-fail"))
 		if err == nil {
@@ -443,9 +544,52 @@ func TestService_Process_PublishFailureIsTransient(t *testing.T) {
 	})
 }
 
+func TestService_Reenrich_EnrichesUpdatesAndPublishes_NeverCreates(t *testing.T) {
+	log := &callLog{}
+	repo := &spyRepo{log: log}
+	spy := &spyEnrichmentClient{log: log, returnEnrich: domain.Enrichment{Tags: []string{"Stoicism"}}}
+	pub := &spyDomainEventPublisher{log: log}
+	svc := NewService(repo, llm.NewService(spy), pub, nil, nil)
+
+	got, err := svc.Reenrich(context.Background(), makeInsight("i-1"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	want := []string{"llm.Enrich", "repo.Update", "events.Publish:InsightEnriched"}
+	if strings.Join(log.entries, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected calls=%v, got %v", want, log.entries)
+	}
+	if got.Enrichment == nil || got.Enrichment.Tags[0] != "stoicism" || got.Enrichment.EnrichedAt.IsZero() {
+		t.Fatalf("returned enrichment = %+v, want normalized tags and an EnrichedAt", got.Enrichment)
+	}
+}
+
+func TestService_Reenrich_EnrichFails_ReturnsErrorInsteadOfSoftFailing(t *testing.T) {
+	log := &callLog{}
+	repo := &spyRepo{log: log}
+	spy := &spyEnrichmentClient{log: log, enrichErr: errors.New("enrich boom")}
+	svc := NewService(repo, llm.NewService(spy), &spyDomainEventPublisher{log: log}, nil, nil)
+
+	if _, err := svc.Reenrich(context.Background(), makeInsight("i-1")); err == nil {
+		t.Fatal("expected the LLM error to be returned")
+	}
+	if len(log.entries) != 1 {
+		t.Fatalf("expected only llm.Enrich, got %v", log.entries)
+	}
+}
+
+func TestService_Reenrich_NilEnricher_ReturnsError(t *testing.T) {
+	svc := NewService(&spyRepo{}, nil, &spyDomainEventPublisher{}, nil, nil)
+
+	if _, err := svc.Reenrich(context.Background(), makeInsight("i-1")); err == nil {
+		t.Fatal("expected an error when no LLM is configured")
+	}
+}
+
 func TestService_ListByTenantID_NoTag_PassesThroughEmpty(t *testing.T) {
 	repo := &spyRepo{}
-	svc := NewService(repo, nil, &spyDomainEventPublisher{})
+	svc := NewService(repo, nil, &spyDomainEventPublisher{}, nil, nil)
 
 	if _, err := svc.ListByTenantID(context.Background(), "t-1", ""); err != nil {
 		t.Fatalf("unexpected err: %v", err)
@@ -457,7 +601,7 @@ func TestService_ListByTenantID_NoTag_PassesThroughEmpty(t *testing.T) {
 
 func TestService_ListByTenantID_DenormalizedTag_NormalizesBeforeQuery(t *testing.T) {
 	repo := &spyRepo{}
-	svc := NewService(repo, nil, &spyDomainEventPublisher{})
+	svc := NewService(repo, nil, &spyDomainEventPublisher{}, nil, nil)
 
 	if _, err := svc.ListByTenantID(context.Background(), "t-1", "Delegation"); err != nil {
 		t.Fatalf("unexpected err: %v", err)
@@ -469,7 +613,7 @@ func TestService_ListByTenantID_DenormalizedTag_NormalizesBeforeQuery(t *testing
 
 func TestService_ListByTenantID_UnnormalizableTag_SkipsRepoReturnsEmpty(t *testing.T) {
 	repo := &spyRepo{}
-	svc := NewService(repo, nil, &spyDomainEventPublisher{})
+	svc := NewService(repo, nil, &spyDomainEventPublisher{}, nil, nil)
 
 	insights, err := svc.ListByTenantID(context.Background(), "t-1", "###")
 	if err != nil {
@@ -482,3 +626,209 @@ func TestService_ListByTenantID_UnnormalizableTag_SkipsRepoReturnsEmpty(t *testi
 		t.Fatalf("expected repo not called for unnormalizable tag")
 	}
 }
+
+type spyDuplicates struct {
+	log       *callLog
+	detection duplicate.Detection
+}
+
+func (s *spyDuplicates) Detect(context.Context, domain.Insight) (duplicate.Detection, error) {
+	s.log.add("duplicates.Detect")
+	return s.detection, nil
+}
+
+func (s *spyDuplicates) Merge(context.Context, domain.Insight, duplicate.Detection) error {
+	s.log.add("duplicates.Merge")
+	return nil
+}
+
+func (s *spyDuplicates) Flag(context.Context, domain.Insight, duplicate.Detection) error {
+	s.log.add("duplicates.Flag")
+	return nil
+}
+
+func (s *spyDuplicates) List(context.Context, string) ([]domain.Duplicate, error) {
+	return nil, nil
+}
+
+func TestService_Process_DuplicateUnderMergePolicy_MergesInsteadOfCreating(t *testing.T) {
+	log := &callLog{}
+	dups := &spyDuplicates{log: log, detection: duplicate.Detection{
+		Policy: domain.DuplicateMerge, Found: true, Match: domain.Insight{ID: "existing"}, Similarity: 1,
+	}}
+	svc := NewService(&spyRepo{log: log, putInserted: true}, llm.NewService(&spyEnrichmentClient{log: log}), &spyDomainEventPublisher{log: log}, dups, nil)
+
+	res, err := svc.Process(context.Background(), makeInsight("id-1"))
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if res.Inserted || res.MergedInto != "existing" {
+		t.Fatalf("result = %+v, want merged into existing", res)
+	}
+	if got := strings.Join(log.entries, ","); got != "duplicates.Detect,duplicates.Merge" {
+		t.Fatalf("calls = %s, want detect then merge and nothing else", got)
+	}
+}
+
+func TestService_Process_DuplicateUnderFlagPolicy_CreatesThenFlags(t *testing.T) {
+	log := &callLog{}
+	dups := &spyDuplicates{log: log, detection: duplicate.Detection{
+		Policy: domain.DuplicateFlag, Found: true, Match: domain.Insight{ID: "existing"}, Similarity: 0.9,
+	}}
+	svc := NewService(&spyRepo{log: log, putInserted: true}, nil, &spyDomainEventPublisher{log: log}, dups, nil)
+
+	res, err := svc.Process(context.Background(), makeInsight("id-1"))
+	if err != nil || !res.Inserted {
+		t.Fatalf("result = %+v, %v; want inserted", res, err)
+	}
+	if got := strings.Join(log.entries[:3], ","); got != "duplicates.Detect,repo.CreateIfAbsent,duplicates.Flag" {
+		t.Fatalf("calls = %v, want detect, create, flag", log.entries)
+	}
+}
+
+// batchEnrichmentClient answers each text with itself as Summary, alone or
+// in a batch, so a test can tell which insight got which answer.
+type batchEnrichmentClient struct {
+	spyEnrichmentClient
+	batches [][]string
+}
+
+func (c *batchEnrichmentClient) Enrich(_ context.Context, text string, _ domain.Language) (domain.Enrichment, error) {
+	return domain.Enrichment{Summary: text, Tags: []string{"reading"}}, nil
+}
+
+func (c *batchEnrichmentClient) EnrichBatch(_ context.Context, texts []string, _ domain.Language) ([]domain.Enrichment, error) {
+	c.batches = append(c.batches, texts)
+	out := make([]domain.Enrichment, len(texts))
+	for i, text := range texts {
+		out[i] = domain.Enrichment{Summary: text, Tags: []string{"reading"}}
+	}
+	return out, nil
+}
+
+func TestService_ProcessBatch_EnrichesTogetherPerTenant(t *testing.T) {
+	repo := &spyRepo{putInserted: true}
+	client := &batchEnrichmentClient{}
+	var updated []domain.Insight
+	svc := NewService(&recordingRepo{spyRepo: repo, updated: &updated}, llm.NewService(client), &spyDomainEventPublisher{}, nil, nil)
+
+	in := []domain.Insight{makeInsight("a"), makeInsight("b"), makeInsight("c"), makeInsight("d")}
+	in[0].Text, in[1].Text, in[2].Text, in[3].Text = "first", "second", "third", "fourth"
+	in[2].TenantID = "t-2"
+
+	results := svc.ProcessBatch(context.Background(), in)
+
+	for i, r := range results {
+		if r.Err != nil || !r.Inserted {
+			t.Fatalf("result %d = %+v, want inserted", i, r)
+		}
+	}
+	if len(client.batches) != 1 || len(client.batches[0]) != 3 {
+		t.Fatalf("batches = %v, want t-1's three texts in one call and t-2's alone", client.batches)
+	}
+	if len(updated) != 4 {
+		t.Fatalf("updated = %d insights, want 4", len(updated))
+	}
+	for _, u := range updated {
+		if u.Enrichment == nil || u.Enrichment.Summary != u.Text {
+			t.Fatalf("insight %s got enrichment %+v, want its own", u.ID, u.Enrichment)
+		}
+	}
+}
+
+func TestService_ProcessBatch_OneFailureDoesNotFailTheOthers(t *testing.T) {
+	repo := &spyRepo{putInserted: true}
+	svc := NewService(repo, llm.NewService(&batchEnrichmentClient{}), &spyDomainEventPublisher{}, nil, nil)
+
+	results := svc.ProcessBatch(context.Background(), []domain.Insight{makeInsight("a"), makeInsight(""), makeInsight("c")})
+
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Fatalf("results = %+v, want the healthy insights processed", results)
+	}
+	if !errors.As(results[1].Err, &apperr.PermanentError{}) {
+		t.Fatalf("result 1 err = %v, want its PermanentError", results[1].Err)
+	}
+}
+
+// recordingRepo keeps every Update, where spyRepo keeps only the last.
+type recordingRepo struct {
+	*spyRepo
+	updated *[]domain.Insight
+}
+
+func (r *recordingRepo) Update(ctx context.Context, insight domain.Insight) error {
+	*r.updated = append(*r.updated, insight)
+	return r.spyRepo.Update(ctx, insight)
+}
+
+func TestService_Process_RecordsDetectedLanguage_TextUntranslated(t *testing.T) {
+	repo := &spyRepo{putInserted: true}
+	svc := NewService(repo, nil, &spyDomainEventPublisher{}, nil, nil)
+
+	in := makeInsight("i-1")
+	in.Text = "Die Gewohnheiten, die wir jeden Tag wiederholen, sind das, was uns ausmacht."
+	if _, err := svc.Process(context.Background(), in); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.gotPutInsight.Language != "de" || repo.gotPutInsight.Text != in.Text {
+		t.Fatalf("stored %+v, want language de and the original text", repo.gotPutInsight)
+	}
+}
+
+func TestService_Reenrich_BackfillsMissingLanguage(t *testing.T) {
+	repo := &spyRepo{}
+	svc := NewService(repo, llm.NewService(&spyEnrichmentClient{}), &spyDomainEventPublisher{}, nil, nil)
+
+	in := makeInsight("i-1")
+	in.Text = "The habits you repeat every day are what shape the person you become."
+	if _, err := svc.Reenrich(context.Background(), in); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if repo.gotUpdateInsight.Language != "en" {
+		t.Fatalf("updated language = %q, want en", repo.gotUpdateInsight.Language)
+	}
+}
+
+type fakeTagHistory struct {
+	snapshots []domain.TagSnapshot
+	gotSince  time.Time
+}
+
+func (f *fakeTagHistory) SaveTagSnapshot(context.Context, domain.TagSnapshot, time.Time) error {
+	return nil
+}
+
+func (f *fakeTagHistory) ListTagSnapshots(_ context.Context, _ string, since time.Time) ([]domain.TagSnapshot, error) {
+	f.gotSince = since
+	return f.snapshots, nil
+}
+
+func TestService_ListTags_FlagsTrendsFromSnapshots(t *testing.T) {
+	now := time.Now()
+	history := &fakeTagHistory{snapshots: []domain.TagSnapshot{
+		{TakenAt: now.AddDate(0, 0, -14), Tags: []domain.TagSummary{{Tag: "focus", Score: 0.1}, {Tag: "habits", Score: 0.8}}},
+		{TakenAt: now.AddDate(0, 0, -7), Tags: []domain.TagSummary{{Tag: "focus", Score: 0.3}, {Tag: "habits", Score: 0.6}}},
+	}}
+	repo := &spyRepo{tags: []domain.TagSummary{{Tag: "focus", Score: 0.5}, {Tag: "habits", Score: 0.4}}}
+	svc := NewService(repo, nil, &spyDomainEventPublisher{}, nil, history)
+
+	tags, err := svc.ListTags(context.Background(), "t-1")
+
+	if err != nil || !tags[0].Trend.Trending || !tags[1].Trend.Fading {
+		t.Fatalf("tags = %+v, %v; want focus trending and habits fading", tags, err)
+	}
+	if window := time.Since(history.gotSince); window < domain.TagTrendWindow {
+		t.Fatalf("read snapshots from %v back, want at least %v", window, domain.TagTrendWindow)
+	}
+}
+
+func TestService_ListTags_NoHistory_LeavesTrendsUnset(t *testing.T) {
+	repo := &spyRepo{tags: []domain.TagSummary{{Tag: "focus", Score: 0.5}}}
+	svc := NewService(repo, nil, &spyDomainEventPublisher{}, nil, nil)
+
+	tags, err := svc.ListTags(context.Background(), "t-1")
+
+	if err != nil || tags[0].Trend != (domain.TagTrend{}) {
+		t.Fatalf("tags = %+v, %v; want no trend without a history repository", tags, err)
+	}
+}
@@ -0,0 +1,207 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// MaxBatchSize caps how many texts one batch call carries: enough to
+// amortize the system prompt and schema, few enough that one answer stays
+// well inside the output limit and one malformed reply costs little.
+const MaxBatchSize = 10
+
+// DeadlineReserve is how much of ctx's deadline EnrichBatch keeps clear of
+// client calls: time for the fallback, and for the caller to save what it
+// got, before a Lambda invocation's deadline. A call that would run into
+// it is cut short and falls back; one that can't start before it never
+// does.
+const DeadlineReserve = 10 * time.Second
+
+var errNoTimeLeft = errors.New("too close to the deadline for an llm call")
+
+// EnrichBatch enriches texts for one tenant, answering for each exactly as
+// Enrich would — cache, budget, breaker and fallback included — but asking
+// the client for up to MaxBatchSize texts per call when it implements
+// ports.BatchEnrichmentClient. Results and errors are in texts' order.
+//
+// A batch the client answered but couldn't map back (ports.ErrMalformedBatch)
+// is retried one text per call, concurrently; any other failure goes to
+// the fallback per text, as Enrich's would. Every client call ends
+// DeadlineReserve before ctx's deadline, so a slow provider costs tags,
+// not the whole invocation.
+func (s *Service) EnrichBatch(ctx context.Context, tenantID string, texts []string) ([]domain.Enrichment, []error) {
+	out := make([]domain.Enrichment, len(texts))
+	errs := make([]error, len(texts))
+
+	if s.client == nil {
+		for i, text := range texts {
+			out[i], errs[i] = s.fallbackFor(ctx, tenantID, text, errNoClient)
+		}
+		return out, errs
+	}
+
+	tagLanguage, err := s.tagLanguage(ctx, tenantID)
+	if err != nil {
+		for i, text := range texts {
+			out[i], errs[i] = s.fallbackFor(ctx, tenantID, text, err)
+		}
+		return out, errs
+	}
+
+	batcher, ok := s.client.(ports.BatchEnrichmentClient)
+	if !ok || len(texts) < 2 {
+		all := make([]int, len(texts))
+		for i := range all {
+			all[i] = i
+		}
+		s.enrichEach(ctx, tenantID, texts, all, tagLanguage, out, errs)
+		return out, errs
+	}
+
+	keys := make([]string, len(texts))
+	var pending []int
+	for i, text := range texts {
+		if s.cache != nil {
+			keys[i] = cacheKey(s.client.Version(), tagLanguage, text)
+			cached, ok, err := s.cache.GetCachedEnrichment(ctx, tenantID, keys[i])
+			if err != nil {
+				slog.WarnContext(ctx, "failed to read enrichment cache", "tenant_id", tenantID, "err", err)
+			} else if ok {
+				out[i] = cached
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+
+	for start := 0; start < len(pending); start += MaxBatchSize {
+		chunk := pending[start:min(start+MaxBatchSize, len(pending))]
+		chunkTexts := make([]string, len(chunk))
+		for j, i := range chunk {
+			chunkTexts[j] = texts[i]
+		}
+
+		var enrichments []domain.Enrichment
+		callCtx, cancel, ok := clientContext(ctx)
+		if ok {
+			enrichments, err = s.enrichBatchUncached(callCtx, tenantID, batcher, chunkTexts, tagLanguage)
+		} else {
+			err = errNoTimeLeft
+		}
+		cancel()
+
+		switch {
+		case err == nil:
+			for j, i := range chunk {
+				out[i] = enrichments[j]
+				if s.cache != nil {
+					if err := s.cache.PutCachedEnrichment(ctx, tenantID, keys[i], enrichments[j], s.now().Add(s.cacheTTL)); err != nil {
+						slog.WarnContext(ctx, "failed to write enrichment cache", "tenant_id", tenantID, "err", err)
+					}
+				}
+			}
+		case errors.Is(err, ports.ErrMalformedBatch):
+			slog.WarnContext(ctx, "llm batch enrichment malformed, enriching one by one",
+				"tenant_id", tenantID, "batch_size", len(chunk), "err", err)
+			s.enrichEach(ctx, tenantID, texts, chunk, tagLanguage, out, errs)
+		default:
+			for _, i := range chunk {
+				out[i], errs[i] = s.fallbackFor(ctx, tenantID, texts[i], err)
+			}
+		}
+	}
+	return out, errs
+}
+
+// enrichEach answers texts at indexes one call each, concurrently, so a
+// batch retried one by one takes as long as its slowest text rather than
+// the sum of them.
+func (s *Service) enrichEach(ctx context.Context, tenantID string, texts []string, indexes []int, tagLanguage domain.Language, out []domain.Enrichment, errs []error) {
+	if len(indexes) == 1 {
+		i := indexes[0]
+		out[i], errs[i] = s.enrichOne(ctx, tenantID, texts[i], tagLanguage)
+		return
+	}
+	var wg sync.WaitGroup
+	for _, i := range indexes {
+		wg.Go(func() {
+			out[i], errs[i] = s.enrichOne(ctx, tenantID, texts[i], tagLanguage)
+		})
+	}
+	wg.Wait()
+}
+
+// enrichOne is Enrich under EnrichBatch's deadline: the client call ends
+// DeadlineReserve before ctx does, and the fallback gets the reserve.
+func (s *Service) enrichOne(ctx context.Context, tenantID, text string, tagLanguage domain.Language) (domain.Enrichment, error) {
+	callCtx, cancel, ok := clientContext(ctx)
+	defer cancel()
+	if !ok {
+		return s.fallbackFor(ctx, tenantID, text, errNoTimeLeft)
+	}
+	enrichment, err := s.callClient(callCtx, tenantID, text, tagLanguage)
+	if err != nil {
+		return s.fallbackFor(ctx, tenantID, text, err)
+	}
+	return enrichment, nil
+}
+
+// clientContext is ctx ending DeadlineReserve before ctx's own deadline,
+// or ctx itself if it has none. ok is false when that's already passed.
+func clientContext(ctx context.Context) (callCtx context.Context, cancel context.CancelFunc, ok bool) {
+	deadline, has := ctx.Deadline()
+	if !has {
+		return ctx, func() {}, true
+	}
+	cutoff := deadline.Add(-DeadlineReserve)
+	if !time.Now().Before(cutoff) {
+		return ctx, func() {}, false
+	}
+	callCtx, cancel = context.WithDeadline(ctx, cutoff)
+	return callCtx, cancel, true
+}
+
+// enrichBatchUncached is enrichUncached for one batch call, recorded as
+// one call whatever it carried. A malformed answer still means the
+// provider answered, so the breaker counts it a success and the budget
+// counts what it cost.
+func (s *Service) enrichBatchUncached(ctx context.Context, tenantID string, batcher ports.BatchEnrichmentClient, texts []string, tagLanguage domain.Language) ([]domain.Enrichment, error) {
+	if s.budget != nil {
+		if err := s.budget.Check(ctx, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.breaker != nil {
+		if err := s.breaker.Allow(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	enrichments, err := batcher.EnrichBatch(ctx, texts, tagLanguage)
+	if s.breaker != nil {
+		if err != nil && !errors.Is(err, ports.ErrMalformedBatch) {
+			s.breaker.Failure(ctx)
+		} else {
+			s.breaker.Success(ctx)
+		}
+	}
+	if err != nil {
+		s.recordBilled(ctx, tenantID, err)
+		return nil, err
+	}
+
+	var inputTokens, outputTokens int64
+	for _, enrichment := range enrichments {
+		inputTokens += enrichment.InputTokens
+		outputTokens += enrichment.OutputTokens
+	}
+	s.recordUsage(ctx, tenantID, inputTokens, outputTokens)
+	return enrichments, nil
+}
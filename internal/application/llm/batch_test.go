@@ -0,0 +1,225 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// batchClient answers each text with its own text as Summary, through
+// EnrichBatch unless batchErr is set.
+type batchClient struct {
+	mu       sync.Mutex
+	batches  [][]string
+	singles  int
+	batchErr error
+}
+
+// batchUsage is what every batchClient batch answer costs.
+const batchUsage = 100
+
+func (c *batchClient) Enrich(_ context.Context, text string, _ domain.Language) (domain.Enrichment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.singles++
+	return domain.Enrichment{Model: "m", Summary: text}, nil
+}
+
+func (c *batchClient) EnrichBatch(_ context.Context, texts []string, _ domain.Language) ([]domain.Enrichment, error) {
+	c.batches = append(c.batches, texts)
+	if c.batchErr != nil {
+		return nil, c.batchErr
+	}
+	out := make([]domain.Enrichment, len(texts))
+	for i, text := range texts {
+		out[i] = domain.Enrichment{Model: "m", Summary: text}
+	}
+	out[0].InputTokens = batchUsage
+	return out, nil
+}
+
+func (c *batchClient) Version() string { return "batch/v1" }
+
+func texts(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("highlight %d", i)
+	}
+	return out
+}
+
+func TestService_EnrichBatch_ChunksAndMapsBackInOrder(t *testing.T) {
+	client := &batchClient{}
+	budget := &spyBudget{}
+	in := texts(MaxBatchSize + 3)
+
+	got, errs := NewService(client).WithBudget(budget).EnrichBatch(context.Background(), "t-1", in)
+
+	if len(client.batches) != 2 || len(client.batches[0]) != MaxBatchSize || len(client.batches[1]) != 3 || client.singles != 0 {
+		t.Fatalf("batches = %d (%d singles), want one of %d and one of 3", len(client.batches), client.singles, MaxBatchSize)
+	}
+	for i := range in {
+		if errs[i] != nil || got[i].Summary != in[i] {
+			t.Fatalf("item %d = %+v, %v; want the answer for %q", i, got[i], errs[i], in[i])
+		}
+	}
+	if len(budget.recorded) != 2 || budget.recorded[0] != (domain.LLMUsage{Calls: 1, InputTokens: batchUsage}) {
+		t.Fatalf("recorded = %+v, want one call per batch with the whole batch's usage", budget.recorded)
+	}
+}
+
+func TestService_EnrichBatch_Malformed_FallsBackToPerItemCalls(t *testing.T) {
+	client := &batchClient{batchErr: fmt.Errorf("%w: unexpected index 7", ports.ErrMalformedBatch)}
+	breaker := NewBreaker(1, time.Minute)
+	in := texts(3)
+
+	got, errs := NewService(client).WithBreaker(breaker).EnrichBatch(context.Background(), "t-1", in)
+
+	if client.singles != 3 {
+		t.Fatalf("per-item calls = %d, want 3", client.singles)
+	}
+	for i := range in {
+		if errs[i] != nil || got[i].Summary != in[i] {
+			t.Fatalf("item %d = %+v, %v; want the per-item answer", i, got[i], errs[i])
+		}
+	}
+	if s := breaker.State(context.Background()); s != BreakerClosed {
+		t.Fatalf("breaker = %s, want a malformed answer not counted as an outage", s)
+	}
+}
+
+func TestService_EnrichBatch_MalformedButBilled_RecordsTheBatchCall(t *testing.T) {
+	malformed := fmt.Errorf("%w: 2 items for 3 highlights", ports.ErrMalformedBatch)
+	client := &batchClient{batchErr: &ports.BilledError{InputTokens: 300, OutputTokens: 90, Err: malformed}}
+	budget := &spyBudget{}
+
+	_, _ = NewService(client).WithBudget(budget).EnrichBatch(context.Background(), "t-1", texts(3))
+
+	if client.singles != 3 {
+		t.Fatalf("per-item calls = %d, want the billed error still treated as malformed", client.singles)
+	}
+	if len(budget.recorded) != 4 || budget.recorded[0] != (domain.LLMUsage{Calls: 1, InputTokens: 300, OutputTokens: 90}) {
+		t.Fatalf("recorded = %+v, want the malformed batch counted once, then each retry", budget.recorded)
+	}
+}
+
+func TestService_EnrichBatch_ClientFails_FallsBackPerItemWithoutRetrying(t *testing.T) {
+	client := &batchClient{batchErr: errors.New("llm down")}
+	svc := NewService(client).WithFallback(NewFallback(&fakeCorpus{}))
+
+	got, errs := svc.EnrichBatch(context.Background(), "t-1", texts(3))
+
+	if client.singles != 0 {
+		t.Fatalf("per-item calls = %d, want none for an unavailable provider", client.singles)
+	}
+	for i := range got {
+		if errs[i] != nil || !got[i].Fallback {
+			t.Fatalf("item %d = %+v, %v; want a fallback enrichment", i, got[i], errs[i])
+		}
+	}
+}
+
+func TestService_EnrichBatch_CachedTextsLeftOutOfTheBatch(t *testing.T) {
+	client := &batchClient{}
+	svc := NewService(client).WithCache(&fakeCache{entries: map[string]domain.Enrichment{}}, 0)
+	in := texts(3)
+
+	if _, err := svc.Enrich(context.Background(), "t-1", in[1]); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	got, errs := svc.EnrichBatch(context.Background(), "t-1", in)
+
+	if len(client.batches) != 1 || len(client.batches[0]) != 2 {
+		t.Fatalf("batches = %v, want one of the 2 uncached texts", client.batches)
+	}
+	for i := range in {
+		if errs[i] != nil || got[i].Summary != in[i] {
+			t.Fatalf("item %d = %+v, %v; want the answer for %q", i, got[i], errs[i], in[i])
+		}
+	}
+
+	if _, errs := svc.EnrichBatch(context.Background(), "t-1", in); errs[0] != nil || len(client.batches) != 1 {
+		t.Fatalf("batches = %d, want batch answers cached too", len(client.batches))
+	}
+}
+
+func TestService_EnrichBatch_NotABatchClient_CallsPerItem(t *testing.T) {
+	client := &countingClient{}
+
+	_, errs := NewService(client).EnrichBatch(context.Background(), "t-1", texts(3))
+
+	if client.calls != 3 || errs[0] != nil {
+		t.Fatalf("client calls = %d, errs = %v; want one Enrich per text", client.calls, errs)
+	}
+}
+
+// stalledClient never answers: each call waits out its ctx.
+type stalledClient struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *stalledClient) wait(ctx context.Context) error {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *stalledClient) Enrich(ctx context.Context, _ string, _ domain.Language) (domain.Enrichment, error) {
+	return domain.Enrichment{}, c.wait(ctx)
+}
+
+func (c *stalledClient) EnrichBatch(ctx context.Context, _ []string, _ domain.Language) ([]domain.Enrichment, error) {
+	return nil, c.wait(ctx)
+}
+
+func (c *stalledClient) Version() string { return "stalled/v1" }
+
+func TestService_EnrichBatch_StalledClient_FallsBackBeforeTheDeadline(t *testing.T) {
+	client := &stalledClient{}
+	svc := NewService(client).WithFallback(NewFallback(&fakeCorpus{}))
+	ctx, cancel := context.WithTimeout(context.Background(), DeadlineReserve+50*time.Millisecond)
+	defer cancel()
+
+	got, errs := svc.EnrichBatch(ctx, "t-1", texts(3))
+
+	if ctx.Err() != nil {
+		t.Fatalf("EnrichBatch returned after the deadline, want the reserve kept clear")
+	}
+	if client.calls != 1 {
+		t.Fatalf("client calls = %d, want the batch call only", client.calls)
+	}
+	for i := range got {
+		if errs[i] != nil || !got[i].Fallback {
+			t.Fatalf("item %d = %+v, %v; want a fallback enrichment", i, got[i], errs[i])
+		}
+	}
+}
+
+func TestService_EnrichBatch_DeadlineInsideTheReserve_SkipsTheClient(t *testing.T) {
+	client := &stalledClient{}
+	svc := NewService(client).WithFallback(NewFallback(&fakeCorpus{}))
+	ctx, cancel := context.WithTimeout(context.Background(), DeadlineReserve/2)
+	defer cancel()
+
+	for _, n := range []int{1, 3} {
+		got, errs := svc.EnrichBatch(ctx, "t-1", texts(n))
+
+		if client.calls != 0 {
+			t.Fatalf("client calls = %d, want none this close to the deadline", client.calls)
+		}
+		for i := range got {
+			if errs[i] != nil || !got[i].Fallback {
+				t.Fatalf("item %d of %d = %+v, %v; want a fallback enrichment", i, n, got[i], errs[i])
+			}
+		}
+	}
+}
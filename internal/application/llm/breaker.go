@@ -0,0 +1,143 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is what Enrich gets from a client call the breaker
+// skipped. The fallback, when configured, answers instead.
+var ErrCircuitOpen = errors.New("llm circuit breaker open")
+
+// BreakerState is a Breaker's position.
+type BreakerState string
+
+const (
+	// BreakerClosed passes every call through and counts consecutive
+	// failures.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen skips every call until the cool-down has passed.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single probe call through; its outcome closes
+	// or reopens the breaker.
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerCoolDown         = time.Minute
+)
+
+// Breaker stops Enrich from calling a provider that keeps failing. The
+// clients already retry (three attempts at up to 30s each), so during an
+// outage every message would otherwise spend minutes of Lambda time before
+// soft-failing; with the breaker open it goes straight to the fallback.
+//
+// State is per process: each warm Lambda container trips on its own
+// failures, and a cold one starts closed. That's enough to bound the time
+// one container burns, without a shared store on the hot path.
+//
+// Every state change is logged as "llm circuit breaker state changed" with
+// from/to fields; the worker's CloudWatch metric filter counts them
+// (terraform/envs/dev/worker.tf).
+type Breaker struct {
+	threshold int
+	coolDown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker opens after threshold consecutive failures and stays open for
+// coolDown before letting a probe through. Non-positive values take the
+// defaults.
+func NewBreaker(threshold int, coolDown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = DefaultBreakerFailureThreshold
+	}
+	if coolDown <= 0 {
+		coolDown = DefaultBreakerCoolDown
+	}
+	return &Breaker{
+		threshold: threshold,
+		coolDown:  coolDown,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// State is the breaker's current position, moving to half-open first if
+// the cool-down has passed.
+func (b *Breaker) State(ctx context.Context) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expireCoolDown(ctx)
+	return b.state
+}
+
+// Allow reports whether a call may go ahead: ErrCircuitOpen if not. Every
+// allowed call must be followed by exactly one Success or Failure.
+func (b *Breaker) Allow(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expireCoolDown(ctx)
+
+	switch b.state {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success closes the breaker and resets its failure count.
+func (b *Breaker) Success(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	b.transition(ctx, BreakerClosed)
+}
+
+// Failure counts a failed call, opening the breaker at the threshold — or
+// straight away when it was the half-open probe that failed.
+func (b *Breaker) Failure(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.transition(ctx, BreakerOpen)
+	}
+}
+
+func (b *Breaker) expireCoolDown(ctx context.Context) {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.coolDown {
+		b.transition(ctx, BreakerHalfOpen)
+	}
+}
+
+// transition moves to state and logs the change; callers hold b.mu.
+func (b *Breaker) transition(ctx context.Context, state BreakerState) {
+	if b.state == state {
+		return
+	}
+	slog.WarnContext(ctx, "llm circuit breaker state changed",
+		"from", string(b.state),
+		"to", string(state),
+		"consecutive_failures", b.failures,
+	)
+	b.state = state
+}
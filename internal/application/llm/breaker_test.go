@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(threshold int, coolDown time.Duration) (*Breaker, *time.Time) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	b := NewBreaker(threshold, coolDown)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_OpensAtThresholdAndProbesAfterCoolDown(t *testing.T) {
+	ctx := context.Background()
+	b, now := newTestBreaker(2, time.Minute)
+
+	b.Failure(ctx)
+	if err := b.Allow(ctx); err != nil {
+		t.Fatalf("one failure below threshold: %v", err)
+	}
+	b.Failure(ctx)
+	if err := b.Allow(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen at threshold", err)
+	}
+
+	*now = now.Add(time.Minute)
+	if err := b.Allow(ctx); err != nil {
+		t.Fatalf("probe after cool-down: %v", err)
+	}
+	if err := b.Allow(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second concurrent probe: err = %v, want ErrCircuitOpen", err)
+	}
+
+	b.Success(ctx)
+	if got := b.State(ctx); got != BreakerClosed {
+		t.Fatalf("state = %s, want closed after a successful probe", got)
+	}
+}
+
+func TestBreaker_FailedProbe_Reopens(t *testing.T) {
+	ctx := context.Background()
+	b, now := newTestBreaker(1, time.Minute)
+
+	b.Failure(ctx)
+	*now = now.Add(time.Minute)
+	if got := b.State(ctx); got != BreakerHalfOpen {
+		t.Fatalf("state = %s, want half_open after cool-down", got)
+	}
+	_ = b.Allow(ctx)
+	b.Failure(ctx)
+
+	if got := b.State(ctx); got != BreakerOpen {
+		t.Fatalf("state = %s, want open after a failed probe", got)
+	}
+}
+
+func TestBreaker_SuccessResetsConsecutiveFailures(t *testing.T) {
+	ctx := context.Background()
+	b, _ := newTestBreaker(2, time.Minute)
+
+	b.Failure(ctx)
+	b.Success(ctx)
+	b.Failure(ctx)
+
+	if got := b.State(ctx); got != BreakerClosed {
+		t.Fatalf("state = %s, want closed: the failures weren't consecutive", got)
+	}
+}
@@ -0,0 +1,29 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// DefaultCacheTTL is how long a cached enrichment is reused. Long enough to
+// cover a passage arriving from both sources or being re-highlighted; short
+// enough that a tenant's cache doesn't grow without bound.
+const DefaultCacheTTL = 30 * 24 * time.Hour
+
+// cacheKey is what an enrichment is cached under: the client's Version,
+// the tag language and the text with whitespace collapsed, hashed. Whitespace is all that's
+// normalized — it's what differs between sources' exports of the same
+// passage, while case and punctuation can change what the model answers.
+func cacheKey(version string, tagLanguage domain.Language, text string) string {
+	h := sha256.New()
+	h.Write([]byte(version))
+	h.Write([]byte{0})
+	h.Write([]byte(tagLanguage))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,139 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+const (
+	// FallbackModel and FallbackVersion are the provenance a Fallback
+	// enrichment carries in place of a model ID and prompt version. Bump
+	// FallbackVersion with any change to how keywords are scored or
+	// mapped.
+	FallbackModel   = "keyword-fallback"
+	FallbackVersion = "keywords-v1"
+
+	// fallbackConcepts is how many top keyphrases a Fallback enrichment
+	// keeps as Concepts.
+	fallbackConcepts = 5
+)
+
+// Fallback derives an enrichment without a network call: RAKE keyphrases
+// from the text, weighted by IDF over the tenant's own highlights, mapped
+// onto tags the tenant already uses. It never invents a tag — a tenant
+// with no tagged insights yet gets none — because a vocabulary grown from
+// raw keywords would fragment tag relevance instead of feeding it.
+//
+// It's not a ports.EnrichmentClient: that port takes text alone, and both
+// the corpus and the vocabulary are per tenant.
+//
+// TRADE-OFF: every call lists the tenant's whole corpus. It only runs
+// while the LLM is down or unconfigured, and the list is the same read the
+// REST API's insight list already does; cache it if fallback traffic ever
+// stops being the exception.
+type Fallback struct {
+	insights ports.InsightRepository
+}
+
+func NewFallback(insights ports.InsightRepository) *Fallback {
+	return &Fallback{insights: insights}
+}
+
+func (f *Fallback) Enrich(ctx context.Context, tenantID, text string) (domain.Enrichment, error) {
+	start := time.Now()
+
+	corpus, err := f.insights.ListByTenantID(ctx, tenantID, "")
+	if err != nil {
+		return domain.Enrichment{}, fmt.Errorf("list corpus: %w", err)
+	}
+
+	docs := make([]map[string]bool, 0, len(corpus))
+	vocabulary := map[string]bool{}
+	for _, in := range corpus {
+		docs = append(docs, wordSet(in.Text))
+		if in.Enrichment != nil {
+			for _, tag := range in.Enrichment.Tags {
+				vocabulary[tag] = true
+			}
+		}
+	}
+
+	phrases := extractKeyphrases(text, corpusIDF(docs))
+
+	concepts := make([]string, 0, fallbackConcepts)
+	for _, p := range phrases[:min(len(phrases), fallbackConcepts)] {
+		concepts = append(concepts, p.text())
+	}
+
+	return domain.Enrichment{
+		Tags:          mapOntoVocabulary(phrases, vocabulary),
+		Concepts:      concepts,
+		Fallback:      true,
+		Model:         FallbackModel,
+		PromptVersion: FallbackVersion,
+		Latency:       time.Since(start),
+	}, nil
+}
+
+// mapOntoVocabulary scores every vocabulary tag by the keyphrases that
+// cover its words: a tag scores the best phrase score of each word it
+// shares with the text, scaled by the fraction of its words present. A
+// single-word tag needs its word; a longer one, at least half of them.
+// Best first, capped at MaxTagsPerInsight.
+func mapOntoVocabulary(phrases []keyphrase, vocabulary map[string]bool) []string {
+	weight := map[string]float64{}
+	for _, p := range phrases {
+		for _, w := range p.words {
+			weight[w] = max(weight[w], p.score)
+		}
+	}
+
+	type scored struct {
+		tag   string
+		score float64
+	}
+	var matches []scored
+	for tag := range vocabulary {
+		var words []string
+		for _, w := range strings.Split(tag, "-") {
+			if w = stem(w); w != "" && !stopwords[w] {
+				words = append(words, w)
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		var matched int
+		var sum float64
+		for _, w := range words {
+			if wt, ok := weight[w]; ok {
+				matched++
+				sum += wt
+			}
+		}
+		if matched == 0 || float64(matched)*2 < float64(len(words)) {
+			continue
+		}
+		matches = append(matches, scored{tag: tag, score: sum * float64(matched) / float64(len(words))})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].tag < matches[j].tag
+	})
+
+	tags := make([]string, 0, domain.MaxTagsPerInsight)
+	for _, m := range matches[:min(len(matches), domain.MaxTagsPerInsight)] {
+		tags = append(tags, m.tag)
+	}
+	return tags
+}
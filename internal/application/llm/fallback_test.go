@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// fakeCorpus only answers ListByTenantID; nothing else is reached from
+// Fallback.Enrich.
+type fakeCorpus struct {
+	ports.InsightRepository
+	insights []domain.Insight
+}
+
+func (f *fakeCorpus) ListByTenantID(_ context.Context, _, _ string) ([]domain.Insight, error) {
+	return f.insights, nil
+}
+
+func taggedInsight(text string, tags ...string) domain.Insight {
+	return domain.Insight{Text: text, Enrichment: &domain.Enrichment{Tags: tags}}
+}
+
+func TestFallback_Enrich_MapsKeywordsOntoTheTenantsVocabulary(t *testing.T) {
+	corpus := &fakeCorpus{insights: []domain.Insight{
+		taggedInsight("Small habits compound over years.", "psychology", "habit-formation"),
+		taggedInsight("Delegate outcomes, not tasks.", "leadership", "delegation"),
+		taggedInsight("Price on value, not on cost.", "business", "pricing-strategy"),
+	}}
+
+	got, err := NewFallback(corpus).Enrich(context.Background(), "t-1",
+		"Tiny habits, repeated daily, beat occasional heroic effort. Habit formation is about identity.")
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+
+	if len(got.Tags) == 0 || got.Tags[0] != "habit-formation" {
+		t.Fatalf("Tags = %v, want habit-formation first", got.Tags)
+	}
+	for _, tag := range got.Tags {
+		if tag == "delegation" || tag == "pricing-strategy" {
+			t.Fatalf("Tags = %v, want nothing the text doesn't mention", got.Tags)
+		}
+	}
+	if !got.Fallback || got.Model != FallbackModel || got.PromptVersion != FallbackVersion {
+		t.Fatalf("provenance = %+v, want it marked as the keyword fallback", got)
+	}
+	if len(got.Concepts) == 0 {
+		t.Fatal("expected the top keyphrases as concepts")
+	}
+}
+
+func TestFallback_Enrich_NoVocabulary_InventsNoTags(t *testing.T) {
+	got, err := NewFallback(&fakeCorpus{}).Enrich(context.Background(), "t-1", "Habits compound.")
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(got.Tags) != 0 {
+		t.Fatalf("Tags = %v, want none without a vocabulary to map onto", got.Tags)
+	}
+}
+
+func TestFallback_Enrich_MultiWordTagNeedsHalfItsWords(t *testing.T) {
+	corpus := &fakeCorpus{insights: []domain.Insight{
+		taggedInsight("x", "delayed-gratification-research"),
+		taggedInsight("y", "locus-of-control"),
+	}}
+
+	got, err := NewFallback(corpus).Enrich(context.Background(), "t-1", "Research on children shows delayed rewards matter.")
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(got.Tags) != 1 || got.Tags[0] != "delayed-gratification-research" {
+		t.Fatalf("Tags = %v, want [delayed-gratification-research] (2 of 3 words present)", got.Tags)
+	}
+}
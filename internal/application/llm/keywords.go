@@ -0,0 +1,155 @@
+package llm
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// stopwords split RAKE candidate phrases and never count as keywords
+// themselves. A short English list: highlights are overwhelmingly English,
+// and a non-English one just yields longer, lower-scoring phrases.
+var stopwords = toSet(strings.Fields(`
+	a about above after again against all also am an and any are as at be
+	because been before being below between both but by can could did do does
+	doing down during each even ever every few for from further had has have
+	having he her here hers him his how however i if in into is it its itself
+	just me more most much must my no nor not now of off often on once only or
+	other our ours out over own rather really same she should so some such than
+	that the their them then there these they this those through to too under
+	until up upon us very was we were what when where whether which while who
+	whom why will with without would yet you your yours
+`))
+
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// keyphrase is one RAKE candidate: a run of non-stopwords, stemmed.
+type keyphrase struct {
+	words []string
+	score float64
+}
+
+func (k keyphrase) text() string { return strings.Join(k.words, " ") }
+
+// tokens lowercases text and splits it into stemmed words, with "" marking
+// every phrase boundary (punctuation) so RAKE doesn't join across one.
+func tokens(text string) []string {
+	var out []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			out = append(out, stem(word.String()))
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '\'':
+			word.WriteRune(r)
+		case unicode.IsSpace(r), r == '-':
+			flush()
+		default:
+			flush()
+			out = append(out, "")
+		}
+	}
+	flush()
+	return out
+}
+
+// stem folds the plural and possessive endings that would otherwise keep
+// "habits" from matching a "habit" tag. Deliberately no more than that —
+// the same trade-off NormalizeTag makes.
+func stem(w string) string {
+	w = strings.TrimSuffix(strings.TrimSuffix(w, "'s"), "'")
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// wordSet is the distinct non-stopwords of text, for document frequency.
+func wordSet(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range tokens(text) {
+		if w != "" && !stopwords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// extractKeyphrases is RAKE (Rose et al., 2010) weighted by IDF over the
+// tenant's corpus: a word's RAKE score (degree/frequency) is multiplied by
+// how rare it is across the tenant's other highlights, so a phrase this
+// tenant writes about constantly doesn't drown out what's particular to
+// this one. Highest score first.
+func extractKeyphrases(text string, idf func(word string) float64) []keyphrase {
+	var phrases []keyphrase
+	var current []string
+	cut := func() {
+		if len(current) > 0 {
+			phrases = append(phrases, keyphrase{words: current})
+			current = nil
+		}
+	}
+	for _, w := range tokens(text) {
+		if w == "" || stopwords[w] || len([]rune(w)) < 3 {
+			cut()
+			continue
+		}
+		current = append(current, w)
+	}
+	cut()
+
+	freq := map[string]float64{}
+	degree := map[string]float64{}
+	for _, p := range phrases {
+		for _, w := range p.words {
+			freq[w]++
+			degree[w] += float64(len(p.words))
+		}
+	}
+
+	seen := map[string]bool{}
+	unique := phrases[:0]
+	for _, p := range phrases {
+		if seen[p.text()] {
+			continue
+		}
+		seen[p.text()] = true
+		for _, w := range p.words {
+			p.score += degree[w] / freq[w] * idf(w)
+		}
+		unique = append(unique, p)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool { return unique[i].score > unique[j].score })
+	return unique
+}
+
+// corpusIDF builds a smoothed IDF over docs (word sets): rarer across the
+// corpus scores higher, and a word the corpus has never seen scores as if
+// it appeared once.
+func corpusIDF(docs []map[string]bool) func(string) float64 {
+	df := map[string]int{}
+	for _, d := range docs {
+		for w := range d {
+			df[w]++
+		}
+	}
+	n := float64(len(docs))
+	return func(w string) float64 {
+		return math.Log((n+1)/(float64(df[w])+1)) + 1
+	}
+}
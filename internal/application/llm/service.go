@@ -2,19 +2,204 @@ package llm
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
+var errNoClient = errors.New("no enrichment client or fallback configured")
+
 type Service struct {
-	client ports.EnrichmentClient
+	client   ports.EnrichmentClient
+	fallback *Fallback
+	budget   ports.Budget
+	breaker  *Breaker
+	cache    ports.EnrichmentCache
+	cacheTTL time.Duration
+	settings ports.TenantSettingsRepository
+	now      func() time.Time
 }
 
+// NewService wraps client, which may be nil when no provider is configured
+// — useful only alongside WithFallback.
 func NewService(client ports.EnrichmentClient) *Service {
-	return &Service{client: client}
+	return &Service{client: client, now: time.Now}
+}
+
+// WithFallback has Enrich answer from f whenever the client is missing,
+// fails, is over budget or behind an open breaker, instead of returning the error.
+func (s *Service) WithFallback(f *Fallback) *Service {
+	s.fallback = f
+	return s
+}
+
+// WithBudget gates every client call on b and records its usage there.
+// The fallback costs nothing and is never gated.
+func (s *Service) WithBudget(b ports.Budget) *Service {
+	s.budget = b
+	return s
+}
+
+// WithBreaker skips client calls while b is open, so a provider outage
+// costs one fast failure per call rather than the client's full retries.
+func (s *Service) WithBreaker(b *Breaker) *Service {
+	s.breaker = b
+	return s
+}
+
+// WithCache reuses a client answer for the same text, under the client's
+// Version, for ttl (DefaultCacheTTL if not positive). A hit is neither
+// gated nor counted by the budget or the breaker: it costs nothing.
+// Fallback answers are never cached, so they don't outlive the outage.
+func (s *Service) WithCache(c ports.EnrichmentCache, ttl time.Duration) *Service {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	s.cache = c
+	s.cacheTTL = ttl
+	return s
+}
+
+// WithSettings has the client tag in each tenant's TagLanguage. Without
+// it, every tenant gets domain.DefaultTagLanguage.
+func (s *Service) WithSettings(settings ports.TenantSettingsRepository) *Service {
+	s.settings = settings
+	return s
+}
+
+// Enrich asks the client, then the fallback. Which one answered is on the
+// result: Enrichment.Fallback, with the fallback's own Model and
+// PromptVersion.
+func (s *Service) Enrich(ctx context.Context, tenantID, text string) (domain.Enrichment, error) {
+	if s.client == nil {
+		return s.fallbackFor(ctx, tenantID, text, errNoClient)
+	}
+	tagLanguage, err := s.tagLanguage(ctx, tenantID)
+	if err != nil {
+		return s.fallbackFor(ctx, tenantID, text, err)
+	}
+	enrichment, err := s.callClient(ctx, tenantID, text, tagLanguage)
+	if err != nil {
+		return s.fallbackFor(ctx, tenantID, text, err)
+	}
+	return enrichment, nil
+}
+
+// tagLanguage is the language tenantID's tags are written in. The
+// fallback doesn't need it: it reuses the tenant's existing tags, which
+// are already in that language.
+func (s *Service) tagLanguage(ctx context.Context, tenantID string) (domain.Language, error) {
+	if s.settings == nil {
+		return domain.DefaultTagLanguage, nil
+	}
+	settings, err := s.settings.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("read tenant settings: %w", err)
+	}
+	return settings.EffectiveTagLanguage(), nil
+}
+
+// fallbackFor answers text from the fallback after the client failed with
+// err, or returns err when there's no fallback.
+func (s *Service) fallbackFor(ctx context.Context, tenantID, text string, err error) (domain.Enrichment, error) {
+	if s.fallback == nil {
+		return domain.Enrichment{}, err
+	}
+	if s.client != nil {
+		slog.WarnContext(ctx, "llm enrichment unavailable, using keyword fallback", "tenant_id", tenantID, "err", err)
+	}
+	return s.fallback.Enrich(ctx, tenantID, text)
+}
+
+// callClient answers from the cache if it can, and otherwise calls the
+// client and caches the answer. Cache failures only cost a call: they're
+// logged, never returned.
+//
+// A hit cost nothing, so its InputTokens, OutputTokens and Latency are
+// zeroed: they described the call that filled the cache, and provenance
+// (or anything summing it) would otherwise count that spend again. Only an
+// answer that normalizes into a valid enrichment is cached, the form
+// insight.Service would store, so one it would reject is asked for afresh
+// next time rather than served back until the entry expires.
+func (s *Service) callClient(ctx context.Context, tenantID, text string, tagLanguage domain.Language) (domain.Enrichment, error) {
+	var key string
+	if s.cache != nil {
+		key = cacheKey(s.client.Version(), tagLanguage, text)
+		cached, ok, err := s.cache.GetCachedEnrichment(ctx, tenantID, key)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to read enrichment cache", "tenant_id", tenantID, "err", err)
+		} else if ok {
+			cached.InputTokens, cached.OutputTokens, cached.Latency = 0, 0, 0
+			return cached, nil
+		}
+	}
+
+	enrichment, err := s.enrichUncached(ctx, tenantID, text, tagLanguage)
+	if err != nil {
+		return domain.Enrichment{}, err
+	}
+
+	if s.cache != nil {
+		normalized := enrichment.Normalize()
+		if err := normalized.Validate(); err != nil {
+			slog.WarnContext(ctx, "not caching an invalid enrichment", "tenant_id", tenantID, "err", err)
+		} else if err := s.cache.PutCachedEnrichment(ctx, tenantID, key, normalized, s.now().Add(s.cacheTTL)); err != nil {
+			slog.WarnContext(ctx, "failed to write enrichment cache", "tenant_id", tenantID, "err", err)
+		}
+	}
+	return enrichment, nil
+}
+
+func (s *Service) enrichUncached(ctx context.Context, tenantID, text string, tagLanguage domain.Language) (domain.Enrichment, error) {
+	if s.budget != nil {
+		if err := s.budget.Check(ctx, tenantID); err != nil {
+			return domain.Enrichment{}, err
+		}
+	}
+
+	if s.breaker != nil {
+		if err := s.breaker.Allow(ctx); err != nil {
+			return domain.Enrichment{}, err
+		}
+	}
+
+	enrichment, err := s.client.Enrich(ctx, text, tagLanguage)
+	if s.breaker != nil {
+		if err != nil {
+			s.breaker.Failure(ctx)
+		} else {
+			s.breaker.Success(ctx)
+		}
+	}
+	if err != nil {
+		s.recordBilled(ctx, tenantID, err)
+		return domain.Enrichment{}, err
+	}
+
+	s.recordUsage(ctx, tenantID, enrichment.InputTokens, enrichment.OutputTokens)
+	return enrichment, nil
+}
+
+// recordBilled counts a failed call the provider billed anyway (a
+// *ports.BilledError); a failure it never answered cost nothing.
+func (s *Service) recordBilled(ctx context.Context, tenantID string, err error) {
+	if billed, ok := errors.AsType[*ports.BilledError](err); ok {
+		s.recordUsage(ctx, tenantID, billed.InputTokens, billed.OutputTokens)
+	}
 }
 
-func (s *Service) Enrich(ctx context.Context, text string) (domain.Enrichment, error) {
-	return s.client.Enrich(ctx, text)
+// recordUsage counts one billed call against tenantID's budget. A failed
+// write is logged, not returned: it would otherwise discard an answer
+// that's already paid for.
+func (s *Service) recordUsage(ctx context.Context, tenantID string, inputTokens, outputTokens int64) {
+	if s.budget == nil {
+		return
+	}
+	if err := s.budget.Record(ctx, tenantID, inputTokens, outputTokens); err != nil {
+		slog.WarnContext(ctx, "failed to record llm usage", "tenant_id", tenantID, "err", err)
+	}
 }
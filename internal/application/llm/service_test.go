@@ -0,0 +1,302 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type stubClient struct {
+	enrichment domain.Enrichment
+	err        error
+}
+
+func (s stubClient) Enrich(context.Context, string, domain.Language) (domain.Enrichment, error) {
+	return s.enrichment, s.err
+}
+
+func (s stubClient) Version() string { return "stub/v1" }
+
+func TestService_Enrich_ClientSucceeds_FallbackUnused(t *testing.T) {
+	svc := NewService(stubClient{enrichment: domain.Enrichment{Model: "m"}}).WithFallback(NewFallback(&fakeCorpus{}))
+
+	got, err := svc.Enrich(context.Background(), "t-1", "text")
+	if err != nil || got.Fallback || got.Model != "m" {
+		t.Fatalf("got %+v, %v; want the client's answer", got, err)
+	}
+}
+
+func TestService_Enrich_ClientFails_FallsBack(t *testing.T) {
+	svc := NewService(stubClient{err: errors.New("llm down")}).WithFallback(NewFallback(&fakeCorpus{}))
+
+	got, err := svc.Enrich(context.Background(), "t-1", "text")
+	if err != nil || !got.Fallback {
+		t.Fatalf("got %+v, %v; want a fallback enrichment", got, err)
+	}
+}
+
+func TestService_Enrich_NoClient_FallsBack(t *testing.T) {
+	got, err := NewService(nil).WithFallback(NewFallback(&fakeCorpus{})).Enrich(context.Background(), "t-1", "text")
+	if err != nil || !got.Fallback {
+		t.Fatalf("got %+v, %v; want a fallback enrichment", got, err)
+	}
+}
+
+func TestService_Enrich_ClientFailsWithoutFallback_ReturnsError(t *testing.T) {
+	boom := errors.New("llm down")
+	if _, err := NewService(stubClient{err: boom}).Enrich(context.Background(), "t-1", "text"); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want the client's error", err)
+	}
+}
+
+type spyBudget struct {
+	mu       sync.Mutex
+	checkErr error
+	recorded []domain.LLMUsage
+}
+
+func (b *spyBudget) Check(context.Context, string) error { return b.checkErr }
+
+func (b *spyBudget) Record(_ context.Context, _ string, inputTokens, outputTokens int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recorded = append(b.recorded, domain.LLMUsage{Calls: 1, InputTokens: inputTokens, OutputTokens: outputTokens})
+	return nil
+}
+
+func TestService_Enrich_ClientSucceeds_RecordsUsage(t *testing.T) {
+	budget := &spyBudget{}
+	svc := NewService(stubClient{enrichment: domain.Enrichment{InputTokens: 120, OutputTokens: 30}}).WithBudget(budget)
+
+	if _, err := svc.Enrich(context.Background(), "t-1", "text"); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(budget.recorded) != 1 || budget.recorded[0].InputTokens != 120 {
+		t.Fatalf("recorded = %+v, want the one completion", budget.recorded)
+	}
+}
+
+func TestService_Enrich_BilledFailure_StillRecordsUsage(t *testing.T) {
+	budget := &spyBudget{}
+	billed := &ports.BilledError{InputTokens: 80, OutputTokens: 512, Err: errors.New("unmarshal structured output")}
+	svc := NewService(stubClient{err: billed}).WithBudget(budget)
+
+	if _, err := svc.Enrich(context.Background(), "t-1", "text"); !errors.Is(err, billed) {
+		t.Fatalf("err = %v, want the client's error", err)
+	}
+	if len(budget.recorded) != 1 || budget.recorded[0].OutputTokens != 512 {
+		t.Fatalf("recorded = %+v, want the unusable answer counted", budget.recorded)
+	}
+}
+
+func TestService_Enrich_UnansweredFailure_RecordsNothing(t *testing.T) {
+	budget := &spyBudget{}
+	svc := NewService(stubClient{err: errors.New("connection refused")}).WithBudget(budget)
+
+	_, _ = svc.Enrich(context.Background(), "t-1", "text")
+
+	if len(budget.recorded) != 0 {
+		t.Fatalf("recorded = %+v, want nothing for a call never answered", budget.recorded)
+	}
+}
+
+func TestService_Enrich_OverBudget_FallsBackWithoutCallingClient(t *testing.T) {
+	budget := &spyBudget{checkErr: ports.ErrBudgetExhausted}
+	svc := NewService(stubClient{err: errors.New("client must not be called")}).
+		WithFallback(NewFallback(&fakeCorpus{})).
+		WithBudget(budget)
+
+	got, err := svc.Enrich(context.Background(), "t-1", "text")
+	if err != nil || !got.Fallback {
+		t.Fatalf("got %+v, %v; want a fallback enrichment", got, err)
+	}
+	if len(budget.recorded) != 0 {
+		t.Fatalf("recorded = %+v, want nothing for a skipped call", budget.recorded)
+	}
+}
+
+func TestService_Enrich_OverBudgetWithoutFallback_ReturnsErrBudgetExhausted(t *testing.T) {
+	svc := NewService(stubClient{}).WithBudget(&spyBudget{checkErr: ports.ErrBudgetExhausted})
+
+	if _, err := svc.Enrich(context.Background(), "t-1", "text"); !errors.Is(err, ports.ErrBudgetExhausted) {
+		t.Fatalf("err = %v, want ErrBudgetExhausted", err)
+	}
+}
+
+type countingClient struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (c *countingClient) Enrich(context.Context, string, domain.Language) (domain.Enrichment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return domain.Enrichment{Model: "m"}, c.err
+}
+
+func (c *countingClient) Version() string { return "counting/v1" }
+
+func TestService_Enrich_BreakerOpen_SkipsClientAndFallsBack(t *testing.T) {
+	client := &countingClient{err: errors.New("llm down")}
+	svc := NewService(client).
+		WithFallback(NewFallback(&fakeCorpus{})).
+		WithBreaker(NewBreaker(2, time.Minute))
+
+	for range 4 {
+		got, err := svc.Enrich(context.Background(), "t-1", "text")
+		if err != nil || !got.Fallback {
+			t.Fatalf("got %+v, %v; want a fallback enrichment", got, err)
+		}
+	}
+	if client.calls != 2 {
+		t.Fatalf("client calls = %d, want 2: the breaker should open at the threshold", client.calls)
+	}
+}
+
+type fakeCache struct {
+	mu      sync.Mutex
+	entries map[string]domain.Enrichment
+}
+
+func (f *fakeCache) GetCachedEnrichment(_ context.Context, tenantID, key string) (domain.Enrichment, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e, ok := f.entries[tenantID+"|"+key]
+	return e, ok, nil
+}
+
+func (f *fakeCache) PutCachedEnrichment(_ context.Context, tenantID, key string, enrichment domain.Enrichment, _ time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[tenantID+"|"+key] = enrichment
+	return nil
+}
+
+func TestService_Enrich_SameTextTwice_CallsClientOnce(t *testing.T) {
+	client := &countingClient{}
+	budget := &spyBudget{}
+	svc := NewService(client).WithBudget(budget).WithCache(&fakeCache{entries: map[string]domain.Enrichment{}}, 0)
+
+	for _, text := range []string{"Small habits  compound.", "\nSmall habits compound. "} {
+		if got, err := svc.Enrich(context.Background(), "t-1", text); err != nil || got.Model != "m" {
+			t.Fatalf("got %+v, %v; want the client's answer", got, err)
+		}
+	}
+	if client.calls != 1 || len(budget.recorded) != 1 {
+		t.Fatalf("client calls = %d, recorded = %d; want 1 each", client.calls, len(budget.recorded))
+	}
+
+	if _, err := svc.Enrich(context.Background(), "t-2", "Small habits compound."); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if client.calls != 2 {
+		t.Fatalf("client calls = %d, want the cache scoped by tenant", client.calls)
+	}
+}
+
+func TestService_Enrich_CacheHit_ReportsNoSpend(t *testing.T) {
+	paid := domain.Enrichment{Tags: []string{"habits"}, Model: "m", InputTokens: 120, OutputTokens: 40, Latency: time.Second}
+	svc := NewService(stubClient{enrichment: paid}).WithCache(&fakeCache{entries: map[string]domain.Enrichment{}}, 0)
+
+	first, err := svc.Enrich(context.Background(), "t-1", "text")
+	if err != nil || first.InputTokens != 120 {
+		t.Fatalf("first = %+v, %v; want the client's answer with its usage", first, err)
+	}
+	hit, err := svc.Enrich(context.Background(), "t-1", "text")
+	if err != nil || hit.Model != "m" || len(hit.Tags) != 1 {
+		t.Fatalf("hit = %+v, %v; want the cached answer", hit, err)
+	}
+	if hit.InputTokens != 0 || hit.OutputTokens != 0 || hit.Latency != 0 {
+		t.Fatalf("hit = %+v, want no tokens or latency for an answer that cost no call", hit)
+	}
+}
+
+func TestService_Enrich_CachesTheNormalizedAnswer(t *testing.T) {
+	cache := &fakeCache{entries: map[string]domain.Enrichment{}}
+	svc := NewService(stubClient{enrichment: domain.Enrichment{Tags: []string{"Habits"}, Actionability: 3, Sentiment: "mixed"}}).WithCache(cache, 0)
+
+	if _, err := svc.Enrich(context.Background(), "t-1", "text"); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(cache.entries) != 1 {
+		t.Fatalf("cache = %+v, want one entry", cache.entries)
+	}
+	for _, cached := range cache.entries {
+		if err := cached.Validate(); err != nil || cached.Actionability != 1 || cached.Sentiment != "" || cached.Tags[0] != "habits" {
+			t.Fatalf("cached %+v (Validate = %v), want it normalized", cached, err)
+		}
+	}
+}
+
+func TestService_Enrich_FallbackAnswer_NotCached(t *testing.T) {
+	cache := &fakeCache{entries: map[string]domain.Enrichment{}}
+	svc := NewService(stubClient{err: errors.New("llm down")}).
+		WithFallback(NewFallback(&fakeCorpus{})).
+		WithCache(cache, 0)
+
+	if _, err := svc.Enrich(context.Background(), "t-1", "text"); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(cache.entries) != 0 {
+		t.Fatalf("cache = %+v, want fallback answers left out", cache.entries)
+	}
+}
+
+type languageClient struct {
+	mu  sync.Mutex
+	got []domain.Language
+}
+
+func (c *languageClient) Enrich(_ context.Context, _ string, tagLanguage domain.Language) (domain.Enrichment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.got = append(c.got, tagLanguage)
+	return domain.Enrichment{Model: "m"}, nil
+}
+
+func (c *languageClient) Version() string { return "language/v1" }
+
+type fakeSettings map[string]domain.Language
+
+func (f fakeSettings) GetTenantSettings(_ context.Context, tenantID string) (domain.TenantSettings, error) {
+	return domain.TenantSettings{TenantID: tenantID, TagLanguage: f[tenantID]}, nil
+}
+
+func (f fakeSettings) SaveTenantSettings(context.Context, domain.TenantSettings) error { return nil }
+
+func TestService_Enrich_AsksForTheTenantsTagLanguage(t *testing.T) {
+	client := &languageClient{}
+	svc := NewService(client).WithSettings(fakeSettings{"t-de": "de"})
+
+	for _, tenantID := range []string{"t-de", "t-unset"} {
+		if _, err := svc.Enrich(context.Background(), tenantID, "text"); err != nil {
+			t.Fatalf("Enrich: %v", err)
+		}
+	}
+	if len(client.got) != 2 || client.got[0] != "de" || client.got[1] != domain.DefaultTagLanguage {
+		t.Fatalf("tag languages = %v, want [de %s]", client.got, domain.DefaultTagLanguage)
+	}
+}
+
+func TestService_Enrich_TagLanguageChange_MissesTheCache(t *testing.T) {
+	client := &languageClient{}
+	settings := fakeSettings{"t-1": "en"}
+	svc := NewService(client).WithSettings(settings).WithCache(&fakeCache{entries: map[string]domain.Enrichment{}}, 0)
+
+	for _, lang := range []domain.Language{"en", "de"} {
+		settings["t-1"] = lang
+		if _, err := svc.Enrich(context.Background(), "t-1", "text"); err != nil {
+			t.Fatalf("Enrich: %v", err)
+		}
+	}
+	if len(client.got) != 2 {
+		t.Fatalf("client calls = %d, want 2: tags in another language aren't the same answer", len(client.got))
+	}
+}
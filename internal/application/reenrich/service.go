@@ -0,0 +1,198 @@
+// Package reenrich backfills enrichment for insights the ingest pipeline
+// left untagged (no key configured, or a soft-failed LLM call in
+// insight.Service.Process) or tagged long ago. Nothing else ever retries
+// those: Process only enriches on first insert.
+//
+// A job is requested (POST /v1/admin/reenrich) and persisted as pending;
+// the reenrich command pair works through it in bounded, rate-limited runs,
+// saving its cursor after every insight so a timed-out run picks up where
+// it left off rather than starting over.
+package reenrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// Request validates job and persists it as pending for the next run.
+	Request(ctx context.Context, job domain.ReenrichJob) error
+
+	// Get loads one tenant's job, progress included.
+	Get(ctx context.Context, tenantID, jobID string) (domain.ReenrichJob, error)
+
+	// Resume works through tenantID's pending jobs, oldest first, until
+	// every one is done or this run's enrichment budget is spent. It
+	// returns the jobs it touched, as they stand afterwards.
+	Resume(ctx context.Context, tenantID string) ([]domain.ReenrichJob, error)
+}
+
+type service struct {
+	jobs     ports.ReenrichJobRepository
+	insights ports.InsightRepository
+	enricher insight.Service
+	limit    int
+	interval time.Duration
+	now      func() time.Time
+	wait     func(ctx context.Context, d time.Duration) error
+}
+
+// NewService builds a Service whose Resume makes at most limit enrichment
+// calls per run, at least interval apart. enricher must have an LLM
+// configured for Resume to get anywhere; Request and Get never call it, so
+// the REST composition roots pass one without.
+func NewService(jobs ports.ReenrichJobRepository, insights ports.InsightRepository, enricher insight.Service, limit int, interval time.Duration) Service {
+	return &service{
+		jobs:     jobs,
+		insights: insights,
+		enricher: enricher,
+		limit:    limit,
+		interval: interval,
+		now:      time.Now,
+		wait:     sleep,
+	}
+}
+
+var _ Service = (*service)(nil)
+
+func (s *service) Request(ctx context.Context, job domain.ReenrichJob) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	return s.jobs.CreateReenrichJob(ctx, job)
+}
+
+func (s *service) Get(ctx context.Context, tenantID, jobID string) (domain.ReenrichJob, error) {
+	return s.jobs.GetReenrichJob(ctx, tenantID, jobID)
+}
+
+func (s *service) Resume(ctx context.Context, tenantID string) ([]domain.ReenrichJob, error) {
+	pending, err := s.jobs.ListPendingReenrichJobs(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list pending jobs: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	insights, err := s.insights.ListByTenantID(ctx, tenantID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list insights: %w", err)
+	}
+	// The cursor is only meaningful over a stable order.
+	sort.Slice(insights, func(i, j int) bool { return insights[i].ID < insights[j].ID })
+
+	r := &run{service: s, budget: s.limit}
+	touched := make([]domain.ReenrichJob, 0, len(pending))
+	for _, job := range pending {
+		job, err := r.work(ctx, job, insights)
+		touched = append(touched, job)
+		if err != nil {
+			return touched, err
+		}
+		if job.Status != domain.JobStatusDone {
+			// Budget spent mid-job; the next run resumes from its cursor.
+			break
+		}
+	}
+	return touched, nil
+}
+
+// run is one Resume call's shared state: the enrichment budget and the
+// rate limiter's "have we called yet" both span every job it works on.
+type run struct {
+	*service
+	budget int
+	calls  int
+}
+
+// work advances job over insights (sorted by ID) from its cursor, saving
+// progress after every enrichment attempt. A failed enrichment is counted
+// and skipped, not retried: a later job with the same scope picks it up
+// again, since it's still untagged (or still old). An exhausted tenant LLM
+// budget is the exception: it ends the run with the cursor left before the
+// insight, so a later run retries it once the budget resets.
+func (r *run) work(ctx context.Context, job domain.ReenrichJob, insights []domain.Insight) (domain.ReenrichJob, error) {
+	for _, in := range insights {
+		if in.ID <= job.Cursor {
+			continue
+		}
+		if !job.Matches(in) {
+			job.Cursor = in.ID
+			continue
+		}
+		if r.budget <= 0 {
+			return job, r.save(ctx, job)
+		}
+
+		if r.calls > 0 {
+			if err := r.wait(ctx, r.interval); err != nil {
+				return job, r.saveAfter(ctx, job, err)
+			}
+		}
+		r.calls++
+		r.budget--
+
+		if _, err := r.enricher.Reenrich(ctx, in); errors.Is(err, ports.ErrBudgetExhausted) {
+			slog.InfoContext(ctx, "tenant LLM budget exhausted, pausing reenrich job",
+				"tenant_id", job.TenantID, "job_id", job.ID)
+			r.budget = 0
+			return job, r.save(ctx, job)
+		} else if err != nil {
+			slog.WarnContext(ctx, "reenrich failed, skipping insight",
+				"tenant_id", job.TenantID, "job_id", job.ID, "insight_id", in.ID, "err", err)
+			job.Failed++
+		} else {
+			job.Enriched++
+		}
+		job.Processed++
+		job.Cursor = in.ID
+
+		if err := r.save(ctx, job); err != nil {
+			return job, err
+		}
+	}
+
+	job.Status = domain.JobStatusDone
+	return job, r.save(ctx, job)
+}
+
+func (r *run) save(ctx context.Context, job domain.ReenrichJob) error {
+	job.UpdatedAt = r.now().UTC()
+	if err := r.jobs.SaveReenrichJobProgress(ctx, job); err != nil {
+		return fmt.Errorf("save job %s progress: %w", job.ID, err)
+	}
+	return nil
+}
+
+// saveAfter records progress made before cause (typically the invocation's
+// deadline) interrupted the run, on a context that cause hasn't already
+// cancelled.
+func (r *run) saveAfter(ctx context.Context, job domain.ReenrichJob, cause error) error {
+	if err := r.save(context.WithoutCancel(ctx), job); err != nil {
+		return fmt.Errorf("%w (and %w)", cause, err)
+	}
+	return cause
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,204 @@
+package reenrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/application/insight"
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeJobs struct {
+	jobs  map[string]domain.ReenrichJob
+	saves []domain.ReenrichJob
+}
+
+func newFakeJobs(jobs ...domain.ReenrichJob) *fakeJobs {
+	f := &fakeJobs{jobs: map[string]domain.ReenrichJob{}}
+	for _, j := range jobs {
+		f.jobs[j.ID] = j
+	}
+	return f
+}
+
+func (f *fakeJobs) CreateReenrichJob(_ context.Context, job domain.ReenrichJob) error {
+	f.jobs[job.ID] = job
+	return nil
+}
+
+func (f *fakeJobs) GetReenrichJob(_ context.Context, _, jobID string) (domain.ReenrichJob, error) {
+	job, ok := f.jobs[jobID]
+	if !ok {
+		return domain.ReenrichJob{}, ports.ErrReenrichJobNotFound
+	}
+	return job, nil
+}
+
+func (f *fakeJobs) ListPendingReenrichJobs(_ context.Context, _ string) ([]domain.ReenrichJob, error) {
+	var pending []domain.ReenrichJob
+	for _, j := range f.jobs {
+		if j.Status == domain.JobStatusPending {
+			pending = append(pending, j)
+		}
+	}
+	return pending, nil
+}
+
+func (f *fakeJobs) SaveReenrichJobProgress(_ context.Context, job domain.ReenrichJob) error {
+	f.jobs[job.ID] = job
+	f.saves = append(f.saves, job)
+	return nil
+}
+
+// fakeInsights only answers ListByTenantID; nothing else is reached from
+// Resume.
+type fakeInsights struct {
+	ports.InsightRepository
+	insights []domain.Insight
+}
+
+func (f *fakeInsights) ListByTenantID(_ context.Context, _, _ string) ([]domain.Insight, error) {
+	return f.insights, nil
+}
+
+type spyEnricher struct {
+	insight.Service
+	errByID map[string]error
+	got     []string
+}
+
+func (s *spyEnricher) Reenrich(_ context.Context, in domain.Insight) (domain.Insight, error) {
+	s.got = append(s.got, in.ID)
+	if err := s.errByID[in.ID]; err != nil {
+		return domain.Insight{}, err
+	}
+	return in, nil
+}
+
+func newTestService(jobs *fakeJobs, insights []domain.Insight, enricher *spyEnricher, limit int) (*service, *[]time.Duration) {
+	var waits []time.Duration
+	s := NewService(jobs, &fakeInsights{insights: insights}, enricher, limit, time.Second).(*service)
+	s.wait = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return s, &waits
+}
+
+func pendingJob(scope domain.ReenrichScope) domain.ReenrichJob {
+	return domain.ReenrichJob{ID: "job-1", TenantID: "t-1", Scope: scope, Status: domain.JobStatusPending}
+}
+
+func tagged(id string) domain.Insight {
+	return domain.Insight{ID: id, Enrichment: &domain.Enrichment{Tags: []string{"x"}}}
+}
+
+func TestService_Resume_MissingScope_OnlyEnrichesUntaggedInsights(t *testing.T) {
+	jobs := newFakeJobs(pendingJob(domain.ReenrichMissing))
+	enricher := &spyEnricher{}
+	svc, waits := newTestService(jobs, []domain.Insight{{ID: "i-3"}, tagged("i-2"), {ID: "i-1"}}, enricher, 10)
+
+	touched, err := svc.Resume(context.Background(), "t-1")
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+
+	if len(enricher.got) != 2 || enricher.got[0] != "i-1" || enricher.got[1] != "i-3" {
+		t.Fatalf("enriched %v, want [i-1 i-3] in id order", enricher.got)
+	}
+	if len(*waits) != 1 {
+		t.Fatalf("waited %d times, want 1 (between calls, never before the first)", len(*waits))
+	}
+	if len(touched) != 1 || touched[0].Status != domain.JobStatusDone || touched[0].Enriched != 2 {
+		t.Fatalf("touched = %+v, want one done job with 2 enriched", touched)
+	}
+}
+
+func TestService_Resume_BudgetSpent_SavesCursorAndResumesFromItNextRun(t *testing.T) {
+	jobs := newFakeJobs(pendingJob(domain.ReenrichAll))
+	insights := []domain.Insight{{ID: "i-1"}, {ID: "i-2"}, {ID: "i-3"}}
+	enricher := &spyEnricher{}
+	svc, _ := newTestService(jobs, insights, enricher, 2)
+
+	if _, err := svc.Resume(context.Background(), "t-1"); err != nil {
+		t.Fatalf("first Resume: %v", err)
+	}
+	first := jobs.jobs["job-1"]
+	if first.Status != domain.JobStatusPending || first.Cursor != "i-2" || first.Processed != 2 {
+		t.Fatalf("after first run job = %+v, want pending at cursor i-2", first)
+	}
+
+	if _, err := svc.Resume(context.Background(), "t-1"); err != nil {
+		t.Fatalf("second Resume: %v", err)
+	}
+	if len(enricher.got) != 3 || enricher.got[2] != "i-3" {
+		t.Fatalf("enriched %v, want the second run to pick up only i-3", enricher.got)
+	}
+	if done := jobs.jobs["job-1"]; done.Status != domain.JobStatusDone || done.Processed != 3 {
+		t.Fatalf("after second run job = %+v, want done with 3 processed", done)
+	}
+}
+
+func TestService_Resume_EnrichFailure_CountedAndSkipped(t *testing.T) {
+	jobs := newFakeJobs(pendingJob(domain.ReenrichAll))
+	enricher := &spyEnricher{errByID: map[string]error{"i-1": errors.New("llm down")}}
+	svc, _ := newTestService(jobs, []domain.Insight{{ID: "i-1"}, {ID: "i-2"}}, enricher, 10)
+
+	if _, err := svc.Resume(context.Background(), "t-1"); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+
+	job := jobs.jobs["job-1"]
+	if job.Failed != 1 || job.Enriched != 1 || job.Status != domain.JobStatusDone {
+		t.Fatalf("job = %+v, want 1 failed, 1 enriched, done", job)
+	}
+}
+
+func TestService_Resume_TenantBudgetExhausted_PausesBeforeInsight(t *testing.T) {
+	jobs := newFakeJobs(pendingJob(domain.ReenrichAll))
+	enricher := &spyEnricher{errByID: map[string]error{"i-2": fmt.Errorf("enrich: %w", ports.ErrBudgetExhausted)}}
+	svc, _ := newTestService(jobs, []domain.Insight{{ID: "i-1"}, {ID: "i-2"}, {ID: "i-3"}}, enricher, 10)
+
+	if _, err := svc.Resume(context.Background(), "t-1"); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+
+	job := jobs.jobs["job-1"]
+	if job.Status == domain.JobStatusDone || job.Cursor != "i-1" || job.Enriched != 1 || job.Failed != 0 {
+		t.Fatalf("job = %+v, want pending at cursor i-1 with nothing failed", job)
+	}
+}
+
+func TestService_Resume_Interrupted_SavesProgressBeforeReturning(t *testing.T) {
+	jobs := newFakeJobs(pendingJob(domain.ReenrichAll))
+	enricher := &spyEnricher{}
+	svc, _ := newTestService(jobs, []domain.Insight{{ID: "i-1"}, {ID: "i-2"}}, enricher, 10)
+	svc.wait = func(context.Context, time.Duration) error { return context.DeadlineExceeded }
+
+	_, err := svc.Resume(context.Background(), "t-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the interruption surfaced", err)
+	}
+
+	job := jobs.jobs["job-1"]
+	if job.Cursor != "i-1" || job.Status != domain.JobStatusPending {
+		t.Fatalf("job = %+v, want pending at cursor i-1", job)
+	}
+}
+
+func TestService_Request_InvalidJob_NeverPersisted(t *testing.T) {
+	jobs := newFakeJobs()
+	svc, _ := newTestService(jobs, nil, &spyEnricher{}, 10)
+
+	err := svc.Request(context.Background(), domain.ReenrichJob{ID: "job-1", Scope: domain.ReenrichBefore})
+	if !errors.Is(err, domain.ErrMissingEnrichedBefore) {
+		t.Fatalf("err = %v, want ErrMissingEnrichedBefore", err)
+	}
+	if len(jobs.jobs) != 0 {
+		t.Fatalf("jobs = %v, want nothing persisted", jobs.jobs)
+	}
+}
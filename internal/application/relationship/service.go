@@ -9,31 +9,189 @@ import (
 	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
+// RejectedRetention is how long a rejected or evicted edge stays in the
+// log: long enough for the agent's next few runs to read it back.
+const RejectedRetention = 30 * 24 * time.Hour
+
 type Service interface {
+	// Put stores rel if the tenant's RelationshipPolicy admits it. A
+	// rejection is logged (see ListRejected) and returned as the reason's
+	// error: domain.ErrBelowMinConfidence, ErrContradictoryEdge or
+	// ErrEdgeCapReached.
 	Put(ctx context.Context, rel domain.Relationship) error
 	ListByInsightID(ctx context.Context, tenantID, insightID string) ([]domain.RelatedInsight, error)
+	// Remove deletes the edge between insightID and otherInsightID on the
+	// user's word and records the pair as not related, so the agent never
+	// posts it again. ports.ErrRelationshipNotFound if there's no edge.
+	Remove(ctx context.Context, tenantID, insightID, otherInsightID string) error
+	// Retype corrects the edge's RelationType on the user's word and
+	// records the verdict. The edge keeps its original direction and
+	// rationale, and its confidence becomes 1: a user's ruling is never
+	// evicted by the agent's guesses.
+	Retype(ctx context.Context, tenantID, insightID, otherInsightID string, relType domain.RelationType) (domain.Relationship, error)
+	// ListRejected returns tenantID's rejected and evicted edges from the
+	// last RejectedRetention, most recent first.
+	ListRejected(ctx context.Context, tenantID string) ([]domain.RejectedRelationship, error)
 }
 
 type service struct {
-	repo   ports.RelationshipRepository
-	events ports.DomainEventPublisher
+	repo     ports.RelationshipRepository
+	rejected ports.RejectedRelationshipLog
+	feedback ports.RelationshipFeedbackRepository
+	settings ports.TenantSettingsRepository
+	events   ports.DomainEventPublisher
+	now      func() time.Time
 }
 
-func NewService(repo ports.RelationshipRepository, events ports.DomainEventPublisher) Service {
-	return &service{repo: repo, events: events}
+func NewService(repo ports.RelationshipRepository, rejected ports.RejectedRelationshipLog, feedback ports.RelationshipFeedbackRepository, settings ports.TenantSettingsRepository, events ports.DomainEventPublisher) Service {
+	return &service{repo: repo, rejected: rejected, feedback: feedback, settings: settings, events: events, now: time.Now}
 }
 
 var _ Service = (*service)(nil)
 
-// Put persists rel, then publishes KnowledgeUpdated (REL 5/IPP-101) so
-// subscribers — the tag relevance score's density component today,
-// something else tomorrow — learn the graph changed.
+// Put checks rel against the user's verdicts and the tenant's policy,
+// persists it, evicts whatever it pushed over an insight's edge cap, then
+// publishes KnowledgeUpdated (REL 5/IPP-101) for each changed edge so
+// subscribers learn the graph changed.
+//
+// Re-posting an existing edge is an update, so it never counts against
+// the cap or conflicts with itself; only a supports/contradicts flip
+// between the same two insights is refused.
+//
+// TRADE-OFF: the policy reads and the writes aren't atomic, so two edges
+// posted at once can both take an insight's last free slot. The agent
+// posts sequentially per tenant, and the next write over the cap evicts
+// the extra.
 func (s *service) Put(ctx context.Context, rel domain.Relationship) error {
+	settings, err := s.settings.GetTenantSettings(ctx, rel.TenantID)
+	if err != nil {
+		return fmt.Errorf("get tenant settings: %w", err)
+	}
+	policy := settings.RelationshipPolicy
+
+	feedback, found, err := s.feedback.GetRelationshipFeedback(ctx, rel.TenantID, rel.FromInsightID, rel.ToInsightID)
+	if err != nil {
+		return fmt.Errorf("get relationship feedback: %w", err)
+	}
+	if found {
+		return s.reject(ctx, rel, domain.RejectUserVerdict, verdictDetail(feedback))
+	}
+
+	if min, ok := policy.MinConfidence[rel.Type]; ok && rel.Confidence < min {
+		return s.reject(ctx, rel, domain.RejectBelowMinConfidence, fmt.Sprintf("minimum for %s is %.2f", rel.Type, min))
+	}
+
+	fromEdges, err := s.repo.ListByInsightID(ctx, rel.TenantID, rel.FromInsightID)
+	if err != nil {
+		return fmt.Errorf("list from insight's edges: %w", err)
+	}
+	if existing, ok := edgeTo(fromEdges, rel.ToInsightID); ok && existing.Type.Opposes(rel.Type) {
+		return s.reject(ctx, rel, domain.RejectContradictsEdge, fmt.Sprintf("existing %s edge (confidence %.2f)", existing.Type, existing.Confidence))
+	}
+
+	var evictions []domain.Relationship
+	if policy.MaxEdgesPerInsight > 0 {
+		toEdges, err := s.repo.ListByInsightID(ctx, rel.TenantID, rel.ToInsightID)
+		if err != nil {
+			return fmt.Errorf("list to insight's edges: %w", err)
+		}
+		for _, side := range []struct {
+			insightID, otherID string
+			edges              []domain.RelatedInsight
+		}{
+			{rel.FromInsightID, rel.ToInsightID, fromEdges},
+			{rel.ToInsightID, rel.FromInsightID, toEdges},
+		} {
+			evict, ok := weakestOverCap(side.edges, side.otherID, policy.MaxEdgesPerInsight, rel.Confidence)
+			if !ok {
+				return s.reject(ctx, rel, domain.RejectEdgeCap, fmt.Sprintf("%s already has %d more confident edges", side.insightID, policy.MaxEdgesPerInsight))
+			}
+			// Neither side's list holds the new pair's edge, so the two
+			// sides never queue the same eviction.
+			for _, edge := range evict {
+				evictions = append(evictions, evictedRelationship(rel.TenantID, side.insightID, edge))
+			}
+		}
+	}
+
 	if err := s.repo.Put(ctx, rel); err != nil {
 		return err
 	}
+	if err := s.publish(ctx, rel, domain.KnowledgeEdgeUpserted); err != nil {
+		return err
+	}
+	for _, evicted := range evictions {
+		if err := s.repo.DeleteRelationship(ctx, evicted.TenantID, evicted.FromInsightID, evicted.ToInsightID); err != nil {
+			return fmt.Errorf("evict relationship: %w", err)
+		}
+		detail := fmt.Sprintf("evicted by %s -> %s (confidence %.2f)", rel.FromInsightID, rel.ToInsightID, rel.Confidence)
+		if err := s.record(ctx, evicted, domain.RejectEvicted, detail); err != nil {
+			return err
+		}
+		if err := s.publish(ctx, evicted, domain.KnowledgeEdgeRemoved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove records the verdict before deleting, so a failure in between
+// leaves the edge standing but already refused to the agent; retrying the
+// DELETE finishes the job.
+func (s *service) Remove(ctx context.Context, tenantID, insightID, otherInsightID string) error {
+	rel, err := s.judge(ctx, tenantID, insightID, otherInsightID, domain.VerdictNotRelated, "")
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteRelationship(ctx, tenantID, insightID, otherInsightID); err != nil {
+		return fmt.Errorf("delete relationship: %w", err)
+	}
+	return s.publish(ctx, rel, domain.KnowledgeEdgeRemoved)
+}
+
+func (s *service) Retype(ctx context.Context, tenantID, insightID, otherInsightID string, relType domain.RelationType) (domain.Relationship, error) {
+	rel, err := s.judge(ctx, tenantID, insightID, otherInsightID, domain.VerdictRetyped, relType)
+	if err != nil {
+		return domain.Relationship{}, err
+	}
+	rel.Type = relType
+	rel.Confidence = 1
+	if err := s.repo.Put(ctx, rel); err != nil {
+		return domain.Relationship{}, err
+	}
+	if err := s.publish(ctx, rel, domain.KnowledgeEdgeRetyped); err != nil {
+		return domain.Relationship{}, err
+	}
+	return rel, nil
+}
+
+// judge looks up the edge a user is ruling on and records their verdict,
+// returning the edge as it was.
+func (s *service) judge(ctx context.Context, tenantID, insightID, otherInsightID string, verdict domain.RelationshipVerdict, relType domain.RelationType) (domain.Relationship, error) {
+	rel, found, err := s.repo.GetRelationship(ctx, tenantID, insightID, otherInsightID)
+	if err != nil {
+		return domain.Relationship{}, fmt.Errorf("get relationship: %w", err)
+	}
+	if !found {
+		return domain.Relationship{}, ports.ErrRelationshipNotFound
+	}
+
+	feedback := domain.RelationshipFeedback{
+		TenantID:       tenantID,
+		InsightID:      insightID,
+		OtherInsightID: otherInsightID,
+		Verdict:        verdict,
+		Type:           relType,
+		DecidedAt:      s.now().UTC(),
+	}
+	if err := s.feedback.SaveRelationshipFeedback(ctx, feedback); err != nil {
+		return domain.Relationship{}, fmt.Errorf("save relationship feedback: %w", err)
+	}
+	return rel, nil
+}
 
-	event := domain.NewKnowledgeUpdatedEvent(rel, time.Now())
+func (s *service) publish(ctx context.Context, rel domain.Relationship, change domain.KnowledgeChange) error {
+	event := domain.NewKnowledgeUpdatedEvent(rel, change, s.now())
 	if err := s.events.Publish(ctx, event); err != nil {
 		return fmt.Errorf("publish %s event: %w", event.EventType, err)
 	}
@@ -43,3 +201,85 @@ func (s *service) Put(ctx context.Context, rel domain.Relationship) error {
 func (s *service) ListByInsightID(ctx context.Context, tenantID, insightID string) ([]domain.RelatedInsight, error) {
 	return s.repo.ListByInsightID(ctx, tenantID, insightID)
 }
+
+func (s *service) ListRejected(ctx context.Context, tenantID string) ([]domain.RejectedRelationship, error) {
+	return s.rejected.ListRejectedRelationships(ctx, tenantID)
+}
+
+// reject logs rel as turned away for reason and returns reason's error.
+func (s *service) reject(ctx context.Context, rel domain.Relationship, reason domain.RejectReason, detail string) error {
+	if err := s.record(ctx, rel, reason, detail); err != nil {
+		return err
+	}
+	return reason.Err()
+}
+
+func (s *service) record(ctx context.Context, rel domain.Relationship, reason domain.RejectReason, detail string) error {
+	now := s.now().UTC()
+	rejected := domain.RejectedRelationship{
+		Relationship: rel,
+		Reason:       reason,
+		Detail:       detail,
+		RejectedAt:   now,
+	}
+	if err := s.rejected.RecordRejectedRelationship(ctx, rejected, now.Add(RejectedRetention)); err != nil {
+		return fmt.Errorf("record rejected relationship: %w", err)
+	}
+	return nil
+}
+
+func verdictDetail(feedback domain.RelationshipFeedback) string {
+	if feedback.Verdict == domain.VerdictRetyped {
+		return "user retyped this pair to " + string(feedback.Type)
+	}
+	return "user marked this pair not related"
+}
+
+func edgeTo(edges []domain.RelatedInsight, insightID string) (domain.RelatedInsight, bool) {
+	for _, edge := range edges {
+		if edge.InsightID == insightID {
+			return edge, true
+		}
+	}
+	return domain.RelatedInsight{}, false
+}
+
+// weakestOverCap returns the edges an insight must drop to take a new one
+// of the given confidence towards newOtherID without exceeding maxEdges —
+// its least confident ones, more than one if the cap was lowered since
+// they were stored. ok is false if any of them is at least as confident
+// as the new edge: ties keep what's already stored.
+func weakestOverCap(edges []domain.RelatedInsight, newOtherID string, maxEdges int, confidence float64) (evict []domain.RelatedInsight, ok bool) {
+	others := make([]domain.RelatedInsight, 0, len(edges))
+	for _, edge := range edges {
+		if edge.InsightID != newOtherID {
+			others = append(others, edge)
+		}
+	}
+	excess := len(others) - maxEdges + 1
+	if excess <= 0 {
+		return nil, true
+	}
+
+	// ListByInsightID sorts by confidence descending, so the weakest are
+	// at the end.
+	evict = others[len(others)-excess:]
+	if evict[0].Confidence >= confidence {
+		return nil, false
+	}
+	return evict, true
+}
+
+// evictedRelationship is insightID's edge as a Relationship for the
+// rejected log. The stored direction isn't on a RelatedInsight, so
+// insightID is recorded as the from side.
+func evictedRelationship(tenantID, insightID string, edge domain.RelatedInsight) domain.Relationship {
+	return domain.Relationship{
+		TenantID:      tenantID,
+		FromInsightID: insightID,
+		ToInsightID:   edge.InsightID,
+		Type:          edge.Type,
+		Confidence:    edge.Confidence,
+		Rationale:     edge.Rationale,
+	}
+}
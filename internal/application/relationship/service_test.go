@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
 type spyRepo struct {
@@ -15,6 +17,12 @@ type spyRepo struct {
 	listRelated   []domain.RelatedInsight
 	listTenantID  string
 	listInsightID string
+	// edges, when set, answers ListByInsightID per insight instead of
+	// listRelated.
+	edges   map[string][]domain.RelatedInsight
+	deleted [][2]string
+	// stored answers GetRelationship, keyed by insight ID then other ID.
+	stored map[[2]string]domain.Relationship
 }
 
 func (s *spyRepo) Put(_ context.Context, rel domain.Relationship) error {
@@ -26,9 +34,69 @@ func (s *spyRepo) Put(_ context.Context, rel domain.Relationship) error {
 func (s *spyRepo) ListByInsightID(_ context.Context, tenantID, insightID string) ([]domain.RelatedInsight, error) {
 	s.listTenantID = tenantID
 	s.listInsightID = insightID
+	if s.edges != nil {
+		return s.edges[insightID], nil
+	}
 	return s.listRelated, nil
 }
 
+func (s *spyRepo) DeleteRelationship(_ context.Context, _, insightID, otherInsightID string) error {
+	s.deleted = append(s.deleted, [2]string{insightID, otherInsightID})
+	return nil
+}
+
+func (s *spyRepo) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return nil, nil
+}
+
+func (s *spyRepo) GetRelationship(_ context.Context, _, insightID, otherInsightID string) (domain.Relationship, bool, error) {
+	rel, ok := s.stored[[2]string{insightID, otherInsightID}]
+	return rel, ok, nil
+}
+
+type spyFeedback struct {
+	saved []domain.RelationshipFeedback
+	// verdicts answers GetRelationshipFeedback, keyed like spyRepo.stored.
+	verdicts map[[2]string]domain.RelationshipFeedback
+}
+
+func (s *spyFeedback) SaveRelationshipFeedback(_ context.Context, feedback domain.RelationshipFeedback) error {
+	s.saved = append(s.saved, feedback)
+	return nil
+}
+
+func (s *spyFeedback) GetRelationshipFeedback(_ context.Context, _, insightID, otherInsightID string) (domain.RelationshipFeedback, bool, error) {
+	feedback, ok := s.verdicts[[2]string{insightID, otherInsightID}]
+	return feedback, ok, nil
+}
+
+type spyRejectedLog struct {
+	recorded  []domain.RejectedRelationship
+	expiresAt []time.Time
+}
+
+func (s *spyRejectedLog) RecordRejectedRelationship(_ context.Context, rejected domain.RejectedRelationship, expiresAt time.Time) error {
+	s.recorded = append(s.recorded, rejected)
+	s.expiresAt = append(s.expiresAt, expiresAt)
+	return nil
+}
+
+func (s *spyRejectedLog) ListRejectedRelationships(_ context.Context, _ string) ([]domain.RejectedRelationship, error) {
+	return s.recorded, nil
+}
+
+type fakeSettings struct {
+	policy domain.RelationshipPolicy
+}
+
+func (f fakeSettings) GetTenantSettings(_ context.Context, tenantID string) (domain.TenantSettings, error) {
+	return domain.TenantSettings{TenantID: tenantID, RelationshipPolicy: f.policy}, nil
+}
+
+func (f fakeSettings) SaveTenantSettings(context.Context, domain.TenantSettings) error {
+	return nil
+}
+
 type spyEventPublisher struct {
 	err       error
 	published []domain.DomainEvent
@@ -53,7 +121,7 @@ func makeRelationship() domain.Relationship {
 func TestService_Put_HappyPath_PersistsThenPublishesKnowledgeUpdated(t *testing.T) {
 	repo := &spyRepo{}
 	pub := &spyEventPublisher{}
-	svc := NewService(repo, pub)
+	svc := NewService(repo, &spyRejectedLog{}, &spyFeedback{}, fakeSettings{}, pub)
 
 	rel := makeRelationship()
 	if err := svc.Put(context.Background(), rel); err != nil {
@@ -79,7 +147,7 @@ func TestService_Put_RepoError_NeverPublishes(t *testing.T) {
 	wantErr := errors.New("write failed")
 	repo := &spyRepo{putErr: wantErr}
 	pub := &spyEventPublisher{}
-	svc := NewService(repo, pub)
+	svc := NewService(repo, &spyRejectedLog{}, &spyFeedback{}, fakeSettings{}, pub)
 
 	err := svc.Put(context.Background(), makeRelationship())
 	if !errors.Is(err, wantErr) {
@@ -94,7 +162,7 @@ func TestService_Put_PublishError_Propagates(t *testing.T) {
 	wantErr := errors.New("publish failed")
 	repo := &spyRepo{}
 	pub := &spyEventPublisher{err: wantErr}
-	svc := NewService(repo, pub)
+	svc := NewService(repo, &spyRejectedLog{}, &spyFeedback{}, fakeSettings{}, pub)
 
 	err := svc.Put(context.Background(), makeRelationship())
 	if !errors.Is(err, wantErr) {
@@ -108,7 +176,7 @@ func TestService_Put_PublishError_Propagates(t *testing.T) {
 func TestService_ListByInsightID_DelegatesToRepo(t *testing.T) {
 	want := []domain.RelatedInsight{{InsightID: "i-2", Text: "hello"}}
 	repo := &spyRepo{listRelated: want}
-	svc := NewService(repo, &spyEventPublisher{})
+	svc := NewService(repo, &spyRejectedLog{}, &spyFeedback{}, fakeSettings{}, &spyEventPublisher{})
 
 	got, err := svc.ListByInsightID(context.Background(), "t-1", "i-1")
 	if err != nil {
@@ -121,3 +189,221 @@ func TestService_ListByInsightID_DelegatesToRepo(t *testing.T) {
 		t.Fatalf("repo received tenant=%q insight=%q, want t-1/i-1", repo.listTenantID, repo.listInsightID)
 	}
 }
+
+func newPolicyService(repo *spyRepo, log *spyRejectedLog, policy domain.RelationshipPolicy, pub *spyEventPublisher) *service {
+	return newFeedbackService(repo, log, &spyFeedback{}, policy, pub)
+}
+
+func newFeedbackService(repo *spyRepo, log *spyRejectedLog, feedback *spyFeedback, policy domain.RelationshipPolicy, pub *spyEventPublisher) *service {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	svc := NewService(repo, log, feedback, fakeSettings{policy: policy}, pub).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestService_Put_BelowMinConfidence_LoggedNotStored(t *testing.T) {
+	repo := &spyRepo{}
+	log := &spyRejectedLog{}
+	pub := &spyEventPublisher{}
+	policy := domain.RelationshipPolicy{MinConfidence: map[domain.RelationType]float64{domain.RelationSupports: 0.95}}
+	svc := newPolicyService(repo, log, policy, pub)
+
+	err := svc.Put(context.Background(), makeRelationship())
+
+	if !errors.Is(err, domain.ErrBelowMinConfidence) {
+		t.Fatalf("err = %v, want ErrBelowMinConfidence", err)
+	}
+	if repo.putCalled || len(pub.published) != 0 {
+		t.Fatalf("put=%v published=%d, want neither", repo.putCalled, len(pub.published))
+	}
+	if len(log.recorded) != 1 || log.recorded[0].Reason != domain.RejectBelowMinConfidence || log.recorded[0].ToInsightID != "i-2" {
+		t.Fatalf("log = %+v, want the rejected edge", log.recorded)
+	}
+	if !log.expiresAt[0].Equal(log.recorded[0].RejectedAt.Add(RejectedRetention)) {
+		t.Fatalf("expiresAt = %v, want RejectedRetention after %v", log.expiresAt[0], log.recorded[0].RejectedAt)
+	}
+}
+
+func TestService_Put_ContradictsExistingEdge_Rejected(t *testing.T) {
+	// i-2 -> i-1 was stored as contradicts; edges are bidirectional, so
+	// i-1 now claiming to support i-2 conflicts with it.
+	repo := &spyRepo{edges: map[string][]domain.RelatedInsight{
+		"i-1": {{InsightID: "i-2", Type: domain.RelationContradicts, Confidence: 0.8}},
+	}}
+	log := &spyRejectedLog{}
+	svc := newPolicyService(repo, log, domain.RelationshipPolicy{}, &spyEventPublisher{})
+
+	err := svc.Put(context.Background(), makeRelationship())
+
+	if !errors.Is(err, domain.ErrContradictoryEdge) || repo.putCalled {
+		t.Fatalf("err = %v, put = %v; want ErrContradictoryEdge without a write", err, repo.putCalled)
+	}
+	if len(log.recorded) != 1 || log.recorded[0].Reason != domain.RejectContradictsEdge {
+		t.Fatalf("log = %+v, want one contradiction", log.recorded)
+	}
+}
+
+func TestService_Put_SameTypeReposted_IsAnUpdate(t *testing.T) {
+	repo := &spyRepo{edges: map[string][]domain.RelatedInsight{
+		"i-1": {{InsightID: "i-2", Type: domain.RelationSupports, Confidence: 0.5}},
+		"i-2": {{InsightID: "i-1", Type: domain.RelationSupports, Confidence: 0.5}},
+	}}
+	svc := newPolicyService(repo, &spyRejectedLog{}, domain.RelationshipPolicy{MaxEdgesPerInsight: 1}, &spyEventPublisher{})
+
+	if err := svc.Put(context.Background(), makeRelationship()); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if !repo.putCalled || len(repo.deleted) != 0 {
+		t.Fatalf("put = %v, deleted = %v; want the edge updated in place", repo.putCalled, repo.deleted)
+	}
+}
+
+func TestService_Put_AtCap_EvictsTheWeakestEdge(t *testing.T) {
+	repo := &spyRepo{edges: map[string][]domain.RelatedInsight{
+		"i-1": {
+			{InsightID: "i-3", Type: domain.RelationExtends, Confidence: 0.95},
+			{InsightID: "i-4", Type: domain.RelationSameTopic, Confidence: 0.6},
+		},
+	}}
+	log := &spyRejectedLog{}
+	pub := &spyEventPublisher{}
+	svc := newPolicyService(repo, log, domain.RelationshipPolicy{MaxEdgesPerInsight: 2}, pub)
+
+	if err := svc.Put(context.Background(), makeRelationship()); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if !repo.putCalled || len(repo.deleted) != 1 || repo.deleted[0] != [2]string{"i-1", "i-4"} {
+		t.Fatalf("put = %v, deleted = %v; want i-1 <-> i-4 evicted", repo.putCalled, repo.deleted)
+	}
+	if len(log.recorded) != 1 || log.recorded[0].Reason != domain.RejectEvicted || log.recorded[0].ToInsightID != "i-4" {
+		t.Fatalf("log = %+v, want the evicted edge", log.recorded)
+	}
+	if len(pub.published) != 2 {
+		t.Fatalf("published = %d events, want the new edge and the eviction", len(pub.published))
+	}
+	if change := pub.published[1].Payload.(domain.KnowledgeUpdatedPayload).Change; change != domain.KnowledgeEdgeRemoved {
+		t.Fatalf("eviction event change = %q, want %q", change, domain.KnowledgeEdgeRemoved)
+	}
+}
+
+func TestService_Put_AtCapWithStrongerEdges_Rejected(t *testing.T) {
+	// i-1 has room; i-2 is full of edges at least as confident as the new one.
+	repo := &spyRepo{edges: map[string][]domain.RelatedInsight{
+		"i-2": {
+			{InsightID: "i-5", Type: domain.RelationSupports, Confidence: 0.95},
+			{InsightID: "i-6", Type: domain.RelationSupports, Confidence: 0.9},
+		},
+	}}
+	log := &spyRejectedLog{}
+	svc := newPolicyService(repo, log, domain.RelationshipPolicy{MaxEdgesPerInsight: 2}, &spyEventPublisher{})
+
+	err := svc.Put(context.Background(), makeRelationship())
+
+	if !errors.Is(err, domain.ErrEdgeCapReached) || repo.putCalled || len(repo.deleted) != 0 {
+		t.Fatalf("err = %v, put = %v, deleted = %v; want ErrEdgeCapReached and no writes", err, repo.putCalled, repo.deleted)
+	}
+	if len(log.recorded) != 1 || log.recorded[0].Reason != domain.RejectEdgeCap {
+		t.Fatalf("log = %+v, want one edge_cap rejection", log.recorded)
+	}
+}
+
+func TestService_Put_CapLowered_EvictsDownToIt(t *testing.T) {
+	repo := &spyRepo{edges: map[string][]domain.RelatedInsight{
+		"i-1": {
+			{InsightID: "i-3", Confidence: 0.8},
+			{InsightID: "i-4", Confidence: 0.7},
+			{InsightID: "i-5", Confidence: 0.6},
+		},
+	}}
+	svc := newPolicyService(repo, &spyRejectedLog{}, domain.RelationshipPolicy{MaxEdgesPerInsight: 2}, &spyEventPublisher{})
+
+	if err := svc.Put(context.Background(), makeRelationship()); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if len(repo.deleted) != 2 || repo.deleted[0][1] != "i-4" || repo.deleted[1][1] != "i-5" {
+		t.Fatalf("deleted = %v, want i-4 and i-5", repo.deleted)
+	}
+}
+
+func TestService_Put_PairTheUserRuledOn_Rejected(t *testing.T) {
+	repo := &spyRepo{}
+	log := &spyRejectedLog{}
+	feedback := &spyFeedback{verdicts: map[[2]string]domain.RelationshipFeedback{
+		{"i-1", "i-2"}: {Verdict: domain.VerdictNotRelated},
+	}}
+	svc := newFeedbackService(repo, log, feedback, domain.RelationshipPolicy{}, &spyEventPublisher{})
+
+	err := svc.Put(context.Background(), makeRelationship())
+
+	if !errors.Is(err, domain.ErrUserVerdict) || !errors.Is(err, domain.ErrRelationshipRejected) || repo.putCalled {
+		t.Fatalf("err = %v, put = %v; want ErrUserVerdict without a write", err, repo.putCalled)
+	}
+	if len(log.recorded) != 1 || log.recorded[0].Reason != domain.RejectUserVerdict {
+		t.Fatalf("log = %+v, want one user_verdict rejection", log.recorded)
+	}
+}
+
+func TestService_Remove_RecordsVerdictDeletesAndPublishes(t *testing.T) {
+	rel := makeRelationship()
+	repo := &spyRepo{stored: map[[2]string]domain.Relationship{{"i-2", "i-1"}: rel}}
+	feedback := &spyFeedback{}
+	pub := &spyEventPublisher{}
+	svc := newFeedbackService(repo, &spyRejectedLog{}, feedback, domain.RelationshipPolicy{}, pub)
+
+	if err := svc.Remove(context.Background(), "t-1", "i-2", "i-1"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	if len(feedback.saved) != 1 || feedback.saved[0].Verdict != domain.VerdictNotRelated || feedback.saved[0].InsightID != "i-2" {
+		t.Fatalf("feedback = %+v, want a not_related verdict from i-2", feedback.saved)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != [2]string{"i-2", "i-1"} {
+		t.Fatalf("deleted = %v, want i-2 <-> i-1", repo.deleted)
+	}
+	if len(pub.published) != 1 || pub.published[0].Payload.(domain.KnowledgeUpdatedPayload).Change != domain.KnowledgeEdgeRemoved {
+		t.Fatalf("published = %+v, want one removed event", pub.published)
+	}
+}
+
+func TestService_Retype_KeepsDirectionAndPinsConfidence(t *testing.T) {
+	rel := makeRelationship()
+	repo := &spyRepo{stored: map[[2]string]domain.Relationship{{"i-2", "i-1"}: rel}}
+	feedback := &spyFeedback{}
+	pub := &spyEventPublisher{}
+	svc := newFeedbackService(repo, &spyRejectedLog{}, feedback, domain.RelationshipPolicy{}, pub)
+
+	got, err := svc.Retype(context.Background(), "t-1", "i-2", "i-1", domain.RelationExtends)
+	if err != nil {
+		t.Fatalf("Retype: %v", err)
+	}
+
+	if got.FromInsightID != "i-1" || got.Type != domain.RelationExtends || got.Confidence != 1 || got.Rationale != rel.Rationale {
+		t.Fatalf("got = %+v, want i-1 -> i-2 extends at confidence 1", got)
+	}
+	if !repo.putCalled || repo.gotPutRel != got {
+		t.Fatalf("put = %v with %+v, want the retyped edge", repo.putCalled, repo.gotPutRel)
+	}
+	if len(feedback.saved) != 1 || feedback.saved[0].Verdict != domain.VerdictRetyped || feedback.saved[0].Type != domain.RelationExtends {
+		t.Fatalf("feedback = %+v, want a retyped verdict", feedback.saved)
+	}
+	if len(pub.published) != 1 || pub.published[0].Payload.(domain.KnowledgeUpdatedPayload).Change != domain.KnowledgeEdgeRetyped {
+		t.Fatalf("published = %+v, want one retyped event", pub.published)
+	}
+}
+
+func TestService_RemoveAndRetype_NoEdge_NotFoundWithoutAVerdict(t *testing.T) {
+	repo := &spyRepo{}
+	feedback := &spyFeedback{}
+	svc := newFeedbackService(repo, &spyRejectedLog{}, feedback, domain.RelationshipPolicy{}, &spyEventPublisher{})
+
+	if err := svc.Remove(context.Background(), "t-1", "i-1", "i-2"); !errors.Is(err, ports.ErrRelationshipNotFound) {
+		t.Fatalf("Remove err = %v, want ErrRelationshipNotFound", err)
+	}
+	if _, err := svc.Retype(context.Background(), "t-1", "i-1", "i-2", domain.RelationExtends); !errors.Is(err, ports.ErrRelationshipNotFound) {
+		t.Fatalf("Retype err = %v, want ErrRelationshipNotFound", err)
+	}
+	if len(feedback.saved) != 0 || len(repo.deleted) != 0 || repo.putCalled {
+		t.Fatalf("feedback = %v, deleted = %v, put = %v; want nothing written", feedback.saved, repo.deleted, repo.putCalled)
+	}
+}
@@ -0,0 +1,130 @@
+// Package review brings ingested insights back in front of the user on a
+// spaced-repetition schedule (domain.ReviewSchedule): GET /v1/review/due
+// lists what's due today, POST /v1/review/:id grades one and reschedules
+// it. Each review also counts toward its tags' Freshness.
+package review
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// Due returns up to limit of tenantID's insights due for review by the
+	// end of today, most pressing first (see domain.DueReviews).
+	Due(ctx context.Context, tenantID string, limit int) ([]domain.DueReview, error)
+
+	// Grade records a review of insightID graded grade and returns its new
+	// schedule. domain.ErrInvalidReviewGrade for a grade out of range,
+	// ports.ErrInsightNotFound for an unknown insight.
+	Grade(ctx context.Context, tenantID, insightID string, grade int) (domain.ReviewSchedule, error)
+}
+
+type service struct {
+	insights      ports.InsightRepository
+	relationships ports.RelationshipRepository
+	reviews       ports.ReviewRepository
+	now           func() time.Time
+}
+
+func NewService(insights ports.InsightRepository, relationships ports.RelationshipRepository, reviews ports.ReviewRepository) Service {
+	return &service{insights: insights, relationships: relationships, reviews: reviews, now: time.Now}
+}
+
+var _ Service = (*service)(nil)
+
+// Due reads the whole tenant at once, as contradiction.Service.List does:
+// every insight is a candidate, since one never reviewed is due as new.
+func (s *service) Due(ctx context.Context, tenantID string, limit int) ([]domain.DueReview, error) {
+	insights, err := s.insights.ListByTenantID(ctx, tenantID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list insights: %w", err)
+	}
+	if len(insights) == 0 {
+		return []domain.DueReview{}, nil
+	}
+
+	schedules, err := s.reviews.ListReviewSchedules(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list review schedules: %w", err)
+	}
+	scheduleOf := make(map[string]domain.ReviewSchedule, len(schedules))
+	for _, schedule := range schedules {
+		scheduleOf[schedule.InsightID] = schedule
+	}
+
+	tags, err := s.insights.ListTags(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	scoreOf := make(map[string]float64, len(tags))
+	for _, tag := range tags {
+		scoreOf[tag.Tag] = tag.Score
+	}
+
+	rels, err := s.relationships.ListRelationships(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list relationships: %w", err)
+	}
+	relatedAt := map[string]time.Time{}
+	for _, rel := range rels {
+		for _, id := range []string{rel.FromInsightID, rel.ToInsightID} {
+			if rel.DiscoveredAt.After(relatedAt[id]) {
+				relatedAt[id] = rel.DiscoveredAt
+			}
+		}
+	}
+
+	candidates := make([]domain.ReviewCandidate, 0, len(insights))
+	for _, insight := range insights {
+		c := domain.ReviewCandidate{Insight: insight, RelatedAt: relatedAt[insight.ID]}
+		if schedule, ok := scheduleOf[insight.ID]; ok {
+			c.Schedule = &schedule
+		}
+		if insight.Enrichment != nil {
+			for _, tag := range insight.Enrichment.Tags {
+				c.TagRelevance = max(c.TagRelevance, scoreOf[tag])
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	return domain.DueReviews(candidates, s.now().UTC(), limit), nil
+}
+
+func (s *service) Grade(ctx context.Context, tenantID, insightID string, grade int) (domain.ReviewSchedule, error) {
+	if grade < domain.MinReviewGrade || grade > domain.MaxReviewGrade {
+		return domain.ReviewSchedule{}, domain.ErrInvalidReviewGrade
+	}
+	insight, found, err := s.insights.GetByID(ctx, tenantID, insightID)
+	if err != nil {
+		return domain.ReviewSchedule{}, fmt.Errorf("get insight: %w", err)
+	}
+	if !found {
+		return domain.ReviewSchedule{}, ports.ErrInsightNotFound
+	}
+
+	schedule, found, err := s.reviews.GetReviewSchedule(ctx, tenantID, insightID)
+	if err != nil {
+		return domain.ReviewSchedule{}, fmt.Errorf("get review schedule: %w", err)
+	}
+	if !found {
+		schedule = domain.NewReviewSchedule(tenantID, insightID)
+	}
+	schedule, err = schedule.Review(grade, s.now().UTC())
+	if err != nil {
+		return domain.ReviewSchedule{}, err
+	}
+
+	var tags []string
+	if insight.Enrichment != nil {
+		tags = insight.Enrichment.Tags
+	}
+	if err := s.reviews.SaveReview(ctx, schedule, tags); err != nil {
+		return domain.ReviewSchedule{}, fmt.Errorf("save review: %w", err)
+	}
+	return schedule, nil
+}
@@ -0,0 +1,146 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeInsights struct {
+	ports.InsightRepository
+	all  []domain.Insight
+	tags []domain.TagSummary
+}
+
+func (f fakeInsights) ListByTenantID(context.Context, string, string) ([]domain.Insight, error) {
+	return f.all, nil
+}
+
+func (f fakeInsights) ListTags(context.Context, string) ([]domain.TagSummary, error) {
+	return f.tags, nil
+}
+
+func (f fakeInsights) GetByID(_ context.Context, _, insightID string) (domain.Insight, bool, error) {
+	for _, insight := range f.all {
+		if insight.ID == insightID {
+			return insight, true, nil
+		}
+	}
+	return domain.Insight{}, false, nil
+}
+
+type fakeRelationships struct {
+	ports.RelationshipRepository
+	all []domain.Relationship
+}
+
+func (f fakeRelationships) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return f.all, nil
+}
+
+type fakeReviews struct {
+	schedules map[string]domain.ReviewSchedule
+	savedTags []string
+}
+
+func (f *fakeReviews) GetReviewSchedule(_ context.Context, _, insightID string) (domain.ReviewSchedule, bool, error) {
+	s, ok := f.schedules[insightID]
+	return s, ok, nil
+}
+
+func (f *fakeReviews) ListReviewSchedules(context.Context, string) ([]domain.ReviewSchedule, error) {
+	var out []domain.ReviewSchedule
+	for _, s := range f.schedules {
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func (f *fakeReviews) ListReviewHistory(context.Context, string) (map[string][]time.Time, error) {
+	return nil, nil
+}
+
+func (f *fakeReviews) SaveReview(_ context.Context, schedule domain.ReviewSchedule, tags []string) error {
+	f.schedules[schedule.InsightID] = schedule
+	f.savedTags = tags
+	return nil
+}
+
+var now = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func tagged(id string, tags ...string) domain.Insight {
+	return domain.Insight{ID: id, TenantID: "t-1", Text: "text of " + id, Enrichment: &domain.Enrichment{Tags: tags}}
+}
+
+func newTestService(rels []domain.Relationship) (*service, *fakeReviews) {
+	insights := fakeInsights{
+		all:  []domain.Insight{tagged("a", "focus"), tagged("b", "sleep"), tagged("c", "sleep"), tagged("d", "sleep")},
+		tags: []domain.TagSummary{{Tag: "focus", Score: 0.9}, {Tag: "sleep", Score: 0.1}},
+	}
+	reviews := &fakeReviews{schedules: map[string]domain.ReviewSchedule{
+		"d": {TenantID: "t-1", InsightID: "d", EaseFactor: 2.5, IntervalDays: 6, DueAt: now.AddDate(0, 0, 3)},
+	}}
+	s := NewService(insights, fakeRelationships{all: rels}, reviews).(*service)
+	s.now = func() time.Time { return now }
+	return s, reviews
+}
+
+func TestService_Due_WeighsTagRelevanceAndRecentRelationships(t *testing.T) {
+	svc, _ := newTestService([]domain.Relationship{{FromInsightID: "c", ToInsightID: "b", DiscoveredAt: now.Add(-time.Hour)}})
+
+	due, err := svc.Due(context.Background(), "t-1", 10)
+	if err != nil {
+		t.Fatalf("Due: %v", err)
+	}
+
+	var ids []string
+	for _, d := range due {
+		ids = append(ids, d.Insight.ID)
+	}
+	// a's focus tag scores highest; b and c were just related; d isn't
+	// due for three days.
+	if len(ids) != 3 || ids[0] != "a" || ids[1] != "b" || ids[2] != "c" {
+		t.Fatalf("due = %v, want a, then b and c", ids)
+	}
+	if !due[0].New {
+		t.Fatalf("due[0] = %+v, want new", due[0])
+	}
+}
+
+func TestService_Grade_ReschedulesAndCountsTowardTags(t *testing.T) {
+	svc, reviews := newTestService(nil)
+
+	schedule, err := svc.Grade(context.Background(), "t-1", "a", 5)
+	if err != nil {
+		t.Fatalf("Grade: %v", err)
+	}
+	if schedule.IntervalDays != 1 || !schedule.DueAt.Equal(now.AddDate(0, 0, 1)) || schedule.Reviews != 1 {
+		t.Fatalf("schedule = %+v, want a first review due tomorrow", schedule)
+	}
+	if len(reviews.savedTags) != 1 || reviews.savedTags[0] != "focus" {
+		t.Fatalf("saved tags = %v, want a's", reviews.savedTags)
+	}
+
+	again, err := svc.Grade(context.Background(), "t-1", "a", 5)
+	if err != nil || again.IntervalDays != 6 || again.Reviews != 2 {
+		t.Fatalf("second Grade = %+v, %v; want it built on the saved schedule", again, err)
+	}
+}
+
+func TestService_Grade_Rejects(t *testing.T) {
+	svc, reviews := newTestService(nil)
+
+	if _, err := svc.Grade(context.Background(), "t-1", "a", 9); !errors.Is(err, domain.ErrInvalidReviewGrade) {
+		t.Fatalf("Grade(9) = %v, want ErrInvalidReviewGrade", err)
+	}
+	if _, err := svc.Grade(context.Background(), "t-1", "missing", 3); !errors.Is(err, ports.ErrInsightNotFound) {
+		t.Fatalf("Grade(missing) = %v, want ErrInsightNotFound", err)
+	}
+	if len(reviews.schedules) != 1 {
+		t.Fatalf("schedules = %+v, want nothing saved", reviews.schedules)
+	}
+}
@@ -0,0 +1,131 @@
+// Package settings is a tenant's own configuration of its library, read
+// and written through GET/PUT /v1/settings. Enrichment reads it directly
+// through ports.TenantSettingsRepository (llm.Service.WithSettings), as
+// does InsightAdapter.ListTags for the tenant's relevance weights.
+package settings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	Get(ctx context.Context, tenantID string) (domain.TenantSettings, error)
+
+	// SetTagLanguage changes the language tenantID's new tags are written
+	// in. Existing tags stay as they are until re-enriched: a reenrich job
+	// (POST /v1/admin/reenrich) brings them over.
+	SetTagLanguage(ctx context.Context, tenantID string, tagLanguage domain.Language) (domain.TenantSettings, error)
+
+	// SetRelationshipPolicy replaces the bar the agent's new edges must
+	// clear. Stored edges are left alone; a lowered edge cap is applied as
+	// each insight next gains an edge.
+	SetRelationshipPolicy(ctx context.Context, tenantID string, policy domain.RelationshipPolicy) (domain.TenantSettings, error)
+
+	// SetTagRelevance replaces how tenantID's tags are ranked; it takes
+	// effect on the next ListTags. Snapshots taken under the old config
+	// drop out of trends and history (ports.TagHistoryRepository), which
+	// start afresh rather than show the jump.
+	SetTagRelevance(ctx context.Context, tenantID string, config domain.TagRelevanceConfig) (domain.TenantSettings, error)
+
+	// PreviewTagRelevance ranks tenantID's tags under config without
+	// saving it, beside where each ranks now.
+	PreviewTagRelevance(ctx context.Context, tenantID string, config domain.TagRelevanceConfig) ([]TagPreview, error)
+}
+
+// TagPreview is one tag as ranked under a proposed TagRelevanceConfig.
+// Rank and CurrentRank count from 1; CurrentRank is under the tenant's
+// saved config, 0 if the tag gained its first insight between the two
+// rankings.
+type TagPreview struct {
+	Tag         domain.TagSummary
+	Rank        int
+	CurrentRank int
+}
+
+type service struct {
+	repo ports.TenantSettingsRepository
+	tags ports.TagRanker
+	now  func() time.Time
+}
+
+func NewService(repo ports.TenantSettingsRepository, tags ports.TagRanker) Service {
+	return &service{repo: repo, tags: tags, now: time.Now}
+}
+
+var _ Service = (*service)(nil)
+
+func (s *service) Get(ctx context.Context, tenantID string) (domain.TenantSettings, error) {
+	return s.repo.GetTenantSettings(ctx, tenantID)
+}
+
+func (s *service) SetTagLanguage(ctx context.Context, tenantID string, tagLanguage domain.Language) (domain.TenantSettings, error) {
+	return s.update(ctx, tenantID, func(settings *domain.TenantSettings) {
+		settings.TagLanguage = tagLanguage
+	})
+}
+
+func (s *service) SetRelationshipPolicy(ctx context.Context, tenantID string, policy domain.RelationshipPolicy) (domain.TenantSettings, error) {
+	if err := policy.Validate(); err != nil {
+		return domain.TenantSettings{}, err
+	}
+	return s.update(ctx, tenantID, func(settings *domain.TenantSettings) {
+		settings.RelationshipPolicy = policy
+	})
+}
+
+func (s *service) SetTagRelevance(ctx context.Context, tenantID string, config domain.TagRelevanceConfig) (domain.TenantSettings, error) {
+	if err := config.Validate(); err != nil {
+		return domain.TenantSettings{}, err
+	}
+	return s.update(ctx, tenantID, func(settings *domain.TenantSettings) {
+		settings.TagRelevance = config
+	})
+}
+
+func (s *service) PreviewTagRelevance(ctx context.Context, tenantID string, config domain.TagRelevanceConfig) ([]TagPreview, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.tags.RankTags(ctx, tenantID, settings.EffectiveTagRelevance())
+	if err != nil {
+		return nil, fmt.Errorf("rank tags under the saved config: %w", err)
+	}
+	proposed, err := s.tags.RankTags(ctx, tenantID, config)
+	if err != nil {
+		return nil, fmt.Errorf("rank tags under the proposed config: %w", err)
+	}
+
+	currentRank := make(map[string]int, len(current))
+	for i, t := range current {
+		currentRank[t.Tag] = i + 1
+	}
+	previews := make([]TagPreview, len(proposed))
+	for i, t := range proposed {
+		previews[i] = TagPreview{Tag: t, Rank: i + 1, CurrentRank: currentRank[t.Tag]}
+	}
+	return previews, nil
+}
+
+// update applies change to tenantID's stored settings and stamps
+// UpdatedAt, leaving every other setting as it was.
+func (s *service) update(ctx context.Context, tenantID string, change func(*domain.TenantSettings)) (domain.TenantSettings, error) {
+	settings, err := s.repo.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return domain.TenantSettings{}, err
+	}
+	change(&settings)
+	settings.UpdatedAt = s.now().UTC()
+	if err := s.repo.SaveTenantSettings(ctx, settings); err != nil {
+		return domain.TenantSettings{}, err
+	}
+	return settings, nil
+}
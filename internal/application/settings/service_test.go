@@ -0,0 +1,126 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type fakeRepo struct {
+	byTenant map[string]domain.TenantSettings
+}
+
+func (f *fakeRepo) GetTenantSettings(_ context.Context, tenantID string) (domain.TenantSettings, error) {
+	if s, ok := f.byTenant[tenantID]; ok {
+		return s, nil
+	}
+	return domain.TenantSettings{TenantID: tenantID}, nil
+}
+
+func (f *fakeRepo) SaveTenantSettings(_ context.Context, s domain.TenantSettings) error {
+	f.byTenant[s.TenantID] = s
+	return nil
+}
+
+func TestService_SetTagLanguage_SavesAndStamps(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	repo := &fakeRepo{byTenant: map[string]domain.TenantSettings{}}
+	svc := &service{repo: repo, now: func() time.Time { return now }}
+
+	got, err := svc.SetTagLanguage(context.Background(), "t-1", "de")
+	if err != nil {
+		t.Fatalf("SetTagLanguage: %v", err)
+	}
+	if got.TenantID != "t-1" || got.TagLanguage != "de" || !got.UpdatedAt.Equal(now) {
+		t.Fatalf("got %+v, want t-1's tag language de, stamped now", got)
+	}
+	if stored, _ := svc.Get(context.Background(), "t-1"); stored.TagLanguage != got.TagLanguage || !stored.UpdatedAt.Equal(got.UpdatedAt) {
+		t.Fatalf("stored %+v, want %+v", stored, got)
+	}
+}
+
+func TestService_SetRelationshipPolicy_KeepsTheTagLanguage(t *testing.T) {
+	repo := &fakeRepo{byTenant: map[string]domain.TenantSettings{"t-1": {TenantID: "t-1", TagLanguage: "de"}}}
+	svc := &service{repo: repo, now: time.Now}
+
+	policy := domain.RelationshipPolicy{MinConfidence: map[domain.RelationType]float64{domain.RelationSupports: 0.7}, MaxEdgesPerInsight: 3}
+	got, err := svc.SetRelationshipPolicy(context.Background(), "t-1", policy)
+	if err != nil {
+		t.Fatalf("SetRelationshipPolicy: %v", err)
+	}
+	stored := repo.byTenant["t-1"]
+	if stored.TagLanguage != "de" || stored.RelationshipPolicy.MaxEdgesPerInsight != 3 || got.RelationshipPolicy.MinConfidence[domain.RelationSupports] != 0.7 {
+		t.Fatalf("stored %+v, want the policy saved beside tag language de", stored)
+	}
+}
+
+func TestService_SetRelationshipPolicy_InvalidPolicy_NotSaved(t *testing.T) {
+	repo := &fakeRepo{byTenant: map[string]domain.TenantSettings{}}
+	svc := &service{repo: repo, now: time.Now}
+
+	policy := domain.RelationshipPolicy{MinConfidence: map[domain.RelationType]float64{domain.RelationSupports: 1.5}}
+	if _, err := svc.SetRelationshipPolicy(context.Background(), "t-1", policy); !errors.Is(err, domain.ErrConfidenceOutOfRange) {
+		t.Fatalf("err = %v, want ErrConfidenceOutOfRange", err)
+	}
+	if len(repo.byTenant) != 0 {
+		t.Fatalf("saved %+v, want nothing", repo.byTenant)
+	}
+}
+
+type fakeRanker struct {
+	// byHalfLife ranks the tenant's tags per config, told apart by
+	// HalfLife.
+	byHalfLife map[time.Duration][]domain.TagSummary
+}
+
+func (f fakeRanker) RankTags(_ context.Context, _ string, config domain.TagRelevanceConfig) ([]domain.TagSummary, error) {
+	return f.byHalfLife[config.HalfLife], nil
+}
+
+func TestService_SetTagRelevance_SavesAValidConfig(t *testing.T) {
+	repo := &fakeRepo{byTenant: map[string]domain.TenantSettings{"t-1": {TenantID: "t-1", TagLanguage: "de"}}}
+	svc := &service{repo: repo, now: time.Now}
+
+	config := domain.DefaultTagRelevanceConfig()
+	config.HalfLife = 30 * 24 * time.Hour
+	if _, err := svc.SetTagRelevance(context.Background(), "t-1", config); err != nil {
+		t.Fatalf("SetTagRelevance: %v", err)
+	}
+	if stored := repo.byTenant["t-1"]; stored.TagRelevance != config || stored.TagLanguage != "de" {
+		t.Fatalf("stored %+v, want the config saved beside tag language de", stored)
+	}
+
+	config.CountWeight = 0.9
+	if _, err := svc.SetTagRelevance(context.Background(), "t-1", config); !errors.Is(err, domain.ErrRelevanceWeightsSum) {
+		t.Fatalf("err = %v, want ErrRelevanceWeightsSum", err)
+	}
+	if repo.byTenant["t-1"].TagRelevance.CountWeight == 0.9 {
+		t.Fatal("an invalid config was saved")
+	}
+}
+
+func TestService_PreviewTagRelevance_RanksBesideTheSavedConfig(t *testing.T) {
+	defaults := domain.DefaultTagRelevanceConfig()
+	proposed := defaults
+	proposed.HalfLife = 30 * 24 * time.Hour
+	ranker := fakeRanker{byHalfLife: map[time.Duration][]domain.TagSummary{
+		defaults.HalfLife: {{Tag: "focus"}, {Tag: "habits"}},
+		proposed.HalfLife: {{Tag: "habits"}, {Tag: "focus"}, {Tag: "sleep"}},
+	}}
+	repo := &fakeRepo{byTenant: map[string]domain.TenantSettings{}}
+	svc := &service{repo: repo, tags: ranker, now: time.Now}
+
+	previews, err := svc.PreviewTagRelevance(context.Background(), "t-1", proposed)
+	if err != nil {
+		t.Fatalf("PreviewTagRelevance: %v", err)
+	}
+	if len(previews) != 3 || previews[0].Tag.Tag != "habits" || previews[0].Rank != 1 || previews[0].CurrentRank != 2 || previews[2].CurrentRank != 0 {
+		t.Fatalf("previews = %+v, want habits up to 1 from 2, and sleep unranked before", previews)
+	}
+	if len(repo.byTenant) != 0 {
+		t.Fatalf("preview saved settings: %+v", repo.byTenant)
+	}
+}
@@ -0,0 +1,77 @@
+// Package taghistory keeps a dated record of every tag's relevance, since
+// domain.TagRelevanceScore only knows the present. The tag-snapshot
+// Lambda takes a snapshot on a schedule; GET /v1/tags/:tag/history reads
+// a tag's series back, and insight.Service.ListTags fits its trend.
+package taghistory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+// SnapshotRetention is how long a snapshot is kept: a year of history,
+// plus a day so the oldest point of a year-long series is still there.
+const SnapshotRetention = 366 * 24 * time.Hour
+
+type Service interface {
+	// Snapshot records the current relevance of tenantID's top
+	// domain.MaxSnapshotTags tags, and the config they were scored under.
+	Snapshot(ctx context.Context, tenantID string) (domain.TagSnapshot, error)
+
+	// History returns tag's series since since, oldest first (see
+	// domain.TagHistory); empty if no snapshot in that span carries it.
+	History(ctx context.Context, tenantID, tag string, since time.Time) ([]domain.TagHistoryPoint, error)
+}
+
+type service struct {
+	tags     ports.TagRanker
+	settings ports.TenantSettingsRepository
+	history  ports.TagHistoryRepository
+	now      func() time.Time
+}
+
+func NewService(tags ports.TagRanker, settings ports.TenantSettingsRepository, history ports.TagHistoryRepository) Service {
+	return &service{tags: tags, settings: settings, history: history, now: time.Now}
+}
+
+var _ Service = (*service)(nil)
+
+// Snapshot truncates TakenAt to the second, the precision snapshots are
+// keyed at. A retried run lands in a later second and so adds a point of
+// its own next to the failed run's, if that one was saved; a day's
+// schedule makes that a near-duplicate the trend fit barely notices. Tags
+// are ranked under the config read here, as ListTags would, so the
+// snapshot records exactly the one they were scored under.
+func (s *service) Snapshot(ctx context.Context, tenantID string) (domain.TagSnapshot, error) {
+	takenAt := s.now().UTC().Truncate(time.Second)
+	settings, err := s.settings.GetTenantSettings(ctx, tenantID)
+	if err != nil {
+		return domain.TagSnapshot{}, fmt.Errorf("get tenant settings: %w", err)
+	}
+	relevance := settings.EffectiveTagRelevance()
+	tags, err := s.tags.RankTags(ctx, tenantID, relevance)
+	if err != nil {
+		return domain.TagSnapshot{}, fmt.Errorf("rank tags: %w", err)
+	}
+
+	// RankTags returns them highest-scored first.
+	tags = tags[:min(len(tags), domain.MaxSnapshotTags)]
+
+	snapshot := domain.TagSnapshot{TenantID: tenantID, TakenAt: takenAt, Relevance: relevance, Tags: tags}
+	if err := s.history.SaveTagSnapshot(ctx, snapshot, takenAt.Add(SnapshotRetention)); err != nil {
+		return domain.TagSnapshot{}, fmt.Errorf("save tag snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (s *service) History(ctx context.Context, tenantID, tag string, since time.Time) ([]domain.TagHistoryPoint, error) {
+	snapshots, err := s.history.ListTagSnapshots(ctx, tenantID, since)
+	if err != nil {
+		return nil, fmt.Errorf("list tag snapshots: %w", err)
+	}
+	return domain.TagHistory(tag, snapshots), nil
+}
@@ -0,0 +1,131 @@
+package taghistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeRanker struct {
+	tags      []domain.TagSummary
+	err       error
+	gotConfig *domain.TagRelevanceConfig
+}
+
+func (f fakeRanker) RankTags(_ context.Context, _ string, config domain.TagRelevanceConfig) ([]domain.TagSummary, error) {
+	if f.gotConfig != nil {
+		*f.gotConfig = config
+	}
+	return f.tags, f.err
+}
+
+type fakeSettings domain.TenantSettings
+
+func (f fakeSettings) GetTenantSettings(context.Context, string) (domain.TenantSettings, error) {
+	return domain.TenantSettings(f), nil
+}
+
+func (f fakeSettings) SaveTenantSettings(context.Context, domain.TenantSettings) error { return nil }
+
+type fakeHistory struct {
+	saved        []domain.TagSnapshot
+	gotExpiresAt time.Time
+	gotSince     time.Time
+}
+
+func (f *fakeHistory) SaveTagSnapshot(_ context.Context, snapshot domain.TagSnapshot, expiresAt time.Time) error {
+	f.saved = append(f.saved, snapshot)
+	f.gotExpiresAt = expiresAt
+	return nil
+}
+
+func (f *fakeHistory) ListTagSnapshots(_ context.Context, _ string, since time.Time) ([]domain.TagSnapshot, error) {
+	f.gotSince = since
+	return f.saved, nil
+}
+
+func newTestService(tags ports.TagRanker, history ports.TagHistoryRepository, now time.Time) *service {
+	svc := NewService(tags, fakeSettings{}, history).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestService_Snapshot_SavesCurrentTagsToExpire(t *testing.T) {
+	now := time.Date(2026, 3, 1, 6, 0, 0, 500, time.UTC)
+	history := &fakeHistory{}
+	svc := newTestService(fakeRanker{tags: []domain.TagSummary{{Tag: "focus", Score: 0.4}}}, history, now)
+
+	snapshot, err := svc.Snapshot(context.Background(), "t-1")
+
+	if err != nil || len(history.saved) != 1 {
+		t.Fatalf("saved = %+v, %v; want one snapshot", history.saved, err)
+	}
+	takenAt := now.Truncate(time.Second)
+	if !snapshot.TakenAt.Equal(takenAt) || snapshot.TenantID != "t-1" || snapshot.Tags[0].Tag != "focus" || snapshot.Relevance != domain.DefaultTagRelevanceConfig() {
+		t.Fatalf("snapshot = %+v", snapshot)
+	}
+	if !history.gotExpiresAt.Equal(takenAt.Add(SnapshotRetention)) {
+		t.Fatalf("expires at %v, want %v", history.gotExpiresAt, takenAt.Add(SnapshotRetention))
+	}
+}
+
+func TestService_Snapshot_RanksUnderAndRecordsTheTenantsConfig(t *testing.T) {
+	config := domain.DefaultTagRelevanceConfig()
+	config.HalfLife = 30 * 24 * time.Hour
+	var got domain.TagRelevanceConfig
+	history := &fakeHistory{}
+	svc := NewService(fakeRanker{gotConfig: &got}, fakeSettings{TagRelevance: config}, history)
+
+	snapshot, err := svc.Snapshot(context.Background(), "t-1")
+
+	if err != nil || got != config || snapshot.Relevance != config {
+		t.Fatalf("ranked under %+v, recorded %+v (%v); want the tenant's config both times", got, snapshot.Relevance, err)
+	}
+}
+
+func TestService_Snapshot_KeepsTheTopTagsOnly(t *testing.T) {
+	tags := make([]domain.TagSummary, domain.MaxSnapshotTags+5)
+	for i := range tags {
+		tags[i] = domain.TagSummary{Tag: fmt.Sprintf("tag-%d", i), Score: 1 - float64(i)/float64(len(tags))}
+	}
+	history := &fakeHistory{}
+	svc := newTestService(fakeRanker{tags: tags}, history, time.Now())
+
+	if _, err := svc.Snapshot(context.Background(), "t-1"); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	saved := history.saved[0].Tags
+	if len(saved) != domain.MaxSnapshotTags || saved[0].Tag != "tag-0" || saved[len(saved)-1].Tag != tags[domain.MaxSnapshotTags-1].Tag {
+		t.Fatalf("saved %d tags, %s to %s; want the top %d", len(saved), saved[0].Tag, saved[len(saved)-1].Tag, domain.MaxSnapshotTags)
+	}
+}
+
+func TestService_Snapshot_RankTagsFails_SavesNothing(t *testing.T) {
+	history := &fakeHistory{}
+	svc := newTestService(fakeRanker{err: errors.New("boom")}, history, time.Now())
+
+	if _, err := svc.Snapshot(context.Background(), "t-1"); err == nil || len(history.saved) != 0 {
+		t.Fatalf("err = %v, saved = %+v; want the error and no snapshot", err, history.saved)
+	}
+}
+
+func TestService_History_ReadsSinceAndExtractsTheTag(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	history := &fakeHistory{saved: []domain.TagSnapshot{
+		{TakenAt: day, Tags: []domain.TagSummary{{Tag: "focus", Score: 0.2}, {Tag: "habits", Score: 0.5}}},
+		{TakenAt: day.AddDate(0, 0, 1), Tags: []domain.TagSummary{{Tag: "focus", Score: 0.3}}},
+	}}
+	svc := newTestService(fakeRanker{}, history, day)
+	since := day.AddDate(0, 0, -30)
+
+	points, err := svc.History(context.Background(), "t-1", "focus", since)
+
+	if err != nil || len(points) != 2 || points[1].Score != 0.3 || !history.gotSince.Equal(since) {
+		t.Fatalf("points = %+v, %v (since %v)", points, err, history.gotSince)
+	}
+}
@@ -0,0 +1,50 @@
+// Package tagmap relates tags to each other (GET /v1/tags/:tag/related):
+// tags that land on the same insights, and tags whose insights the agent
+// has linked. ListTags ranks each tag alone; this is the map between them.
+package tagmap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// Related returns up to limit tags associated with tag, best first
+	// (see domain.RelatedTags). ports.ErrUnknownTag if no insight carries
+	// tag.
+	Related(ctx context.Context, tenantID, tag string, limit int) ([]domain.RelatedTag, error)
+}
+
+type service struct {
+	insights      ports.InsightRepository
+	relationships ports.RelationshipRepository
+}
+
+func NewService(insights ports.InsightRepository, relationships ports.RelationshipRepository) Service {
+	return &service{insights: insights, relationships: relationships}
+}
+
+var _ Service = (*service)(nil)
+
+// Related reads the whole graph at once, as graph.Service.Export does:
+// the lift of any pair depends on every insight's tags, not just those
+// carrying tag.
+func (s *service) Related(ctx context.Context, tenantID, tag string, limit int) ([]domain.RelatedTag, error) {
+	insights, err := s.insights.ListByTenantID(ctx, tenantID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list insights: %w", err)
+	}
+	rels, err := s.relationships.ListRelationships(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list relationships: %w", err)
+	}
+
+	related, ok := domain.RelatedTags(tag, insights, rels)
+	if !ok {
+		return nil, ports.ErrUnknownTag
+	}
+	return related[:min(len(related), limit)], nil
+}
@@ -0,0 +1,61 @@
+package tagmap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeInsights struct {
+	ports.InsightRepository
+	all []domain.Insight
+}
+
+func (f fakeInsights) ListByTenantID(context.Context, string, string) ([]domain.Insight, error) {
+	return f.all, nil
+}
+
+type fakeRelationships struct {
+	ports.RelationshipRepository
+	all []domain.Relationship
+}
+
+func (f fakeRelationships) ListRelationships(context.Context, string) ([]domain.Relationship, error) {
+	return f.all, nil
+}
+
+func tagged(id string, tags ...string) domain.Insight {
+	return domain.Insight{ID: id, Enrichment: &domain.Enrichment{Tags: tags}}
+}
+
+func TestService_Related_CapsAtLimit(t *testing.T) {
+	svc := NewService(fakeInsights{all: []domain.Insight{
+		tagged("1", "delegation", "trust", "meetings"),
+		tagged("2", "delegation", "feedback"),
+		tagged("3", "habits"),
+	}}, fakeRelationships{all: []domain.Relationship{
+		{FromInsightID: "2", ToInsightID: "3", Type: domain.RelationExtends, Confidence: 0.8},
+	}})
+
+	related, err := svc.Related(context.Background(), "t-1", "delegation", 2)
+
+	if err != nil || len(related) != 2 {
+		t.Fatalf("related = %+v, %v; want the top 2 of 4", related, err)
+	}
+
+	all, _ := svc.Related(context.Background(), "t-1", "delegation", 10)
+	if len(all) != 4 {
+		t.Fatalf("related = %+v, want trust, meetings, feedback and habits", all)
+	}
+}
+
+func TestService_Related_UnknownTag(t *testing.T) {
+	svc := NewService(fakeInsights{all: []domain.Insight{tagged("1", "trust")}}, fakeRelationships{})
+
+	if _, err := svc.Related(context.Background(), "t-1", "delegation", 10); !errors.Is(err, ports.ErrUnknownTag) {
+		t.Fatalf("err = %v, want ErrUnknownTag", err)
+	}
+}
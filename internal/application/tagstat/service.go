@@ -0,0 +1,67 @@
+// Package tagstat rebuilds the per-tag aggregates GET /v1/tags ranks
+// from (domain.TagStat). The adapters keep them current as memberships,
+// relationship edges and reviews are written; a rebuild recomputes them from
+// those when they've drifted, or for data written before they existed.
+package tagstat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type Service interface {
+	// Rebuild recomputes every aggregate in tenantID from scratch and
+	// returns what it wrote.
+	Rebuild(ctx context.Context, tenantID string) ([]domain.TagStat, error)
+}
+
+type service struct {
+	stats   ports.TagStatRepository
+	reviews ports.ReviewRepository
+}
+
+func NewService(stats ports.TagStatRepository, reviews ports.ReviewRepository) Service {
+	return &service{stats: stats, reviews: reviews}
+}
+
+var _ Service = (*service)(nil)
+
+func (s *service) Rebuild(ctx context.Context, tenantID string) ([]domain.TagStat, error) {
+	memberships, err := s.stats.ListAllTagMemberships(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list tag memberships: %w", err)
+	}
+	degrees, err := s.stats.RelationshipDegrees(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("relationship degrees: %w", err)
+	}
+
+	reviewed, err := s.reviews.ListReviewHistory(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list review history: %w", err)
+	}
+	if reviewed == nil {
+		reviewed = map[string][]time.Time{}
+	}
+	// Reviews recorded before the log existed left only the schedule's
+	// last one behind; count that rather than none.
+	schedules, err := s.reviews.ListReviewSchedules(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list review schedules: %w", err)
+	}
+	for _, schedule := range schedules {
+		if len(reviewed[schedule.InsightID]) == 0 && !schedule.LastReviewedAt.IsZero() {
+			reviewed[schedule.InsightID] = []time.Time{schedule.LastReviewedAt}
+		}
+	}
+
+	stats := domain.BuildTagStats(memberships, degrees, reviewed)
+	if err := s.stats.ReplaceTagStats(ctx, tenantID, stats); err != nil {
+		return nil, fmt.Errorf("replace tag stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,96 @@
+package tagstat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
+)
+
+type fakeStats struct {
+	memberships map[string][]domain.TagMembership
+	degrees     map[string]int
+	listErr     error
+
+	replaced    []domain.TagStat
+	replaceCall int
+}
+
+func (f *fakeStats) ListAllTagMemberships(context.Context, string) (map[string][]domain.TagMembership, error) {
+	return f.memberships, f.listErr
+}
+
+func (f *fakeStats) RelationshipDegrees(context.Context, string) (map[string]int, error) {
+	return f.degrees, nil
+}
+
+type fakeReviews struct {
+	ports.ReviewRepository
+	schedules []domain.ReviewSchedule
+	history   map[string][]time.Time
+}
+
+func (f fakeReviews) ListReviewSchedules(context.Context, string) ([]domain.ReviewSchedule, error) {
+	return f.schedules, nil
+}
+
+func (f fakeReviews) ListReviewHistory(context.Context, string) (map[string][]time.Time, error) {
+	return f.history, nil
+}
+
+func (f *fakeStats) ReplaceTagStats(_ context.Context, _ string, stats []domain.TagStat) error {
+	f.replaced = stats
+	f.replaceCall++
+	return nil
+}
+
+func TestService_Rebuild_ReplacesWithAggregatesFromScratch(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	stats := &fakeStats{
+		memberships: map[string][]domain.TagMembership{
+			"focus": {{InsightID: "a", HighlightedAt: at}, {InsightID: "b", HighlightedAt: at}},
+			"sleep": {{InsightID: "b", HighlightedAt: at}},
+		},
+		degrees: map[string]int{"b": 2},
+	}
+
+	// a's reviews are all in the log; b's only review predates it.
+	reviews := fakeReviews{
+		schedules: []domain.ReviewSchedule{
+			{InsightID: "a", Reviews: 2, LastReviewedAt: at.Add(2 * time.Hour)},
+			{InsightID: "b", Reviews: 1, LastReviewedAt: at.Add(3 * time.Hour)},
+		},
+		history: map[string][]time.Time{"a": {at.Add(time.Hour), at.Add(2 * time.Hour)}},
+	}
+
+	got, err := NewService(stats, reviews).Rebuild(context.Background(), "t-1")
+
+	if err != nil || stats.replaceCall != 1 {
+		t.Fatalf("Rebuild = %v, replaced %d times; want one replace", err, stats.replaceCall)
+	}
+	if len(got) != 2 || got[0].Tag != "focus" || got[0].InsightCount != 2 || got[0].DegreeSum != 2 || got[1].Tag != "sleep" {
+		t.Fatalf("Rebuild = %+v", got)
+	}
+	focusReviews, sleepReviews := got[0].ReviewedBuckets, got[1].ReviewedBuckets
+	if len(focusReviews) != 3 || focusReviews[domain.TagStatHour(at.Add(time.Hour))] != 1 || focusReviews[domain.TagStatHour(at.Add(2*time.Hour))] != 1 ||
+		len(sleepReviews) != 1 || sleepReviews[domain.TagStatHour(at.Add(3*time.Hour))] != 1 {
+		t.Fatalf("reviewed buckets = %v / %v, want both of a's logged reviews under focus and b's last one under both", focusReviews, sleepReviews)
+	}
+	if len(stats.replaced) != len(got) {
+		t.Fatalf("replaced %+v, want what was returned", stats.replaced)
+	}
+}
+
+func TestService_Rebuild_ReadErrorReplacesNothing(t *testing.T) {
+	stats := &fakeStats{listErr: errors.New("boom")}
+
+	if _, err := NewService(stats, fakeReviews{}).Rebuild(context.Background(), "t-1"); err == nil {
+		t.Fatalf("Rebuild succeeded, want the read error")
+	}
+	if stats.replaceCall != 0 {
+		t.Fatalf("replaced after a failed read; a partial read would wipe aggregates")
+	}
+}
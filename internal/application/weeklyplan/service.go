@@ -29,16 +29,25 @@ type Service interface {
 
 	SetReady(ctx context.Context, tenantID, planID string, actions []domain.Action) error
 	SetFailed(ctx context.Context, tenantID, planID, reason string) error
+
+	// UpdateAction records the user's progress on the ready plan's action
+	// at index and returns the action as stored. Marking it done publishes
+	// ActionCompleted, so the Action Agent can weigh what was finished
+	// when it drafts the next plan on the same tag. If the action moved on
+	// between the read and the write, nothing is stored and it returns
+	// ports.ErrActionChanged.
+	UpdateAction(ctx context.Context, tenantID, planID string, index int, update domain.ActionUpdate) (domain.Action, error)
 }
 
 type service struct {
 	repo     ports.WeeklyPlanRepository
 	insights ports.InsightRepository
 	events   ports.DomainEventPublisher
+	now      func() time.Time
 }
 
 func NewService(repo ports.WeeklyPlanRepository, insights ports.InsightRepository, events ports.DomainEventPublisher) Service {
-	return &service{repo: repo, insights: insights, events: events}
+	return &service{repo: repo, insights: insights, events: events, now: time.Now}
 }
 
 var _ Service = (*service)(nil)
@@ -96,6 +105,10 @@ func (s *service) Get(ctx context.Context, tenantID, planID string) (domain.Plan
 			Title:              action.Title,
 			Why:                action.Why,
 			SupportingInsights: supporting,
+			Status:             action.Status,
+			StartedAt:          action.StartedAt,
+			CompletedAt:        action.CompletedAt,
+			Reflection:         action.Reflection,
 		}
 	}
 
@@ -121,3 +134,33 @@ func (s *service) SetReady(ctx context.Context, tenantID, planID string, actions
 func (s *service) SetFailed(ctx context.Context, tenantID, planID, reason string) error {
 	return s.repo.SetFailed(ctx, tenantID, planID, reason)
 }
+
+// UpdateAction validates update before loading the plan, so a malformed
+// request costs no read. Only a move into ActionDone publishes: editing
+// the reflection on an already-done action doesn't announce it again.
+func (s *service) UpdateAction(ctx context.Context, tenantID, planID string, index int, update domain.ActionUpdate) (domain.Action, error) {
+	if err := update.Validate(); err != nil {
+		return domain.Action{}, err
+	}
+	plan, err := s.repo.Get(ctx, tenantID, planID)
+	if err != nil {
+		return domain.Action{}, err
+	}
+
+	now := s.now().UTC()
+	before, after, err := plan.UpdateAction(index, update, now)
+	if err != nil {
+		return domain.Action{}, err
+	}
+	if err := s.repo.UpdateAction(ctx, tenantID, planID, index, before, after); err != nil {
+		return domain.Action{}, err
+	}
+
+	if after.Status == domain.ActionDone && before.Status != domain.ActionDone {
+		event := domain.NewActionCompletedEvent(plan, index, after, now)
+		if err := s.events.Publish(ctx, event); err != nil {
+			return domain.Action{}, fmt.Errorf("publish %s event: %w", event.EventType, err)
+		}
+	}
+	return after, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+	"github.com/marcogerstmann/insight-processing-platform/internal/ports"
 )
 
 type spyRepo struct {
@@ -23,6 +25,12 @@ type spyRepo struct {
 
 	setFailedErr    error
 	setFailedReason string
+
+	updateActionErr    error
+	updateActionCalled bool
+	updateActionIndex  int
+	updateActionBefore domain.Action
+	updatedAction      domain.Action
 }
 
 func (s *spyRepo) Create(_ context.Context, plan domain.WeeklyPlan) error {
@@ -48,6 +56,14 @@ func (s *spyRepo) SetFailed(_ context.Context, _, _, reason string) error {
 	return s.setFailedErr
 }
 
+func (s *spyRepo) UpdateAction(_ context.Context, _, _ string, index int, before, after domain.Action) error {
+	s.updateActionCalled = true
+	s.updateActionIndex = index
+	s.updateActionBefore = before
+	s.updatedAction = after
+	return s.updateActionErr
+}
+
 type fakeInsightRepo struct {
 	byTagAndTenant map[string][]domain.Insight // key: tenantID + "|" + tag
 }
@@ -66,6 +82,10 @@ func (f *fakeInsightRepo) ListTags(context.Context, string) ([]domain.TagSummary
 	return nil, nil
 }
 
+func (f *fakeInsightRepo) GetByID(context.Context, string, string) (domain.Insight, bool, error) {
+	return domain.Insight{}, false, nil
+}
+
 type spyEventPublisher struct {
 	err       error
 	published []domain.DomainEvent
@@ -227,3 +247,84 @@ func TestService_SetFailed_DelegatesToRepo(t *testing.T) {
 		t.Fatalf("repo.setFailedReason = %q, want %q", repo.setFailedReason, "timed out")
 	}
 }
+
+func readyPlanWithActions(statuses ...domain.ActionStatus) domain.WeeklyPlan {
+	plan := domain.WeeklyPlan{ID: "p-1", TenantID: "t-1", Tag: "golang", Status: domain.PlanStatusReady}
+	for _, status := range statuses {
+		plan.Actions = append(plan.Actions, domain.Action{Title: "act", SupportingInsightIDs: []string{"i-1"}, Status: status})
+	}
+	return plan
+}
+
+func statusPtr(s domain.ActionStatus) *domain.ActionStatus { return &s }
+
+func TestService_UpdateAction_Done_PersistsThenPublishesActionCompleted(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	repo := &spyRepo{getPlan: readyPlanWithActions(domain.ActionTodo, domain.ActionInProgress)}
+	pub := &spyEventPublisher{}
+	svc := NewService(repo, &fakeInsightRepo{}, pub).(*service)
+	svc.now = func() time.Time { return now }
+
+	reflection := "went well"
+	got, err := svc.UpdateAction(context.Background(), "t-1", "p-1", 1, domain.ActionUpdate{Status: statusPtr(domain.ActionDone), Reflection: &reflection})
+	if err != nil {
+		t.Fatalf("UpdateAction: %v", err)
+	}
+
+	if got.Status != domain.ActionDone || !got.CompletedAt.Equal(now) || got.Reflection != "went well" {
+		t.Fatalf("action = %+v, want done at now with the reflection", got)
+	}
+	if !repo.updateActionCalled || repo.updateActionIndex != 1 || repo.updateActionBefore.Status != domain.ActionInProgress || repo.updatedAction.Status != domain.ActionDone {
+		t.Fatalf("repo.UpdateAction index=%d before=%+v action=%+v, want index 1 from in progress to done", repo.updateActionIndex, repo.updateActionBefore, repo.updatedAction)
+	}
+	if len(pub.published) != 1 || pub.published[0].EventType != domain.ActionCompleted || pub.published[0].TenantID != "t-1" {
+		t.Fatalf("published = %+v, want one ActionCompleted for t-1", pub.published)
+	}
+}
+
+func TestService_UpdateAction_AlreadyDone_NeverPublishesAgain(t *testing.T) {
+	repo := &spyRepo{getPlan: readyPlanWithActions(domain.ActionDone)}
+	pub := &spyEventPublisher{}
+	svc := NewService(repo, &fakeInsightRepo{}, pub)
+
+	reflection := "an afterthought"
+	if _, err := svc.UpdateAction(context.Background(), "t-1", "p-1", 0, domain.ActionUpdate{Reflection: &reflection}); err != nil {
+		t.Fatalf("UpdateAction: %v", err)
+	}
+	if !repo.updateActionCalled || len(pub.published) != 0 {
+		t.Fatalf("updated=%v published=%d, want the write but no event", repo.updateActionCalled, len(pub.published))
+	}
+}
+
+func TestService_UpdateAction_InvalidUpdate_NeverReachesRepo(t *testing.T) {
+	repo := &spyRepo{getErr: errors.New("must not be called")}
+	svc := NewService(repo, &fakeInsightRepo{}, &spyEventPublisher{})
+
+	_, err := svc.UpdateAction(context.Background(), "t-1", "p-1", 0, domain.ActionUpdate{Status: statusPtr("finished")})
+	if !errors.Is(err, domain.ErrUnknownActionStatus) {
+		t.Fatalf("err = %v, want ErrUnknownActionStatus", err)
+	}
+}
+
+func TestService_UpdateAction_OutOfRange_NeverWrites(t *testing.T) {
+	repo := &spyRepo{getPlan: readyPlanWithActions(domain.ActionTodo)}
+	svc := NewService(repo, &fakeInsightRepo{}, &spyEventPublisher{})
+
+	_, err := svc.UpdateAction(context.Background(), "t-1", "p-1", 3, domain.ActionUpdate{Status: statusPtr(domain.ActionDone)})
+	if !errors.Is(err, domain.ErrActionNotFound) || repo.updateActionCalled {
+		t.Fatalf("err = %v, updated = %v; want ErrActionNotFound and no write", err, repo.updateActionCalled)
+	}
+}
+
+func TestService_UpdateAction_RepoError_NeverPublishes(t *testing.T) {
+	// A concurrent request finished the action first: its event is the
+	// only one.
+	repo := &spyRepo{getPlan: readyPlanWithActions(domain.ActionTodo), updateActionErr: ports.ErrActionChanged}
+	pub := &spyEventPublisher{}
+	svc := NewService(repo, &fakeInsightRepo{}, pub)
+
+	_, err := svc.UpdateAction(context.Background(), "t-1", "p-1", 0, domain.ActionUpdate{Status: statusPtr(domain.ActionDone)})
+	if !errors.Is(err, ports.ErrActionChanged) || len(pub.published) != 0 {
+		t.Fatalf("err = %v, published = %d; want ErrActionChanged and no event", err, len(pub.published))
+	}
+}
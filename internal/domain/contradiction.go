@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"time"
+)
+
+// maxResolutionNoteLength keeps a resolution note to a paragraph.
+const maxResolutionNoteLength = 2000
+
+var ErrResolutionNoteTooLong = errors.New("resolution note too long")
+
+// ContradictionResolution is a user marking the contradicts edge between
+// two insights as dealt with: they've thought it through, and Note says
+// how. It's keyed by the pair in ContradictionPair order, so it doesn't
+// matter which side the edge was discovered from.
+type ContradictionResolution struct {
+	TenantID       string
+	InsightID      string
+	OtherInsightID string
+	Note           string
+	ResolvedAt     time.Time
+}
+
+func (r ContradictionResolution) Validate() error {
+	if len(r.Note) > maxResolutionNoteLength {
+		return ErrResolutionNoteTooLong
+	}
+	return nil
+}
+
+// ContradictionPair orders two insight IDs the way a resolution is keyed.
+func ContradictionPair(insightID, otherInsightID string) (string, string) {
+	if insightID > otherInsightID {
+		return otherInsightID, insightID
+	}
+	return insightID, otherInsightID
+}
+
+// Contradiction is a contradicts edge with both insights' texts, the tags
+// they share, and the user's resolution if there is one.
+type Contradiction struct {
+	Relationship Relationship
+	FromText     string
+	ToText       string
+	SharedTags   []string
+	Resolution   *ContradictionResolution
+}
+
+// ContradictionGroup is the contradictions between insights sharing Tag;
+// Tag is empty for those sharing none.
+type ContradictionGroup struct {
+	Tag            string
+	Contradictions []Contradiction
+}
+
+// GroupContradictions files each contradiction under every tag its two
+// insights share, or the untagged group if none. Within a group the most
+// confident come first, then the most recently discovered. Groups run from
+// the most contradictions to the fewest, by tag on a tie, untagged last.
+func GroupContradictions(contradictions []Contradiction) []ContradictionGroup {
+	byTag := map[string][]Contradiction{}
+	for _, c := range contradictions {
+		if len(c.SharedTags) == 0 {
+			byTag[""] = append(byTag[""], c)
+			continue
+		}
+		for _, tag := range c.SharedTags {
+			byTag[tag] = append(byTag[tag], c)
+		}
+	}
+
+	groups := make([]ContradictionGroup, 0, len(byTag))
+	for tag, cs := range byTag {
+		sort.SliceStable(cs, func(i, j int) bool {
+			a, b := cs[i].Relationship, cs[j].Relationship
+			if a.Confidence != b.Confidence {
+				return a.Confidence > b.Confidence
+			}
+			return a.DiscoveredAt.After(b.DiscoveredAt)
+		})
+		groups = append(groups, ContradictionGroup{Tag: tag, Contradictions: cs})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if (a.Tag == "") != (b.Tag == "") {
+			return b.Tag == ""
+		}
+		if len(a.Contradictions) != len(b.Contradictions) {
+			return len(a.Contradictions) > len(b.Contradictions)
+		}
+		return a.Tag < b.Tag
+	})
+	return groups
+}
+
+// SharedTags returns the tags both insights carry, in a's order.
+func SharedTags(a, b Insight) []string {
+	if a.Enrichment == nil || b.Enrichment == nil {
+		return nil
+	}
+	var shared []string
+	for _, tag := range a.Enrichment.Tags {
+		if slices.Contains(b.Enrichment.Tags, tag) && !slices.Contains(shared, tag) {
+			shared = append(shared, tag)
+		}
+	}
+	return shared
+}
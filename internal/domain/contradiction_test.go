@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func contradiction(from, to string, confidence float64, discoveredAt time.Time, tags ...string) Contradiction {
+	return Contradiction{
+		Relationship: Relationship{FromInsightID: from, ToInsightID: to, Type: RelationContradicts, Confidence: confidence, DiscoveredAt: discoveredAt},
+		SharedTags:   tags,
+	}
+}
+
+func TestGroupContradictions(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	groups := GroupContradictions([]Contradiction{
+		contradiction("a", "b", 0.7, day, "focus"),
+		contradiction("c", "d", 0.9, day, "focus", "habits"),
+		contradiction("e", "f", 0.7, day.Add(time.Hour), "focus"),
+		contradiction("g", "h", 0.8, day),
+		contradiction("i", "j", 0.6, day, "sleep"),
+	})
+
+	var tags []string
+	for _, g := range groups {
+		tags = append(tags, g.Tag)
+	}
+	if strings.Join(tags, ",") != "focus,habits,sleep," {
+		t.Fatalf("groups = %v, want focus (3), then habits and sleep (1 each, by tag), then untagged", tags)
+	}
+	var order []string
+	for _, c := range groups[0].Contradictions {
+		order = append(order, c.Relationship.FromInsightID)
+	}
+	if strings.Join(order, ",") != "c,e,a" {
+		t.Fatalf("focus order = %v, want most confident, then most recent", order)
+	}
+	if groups[1].Contradictions[0].Relationship.FromInsightID != "c" {
+		t.Fatalf("c-d shares habits too but isn't filed under it")
+	}
+	if groups[3].Contradictions[0].Relationship.FromInsightID != "g" {
+		t.Fatalf("untagged group = %+v, want g-h", groups[3])
+	}
+}
+
+func TestSharedTags(t *testing.T) {
+	a := Insight{Enrichment: &Enrichment{Tags: []string{"focus", "sleep", "habits"}}}
+	b := Insight{Enrichment: &Enrichment{Tags: []string{"habits", "focus"}}}
+
+	if got := SharedTags(a, b); strings.Join(got, ",") != "focus,habits" {
+		t.Fatalf("SharedTags = %v, want focus,habits", got)
+	}
+	if got := SharedTags(a, Insight{}); got != nil {
+		t.Fatalf("SharedTags with an unenriched insight = %v, want none", got)
+	}
+}
+
+func TestContradictionPair(t *testing.T) {
+	if a, b := ContradictionPair("z", "a"); a != "a" || b != "z" {
+		t.Fatalf("ContradictionPair(z, a) = %s, %s", a, b)
+	}
+	if a, b := ContradictionPair("a", "z"); a != "a" || b != "z" {
+		t.Fatalf("ContradictionPair(a, z) = %s, %s", a, b)
+	}
+}
+
+func TestContradictionResolution_Validate(t *testing.T) {
+	if err := (ContradictionResolution{Note: "kept both; different contexts"}).Validate(); err != nil {
+		t.Fatalf("Validate = %v", err)
+	}
+	if err := (ContradictionResolution{Note: strings.Repeat("x", maxResolutionNoteLength+1)}).Validate(); !errors.Is(err, ErrResolutionNoteTooLong) {
+		t.Fatalf("Validate = %v, want ErrResolutionNoteTooLong", err)
+	}
+}
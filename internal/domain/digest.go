@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DigestPeriod is how often a digest is sent, and so how far back the
+// first one of its kind looks (Window).
+type DigestPeriod string
+
+const (
+	DigestDaily  DigestPeriod = "daily"
+	DigestWeekly DigestPeriod = "weekly"
+)
+
+const (
+	// MaxDigestEntries caps each list a digest carries; the counts beside
+	// them stay exact. A digest is something to skim, and the whole of it
+	// is stored in one item.
+	MaxDigestEntries = 20
+
+	// MaxDigestTagMoves is how many of the biggest score changes a digest
+	// lists.
+	MaxDigestTagMoves = 5
+
+	// minDigestTagMove is the smallest score change worth listing; scores
+	// live in [0,1], and recency alone nudges every tag a little each day.
+	minDigestTagMove = 0.01
+
+	// digestExcerptRunes is how much of an insight's text a digest quotes.
+	digestExcerptRunes = 200
+)
+
+var ErrInvalidDigestPeriod = errors.New("digest period must be daily or weekly")
+
+func ParseDigestPeriod(s string) (DigestPeriod, error) {
+	switch p := DigestPeriod(s); p {
+	case DigestDaily, DigestWeekly:
+		return p, nil
+	}
+	return "", ErrInvalidDigestPeriod
+}
+
+// Window is how far back a digest looks when there's no earlier one of the
+// same period to pick up from.
+func (p DigestPeriod) Window() time.Duration {
+	if p == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Digest is what changed in a tenant between Since, the previous digest
+// of the same Period that was sent, and GeneratedAt, rendered for reading.
+type Digest struct {
+	TenantID    string
+	Period      DigestPeriod
+	Since       time.Time
+	GeneratedAt time.Time
+	// Notified is whether the digest was sent. One that wasn't stays
+	// stored, but the next of its period covers its span again.
+	Notified bool
+
+	// InsightCount and RelationshipCount are how many were new in the
+	// span; Insights lists the most recently highlighted of them and
+	// Relationships the most recently discovered, MaxDigestEntries each.
+	InsightCount      int
+	Insights          []DigestInsight
+	RelationshipCount int
+	Relationships     []DigestRelationship
+	TagMoves          []TagMove
+	Plans             []DigestPlan
+
+	Markdown string
+	HTML     string
+}
+
+// DigestInsight is a newly ingested insight, quoted.
+type DigestInsight struct {
+	InsightID string
+	Source    string
+	Excerpt   string
+	Tags      []string
+}
+
+// DigestRelationship is a newly discovered edge, with both ends quoted.
+type DigestRelationship struct {
+	FromInsightID string
+	FromExcerpt   string
+	ToInsightID   string
+	ToExcerpt     string
+	Type          RelationType
+	Confidence    float64
+}
+
+// TagMove is how far a tag's relevance score moved over a digest's span.
+// A tag that appeared or vanished moves from or to 0.
+type TagMove struct {
+	Tag  string
+	From float64
+	To   float64
+}
+
+func (m TagMove) Delta() float64 {
+	return m.To - m.From
+}
+
+// DigestPlan is a weekly plan that moved in the span: requested, resolved,
+// or with actions started or finished.
+type DigestPlan struct {
+	PlanID        string
+	Tag           string
+	FocusSentence string
+	Status        PlanStatus
+	Actions       int
+	// Requested and Resolved are whether the plan was requested, and
+	// became ready or failed, within the span.
+	Requested bool
+	Resolved  bool
+	// ActionsStarted and ActionsFinished count the actions started, and
+	// done or skipped, within the span; CompletionPercent is where the
+	// whole plan stands at its end.
+	ActionsStarted    int
+	ActionsFinished   int
+	CompletionPercent int
+}
+
+// NewDigestInsight quotes insight.
+func NewDigestInsight(insight Insight) DigestInsight {
+	d := DigestInsight{InsightID: insight.ID, Source: insight.Source, Excerpt: DigestExcerpt(insight.Text)}
+	if insight.Enrichment != nil {
+		d.Tags = insight.Enrichment.Tags
+	}
+	return d
+}
+
+// NewDigestPlan summarizes what happened to plan after since; ok is false
+// if nothing did. An action's progress is judged by its StartedAt and
+// CompletedAt, so one moved back to todo since counts for nothing.
+func NewDigestPlan(plan WeeklyPlan, since time.Time) (d DigestPlan, ok bool) {
+	d = DigestPlan{
+		PlanID:            plan.ID,
+		Tag:               plan.Tag,
+		FocusSentence:     plan.FocusSentence,
+		Status:            plan.Status,
+		Actions:           len(plan.Actions),
+		Requested:         plan.CreatedAt.After(since),
+		Resolved:          plan.ResolvedAt.After(since),
+		CompletionPercent: plan.CompletionPercent(),
+	}
+	for _, a := range plan.Actions {
+		if a.StartedAt.After(since) {
+			d.ActionsStarted++
+		}
+		if a.CompletedAt.After(since) {
+			d.ActionsFinished++
+		}
+	}
+	return d, d.Requested || d.Resolved || d.ActionsStarted > 0 || d.ActionsFinished > 0
+}
+
+// DigestExcerpt is text as a digest quotes it: Excerpt to
+// digestExcerptRunes.
+func DigestExcerpt(text string) string {
+	return Excerpt(text, digestExcerptRunes)
+}
+
+// Excerpt is text with its whitespace collapsed, cut to maxRunes at a
+// word boundary, with an ellipsis if anything was cut.
+func Excerpt(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+	cut := string([]rune(text)[:maxRunes])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// DigestBaseline picks the snapshot to measure tag moves from: the last one
+// taken at or before since, or failing that the first one after it. found
+// is false if snapshots is empty.
+func DigestBaseline(snapshots []TagSnapshot, since time.Time) (baseline TagSnapshot, found bool) {
+	for _, s := range snapshots {
+		switch {
+		case !found:
+			baseline, found = s, true
+		case !s.TakenAt.After(since):
+			if baseline.TakenAt.After(since) || s.TakenAt.After(baseline.TakenAt) {
+				baseline = s
+			}
+		case baseline.TakenAt.After(since) && s.TakenAt.Before(baseline.TakenAt):
+			baseline = s
+		}
+	}
+	return baseline, found
+}
+
+// TopTagMoves compares current scores against baseline's and returns up
+// to limit tags that moved at least minDigestTagMove, biggest move first
+// either way, ties by tag.
+func TopTagMoves(current []TagSummary, baseline TagSnapshot, limit int) []TagMove {
+	moves := map[string]*TagMove{}
+	for _, t := range baseline.Tags {
+		moves[t.Tag] = &TagMove{Tag: t.Tag, From: t.Score}
+	}
+	for _, t := range current {
+		if m, ok := moves[t.Tag]; ok {
+			m.To = t.Score
+		} else {
+			moves[t.Tag] = &TagMove{Tag: t.Tag, To: t.Score}
+		}
+	}
+
+	out := make([]TagMove, 0, len(moves))
+	for _, m := range moves {
+		if math.Abs(m.Delta()) >= minDigestTagMove {
+			out = append(out, *m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		di, dj := math.Abs(out[i].Delta()), math.Abs(out[j].Delta())
+		if di != dj {
+			return di > dj
+		}
+		return out[i].Tag < out[j].Tag
+	})
+	return out[:min(len(out), limit)]
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestParseDigestPeriod(t *testing.T) {
+	for _, s := range []string{"daily", "weekly"} {
+		if p, err := ParseDigestPeriod(s); err != nil || string(p) != s {
+			t.Fatalf("ParseDigestPeriod(%q) = %q, %v", s, p, err)
+		}
+	}
+	if _, err := ParseDigestPeriod("hourly"); !errors.Is(err, ErrInvalidDigestPeriod) {
+		t.Fatalf("ParseDigestPeriod(hourly) err = %v, want ErrInvalidDigestPeriod", err)
+	}
+}
+
+func TestDigestExcerpt(t *testing.T) {
+	if got := DigestExcerpt("  short\n text "); got != "short text" {
+		t.Fatalf("DigestExcerpt = %q, want whitespace collapsed", got)
+	}
+
+	long := strings.Repeat("word ", 100)
+	got := DigestExcerpt(long)
+	if !strings.HasSuffix(got, "word…") || utf8.RuneCountInString(got) > digestExcerptRunes+1 {
+		t.Fatalf("DigestExcerpt = %q, want cut at a word within %d runes", got, digestExcerptRunes)
+	}
+}
+
+func TestDigestBaseline(t *testing.T) {
+	since := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	at := func(days int) TagSnapshot { return TagSnapshot{TakenAt: since.AddDate(0, 0, days)} }
+
+	if _, found := DigestBaseline(nil, since); found {
+		t.Fatal("found a baseline among no snapshots")
+	}
+	if got, _ := DigestBaseline([]TagSnapshot{at(1), at(-3), at(0), at(-1)}, since); !got.TakenAt.Equal(since) {
+		t.Fatalf("baseline at %v, want the last one at or before since", got.TakenAt)
+	}
+	if got, _ := DigestBaseline([]TagSnapshot{at(3), at(1), at(2)}, since); !got.TakenAt.Equal(since.AddDate(0, 0, 1)) {
+		t.Fatalf("baseline at %v, want the first one after since", got.TakenAt)
+	}
+}
+
+func TestTopTagMoves(t *testing.T) {
+	baseline := snapshotAt(time.Time{}, map[string]float64{"focus": 0.5, "habits": 0.3, "gone": 0.2, "still": 0.4})
+	current := []TagSummary{
+		{Tag: "focus", Score: 0.2},
+		{Tag: "habits", Score: 0.6},
+		{Tag: "still", Score: 0.405},
+		{Tag: "new", Score: 0.1},
+	}
+
+	moves := TopTagMoves(current, baseline, 3)
+
+	want := []string{"focus", "habits", "gone"}
+	if len(moves) != len(want) {
+		t.Fatalf("moves = %+v, want %v", moves, want)
+	}
+	for i, tag := range want {
+		if moves[i].Tag != tag {
+			t.Fatalf("moves = %+v, want %v (ties by tag, under-threshold dropped)", moves, want)
+		}
+	}
+	if moves[2].To != 0 || moves[2].Delta() >= 0 {
+		t.Fatalf("gone = %+v, want a fall to 0", moves[2])
+	}
+}
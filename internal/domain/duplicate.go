@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+	"hash/fnv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DuplicatePolicy is what ingest does with a new insight whose text
+// matches one the tenant already has from another source or highlight.
+type DuplicatePolicy string
+
+const (
+	// DuplicateMerge records the new insight's source on the existing one
+	// instead of storing it, so tags and relationships stay in one place.
+	DuplicateMerge DuplicatePolicy = "merge"
+	// DuplicateFlag stores and enriches the new insight as usual and
+	// records the pair for review (GET /v1/duplicates).
+	DuplicateFlag DuplicatePolicy = "flag"
+	// DuplicateOff skips detection entirely.
+	DuplicateOff DuplicatePolicy = "off"
+)
+
+// DefaultDuplicateThreshold is the signature similarity at or above which
+// two texts count as the same passage. Picked, not measured: high enough
+// that two highlights sharing a sentence don't match, low enough that one
+// source's typographic quotes or trailing ellipsis don't prevent one.
+const DefaultDuplicateThreshold = 0.8
+
+var ErrUnknownDuplicatePolicy = errors.New("unknown duplicate policy")
+
+// ParseDuplicatePolicy accepts "merge", "flag" or "off"; empty is flag,
+// which loses nothing while the threshold earns trust.
+func ParseDuplicatePolicy(s string) (DuplicatePolicy, error) {
+	switch p := DuplicatePolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return DuplicateFlag, nil
+	case DuplicateMerge, DuplicateFlag, DuplicateOff:
+		return p, nil
+	}
+	return "", ErrUnknownDuplicatePolicy
+}
+
+// SourceRef is one source an insight was also imported from, recorded on
+// it when DuplicateMerge folded that import in. InsightID is the ID the
+// merged import would have had, so redelivering it merges nothing twice.
+type SourceRef struct {
+	Source        string
+	InsightID     string
+	HighlightedAt time.Time
+	MergedAt      time.Time
+}
+
+// Duplicate is a flagged pair: InsightID was stored despite matching
+// DuplicateOfID. Both texts are denormalized at detection time, like
+// RelatedInsight.Text, so review needs no further reads.
+type Duplicate struct {
+	TenantID        string
+	InsightID       string
+	Source          string
+	Text            string
+	DuplicateOfID   string
+	DuplicateOfText string
+	Similarity      float64
+	DetectedAt      time.Time
+}
+
+// signatureSize is the number of MinHash functions. The similarity
+// estimate's standard error is about 1/sqrt(64) ≈ 0.06 — coarse, but the
+// threshold sits well away from where different passages land.
+const (
+	signatureSize  = 64
+	shingleWords   = 3
+	minHashSeedMix = 0x9e3779b97f4a7c15
+)
+
+// TextSignature summarizes a text for near-duplicate comparison: a hash
+// of its normalized words, for exact matches, and a MinHash signature over
+// its word shingles, whose agreement estimates Jaccard similarity.
+type TextSignature struct {
+	hash  uint64
+	mins  [signatureSize]uint64
+	empty bool
+}
+
+// NewTextSignature normalizes text to lowercase letters and digits split
+// into words, so whitespace, punctuation and case differences between
+// sources' exports don't count.
+func NewTextSignature(text string) TextSignature {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return TextSignature{empty: true}
+	}
+
+	sig := TextSignature{hash: hashString(strings.Join(words, " "))}
+	for i := range sig.mins {
+		sig.mins[i] = ^uint64(0)
+	}
+
+	n := shingleWords
+	if len(words) < n {
+		n = len(words)
+	}
+	for i := 0; i+n <= len(words); i++ {
+		h := hashString(strings.Join(words[i:i+n], " "))
+		for j := range sig.mins {
+			if v := mix64(h ^ (uint64(j+1) * minHashSeedMix)); v < sig.mins[j] {
+				sig.mins[j] = v
+			}
+		}
+	}
+	return sig
+}
+
+// Similarity is 1 for the same normalized text and otherwise the share of
+// MinHash functions the two agree on; an empty text matches nothing.
+func (s TextSignature) Similarity(other TextSignature) float64 {
+	if s.empty || other.empty {
+		return 0
+	}
+	if s.hash == other.hash {
+		return 1
+	}
+	same := 0
+	for i := range s.mins {
+		if s.mins[i] == other.mins[i] {
+			same++
+		}
+	}
+	return float64(same) / signatureSize
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix64 is SplitMix64's finalizer: it turns one shingle hash into
+// signatureSize independent-enough ones, one per seed.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
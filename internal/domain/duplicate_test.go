@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestTextSignature_Similarity(t *testing.T) {
+	const passage = "We are what we repeatedly do. Excellence, then, is not an act, but a habit."
+	tests := map[string]struct {
+		other   string
+		atLeast float64
+		below   float64
+	}{
+		"same text":                           {other: passage, atLeast: 1, below: 1.01},
+		"punctuation, case and spacing only":  {other: "we are what we repeatedly do   excellence then is not an act but a habit", atLeast: 1, below: 1.01},
+		"one word changed":                    {other: "We are what we repeatedly do. Excellence, then, is not an act, but a practice.", atLeast: DefaultDuplicateThreshold, below: 1},
+		"different passage on the same topic": {other: "Habits are the compound interest of self-improvement.", atLeast: 0, below: 0.2},
+		"empty":                               {other: "  ...  ", atLeast: 0, below: 0.01},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := NewTextSignature(passage).Similarity(NewTextSignature(tc.other))
+			if got < tc.atLeast || got >= tc.below {
+				t.Fatalf("Similarity = %.2f, want within [%.2f, %.2f)", got, tc.atLeast, tc.below)
+			}
+		})
+	}
+}
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	if p, err := ParseDuplicatePolicy(""); err != nil || p != DuplicateFlag {
+		t.Fatalf("empty = %q, %v; want flag", p, err)
+	}
+	if p, err := ParseDuplicatePolicy(" Merge "); err != nil || p != DuplicateMerge {
+		t.Fatalf("Merge = %q, %v; want merge", p, err)
+	}
+	if _, err := ParseDuplicatePolicy("delete"); err != ErrUnknownDuplicatePolicy {
+		t.Fatalf("err = %v, want ErrUnknownDuplicatePolicy", err)
+	}
+}
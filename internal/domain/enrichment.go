@@ -1,5 +1,127 @@
 package domain
 
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Sentiment is the overall tone of a highlight, as the LLM reads it.
+type Sentiment string
+
+const (
+	SentimentPositive Sentiment = "positive"
+	SentimentNeutral  Sentiment = "neutral"
+	SentimentNegative Sentiment = "negative"
+)
+
+func (s Sentiment) Valid() bool {
+	switch s {
+	case SentimentPositive, SentimentNeutral, SentimentNegative:
+		return true
+	}
+	return false
+}
+
+// MaxConceptsPerInsight caps how many key concepts an enrichment keeps,
+// regardless of how many the LLM returns.
+const MaxConceptsPerInsight = 8
+
+var (
+	ErrActionabilityOutOfRange = errors.New("actionability must be between 0 and 1")
+	ErrUnknownSentiment        = errors.New("unknown sentiment")
+)
+
 type Enrichment struct {
 	Tags []string
+
+	// Summary restates the highlight in one sentence.
+	Summary string
+	// Concepts are the key ideas and named entities (people, books,
+	// frameworks) the highlight mentions, as written — unlike Tags they
+	// aren't normalized into a shared vocabulary.
+	Concepts  []string
+	Sentiment Sentiment
+	// Actionability scores, from 0 to 1, how directly the highlight is
+	// advice someone could act on. The weekly planner ranks by it.
+	Actionability float64
+	// Question is one question the highlight raises for the reader.
+	Question string
+
+	// EnrichedAt is when these tags were produced. Zero for insights
+	// enriched before it was recorded, which a ReenrichBefore job treats
+	// as older than any cutoff.
+	EnrichedAt time.Time
+
+	// Provenance: which model and prompt produced Tags, and what the call
+	// cost. All zero for insights enriched before it was recorded.
+	//
+	// PromptVersion names the prompt and output schema together — the
+	// adapter bumps it whenever either changes, so tags produced under
+	// different instructions can be told apart.
+	//
+	// Fallback marks tags derived offline by keyword extraction while the
+	// LLM was down or unconfigured (llm.Fallback); Model and PromptVersion
+	// then name the extractor rather than a model.
+	Fallback      bool
+	Model         string
+	PromptVersion string
+	InputTokens   int64
+	OutputTokens  int64
+	Latency       time.Duration
+}
+
+// Normalize cleans up what the LLM returned the same lenient way
+// NormalizeTags does for tags: whitespace trimmed, blank and duplicate
+// concepts dropped, the lists capped. Neither the providers nor local
+// models hold strictly to the output schema, so an out-of-range
+// Actionability is clamped into [0,1] and a Sentiment outside the three
+// known ones is cleared, rather than costing the answer its tags.
+func (e Enrichment) Normalize() Enrichment {
+	e.Tags = NormalizeTags(e.Tags)
+	e.Summary = strings.TrimSpace(e.Summary)
+	e.Question = strings.TrimSpace(e.Question)
+	e.Concepts = normalizeConcepts(e.Concepts)
+	e.Sentiment = Sentiment(strings.ToLower(strings.TrimSpace(string(e.Sentiment))))
+	if !e.Sentiment.Valid() {
+		e.Sentiment = ""
+	}
+	if math.IsNaN(e.Actionability) {
+		e.Actionability = 0
+	}
+	e.Actionability = max(0, min(e.Actionability, 1))
+	return e
+}
+
+// Validate rejects values outside what Normalize produces. An empty
+// Sentiment is allowed: enrichments from before it existed have none.
+func (e Enrichment) Validate() error {
+	if e.Actionability < 0 || e.Actionability > 1 {
+		return ErrActionabilityOutOfRange
+	}
+	if e.Sentiment != "" && !e.Sentiment.Valid() {
+		return ErrUnknownSentiment
+	}
+	return nil
+}
+
+func normalizeConcepts(raw []string) []string {
+	seen := make(map[string]bool, len(raw))
+	result := make([]string, 0, min(len(raw), MaxConceptsPerInsight))
+
+	for _, r := range raw {
+		if len(result) >= MaxConceptsPerInsight {
+			break
+		}
+		concept := strings.Join(strings.Fields(r), " ")
+		key := strings.ToLower(concept)
+		if concept == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, concept)
+	}
+
+	return result
 }
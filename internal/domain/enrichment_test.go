@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEnrichment_Normalize(t *testing.T) {
+	got := Enrichment{
+		Tags:     []string{"Psychology", "psychology"},
+		Summary:  "  One sentence.  ",
+		Concepts: []string{"Daniel  Kahneman", "daniel kahneman", " ", "loss aversion"},
+		Question: "\nWhat would you change?\n",
+	}.Normalize()
+
+	if len(got.Tags) != 1 || got.Tags[0] != "psychology" {
+		t.Errorf("Tags = %v, want [psychology]", got.Tags)
+	}
+	if got.Summary != "One sentence." || got.Question != "What would you change?" {
+		t.Errorf("Summary = %q, Question = %q, want both trimmed", got.Summary, got.Question)
+	}
+	if len(got.Concepts) != 2 || got.Concepts[0] != "Daniel Kahneman" || got.Concepts[1] != "loss aversion" {
+		t.Errorf("Concepts = %v, want [Daniel Kahneman loss aversion]", got.Concepts)
+	}
+}
+
+func TestEnrichment_Normalize_ClampsActionabilityAndClearsUnknownSentiment(t *testing.T) {
+	tests := map[string]struct {
+		in            Enrichment
+		actionability float64
+		sentiment     Sentiment
+	}{
+		"above one":         {in: Enrichment{Actionability: 3, Sentiment: "mixed"}, actionability: 1},
+		"negative":          {in: Enrichment{Actionability: -0.2, Sentiment: " Negative "}, sentiment: SentimentNegative},
+		"not a number":      {in: Enrichment{Actionability: math.NaN()}},
+		"in range, as sent": {in: Enrichment{Actionability: 0.4, Sentiment: SentimentPositive}, actionability: 0.4, sentiment: SentimentPositive},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.in.Normalize()
+			if got.Actionability != tc.actionability || got.Sentiment != tc.sentiment {
+				t.Fatalf("Actionability = %v, Sentiment = %q; want %v, %q", got.Actionability, got.Sentiment, tc.actionability, tc.sentiment)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatalf("Validate() = %v after Normalize", err)
+			}
+		})
+	}
+}
+
+func TestEnrichment_Normalize_CapsConcepts(t *testing.T) {
+	raw := make([]string, 0, MaxConceptsPerInsight+2)
+	for i := range MaxConceptsPerInsight + 2 {
+		raw = append(raw, string(rune('a'+i)))
+	}
+
+	if got := (Enrichment{Concepts: raw}).Normalize().Concepts; len(got) != MaxConceptsPerInsight {
+		t.Fatalf("len(Concepts) = %d, want %d", len(got), MaxConceptsPerInsight)
+	}
+}
+
+func TestEnrichment_Validate(t *testing.T) {
+	tests := map[string]struct {
+		in   Enrichment
+		want error
+	}{
+		"valid":                    {in: Enrichment{Sentiment: SentimentPositive, Actionability: 0.7}},
+		"legacy, no new fields":    {in: Enrichment{Tags: []string{"a"}}},
+		"actionability above one":  {in: Enrichment{Actionability: 1.2}, want: ErrActionabilityOutOfRange},
+		"negative actionability":   {in: Enrichment{Actionability: -0.1}, want: ErrActionabilityOutOfRange},
+		"unknown sentiment":        {in: Enrichment{Sentiment: "ecstatic"}, want: ErrUnknownSentiment},
+		"bounds are inclusive":     {in: Enrichment{Actionability: 1}},
+		"zero actionability is ok": {in: Enrichment{Sentiment: SentimentNeutral}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.in.Validate(); !errors.Is(err, tc.want) {
+				t.Fatalf("Validate() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
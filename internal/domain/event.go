@@ -3,6 +3,7 @@ package domain
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"strconv"
 	"time"
 )
 
@@ -14,6 +15,7 @@ const (
 	InsightEnriched     EventType = "InsightEnriched"
 	KnowledgeUpdated    EventType = "KnowledgeUpdated"
 	WeeklyPlanRequested EventType = "WeeklyPlanRequested"
+	ActionCompleted     EventType = "ActionCompleted"
 )
 
 // domainEventVersion is the envelope schema version. It starts at 1; bump it
@@ -59,10 +61,19 @@ type InsightCreatedPayload struct {
 	Source    string `json:"source"`
 }
 
-// InsightEnrichedPayload is the InsightEnriched event's payload.
+// InsightEnrichedPayload is the InsightEnriched event's payload. Unlike
+// InsightCreatedPayload it carries the enrichment's content, not just its
+// id: a planner choosing which insights to act on filters on Actionability
+// without a read per event.
 type InsightEnrichedPayload struct {
-	InsightID string   `json:"insight_id"`
-	Tags      []string `json:"tags"`
+	InsightID     string    `json:"insight_id"`
+	Tags          []string  `json:"tags"`
+	Summary       string    `json:"summary,omitempty"`
+	Concepts      []string  `json:"concepts,omitempty"`
+	Sentiment     Sentiment `json:"sentiment,omitempty"`
+	Actionability float64   `json:"actionability"`
+	Question      string    `json:"question,omitempty"`
+	Fallback      bool      `json:"fallback,omitempty"`
 }
 
 // NewInsightCreatedEvent builds the envelope published right after an
@@ -75,34 +86,65 @@ func NewInsightCreatedEvent(insight Insight, occurredAt time.Time) DomainEvent {
 }
 
 // NewInsightEnrichedEvent builds the envelope published right after an
-// insight's enrichment is durably written.
+// insight's enrichment is durably written. The subject ID folds in
+// EnrichedAt, so a re-enrichment (application/reenrich) announces itself
+// under a fresh event ID instead of one subscribers already deduped.
 func NewInsightEnrichedEvent(insight Insight, occurredAt time.Time) DomainEvent {
-	var tags []string
-	if insight.Enrichment != nil {
-		tags = insight.Enrichment.Tags
+	payload := InsightEnrichedPayload{InsightID: insight.ID}
+	subjectID := insight.ID
+	if e := insight.Enrichment; e != nil {
+		payload.Tags = e.Tags
+		payload.Summary = e.Summary
+		payload.Concepts = e.Concepts
+		payload.Sentiment = e.Sentiment
+		payload.Actionability = e.Actionability
+		payload.Question = e.Question
+		payload.Fallback = e.Fallback
+		if !e.EnrichedAt.IsZero() {
+			subjectID += "|" + e.EnrichedAt.UTC().Format(time.RFC3339Nano)
+		}
 	}
-	return NewDomainEvent(InsightEnriched, insight.TenantID, insight.ID, occurredAt, InsightEnrichedPayload{
-		InsightID: insight.ID,
-		Tags:      tags,
-	})
+	return NewDomainEvent(InsightEnriched, insight.TenantID, subjectID, occurredAt, payload)
 }
 
+// KnowledgeChange is what happened to the edge a KnowledgeUpdated event
+// is about.
+type KnowledgeChange string
+
+const (
+	// KnowledgeEdgeUpserted is an edge the agent posted, new or updated.
+	KnowledgeEdgeUpserted KnowledgeChange = "upserted"
+	// KnowledgeEdgeRetyped is a user correcting an edge's RelationType.
+	KnowledgeEdgeRetyped KnowledgeChange = "retyped"
+	// KnowledgeEdgeRemoved is an edge gone: a user marking it not related,
+	// or relationship policy evicting it for a more confident one.
+	KnowledgeEdgeRemoved KnowledgeChange = "removed"
+)
+
 // KnowledgeUpdatedPayload is the KnowledgeUpdated event's payload (REL
-// 5/IPP-101): the pair of insights a newly persisted relationship connects.
+// 5/IPP-101): the pair of insights whose edge changed, and how.
 type KnowledgeUpdatedPayload struct {
-	FromInsightID string `json:"from_insight_id"`
-	ToInsightID   string `json:"to_insight_id"`
+	FromInsightID string          `json:"from_insight_id"`
+	ToInsightID   string          `json:"to_insight_id"`
+	Change        KnowledgeChange `json:"change"`
 }
 
 // NewKnowledgeUpdatedEvent builds the envelope published right after a
-// relationship edge is durably written (RelationshipRepository.Put). The
-// subject ID is the edge itself, not either insight alone, so re-posting
-// the same edge (Put is idempotent) redelivers the same deterministic
-// event ID rather than a fresh one each time.
-func NewKnowledgeUpdatedEvent(rel Relationship, occurredAt time.Time) DomainEvent {
-	return NewDomainEvent(KnowledgeUpdated, rel.TenantID, rel.FromInsightID+"|"+rel.ToInsightID, occurredAt, KnowledgeUpdatedPayload{
+// relationship edge is durably changed. For an upsert the subject ID is
+// the edge itself, not either insight alone, so re-posting the same edge
+// (Put is idempotent) redelivers the same deterministic event ID rather
+// than a fresh one each time. A retype or removal is a one-off user or
+// policy action, so its subject also carries the change and occurredAt:
+// it must not dedupe against the edge's earlier upsert.
+func NewKnowledgeUpdatedEvent(rel Relationship, change KnowledgeChange, occurredAt time.Time) DomainEvent {
+	subjectID := rel.FromInsightID + "|" + rel.ToInsightID
+	if change != KnowledgeEdgeUpserted {
+		subjectID += "|" + string(change) + "|" + occurredAt.UTC().Format(time.RFC3339Nano)
+	}
+	return NewDomainEvent(KnowledgeUpdated, rel.TenantID, subjectID, occurredAt, KnowledgeUpdatedPayload{
 		FromInsightID: rel.FromInsightID,
 		ToInsightID:   rel.ToInsightID,
+		Change:        change,
 	})
 }
 
@@ -126,3 +168,36 @@ func NewWeeklyPlanRequestedEvent(plan WeeklyPlan, occurredAt time.Time) DomainEv
 		FocusSentence: plan.FocusSentence,
 	})
 }
+
+// ActionCompletedPayload is the ActionCompleted event's payload: one
+// action of a weekly plan the user marked done, with what the Action Agent
+// needs to weigh it when drafting the next plan on the same tag — what was
+// asked, on which insights, and how the user says it went.
+type ActionCompletedPayload struct {
+	PlanID               string    `json:"plan_id"`
+	Tag                  string    `json:"tag"`
+	ActionIndex          int       `json:"action_index"`
+	Title                string    `json:"title"`
+	SupportingInsightIDs []string  `json:"supporting_insight_ids"`
+	Reflection           string    `json:"reflection,omitempty"`
+	StartedAt            time.Time `json:"started_at"`
+	CompletedAt          time.Time `json:"completed_at"`
+}
+
+// NewActionCompletedEvent builds the envelope published right after an
+// action's move to ActionDone is durably written. The subject ID carries
+// CompletedAt, so an action reopened and completed again announces itself
+// afresh, while a retried write of the same completion dedupes.
+func NewActionCompletedEvent(plan WeeklyPlan, index int, action Action, occurredAt time.Time) DomainEvent {
+	subjectID := plan.ID + "|" + strconv.Itoa(index) + "|" + action.CompletedAt.UTC().Format(time.RFC3339Nano)
+	return NewDomainEvent(ActionCompleted, plan.TenantID, subjectID, occurredAt, ActionCompletedPayload{
+		PlanID:               plan.ID,
+		Tag:                  plan.Tag,
+		ActionIndex:          index,
+		Title:                action.Title,
+		SupportingInsightIDs: action.SupportingInsightIDs,
+		Reflection:           action.Reflection,
+		StartedAt:            action.StartedAt,
+		CompletedAt:          action.CompletedAt,
+	})
+}
@@ -98,11 +98,78 @@ func TestNewInsightEventConstructors(t *testing.T) {
 		}
 
 		enriched := insight
-		enriched.Enrichment = &Enrichment{Tags: []string{"stoicism"}}
+		enriched.Enrichment = &Enrichment{Tags: []string{"stoicism"}, Summary: "Control what you can.", Actionability: 0.8}
 		ev2 := NewInsightEnrichedEvent(enriched, now)
 		payload2 := ev2.Payload.(InsightEnrichedPayload)
 		if len(payload2.Tags) != 1 || payload2.Tags[0] != "stoicism" {
 			t.Fatalf("expected tags=[stoicism], got %v", payload2.Tags)
 		}
+		if payload2.Summary != "Control what you can." || payload2.Actionability != 0.8 {
+			t.Fatalf("expected summary and actionability carried over, got %+v", payload2)
+		}
+	})
+
+	t.Run("InsightEnriched subject folds in EnrichedAt, so a re-enrichment gets a fresh EventID", func(t *testing.T) {
+		first := insight
+		first.Enrichment = &Enrichment{Tags: []string{"a"}, EnrichedAt: now}
+		again := insight
+		again.Enrichment = &Enrichment{Tags: []string{"a"}, EnrichedAt: now.Add(24 * time.Hour)}
+
+		if NewInsightEnrichedEvent(first, now).EventID == NewInsightEnrichedEvent(again, now).EventID {
+			t.Fatal("expected distinct EventIDs for two enrichments of the same insight")
+		}
+		if NewInsightEnrichedEvent(first, now).EventID != NewInsightEnrichedEvent(first, now.Add(time.Hour)).EventID {
+			t.Fatal("expected the same enrichment to keep its EventID across redelivery")
+		}
+	})
+}
+
+func TestNewKnowledgeUpdatedEvent(t *testing.T) {
+	now := time.Date(2026, 8, 13, 12, 0, 0, 0, time.UTC)
+	rel := Relationship{TenantID: "tenant-1", FromInsightID: "i-1", ToInsightID: "i-2", Type: RelationSupports}
+
+	t.Run("upsert keeps one EventID per edge across re-posts", func(t *testing.T) {
+		ev := NewKnowledgeUpdatedEvent(rel, KnowledgeEdgeUpserted, now)
+		if ev.EventID != NewKnowledgeUpdatedEvent(rel, KnowledgeEdgeUpserted, now.Add(time.Hour)).EventID {
+			t.Fatal("expected re-posting the same edge to keep its EventID")
+		}
+		payload, ok := ev.Payload.(KnowledgeUpdatedPayload)
+		if !ok || payload.Change != KnowledgeEdgeUpserted || payload.FromInsightID != "i-1" {
+			t.Fatalf("payload = %+v, ok=%v", ev.Payload, ok)
+		}
+	})
+
+	t.Run("retype and removal never dedupe against the upsert or each other", func(t *testing.T) {
+		upserted := NewKnowledgeUpdatedEvent(rel, KnowledgeEdgeUpserted, now).EventID
+		retyped := NewKnowledgeUpdatedEvent(rel, KnowledgeEdgeRetyped, now).EventID
+		removed := NewKnowledgeUpdatedEvent(rel, KnowledgeEdgeRemoved, now).EventID
+		removedAgain := NewKnowledgeUpdatedEvent(rel, KnowledgeEdgeRemoved, now.Add(time.Hour)).EventID
+		if upserted == retyped || upserted == removed || retyped == removed || removed == removedAgain {
+			t.Fatalf("expected distinct EventIDs, got upserted=%s retyped=%s removed=%s removedAgain=%s", upserted, retyped, removed, removedAgain)
+		}
 	})
 }
+
+func TestNewActionCompletedEvent(t *testing.T) {
+	now := time.Date(2026, 8, 13, 12, 0, 0, 0, time.UTC)
+	plan := WeeklyPlan{ID: "plan-1", TenantID: "tenant-1", Tag: "focus"}
+	action := Action{Title: "Block 9-11", SupportingInsightIDs: []string{"i-1"}, Status: ActionDone, CompletedAt: now, Reflection: "worked"}
+
+	ev := NewActionCompletedEvent(plan, 2, action, now)
+	payload, ok := ev.Payload.(ActionCompletedPayload)
+	if ev.EventType != ActionCompleted || ev.TenantID != "tenant-1" || !ok ||
+		payload.PlanID != "plan-1" || payload.Tag != "focus" || payload.ActionIndex != 2 || payload.Reflection != "worked" {
+		t.Fatalf("event = %+v", ev)
+	}
+	if ev.EventID != NewActionCompletedEvent(plan, 2, action, now.Add(time.Minute)).EventID {
+		t.Fatal("expected a retried publish of the same completion to keep its EventID")
+	}
+	again := action
+	again.CompletedAt = now.Add(time.Hour)
+	if ev.EventID == NewActionCompletedEvent(plan, 2, again, now.Add(time.Hour)).EventID {
+		t.Fatal("expected completing the action again to get a fresh EventID")
+	}
+	if ev.EventID == NewActionCompletedEvent(plan, 1, action, now).EventID {
+		t.Fatal("expected another action's completion to get its own EventID")
+	}
+}
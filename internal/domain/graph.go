@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Traversal bounds. Every node a traversal expands costs one query over
+// its REL# items, so these cap the reads one request can trigger.
+const (
+	// MaxGraphDepth is the furthest a neighborhood reaches, in hops.
+	MaxGraphDepth = 3
+	// MaxPathLength is the longest path searched for, in hops.
+	MaxPathLength = 6
+	// MaxGraphNodes caps the nodes a neighborhood returns and a path
+	// search visits.
+	MaxGraphNodes = 200
+)
+
+var (
+	ErrGraphDepthOutOfRange = fmt.Errorf("depth must be between 1 and %d", MaxGraphDepth)
+	// ErrNoPath means no path within MaxPathLength hops, MaxGraphNodes
+	// visited, and the filter.
+	ErrNoPath = errors.New("no path between the insights")
+)
+
+// GraphFilter narrows which edges a traversal follows. The zero value
+// follows every edge.
+type GraphFilter struct {
+	// Types lists the relation types to follow; empty means all of them.
+	Types         []RelationType
+	MinConfidence float64
+}
+
+func (f GraphFilter) Validate() error {
+	for _, relType := range f.Types {
+		if !relType.Valid() {
+			return ErrUnknownRelationType
+		}
+	}
+	if f.MinConfidence < 0 || f.MinConfidence > 1 {
+		return ErrConfidenceOutOfRange
+	}
+	return nil
+}
+
+// Follows reports whether a traversal may cross edge.
+func (f GraphFilter) Follows(edge RelatedInsight) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, edge.Type) {
+		return false
+	}
+	return edge.Confidence >= f.MinConfidence
+}
+
+// GraphNode is an insight reached by a traversal, Depth hops from where
+// it started.
+type GraphNode struct {
+	InsightID string
+	Text      string
+	Depth     int
+}
+
+// GraphEdge is a relationship in its original direction.
+type GraphEdge struct {
+	FromInsightID string
+	ToInsightID   string
+	Type          RelationType
+	Confidence    float64
+	Rationale     string
+}
+
+// Graph is a traversal's result. For a path, Nodes run from start to end
+// and Edges[i] joins Nodes[i] and Nodes[i+1], in whichever direction it
+// was discovered.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+	// Truncated is true if MaxGraphNodes cut the neighborhood short.
+	Truncated bool
+}
+
+// GraphExport is a tenant's whole graph, or one tag's subgraph, for
+// tools outside the platform. Every relationship joins two of Insights.
+type GraphExport struct {
+	Insights      []Insight
+	Relationships []Relationship
+}
+
+// GraphEdgeFrom turns insightID's view of an edge back into the edge.
+func GraphEdgeFrom(insightID string, edge RelatedInsight) GraphEdge {
+	from, to := insightID, edge.InsightID
+	if !edge.Outgoing {
+		from, to = to, from
+	}
+	return GraphEdge{
+		FromInsightID: from,
+		ToInsightID:   to,
+		Type:          edge.Type,
+		Confidence:    edge.Confidence,
+		Rationale:     edge.Rationale,
+	}
+}
@@ -0,0 +1,351 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxRankedInsights caps each ranking GraphAnalytics keeps, so the
+	// tenant's one analytics item stays well under DynamoDB's 400 KB.
+	MaxRankedInsights = 50
+	// MaxGraphClusters caps the clusters kept, largest first.
+	MaxGraphClusters = 25
+	// MaxClusterMembers caps the insight IDs a cluster lists, most central
+	// first; GraphCluster.Size still counts all of them.
+	MaxClusterMembers = 100
+	// maxClusterTags is how many of its most common tags label a cluster.
+	maxClusterTags = 5
+	// rankExcerptRunes is how much of its text a ranked insight keeps:
+	// enough to recognize it by, little enough that a ranking of long
+	// highlights doesn't fill the analytics item.
+	rankExcerptRunes = 200
+
+	// pageRankDamping is the usual 0.85: the chance a random walk follows
+	// an edge rather than jumping to any insight.
+	pageRankDamping = 0.85
+	// pageRankIterations and pageRankTolerance bound the power iteration;
+	// a personal-scale graph converges well within them.
+	pageRankIterations = 100
+	pageRankTolerance  = 1e-9
+	// labelPropagationRounds bounds label propagation, which can oscillate
+	// between two equally good labelings instead of settling.
+	labelPropagationRounds = 20
+)
+
+// InsightRank is how one insight scores in the graph.
+type InsightRank struct {
+	InsightID string
+	// Text is an excerpt of the insight's text (Excerpt); the insight
+	// itself has the rest.
+	Text string
+	// ClusterID is 0 for an insight in no cluster (one with no edges).
+	ClusterID int
+	// Centrality is the insight's PageRank; all of them sum to 1.
+	Centrality float64
+	// Bridging is the share of the insight's edge weight that leaves its
+	// own cluster, spread across clusters (the participation coefficient):
+	// 0 if every edge stays home, approaching 1 if its links are split
+	// evenly over many clusters.
+	Bridging float64
+}
+
+// GraphCluster is a community of insights more densely related to each
+// other than to the rest of the graph.
+type GraphCluster struct {
+	// ID numbers clusters by size, largest first, from 1. It's only
+	// stable within one computation.
+	ID         int
+	Size       int
+	InsightIDs []string
+	// Tags are the cluster's most common tags, most common first.
+	Tags []string
+}
+
+// GraphAnalytics is a tenant's graph as last analysed.
+type GraphAnalytics struct {
+	TenantID   string
+	ComputedAt time.Time
+	Clusters   []GraphCluster
+	// Central and Bridges rank insights by Centrality and Bridging, highest
+	// first. Bridges only lists insights with Bridging above zero.
+	Central []InsightRank
+	Bridges []InsightRank
+}
+
+// ComputeGraphAnalytics finds clusters by label propagation over the
+// relationships, undirected and weighted by confidence, then ranks the
+// insights by PageRank and by how they bridge those clusters. It is
+// deterministic: ties go to the smaller insight ID.
+func ComputeGraphAnalytics(tenantID string, insights []Insight, relationships []Relationship, now time.Time) GraphAnalytics {
+	g := newAnalyticsGraph(insights, relationships)
+	labels := g.propagateLabels()
+	rank := g.pageRank()
+
+	// Number the clusters: two or more insights, largest first.
+	members := map[int][]int{}
+	for node, label := range labels {
+		members[label] = append(members[label], node)
+	}
+	var roots []int
+	for label, nodes := range members {
+		if len(nodes) > 1 {
+			roots = append(roots, label)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		a, b := members[roots[i]], members[roots[j]]
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return g.ids[roots[i]] < g.ids[roots[j]]
+	})
+	clusterOf := make([]int, len(g.ids))
+	for i, label := range roots {
+		for _, node := range members[label] {
+			clusterOf[node] = i + 1
+		}
+	}
+
+	ranks := make([]InsightRank, len(g.ids))
+	for node, id := range g.ids {
+		ranks[node] = InsightRank{
+			InsightID:  id,
+			Text:       g.text[node],
+			ClusterID:  clusterOf[node],
+			Centrality: rank[node],
+			Bridging:   g.bridging(node, clusterOf),
+		}
+	}
+
+	analytics := GraphAnalytics{TenantID: tenantID, ComputedAt: now}
+	for i, label := range roots {
+		if i == MaxGraphClusters {
+			break
+		}
+		nodes := members[label]
+		sort.Slice(nodes, func(a, b int) bool { return higher(rank, g.ids, nodes[a], nodes[b]) })
+		cluster := GraphCluster{ID: i + 1, Size: len(nodes), Tags: g.commonTags(nodes)}
+		for _, node := range nodes[:min(len(nodes), MaxClusterMembers)] {
+			cluster.InsightIDs = append(cluster.InsightIDs, g.ids[node])
+		}
+		analytics.Clusters = append(analytics.Clusters, cluster)
+	}
+
+	byCentrality := make([]int, len(g.ids))
+	for i := range byCentrality {
+		byCentrality[i] = i
+	}
+	sort.Slice(byCentrality, func(a, b int) bool { return higher(rank, g.ids, byCentrality[a], byCentrality[b]) })
+	for _, node := range byCentrality[:min(len(byCentrality), MaxRankedInsights)] {
+		analytics.Central = append(analytics.Central, ranks[node])
+	}
+
+	bridging := make([]float64, len(ranks))
+	var bridges []int
+	for node, r := range ranks {
+		bridging[node] = r.Bridging
+		if r.Bridging > 0 {
+			bridges = append(bridges, node)
+		}
+	}
+	sort.Slice(bridges, func(a, b int) bool { return higher(bridging, g.ids, bridges[a], bridges[b]) })
+	for _, node := range bridges[:min(len(bridges), MaxRankedInsights)] {
+		analytics.Bridges = append(analytics.Bridges, ranks[node])
+	}
+	return analytics
+}
+
+// higher orders nodes by score, descending, then by insight ID.
+func higher(score []float64, ids []string, a, b int) bool {
+	if score[a] != score[b] {
+		return score[a] > score[b]
+	}
+	return ids[a] < ids[b]
+}
+
+// analyticsGraph is the relationship graph with insights numbered in ID
+// order and each edge folded into one undirected, confidence-weighted
+// link; a pair related both ways keeps the stronger of the two.
+type analyticsGraph struct {
+	ids  []string
+	text []string
+	tags [][]string
+	// adj[n] maps n's neighbours to the weight of the link.
+	adj []map[int]float64
+}
+
+func newAnalyticsGraph(insights []Insight, relationships []Relationship) analyticsGraph {
+	sorted := slices.Clone(insights)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	g := analyticsGraph{
+		ids:  make([]string, len(sorted)),
+		text: make([]string, len(sorted)),
+		tags: make([][]string, len(sorted)),
+		adj:  make([]map[int]float64, len(sorted)),
+	}
+	index := make(map[string]int, len(sorted))
+	for i, insight := range sorted {
+		g.ids[i], g.text[i] = insight.ID, Excerpt(insight.Text, rankExcerptRunes)
+		if insight.Enrichment != nil {
+			g.tags[i] = insight.Enrichment.Tags
+		}
+		g.adj[i] = map[int]float64{}
+		index[insight.ID] = i
+	}
+	for _, rel := range relationships {
+		from, okFrom := index[rel.FromInsightID]
+		to, okTo := index[rel.ToInsightID]
+		if !okFrom || !okTo || from == to || rel.Confidence <= 0 {
+			continue
+		}
+		if rel.Confidence > g.adj[from][to] {
+			g.adj[from][to] = rel.Confidence
+			g.adj[to][from] = rel.Confidence
+		}
+	}
+	return g
+}
+
+// neighbours lists node's neighbours in index order, so every pass over
+// them sums and breaks ties the same way.
+func (g analyticsGraph) neighbours(node int) []int {
+	out := make([]int, 0, len(g.adj[node]))
+	for n := range g.adj[node] {
+		out = append(out, n)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// propagateLabels starts every insight in its own community, then has each
+// take on the label its neighbours carry the most weight of, until no label
+// changes. Labels are node indexes; ties go to the smaller one.
+func (g analyticsGraph) propagateLabels() []int {
+	labels := make([]int, len(g.ids))
+	for i := range labels {
+		labels[i] = i
+	}
+	for round := 0; round < labelPropagationRounds; round++ {
+		changed := false
+		for node := range labels {
+			if len(g.adj[node]) == 0 {
+				continue
+			}
+			weight := map[int]float64{}
+			for _, n := range g.neighbours(node) {
+				weight[labels[n]] += g.adj[node][n]
+			}
+			best := labels[node]
+			for label, w := range weight {
+				if w > weight[best] || (w == weight[best] && label < best) {
+					best = label
+				}
+			}
+			if best != labels[node] {
+				labels[node] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return labels
+}
+
+// pageRank is weighted PageRank by power iteration. An insight with no
+// edges spreads its rank evenly over the whole graph, as if it linked to
+// everything, so the ranks keep summing to 1.
+func (g analyticsGraph) pageRank() []float64 {
+	n := len(g.ids)
+	if n == 0 {
+		return nil
+	}
+	strength := make([]float64, n)
+	for node := range g.adj {
+		for _, w := range g.adj[node] {
+			strength[node] += w
+		}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for iter := 0; iter < pageRankIterations; iter++ {
+		dangling := 0.0
+		for node := range rank {
+			if strength[node] == 0 {
+				dangling += rank[node]
+			}
+		}
+		base := (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		for node := range next {
+			next[node] = base
+			for _, nb := range g.neighbours(node) {
+				next[node] += pageRankDamping * rank[nb] * g.adj[node][nb] / strength[nb]
+			}
+		}
+		delta := 0.0
+		for node := range rank {
+			delta += math.Abs(next[node] - rank[node])
+		}
+		rank, next = next, rank
+		if delta < pageRankTolerance {
+			break
+		}
+	}
+	return rank
+}
+
+// bridging is node's participation coefficient over clusterOf: one minus
+// the sum of squared shares of its edge weight going to each cluster.
+func (g analyticsGraph) bridging(node int, clusterOf []int) float64 {
+	total := 0.0
+	perCluster := map[int]float64{}
+	for _, nb := range g.neighbours(node) {
+		w := g.adj[node][nb]
+		perCluster[clusterOf[nb]] += w
+		total += w
+	}
+	if len(perCluster) < 2 {
+		return 0
+	}
+	clusters := make([]int, 0, len(perCluster))
+	for c := range perCluster {
+		clusters = append(clusters, c)
+	}
+	slices.Sort(clusters)
+	sum := 0.0
+	for _, c := range clusters {
+		share := perCluster[c] / total
+		sum += share * share
+	}
+	return 1 - sum
+}
+
+// commonTags returns the tags most of nodes carry, most common first.
+func (g analyticsGraph) commonTags(nodes []int) []string {
+	count := map[string]int{}
+	for _, node := range nodes {
+		for _, tag := range g.tags[node] {
+			count[tag]++
+		}
+	}
+	tags := make([]string, 0, len(count))
+	for tag := range count {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if count[tags[i]] != count[tags[j]] {
+			return count[tags[i]] > count[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags[:min(len(tags), maxClusterTags)]
+}
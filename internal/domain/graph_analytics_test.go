@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func tagged(id string, tags ...string) Insight {
+	return Insight{ID: id, Text: "text " + id, Enrichment: &Enrichment{Tags: tags}}
+}
+
+func related(from, to string, confidence float64) Relationship {
+	return Relationship{FromInsightID: from, ToInsightID: to, Type: RelationSupports, Confidence: confidence}
+}
+
+// twoTriangles is two tightly knit groups, a-b-c on focus and d-e-f on
+// habits, that only x links, plus z on its own.
+func twoTriangles() ([]Insight, []Relationship) {
+	insights := []Insight{
+		tagged("a", "focus"), tagged("b", "focus"), tagged("c", "focus", "deep-work"),
+		tagged("d", "habits"), tagged("e", "habits"), tagged("f", "habits"),
+		tagged("x", "focus", "habits"), tagged("z"),
+	}
+	rels := []Relationship{
+		related("a", "b", 0.9), related("b", "c", 0.9), related("c", "a", 0.9),
+		related("d", "e", 0.9), related("e", "f", 0.9), related("f", "d", 0.9),
+		related("x", "a", 0.6), related("d", "x", 0.6),
+	}
+	return insights, rels
+}
+
+func TestComputeGraphAnalytics_FindsClustersAndTheBridgeBetweenThem(t *testing.T) {
+	insights, rels := twoTriangles()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got := ComputeGraphAnalytics("t-1", insights, rels, now)
+
+	if got.TenantID != "t-1" || !got.ComputedAt.Equal(now) {
+		t.Fatalf("tenant = %q computed_at = %v", got.TenantID, got.ComputedAt)
+	}
+	if len(got.Clusters) != 2 {
+		t.Fatalf("clusters = %+v, want 2", got.Clusters)
+	}
+	clusterOf := map[string]int{}
+	for _, c := range got.Clusters {
+		if c.Size != len(c.InsightIDs) {
+			t.Fatalf("cluster %d: size %d but %d ids", c.ID, c.Size, len(c.InsightIDs))
+		}
+		for _, id := range c.InsightIDs {
+			clusterOf[id] = c.ID
+		}
+	}
+	if clusterOf["a"] != clusterOf["b"] || clusterOf["b"] != clusterOf["c"] ||
+		clusterOf["d"] != clusterOf["e"] || clusterOf["e"] != clusterOf["f"] || clusterOf["a"] == clusterOf["d"] {
+		t.Fatalf("clusters = %+v, want a-b-c and d-e-f apart", got.Clusters)
+	}
+	if _, ok := clusterOf["z"]; ok {
+		t.Fatalf("z has no edges but is in a cluster")
+	}
+	if first := got.Clusters[clusterOf["a"]-1]; first.Tags[0] != "focus" {
+		t.Fatalf("a's cluster tags = %v, want focus first", first.Tags)
+	}
+
+	if len(got.Bridges) == 0 || got.Bridges[0].InsightID != "x" {
+		t.Fatalf("bridges = %+v, want x first", got.Bridges)
+	}
+	if got.Bridges[0].Bridging <= 0.4 {
+		t.Fatalf("x bridging = %v, want its links split about evenly", got.Bridges[0].Bridging)
+	}
+	for _, r := range got.Bridges {
+		if r.InsightID == "b" || r.InsightID == "e" || r.InsightID == "z" {
+			t.Fatalf("%s only links inside its cluster but is listed as a bridge", r.InsightID)
+		}
+	}
+
+	if len(got.Central) != len(insights) {
+		t.Fatalf("central = %d insights, want all %d", len(got.Central), len(insights))
+	}
+	sum := 0.0
+	for i, r := range got.Central {
+		sum += r.Centrality
+		if i > 0 && r.Centrality > got.Central[i-1].Centrality {
+			t.Fatalf("central not ordered by centrality: %+v", got.Central)
+		}
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Fatalf("centrality sums to %v, want 1", sum)
+	}
+	if last := got.Central[len(got.Central)-1]; last.InsightID != "z" || last.ClusterID != 0 {
+		t.Fatalf("least central = %+v, want the isolated z", last)
+	}
+	if got.Central[0].Text == "" {
+		t.Fatalf("rank carries no text")
+	}
+}
+
+func TestComputeGraphAnalytics_IsDeterministic(t *testing.T) {
+	insights, rels := twoTriangles()
+	reversed := make([]Insight, len(insights))
+	for i, insight := range insights {
+		reversed[len(insights)-1-i] = insight
+	}
+
+	a := ComputeGraphAnalytics("t-1", insights, rels, time.Time{})
+	b := ComputeGraphAnalytics("t-1", reversed, rels, time.Time{})
+
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("input order changed the result:\n%+v\n%+v", a, b)
+	}
+}
+
+func TestComputeGraphAnalytics_IgnoresEdgesToUnknownInsights(t *testing.T) {
+	got := ComputeGraphAnalytics("t-1", []Insight{tagged("a"), tagged("b")},
+		[]Relationship{related("a", "gone", 0.9), related("a", "a", 0.9)}, time.Time{})
+
+	if len(got.Clusters) != 0 || len(got.Bridges) != 0 || len(got.Central) != 2 {
+		t.Fatalf("got %+v, want two unrelated insights", got)
+	}
+}
+
+func TestComputeGraphAnalytics_CapsRankings(t *testing.T) {
+	var insights []Insight
+	var rels []Relationship
+	for i := 0; i < MaxRankedInsights+10; i++ {
+		id := string(rune('A'+i/26)) + string(rune('a'+i%26))
+		insights = append(insights, tagged(id))
+		if i > 0 {
+			rels = append(rels, related(insights[0].ID, id, 0.5))
+		}
+	}
+
+	insights[0].Text = strings.Repeat("a long highlight ", 100)
+
+	got := ComputeGraphAnalytics("t-1", insights, rels, time.Time{})
+
+	if len(got.Central) != MaxRankedInsights {
+		t.Fatalf("central = %d, want capped at %d", len(got.Central), MaxRankedInsights)
+	}
+	if len(got.Clusters) != 1 || got.Clusters[0].Size != len(insights) || len(got.Clusters[0].InsightIDs) != len(insights) {
+		t.Fatalf("clusters = %+v, want one star of %d", got.Clusters, len(insights))
+	}
+	if got.Central[0].InsightID != insights[0].ID {
+		t.Fatalf("most central = %s, want the hub", got.Central[0].InsightID)
+	}
+	if n := utf8.RuneCountInString(got.Central[0].Text); n > rankExcerptRunes+1 || !strings.HasSuffix(got.Central[0].Text, "…") {
+		t.Fatalf("hub text = %d runes, want an excerpt of at most %d", n, rankExcerptRunes)
+	}
+}
+
+func TestComputeGraphAnalytics_Empty(t *testing.T) {
+	got := ComputeGraphAnalytics("t-1", nil, nil, time.Time{})
+
+	if len(got.Clusters) != 0 || len(got.Central) != 0 || len(got.Bridges) != 0 {
+		t.Fatalf("got %+v, want nothing", got)
+	}
+}
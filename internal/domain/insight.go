@@ -3,11 +3,19 @@ package domain
 import "time"
 
 type Insight struct {
-	ID            string
-	TenantID      string
-	Source        string
-	Text          string
-	Notes         string
+	ID       string
+	TenantID string
+	Source   string
+	Text     string
+	Notes    string
+	// Language is what Text is written in, detected at ingest
+	// (DetectLanguage); LanguageUnknown when it couldn't be called or for
+	// insights stored before it was recorded. Text itself is never
+	// translated: only the tags are, into the tenant's TagLanguage.
+	Language      Language
 	Enrichment    *Enrichment
 	HighlightedAt time.Time
+	// MergedSources are the other imports of this passage folded into it
+	// by DuplicateMerge; the insight's own Source is not among them.
+	MergedSources []SourceRef
 }
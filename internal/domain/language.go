@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// Language is an ISO 639-1 code: the language a highlight is written in,
+// or the one a tenant's tags are written in.
+type Language string
+
+// LanguageUnknown is what DetectLanguage returns when a text is too short
+// or too mixed to call.
+const LanguageUnknown Language = ""
+
+// DefaultTagLanguage is the canonical tag language of a tenant that hasn't
+// chosen one.
+const DefaultTagLanguage Language = "en"
+
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// languages are the ones DetectLanguage can recognize and a tenant can
+// choose for its tags, each with its most frequent function words. Those
+// words are short, common and rarely shared, which is all a highlight-sized
+// text needs; anything finer is the LLM's job.
+var languages = map[Language]struct {
+	name      string
+	stopwords []string
+}{
+	"en": {"English", []string{"the", "and", "of", "to", "is", "that", "it", "in", "you", "for", "with", "are", "this", "not", "be", "what", "have", "they", "your", "was"}},
+	"de": {"German", []string{"der", "die", "und", "das", "ist", "nicht", "ich", "sie", "es", "mit", "den", "auf", "sich", "ein", "eine", "zu", "auch", "wir", "wenn", "dass"}},
+	"fr": {"French", []string{"le", "la", "les", "et", "est", "des", "une", "que", "pas", "pour", "qui", "dans", "vous", "nous", "sur", "ce", "il", "du", "au", "avec"}},
+	"es": {"Spanish", []string{"el", "los", "las", "y", "es", "que", "una", "por", "para", "con", "del", "se", "lo", "como", "pero", "más", "su", "al", "muy", "está"}},
+	"it": {"Italian", []string{"il", "di", "che", "è", "non", "per", "una", "sono", "gli", "della", "come", "ma", "con", "anche", "si", "nel", "questo", "alla", "più", "lo"}},
+	"nl": {"Dutch", []string{"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "je", "ik", "zijn", "met", "voor", "maar", "ook", "er", "wat", "wij", "naar"}},
+	"pt": {"Portuguese", []string{"o", "os", "as", "e", "é", "que", "não", "uma", "um", "para", "com", "do", "da", "em", "se", "mais", "por", "como", "mas", "você"}},
+}
+
+// minLanguageHits is how many function words a text needs before
+// DetectLanguage calls it: below that, one shared word ("de", "is") would
+// decide.
+const minLanguageHits = 3
+
+// ParseLanguage accepts a supported ISO 639-1 code, case-insensitively.
+func ParseLanguage(s string) (Language, error) {
+	l := Language(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := languages[l]; !ok {
+		return LanguageUnknown, ErrUnsupportedLanguage
+	}
+	return l, nil
+}
+
+// Name is l's English name, as the enrichment prompt writes it, or the
+// code itself for a language DetectLanguage doesn't know.
+func (l Language) Name() string {
+	if lang, ok := languages[l]; ok {
+		return lang.name
+	}
+	return string(l)
+}
+
+// DetectLanguage guesses text's language by counting each supported
+// language's function words in it. The winner needs minLanguageHits and a
+// clear lead over the runner-up; otherwise it's LanguageUnknown.
+//
+// TRADE-OFF: a function-word count, not a trained model. It tells the
+// supported languages apart on a sentence or more and gives up on a
+// fragment, which is the right failure: an unknown language only means
+// the tags are translated from whatever the highlight turns out to be.
+func DetectLanguage(text string) Language {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	best, bestHits, runnerUp := LanguageUnknown, 0, 0
+	for code, lang := range languages {
+		hits := 0
+		for _, w := range words {
+			for _, stop := range lang.stopwords {
+				if w == stop {
+					hits++
+					break
+				}
+			}
+		}
+		// A tie leaves runnerUp equal to bestHits, so which of the two
+		// came first in the map doesn't matter: neither wins.
+		if hits > bestHits {
+			best, bestHits, runnerUp = code, hits, bestHits
+		} else if hits > runnerUp {
+			runnerUp = hits
+		}
+	}
+
+	if bestHits < minLanguageHits || 2*bestHits < 3*runnerUp {
+		return LanguageUnknown
+	}
+	return best
+}
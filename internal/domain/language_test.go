@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]struct {
+		text string
+		want Language
+	}{
+		"english":  {"The habits you repeat every day are what shape the person you become.", "en"},
+		"german":   {"Die Gewohnheiten, die wir jeden Tag wiederholen, sind das, was uns ausmacht, und nicht die Ziele.", "de"},
+		"french":   {"Les habitudes que nous répétons chaque jour sont ce qui nous définit, et pas les objectifs.", "fr"},
+		"spanish":  {"Los hábitos que repetimos cada día son lo que nos define, pero no los objetivos.", "es"},
+		"fragment": {"Atomic Habits", LanguageUnknown},
+		"empty":    {"", LanguageUnknown},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := DetectLanguage(tc.text); got != tc.want {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	if got, err := ParseLanguage(" DE "); err != nil || got != "de" {
+		t.Errorf("ParseLanguage(DE) = %q, %v; want de", got, err)
+	}
+	if _, err := ParseLanguage("xx"); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("ParseLanguage(xx) err = %v, want ErrUnsupportedLanguage", err)
+	}
+	if _, err := ParseLanguage(""); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("ParseLanguage(\"\") err = %v, want ErrUnsupportedLanguage", err)
+	}
+}
+
+func TestLanguage_Name(t *testing.T) {
+	if got := Language("de").Name(); got != "German" {
+		t.Errorf("Name() = %q, want German", got)
+	}
+}
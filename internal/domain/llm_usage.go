@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// LLMUsage is what a tenant's LLM calls consumed over one period.
+// CostMicros is in millionths of a US dollar, so it sums exactly.
+type LLMUsage struct {
+	Calls        int64
+	InputTokens  int64
+	OutputTokens int64
+	CostMicros   int64
+}
+
+func (u LLMUsage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens
+}
+
+// UsageDay and UsageMonth key the periods a tenant's LLMUsage is counted
+// over. Always UTC, so every caller agrees on when a period rolls over.
+func UsageDay(t time.Time) string   { return t.UTC().Format(time.DateOnly) }
+func UsageMonth(t time.Time) string { return t.UTC().Format("2006-01") }
+
+// ModelPrice is what the configured model charges, in US dollars per
+// million tokens — the unit providers publish prices in.
+type ModelPrice struct {
+	InputPerMTok  float64
+	OutputPerMTok float64
+}
+
+// CostMicros prices a call. Dollars per million tokens is micro-dollars
+// per token, so no scaling is needed.
+func (p ModelPrice) CostMicros(inputTokens, outputTokens int64) int64 {
+	return int64(math.Round(float64(inputTokens)*p.InputPerMTok + float64(outputTokens)*p.OutputPerMTok))
+}
+
+// BudgetLimits caps every tenant's LLM spend, per UTC day and per month.
+// A zero limit is no limit.
+type BudgetLimits struct {
+	DailyTokens       int64
+	MonthlyTokens     int64
+	DailyCostMicros   int64
+	MonthlyCostMicros int64
+}
+
+// Exhausted reports whether day or month has reached any limit.
+func (l BudgetLimits) Exhausted(day, month LLMUsage) bool {
+	return reached(day.Tokens(), l.DailyTokens) ||
+		reached(month.Tokens(), l.MonthlyTokens) ||
+		reached(day.CostMicros, l.DailyCostMicros) ||
+		reached(month.CostMicros, l.MonthlyCostMicros)
+}
+
+func reached(used, limit int64) bool {
+	return limit > 0 && used >= limit
+}
+
+// UsageReport is a tenant's consumption for the current day and month,
+// against the limits in force.
+type UsageReport struct {
+	TenantID   string
+	Day        string
+	DayUsage   LLMUsage
+	Month      string
+	MonthUsage LLMUsage
+	Limits     BudgetLimits
+	Exhausted  bool
+}
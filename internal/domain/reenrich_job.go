@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ReenrichScope selects which of a tenant's insights a ReenrichJob re-runs
+// enrichment for.
+type ReenrichScope string
+
+const (
+	// ReenrichMissing covers insights with no Enrichment at all: ingested
+	// while no key was configured, or soft-failed by insight.Service.Process.
+	ReenrichMissing ReenrichScope = "missing"
+	// ReenrichAll re-enriches every insight, tagged or not.
+	ReenrichAll ReenrichScope = "all"
+	// ReenrichBefore covers insights whose enrichment is older than
+	// ReenrichJob.EnrichedBefore, plus untagged ones — an untagged insight
+	// is older than any cutoff.
+	ReenrichBefore ReenrichScope = "before"
+	// ReenrichFallback covers insights whose tags came from the offline
+	// keyword fallback rather than an LLM, plus untagged ones: everything
+	// an outage left worse off than it should be.
+	ReenrichFallback ReenrichScope = "fallback"
+	// ReenrichVersion covers insights tagged by ReenrichJob.Model and/or
+	// under ReenrichJob.PromptVersion — output a newer model or prompt
+	// should replace. Either left empty matches any.
+	ReenrichVersion ReenrichScope = "version"
+)
+
+func (s ReenrichScope) Valid() bool {
+	switch s {
+	case ReenrichMissing, ReenrichAll, ReenrichBefore, ReenrichFallback, ReenrichVersion:
+		return true
+	}
+	return false
+}
+
+// JobStatus tracks a long-running, resumable job. A job starts pending and
+// stays pending across as many invocations as it takes to work through its
+// scope; only the run that finds nothing left marks it done.
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusDone    JobStatus = "done"
+)
+
+var (
+	ErrUnknownReenrichScope   = errors.New("unknown reenrich scope")
+	ErrMissingEnrichedBefore  = errors.New("enriched_before is required for scope \"before\"")
+	ErrUnexpectedEnrichedDate = errors.New("enriched_before is only valid for scope \"before\"")
+	ErrMissingVersion         = errors.New("model or prompt_version is required for scope \"version\"")
+	ErrUnexpectedVersion      = errors.New("model and prompt_version are only valid for scope \"version\"")
+)
+
+// ReenrichJob is a tenant's request to re-run enrichment over a slice of
+// its existing insights. Progress lives on the job itself (Cursor plus the
+// counters) so a run that times out or fails halfway resumes where it
+// stopped instead of re-paying for every insight before it.
+//
+// Cursor is the last insight ID a run finished with, successfully or not.
+// Insights are walked in ID order, so "everything after Cursor" is exactly
+// the work still outstanding.
+type ReenrichJob struct {
+	ID             string
+	TenantID       string
+	Scope          ReenrichScope
+	EnrichedBefore time.Time
+	Model          string
+	PromptVersion  string
+	Status         JobStatus
+	Cursor         string
+	Processed      int
+	Enriched       int
+	Failed         int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Validate checks the job's criteria: a known scope, a cutoff date exactly
+// when the scope needs one, and likewise a model or prompt version.
+func (j ReenrichJob) Validate() error {
+	if !j.Scope.Valid() {
+		return ErrUnknownReenrichScope
+	}
+	if j.Scope == ReenrichBefore && j.EnrichedBefore.IsZero() {
+		return ErrMissingEnrichedBefore
+	}
+	if j.Scope != ReenrichBefore && !j.EnrichedBefore.IsZero() {
+		return ErrUnexpectedEnrichedDate
+	}
+	hasVersion := j.Model != "" || j.PromptVersion != ""
+	if j.Scope == ReenrichVersion && !hasVersion {
+		return ErrMissingVersion
+	}
+	if j.Scope != ReenrichVersion && hasVersion {
+		return ErrUnexpectedVersion
+	}
+	return nil
+}
+
+// Matches reports whether insight falls inside the job's scope.
+func (j ReenrichJob) Matches(insight Insight) bool {
+	switch j.Scope {
+	case ReenrichAll:
+		return true
+	case ReenrichMissing:
+		return insight.Enrichment == nil
+	case ReenrichBefore:
+		return insight.Enrichment == nil || insight.Enrichment.EnrichedAt.Before(j.EnrichedBefore)
+	case ReenrichFallback:
+		return insight.Enrichment == nil || insight.Enrichment.Fallback
+	case ReenrichVersion:
+		// Untagged insights weren't produced by any version; ReenrichMissing
+		// is the scope for those.
+		e := insight.Enrichment
+		return e != nil &&
+			(j.Model == "" || e.Model == j.Model) &&
+			(j.PromptVersion == "" || e.PromptVersion == j.PromptVersion)
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReenrichJob_Validate(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		job  ReenrichJob
+		want error
+	}{
+		{"missing scope is valid", ReenrichJob{Scope: ReenrichMissing}, nil},
+		{"before with a cutoff is valid", ReenrichJob{Scope: ReenrichBefore, EnrichedBefore: cutoff}, nil},
+		{"unknown scope", ReenrichJob{Scope: "sometimes"}, ErrUnknownReenrichScope},
+		{"before without a cutoff", ReenrichJob{Scope: ReenrichBefore}, ErrMissingEnrichedBefore},
+		{"cutoff on a scope that ignores it", ReenrichJob{Scope: ReenrichAll, EnrichedBefore: cutoff}, ErrUnexpectedEnrichedDate},
+		{"version with a prompt version is valid", ReenrichJob{Scope: ReenrichVersion, PromptVersion: "enrich-v2"}, nil},
+		{"version without model or prompt version", ReenrichJob{Scope: ReenrichVersion}, ErrMissingVersion},
+		{"model on a scope that ignores it", ReenrichJob{Scope: ReenrichMissing, Model: "gpt-4o-mini"}, ErrUnexpectedVersion},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.job.Validate(); !errors.Is(err, tc.want) {
+				t.Fatalf("Validate() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestReenrichJob_Matches(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	untagged := Insight{ID: "i-1"}
+	old := Insight{ID: "i-2", Enrichment: &Enrichment{EnrichedAt: cutoff.Add(-time.Hour)}}
+	legacy := Insight{ID: "i-3", Enrichment: &Enrichment{}}
+	fresh := Insight{ID: "i-4", Enrichment: &Enrichment{EnrichedAt: cutoff.Add(time.Hour)}}
+
+	before := ReenrichJob{Scope: ReenrichBefore, EnrichedBefore: cutoff}
+	for _, in := range []Insight{untagged, old, legacy} {
+		if !before.Matches(in) {
+			t.Errorf("before-cutoff job should match %s", in.ID)
+		}
+	}
+	if before.Matches(fresh) {
+		t.Error("before-cutoff job should not match an insight enriched after the cutoff")
+	}
+
+	missing := ReenrichJob{Scope: ReenrichMissing}
+	if !missing.Matches(untagged) || missing.Matches(old) {
+		t.Error("missing-scope job should match only untagged insights")
+	}
+
+	fallback := ReenrichJob{Scope: ReenrichFallback}
+	keywords := Insight{ID: "i-5", Enrichment: &Enrichment{Fallback: true}}
+	if !fallback.Matches(keywords) || !fallback.Matches(untagged) || fallback.Matches(fresh) {
+		t.Error("fallback-scope job should match keyword-fallback and untagged insights only")
+	}
+
+	v2 := Insight{ID: "i-6", Enrichment: &Enrichment{Model: "gpt-4o-mini", PromptVersion: "enrich-v2"}}
+	v3 := Insight{ID: "i-7", Enrichment: &Enrichment{Model: "gpt-4o-mini", PromptVersion: "enrich-v3"}}
+	otherModel := Insight{ID: "i-8", Enrichment: &Enrichment{Model: "claude-haiku", PromptVersion: "enrich-v2"}}
+	byPrompt := ReenrichJob{Scope: ReenrichVersion, PromptVersion: "enrich-v2"}
+	if !byPrompt.Matches(v2) || !byPrompt.Matches(otherModel) || byPrompt.Matches(v3) || byPrompt.Matches(untagged) {
+		t.Error("version-scope job should match every model's enrich-v2 output only")
+	}
+	byBoth := ReenrichJob{Scope: ReenrichVersion, Model: "gpt-4o-mini", PromptVersion: "enrich-v2"}
+	if !byBoth.Matches(v2) || byBoth.Matches(otherModel) || byBoth.Matches(v3) {
+		t.Error("version-scope job with a model should match that model's enrich-v2 output only")
+	}
+}
@@ -54,6 +54,9 @@ type RelatedInsight struct {
 	Type       RelationType
 	Confidence float64
 	Rationale  string
+	// Outgoing is true if the edge was discovered from this insight's
+	// side, i.e. it's the edge's FromInsightID.
+	Outgoing bool
 }
 
 // Validate checks the fields that don't require a database round trip:
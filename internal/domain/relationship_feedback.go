@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// RelationshipVerdict is a user's ruling on the edge between two insights.
+type RelationshipVerdict string
+
+const (
+	// VerdictNotRelated is the user removing an edge the agent invented.
+	VerdictNotRelated RelationshipVerdict = "not_related"
+	// VerdictRetyped is the user keeping the edge under a different
+	// RelationType.
+	VerdictRetyped RelationshipVerdict = "retyped"
+)
+
+// RelationshipFeedback is a user's verdict on a pair of insights. It
+// outlives the edge itself: the agent must not post the pair again, so
+// relationship.Service.Put refuses it and the AI service skips it before
+// spending an LLM call on it.
+type RelationshipFeedback struct {
+	TenantID       string
+	InsightID      string
+	OtherInsightID string
+	Verdict        RelationshipVerdict
+	// Type is the user's RelationType for VerdictRetyped, empty otherwise.
+	Type      RelationType
+	DecidedAt time.Time
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRelationshipRejected is wrapped by every reason an edge the agent
+// posted isn't stored, so callers can tell "policy said no" from a
+// failure without listing each reason.
+var ErrRelationshipRejected = errors.New("relationship rejected")
+
+var (
+	ErrBelowMinConfidence = fmt.Errorf("%w: confidence is below the tenant's minimum for this relation type", ErrRelationshipRejected)
+	ErrContradictoryEdge  = fmt.Errorf("%w: contradicts an existing edge between the same insights", ErrRelationshipRejected)
+	ErrEdgeCapReached     = fmt.Errorf("%w: insight already has its maximum number of stronger edges", ErrRelationshipRejected)
+	ErrUserVerdict        = fmt.Errorf("%w: the user has already ruled on this pair of insights", ErrRelationshipRejected)
+)
+
+// RelationshipPolicy is a tenant's bar for which of the agent's edges get
+// stored (see relationship.Service.Put). The zero value admits every edge
+// that passes Relationship.Validate.
+type RelationshipPolicy struct {
+	// MinConfidence is the lowest confidence stored per relation type; a
+	// type that isn't listed has no minimum.
+	MinConfidence map[RelationType]float64
+	// MaxEdgesPerInsight caps each insight's edges, keeping the most
+	// confident ones. Zero means no cap.
+	MaxEdgesPerInsight int
+}
+
+// Validate rejects minimums outside [0,1] or on unknown types, and a
+// negative cap.
+func (p RelationshipPolicy) Validate() error {
+	for relType, min := range p.MinConfidence {
+		if !relType.Valid() {
+			return ErrUnknownRelationType
+		}
+		if min < 0 || min > 1 {
+			return ErrConfidenceOutOfRange
+		}
+	}
+	if p.MaxEdgesPerInsight < 0 {
+		return errors.New("max edges per insight must not be negative")
+	}
+	return nil
+}
+
+// Opposes reports whether an insight can't hold both t and other towards
+// the same insight: one supporting it and one contradicting it.
+func (t RelationType) Opposes(other RelationType) bool {
+	return (t == RelationSupports && other == RelationContradicts) ||
+		(t == RelationContradicts && other == RelationSupports)
+}
+
+// RejectReason is why an edge isn't (or is no longer) stored.
+type RejectReason string
+
+const (
+	RejectBelowMinConfidence RejectReason = "below_min_confidence"
+	RejectContradictsEdge    RejectReason = "contradicts_existing_edge"
+	RejectEdgeCap            RejectReason = "edge_cap"
+	// RejectUserVerdict is the agent posting a pair the user removed or
+	// retyped (see RelationshipFeedback).
+	RejectUserVerdict RejectReason = "user_verdict"
+	// RejectEvicted is an edge that was stored, then pushed out by a more
+	// confident one when an insight hit MaxEdgesPerInsight.
+	RejectEvicted RejectReason = "evicted"
+)
+
+// Err is the error the relationship service returns for r. RejectEvicted
+// has none: the edge that was written succeeded.
+func (r RejectReason) Err() error {
+	switch r {
+	case RejectBelowMinConfidence:
+		return ErrBelowMinConfidence
+	case RejectContradictsEdge:
+		return ErrContradictoryEdge
+	case RejectEdgeCap:
+		return ErrEdgeCapReached
+	case RejectUserVerdict:
+		return ErrUserVerdict
+	}
+	return nil
+}
+
+// RejectedRelationship is one entry of the log the agent reads back to
+// learn which of its edges the policy turned away.
+type RejectedRelationship struct {
+	Relationship
+	Reason RejectReason
+	// Detail says what the edge lost to, e.g. the existing edge it
+	// contradicts or the one that evicted it, for the agent to log.
+	Detail     string
+	RejectedAt time.Time
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRelationshipPolicy_Validate(t *testing.T) {
+	tests := map[string]struct {
+		policy RelationshipPolicy
+		want   error
+	}{
+		"zero value":       {policy: RelationshipPolicy{}},
+		"minimum and cap":  {policy: RelationshipPolicy{MinConfidence: map[RelationType]float64{RelationSupports: 0.7}, MaxEdgesPerInsight: 10}},
+		"unknown type":     {policy: RelationshipPolicy{MinConfidence: map[RelationType]float64{"refutes": 0.7}}, want: ErrUnknownRelationType},
+		"minimum above 1":  {policy: RelationshipPolicy{MinConfidence: map[RelationType]float64{RelationSupports: 1.2}}, want: ErrConfidenceOutOfRange},
+		"negative minimum": {policy: RelationshipPolicy{MinConfidence: map[RelationType]float64{RelationExtends: -0.1}}, want: ErrConfidenceOutOfRange},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.policy.Validate(); !errors.Is(err, tc.want) {
+				t.Fatalf("Validate = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if err := (RelationshipPolicy{MaxEdgesPerInsight: -1}).Validate(); err == nil {
+		t.Fatalf("negative cap accepted")
+	}
+}
+
+func TestRelationType_Opposes(t *testing.T) {
+	if !RelationSupports.Opposes(RelationContradicts) || !RelationContradicts.Opposes(RelationSupports) {
+		t.Fatalf("supports and contradicts should oppose each other both ways")
+	}
+	if RelationSupports.Opposes(RelationSupports) || RelationExtends.Opposes(RelationContradicts) {
+		t.Fatalf("only supports/contradicts oppose")
+	}
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Review scheduling is SM-2: a grade of 3 or more is a pass and stretches
+// the interval by the ease factor, anything less starts the insight over
+// at one day. The ease factor itself drifts with every grade.
+const (
+	MinReviewGrade = 0
+	MaxReviewGrade = 5
+
+	reviewPassingGrade = 3
+	initialEaseFactor  = 2.5
+	minEaseFactor      = 1.3
+
+	// MaxNewReviews caps the never-reviewed insights one due list brings
+	// in, so a freshly imported library doesn't bury the reviews already
+	// under way.
+	MaxNewReviews = 10
+
+	// reviewRelevanceWeight and reviewRelatedWeight are how much a due
+	// insight's best tag score and a recently discovered relationship
+	// lift it up the list, against the 1 every scheduled review starts
+	// from and the up-to-1 it gains by being overdue. A relationship's
+	// lift halves every reviewRelatedHalfLife: a new link is a good
+	// moment to revisit both ends.
+	reviewRelevanceWeight = 1.0
+	reviewRelatedWeight   = 0.5
+	reviewRelatedHalfLife = 3 * 24 * time.Hour
+)
+
+var ErrInvalidReviewGrade = errors.New("grade must be between 0 and 5")
+
+// ReviewSchedule is when an insight is next due for review and the SM-2
+// state that put it there. An insight without one has never been reviewed
+// and is due as new (NewReviewSchedule).
+type ReviewSchedule struct {
+	TenantID  string
+	InsightID string
+	// Repetitions counts the passing reviews in a row.
+	Repetitions  int
+	EaseFactor   float64
+	IntervalDays int
+	DueAt        time.Time
+	// Reviews counts every review, passing or not.
+	Reviews        int
+	LastGrade      int
+	LastReviewedAt time.Time
+}
+
+func NewReviewSchedule(tenantID, insightID string) ReviewSchedule {
+	return ReviewSchedule{TenantID: tenantID, InsightID: insightID, EaseFactor: initialEaseFactor}
+}
+
+// Review returns s after a review graded grade at now.
+func (s ReviewSchedule) Review(grade int, now time.Time) (ReviewSchedule, error) {
+	if grade < MinReviewGrade || grade > MaxReviewGrade {
+		return ReviewSchedule{}, ErrInvalidReviewGrade
+	}
+
+	if grade >= reviewPassingGrade {
+		switch s.Repetitions {
+		case 0:
+			s.IntervalDays = 1
+		case 1:
+			s.IntervalDays = 6
+		default:
+			s.IntervalDays = int(math.Round(float64(s.IntervalDays) * s.EaseFactor))
+		}
+		s.Repetitions++
+	} else {
+		s.Repetitions = 0
+		s.IntervalDays = 1
+	}
+
+	miss := float64(MaxReviewGrade - grade)
+	s.EaseFactor = max(minEaseFactor, s.EaseFactor+0.1-miss*(0.08+miss*0.02))
+	s.DueAt = now.AddDate(0, 0, s.IntervalDays)
+	s.Reviews++
+	s.LastGrade = grade
+	s.LastReviewedAt = now
+	return s, nil
+}
+
+// ReviewCandidate is an insight that may be due, with what weighs it on
+// the due list.
+type ReviewCandidate struct {
+	Insight Insight
+	// Schedule is nil for an insight never reviewed.
+	Schedule *ReviewSchedule
+	// TagRelevance is the best relevance score among the insight's tags.
+	TagRelevance float64
+	// RelatedAt is when the insight's most recent relationship was
+	// discovered; zero if it has none.
+	RelatedAt time.Time
+}
+
+// DueReview is one insight on the due list. Schedule is a fresh
+// NewReviewSchedule when New.
+type DueReview struct {
+	Insight  Insight
+	Schedule ReviewSchedule
+	New      bool
+	Priority float64
+}
+
+// DueReviews picks the candidates due by the end of now's UTC day, at most
+// MaxNewReviews of them new, and returns up to limit, highest Priority
+// first (ties to the earliest due, then insight ID). A scheduled review
+// scores 1 plus how overdue it is as a share of its interval, capped at
+// 1; a new one starts from 0. Both gain from tag relevance and from a
+// recently discovered relationship.
+func DueReviews(candidates []ReviewCandidate, now time.Time, limit int) []DueReview {
+	endOfDay := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+	var scheduled, fresh []DueReview
+	for _, c := range candidates {
+		due := DueReview{Insight: c.Insight}
+		boost := reviewRelevanceWeight*c.TagRelevance + reviewRelatedWeight*relatedLift(c.RelatedAt, now)
+		if c.Schedule == nil {
+			due.Schedule = NewReviewSchedule(c.Insight.TenantID, c.Insight.ID)
+			due.New = true
+			due.Priority = boost
+			fresh = append(fresh, due)
+			continue
+		}
+		if !c.Schedule.DueAt.Before(endOfDay) {
+			continue
+		}
+		due.Schedule = *c.Schedule
+		overdue := now.Sub(c.Schedule.DueAt).Hours() / 24 / float64(max(1, c.Schedule.IntervalDays))
+		due.Priority = 1 + max(0, min(1, overdue)) + boost
+		scheduled = append(scheduled, due)
+	}
+
+	sortDueReviews(fresh)
+	out := append(scheduled, fresh[:min(len(fresh), MaxNewReviews)]...)
+	sortDueReviews(out)
+	return out[:min(len(out), limit)]
+}
+
+func relatedLift(relatedAt, now time.Time) float64 {
+	if relatedAt.IsZero() {
+		return 0
+	}
+	age := max(0, now.Sub(relatedAt))
+	return math.Exp(-math.Ln2 * age.Hours() / reviewRelatedHalfLife.Hours())
+}
+
+func sortDueReviews(reviews []DueReview) {
+	sort.Slice(reviews, func(i, j int) bool {
+		a, b := reviews[i], reviews[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if !a.Schedule.DueAt.Equal(b.Schedule.DueAt) {
+			return a.Schedule.DueAt.Before(b.Schedule.DueAt)
+		}
+		return a.Insight.ID < b.Insight.ID
+	})
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReviewSchedule_Review(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	s := NewReviewSchedule("t-1", "a")
+
+	var intervals []int
+	for i := 0; i < 4; i++ {
+		var err error
+		if s, err = s.Review(4, now); err != nil {
+			t.Fatalf("Review: %v", err)
+		}
+		intervals = append(intervals, s.IntervalDays)
+	}
+	if intervals[0] != 1 || intervals[1] != 6 || intervals[2] != 15 || intervals[3] != 38 {
+		t.Fatalf("intervals = %v, want 1, 6, then growing by the ease factor", intervals)
+	}
+	if s.EaseFactor != initialEaseFactor || s.Repetitions != 4 || s.Reviews != 4 {
+		t.Fatalf("schedule = %+v, want ease unchanged by 4s", s)
+	}
+	if !s.DueAt.Equal(now.AddDate(0, 0, 38)) || !s.LastReviewedAt.Equal(now) || s.LastGrade != 4 {
+		t.Fatalf("schedule = %+v", s)
+	}
+
+	lapsed, err := s.Review(1, now)
+	if err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	if lapsed.IntervalDays != 1 || lapsed.Repetitions != 0 || lapsed.Reviews != 5 || lapsed.EaseFactor >= s.EaseFactor {
+		t.Fatalf("after a failed review = %+v, want it started over with a lower ease", lapsed)
+	}
+
+	hard := NewReviewSchedule("t-1", "b")
+	for i := 0; i < 10; i++ {
+		hard, _ = hard.Review(0, now)
+	}
+	if hard.EaseFactor != minEaseFactor {
+		t.Fatalf("ease = %v, want floored at %v", hard.EaseFactor, minEaseFactor)
+	}
+
+	if _, err := s.Review(6, now); !errors.Is(err, ErrInvalidReviewGrade) {
+		t.Fatalf("Review(6) = %v, want ErrInvalidReviewGrade", err)
+	}
+}
+
+func TestDueReviews(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	scheduled := func(id string, due time.Time, interval int) ReviewCandidate {
+		return ReviewCandidate{
+			Insight:  Insight{ID: id},
+			Schedule: &ReviewSchedule{InsightID: id, DueAt: due, IntervalDays: interval},
+		}
+	}
+
+	later := scheduled("later", now.Add(20*time.Hour), 6)
+	overdue := scheduled("overdue", now.AddDate(0, 0, -6), 6)
+	dueToday := scheduled("today", now.Add(3*time.Hour), 6)
+	relevant := scheduled("relevant", now.Add(-time.Hour), 6)
+	relevant.TagRelevance = 0.9
+	fresh := ReviewCandidate{Insight: Insight{ID: "new"}}
+	related := ReviewCandidate{Insight: Insight{ID: "new-related"}, RelatedAt: now.Add(-time.Hour)}
+
+	got := DueReviews([]ReviewCandidate{later, overdue, dueToday, relevant, fresh, related}, now, 10)
+
+	var ids []string
+	for _, d := range got {
+		ids = append(ids, d.Insight.ID)
+	}
+	want := []string{"overdue", "relevant", "today", "new-related", "new"}
+	if len(ids) != len(want) {
+		t.Fatalf("due = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("due = %v, want %v", ids, want)
+		}
+	}
+	if !got[4].New || got[4].Schedule.EaseFactor != initialEaseFactor || got[0].New {
+		t.Fatalf("new flags = %+v", got)
+	}
+
+	if capped := DueReviews([]ReviewCandidate{overdue, dueToday, fresh}, now, 2); len(capped) != 2 {
+		t.Fatalf("due = %+v, want capped at the limit", capped)
+	}
+}
+
+func TestDueReviews_CapsNewInsights(t *testing.T) {
+	var candidates []ReviewCandidate
+	for i := 0; i < MaxNewReviews+5; i++ {
+		candidates = append(candidates, ReviewCandidate{Insight: Insight{ID: string(rune('a' + i))}})
+	}
+	if got := DueReviews(candidates, time.Now(), 100); len(got) != MaxNewReviews {
+		t.Fatalf("due = %d, want %d new", len(got), MaxNewReviews)
+	}
+}
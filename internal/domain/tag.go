@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"strings"
 	"unicode"
 )
@@ -13,6 +14,10 @@ const MaxTagsPerInsight = 5
 // returning a sentence instead of a tag).
 const maxTagLength = 40
 
+// ErrInvalidTag is a tag NormalizeTag rejects, where a caller asked for
+// one by name.
+var ErrInvalidTag = errors.New("invalid tag")
+
 // Tag is a normalized, storable tag: lowercase, hyphenated, punctuation-free.
 type Tag string
 
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// RelatedTag is another tag's association with a given one, measured two
+// ways: how often the two tag the same insight, and how often a
+// relationship edge joins an insight tagged with one to an insight tagged
+// with the other.
+//
+// Lift is observed over expected-if-unrelated: 1 means no association,
+// above 1 more than chance. PMI is its base-2 log. Both favour rare tags
+// (one shared insight between two one-off tags is a huge lift), so Score
+// ranks by normalized PMI instead, which stays in [-1,1] and only reaches
+// 1 when the tags always go together.
+type RelatedTag struct {
+	Tag string
+
+	// CoOccurrences counts the insights carrying both tags.
+	CoOccurrences    int
+	CoOccurrenceLift float64
+	CoOccurrencePMI  float64
+
+	// Edges counts the relationship edges joining the two tags' insights.
+	Edges    int
+	EdgeLift float64
+	EdgePMI  float64
+
+	// Score is the higher of the two normalized PMIs.
+	Score float64
+}
+
+// RelatedTags scores every tag associated with tag in insights, through
+// shared insights or relationship edges between them, best first (ties by
+// tag). It reports false if no insight carries tag.
+//
+// Co-occurrence counts only enriched insights with at least one tag, and
+// edges only those whose two ends are both such insights; an edge counts
+// once for a tag however its ends carry the two.
+func RelatedTags(tag string, insights []Insight, relationships []Relationship) ([]RelatedTag, bool) {
+	tagsOf := make(map[string][]string, len(insights))
+	for _, insight := range insights {
+		if insight.Enrichment != nil && len(insight.Enrichment.Tags) > 0 {
+			tagsOf[insight.ID] = insight.Enrichment.Tags
+		}
+	}
+
+	// Co-occurrence over insights.
+	total := len(tagsOf)
+	perTag := map[string]int{}
+	withTag := map[string]int{}
+	for _, tags := range tagsOf {
+		has := false
+		for _, t := range tags {
+			perTag[t]++
+			if t == tag {
+				has = true
+			}
+		}
+		if has {
+			for _, t := range tags {
+				if t != tag {
+					withTag[t]++
+				}
+			}
+		}
+	}
+	if perTag[tag] == 0 {
+		return nil, false
+	}
+
+	// The same over edge endpoints: each edge has two, so an edge's ends
+	// tagged a and b are one draw of a pair from 2*edges endpoints.
+	edges := 0
+	perTagEnds := map[string]int{}
+	edgesWithTag := map[string]int{}
+	for _, rel := range relationships {
+		from, okFrom := tagsOf[rel.FromInsightID]
+		to, okTo := tagsOf[rel.ToInsightID]
+		if !okFrom || !okTo {
+			continue
+		}
+		edges++
+		for _, t := range from {
+			perTagEnds[t]++
+		}
+		for _, t := range to {
+			perTagEnds[t]++
+		}
+		joined := map[string]bool{}
+		for _, pair := range [2][2][]string{{from, to}, {to, from}} {
+			if !slices.Contains(pair[0], tag) {
+				continue
+			}
+			for _, t := range pair[1] {
+				if t != tag && !joined[t] {
+					joined[t] = true
+					edgesWithTag[t]++
+				}
+			}
+		}
+	}
+
+	candidates := map[string]bool{}
+	for t := range withTag {
+		candidates[t] = true
+	}
+	for t := range edgesWithTag {
+		candidates[t] = true
+	}
+
+	related := make([]RelatedTag, 0, len(candidates))
+	for t := range candidates {
+		r := RelatedTag{Tag: t, CoOccurrences: withTag[t], Edges: edgesWithTag[t], Score: -1}
+		if r.CoOccurrences > 0 {
+			pA, pB := float64(perTag[tag])/float64(total), float64(perTag[t])/float64(total)
+			var npmi float64
+			r.CoOccurrenceLift, r.CoOccurrencePMI, npmi = association(float64(r.CoOccurrences)/float64(total), pA*pB)
+			r.Score = max(r.Score, npmi)
+		}
+		if r.Edges > 0 {
+			// An edge can join the two either way round, so the chance
+			// one does by accident is twice the product of their shares
+			// of the 2*edges endpoints.
+			pA, pB := float64(perTagEnds[tag])/float64(2*edges), float64(perTagEnds[t])/float64(2*edges)
+			var npmi float64
+			r.EdgeLift, r.EdgePMI, npmi = association(float64(r.Edges)/float64(edges), 2*pA*pB)
+			r.Score = max(r.Score, npmi)
+		}
+		related = append(related, r)
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].Tag < related[j].Tag
+	})
+	return related, true
+}
+
+// association compares pJoint, how often the pair was observed, with
+// pExpected, how often it would be by chance.
+func association(pJoint, pExpected float64) (lift, pmi, npmi float64) {
+	lift = pJoint / pExpected
+	pmi = math.Log2(lift)
+	return lift, pmi, normalizedPMI(pmi, pJoint)
+}
+
+// normalizedPMI divides pmi by -log2(pJoint); a pair seen on every draw
+// is as associated as it gets.
+func normalizedPMI(pmi, pJoint float64) float64 {
+	if pJoint >= 1 {
+		return 1
+	}
+	return math.Max(-1, math.Min(1, pmi/-math.Log2(pJoint)))
+}
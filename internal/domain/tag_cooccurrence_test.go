@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func tagMapFixture() ([]Insight, []Relationship) {
+	insights := []Insight{
+		tagged("1", "delegation", "trust"),
+		tagged("2", "delegation", "trust"),
+		tagged("3", "delegation", "meetings"),
+		tagged("4", "trust"),
+		tagged("5", "sleep"),
+		tagged("6", "sleep"),
+		tagged("7", "habits"),
+		{ID: "8"},
+	}
+	rels := []Relationship{
+		related("3", "7", 0.8),
+		related("7", "1", 0.7),
+		related("5", "6", 0.9),
+		related("1", "8", 0.9), // 8 has no tags, so this edge doesn't count
+	}
+	return insights, rels
+}
+
+func findRelatedTag(t *testing.T, related []RelatedTag, tag string) RelatedTag {
+	t.Helper()
+	for _, r := range related {
+		if r.Tag == tag {
+			return r
+		}
+	}
+	t.Fatalf("%s not among %+v", tag, related)
+	return RelatedTag{}
+}
+
+func TestRelatedTags_CoOccurrence(t *testing.T) {
+	insights, rels := tagMapFixture()
+
+	related, ok := RelatedTags("delegation", insights, rels)
+
+	if !ok {
+		t.Fatalf("delegation reported unknown")
+	}
+	trust := findRelatedTag(t, related, "trust")
+	// 7 tagged insights: delegation on 3, trust on 3, both on 2.
+	wantLift := (2.0 / 7) / ((3.0 / 7) * (3.0 / 7))
+	if trust.CoOccurrences != 2 || math.Abs(trust.CoOccurrenceLift-wantLift) > 1e-9 || math.Abs(trust.CoOccurrencePMI-math.Log2(wantLift)) > 1e-9 {
+		t.Fatalf("trust = %+v, want 2 co-occurrences at lift %v", trust, wantLift)
+	}
+	if trust.Edges != 0 || trust.EdgeLift != 0 {
+		t.Fatalf("trust = %+v, want no edges", trust)
+	}
+	for _, r := range related {
+		if r.Tag == "delegation" || r.Tag == "sleep" {
+			t.Fatalf("related = %+v, want neither the tag itself nor the unconnected sleep", related)
+		}
+	}
+}
+
+func TestRelatedTags_EdgesJoinTagsThatNeverShareAnInsight(t *testing.T) {
+	insights, rels := tagMapFixture()
+
+	related, _ := RelatedTags("delegation", insights, rels)
+
+	habits := findRelatedTag(t, related, "habits")
+	if habits.CoOccurrences != 0 || habits.Edges != 2 {
+		t.Fatalf("habits = %+v, want 2 edges and no shared insight", habits)
+	}
+	// 3 counted edges, 6 endpoints: delegation on 2 (3 and 1), habits on 2 (7 twice).
+	wantLift := (2.0 / 3) / (2 * (2.0 / 6) * (2.0 / 6))
+	if math.Abs(habits.EdgeLift-wantLift) > 1e-9 || habits.Score <= 0 {
+		t.Fatalf("habits = %+v, want edge lift %v and a positive score", habits, wantLift)
+	}
+	if related[0].Score < related[len(related)-1].Score {
+		t.Fatalf("related not ordered by score: %+v", related)
+	}
+}
+
+func TestRelatedTags_UnknownTag(t *testing.T) {
+	insights, rels := tagMapFixture()
+
+	if related, ok := RelatedTags("nope", insights, rels); ok || related != nil {
+		t.Fatalf("got %+v, %v; want unknown", related, ok)
+	}
+}
+
+func TestRelatedTags_EdgeCountsOncePerTag(t *testing.T) {
+	insights := []Insight{tagged("1", "a", "b"), tagged("2", "a", "b")}
+
+	related, _ := RelatedTags("a", insights, []Relationship{related("1", "2", 0.9)})
+
+	if b := findRelatedTag(t, related, "b"); b.Edges != 1 || b.Score != 1 {
+		t.Fatalf("b = %+v, want one edge and a perfect score", b)
+	}
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// TagTrendWindow is how far back trend detection looks: long enough
+	// for a handful of snapshots, short enough that last quarter's burst
+	// doesn't still read as trending.
+	TagTrendWindow = 28 * 24 * time.Hour
+
+	// minTagTrendPoints is how many points, the live score included, a
+	// slope needs before it means anything.
+	minTagTrendPoints = 3
+
+	// tagTrendThreshold is the slope, in score per week, past which a tag
+	// counts as trending or fading. Scores live in [0,1]; 0.02 a week is
+	// about a tenth of the range over TagTrendWindow. Picked, not
+	// measured.
+	tagTrendThreshold = 0.02
+)
+
+// MaxSnapshotTags caps how many tags a snapshot keeps, the highest-scored
+// first. A snapshot is stored as one item, and at worst a tag's entry is a
+// couple of hundred bytes: this keeps it well under DynamoDB's 400 KB item
+// limit. A tag below the cut has no point in that snapshot, which
+// TagHistory and the trend fit already treat as a gap.
+const MaxSnapshotTags = 1000
+
+// TagSnapshot is every tag's relevance in a tenant as of TakenAt, kept so
+// scores can be compared over time (TagRelevanceScore only knows now).
+// Tags only carries the fields ListTags scores; their Trend is unset.
+type TagSnapshot struct {
+	TenantID string
+	TakenAt  time.Time
+	// Relevance is the config Tags were scored under. The zero value,
+	// as in TenantSettings, means DefaultTagRelevanceConfig: snapshots
+	// taken before it was recorded were all scored under the defaults.
+	Relevance TagRelevanceConfig
+	Tags      []TagSummary
+}
+
+// ScoredUnder reports whether s's scores were weighed under config, and
+// so compare with scores weighed under it now: a change of weights or
+// half-life moves every score without anything having happened.
+func (s TagSnapshot) ScoredUnder(config TagRelevanceConfig) bool {
+	return s.Relevance.orDefault() == config.orDefault()
+}
+
+// TagHistoryPoint is one tag's relevance in one snapshot.
+type TagHistoryPoint struct {
+	TakenAt         time.Time
+	InsightCount    int
+	Score           float64
+	ScoreComponents TagScoreComponents
+}
+
+// TagTrend is the direction a tag's score has been moving over
+// TagTrendWindow. Trending and Fading are both false when the slope is
+// flat or there aren't minTagTrendPoints to fit one.
+type TagTrend struct {
+	// Slope is the least-squares change in score per week.
+	Slope    float64
+	Trending bool
+	Fading   bool
+}
+
+// TagHistory is tag's series across snapshots, oldest first, starting at
+// the first snapshot that carries it. A later snapshot without it is a
+// zero point — the tag had lost its last insight — rather than a gap.
+// Empty if no snapshot carries tag.
+func TagHistory(tag string, snapshots []TagSnapshot) []TagHistoryPoint {
+	return tagHistories(snapshots)[tag]
+}
+
+// tagHistories is TagHistory for every tag in snapshots at once.
+func tagHistories(snapshots []TagSnapshot) map[string][]TagHistoryPoint {
+	sorted := make([]TagSnapshot, len(snapshots))
+	copy(sorted, snapshots)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TakenAt.Before(sorted[j].TakenAt) })
+
+	histories := map[string][]TagHistoryPoint{}
+	for _, snapshot := range sorted {
+		seen := make(map[string]bool, len(snapshot.Tags))
+		for _, t := range snapshot.Tags {
+			seen[t.Tag] = true
+			histories[t.Tag] = append(histories[t.Tag], TagHistoryPoint{
+				TakenAt:         snapshot.TakenAt,
+				InsightCount:    t.InsightCount,
+				Score:           t.Score,
+				ScoreComponents: t.ScoreComponents,
+			})
+		}
+		for tag, points := range histories {
+			if !seen[tag] {
+				histories[tag] = append(points, TagHistoryPoint{TakenAt: snapshot.TakenAt})
+			}
+		}
+	}
+	return histories
+}
+
+// ComputeTagTrend fits a least-squares line through points' scores over
+// time.
+func ComputeTagTrend(points []TagHistoryPoint) TagTrend {
+	if len(points) < minTagTrendPoints {
+		return TagTrend{}
+	}
+
+	weeks := make([]float64, len(points))
+	var meanX, meanY float64
+	for i, p := range points {
+		weeks[i] = p.TakenAt.Sub(points[0].TakenAt).Hours() / (7 * 24)
+		meanX += weeks[i]
+		meanY += p.Score
+	}
+	meanX /= float64(len(points))
+	meanY /= float64(len(points))
+
+	var covariance, variance float64
+	for i, p := range points {
+		dx := weeks[i] - meanX
+		covariance += dx * (p.Score - meanY)
+		variance += dx * dx
+	}
+	if variance == 0 {
+		return TagTrend{}
+	}
+
+	slope := covariance / variance
+	return TagTrend{
+		Slope:    slope,
+		Trending: slope >= tagTrendThreshold,
+		Fading:   slope <= -tagTrendThreshold,
+	}
+}
+
+// AnnotateTagTrends sets each tag's Trend from its history in snapshots
+// — the caller's pick of the last TagTrendWindow — plus its current score
+// as the latest point, at now.
+func AnnotateTagTrends(tags []TagSummary, snapshots []TagSnapshot, now time.Time) {
+	histories := tagHistories(snapshots)
+	for i, t := range tags {
+		points := append(histories[t.Tag], TagHistoryPoint{
+			TakenAt:         now,
+			InsightCount:    t.InsightCount,
+			Score:           t.Score,
+			ScoreComponents: t.ScoreComponents,
+		})
+		tags[i].Trend = ComputeTagTrend(points)
+	}
+}
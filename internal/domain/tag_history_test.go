@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func snapshotAt(takenAt time.Time, scores map[string]float64) TagSnapshot {
+	snapshot := TagSnapshot{TenantID: "t-1", TakenAt: takenAt}
+	for tag, score := range scores {
+		snapshot.Tags = append(snapshot.Tags, TagSummary{Tag: tag, InsightCount: 1, Score: score})
+	}
+	return snapshot
+}
+
+func TestTagHistory_StartsAtFirstAppearanceAndZeroesGaps(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []TagSnapshot{
+		snapshotAt(day.AddDate(0, 0, 2), map[string]float64{"focus": 0.4}),
+		snapshotAt(day, map[string]float64{"habits": 0.2}),
+		snapshotAt(day.AddDate(0, 0, 1), map[string]float64{"focus": 0.3, "habits": 0.1}),
+	}
+
+	focus := TagHistory("focus", snapshots)
+
+	if len(focus) != 2 || !focus[0].TakenAt.Equal(day.AddDate(0, 0, 1)) || focus[1].Score != 0.4 {
+		t.Fatalf("focus = %+v, want its two snapshots, oldest first", focus)
+	}
+	habits := TagHistory("habits", snapshots)
+	if len(habits) != 3 || habits[2].Score != 0 || habits[2].InsightCount != 0 {
+		t.Fatalf("habits = %+v, want a zero point where it dropped out", habits)
+	}
+	if got := TagHistory("sleep", snapshots); len(got) != 0 {
+		t.Fatalf("sleep = %+v, want none", got)
+	}
+}
+
+func TestComputeTagTrend(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	series := func(scores ...float64) []TagHistoryPoint {
+		var points []TagHistoryPoint
+		for i, s := range scores {
+			points = append(points, TagHistoryPoint{TakenAt: start.Add(time.Duration(i) * week), Score: s})
+		}
+		return points
+	}
+
+	rising := ComputeTagTrend(series(0.1, 0.2, 0.3))
+	if !rising.Trending || rising.Fading || math.Abs(rising.Slope-0.1) > 1e-9 {
+		t.Fatalf("rising = %+v, want trending at 0.1/week", rising)
+	}
+	if falling := ComputeTagTrend(series(0.5, 0.4, 0.2)); !falling.Fading || falling.Trending {
+		t.Fatalf("falling = %+v, want fading", falling)
+	}
+	if flat := ComputeTagTrend(series(0.3, 0.31, 0.3)); flat.Trending || flat.Fading {
+		t.Fatalf("flat = %+v, want neither", flat)
+	}
+	if short := ComputeTagTrend(series(0.1, 0.9)); short != (TagTrend{}) {
+		t.Fatalf("two points = %+v, want no trend", short)
+	}
+}
+
+func TestAnnotateTagTrends_CountsTheLiveScore(t *testing.T) {
+	now := time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+	snapshots := []TagSnapshot{
+		snapshotAt(now.AddDate(0, 0, -14), map[string]float64{"focus": 0.2, "habits": 0.6}),
+		snapshotAt(now.AddDate(0, 0, -7), map[string]float64{"focus": 0.3, "habits": 0.5}),
+	}
+	tags := []TagSummary{{Tag: "focus", Score: 0.4}, {Tag: "habits", Score: 0.4}, {Tag: "sleep", Score: 0.9}}
+
+	AnnotateTagTrends(tags, snapshots, now)
+
+	if !tags[0].Trend.Trending || !tags[1].Trend.Fading {
+		t.Fatalf("trends = %+v, want focus trending and habits fading", tags)
+	}
+	if tags[2].Trend != (TagTrend{}) {
+		t.Fatalf("sleep has no history but got %+v", tags[2].Trend)
+	}
+}
@@ -16,11 +16,13 @@ type TagMembership struct {
 
 // TagSummary is a tenant's tag aggregated across its memberships: how many
 // insights carry it, when the most recent one was highlighted, and how
-// relevant that usage is overall (see TagRelevanceScore).
+// relevant that usage is overall (see TagRelevanceScore). Trend is only
+// set where past snapshots are at hand (see AnnotateTagTrends).
 type TagSummary struct {
 	Tag             string
 	InsightCount    int
 	LastInsightAt   time.Time
 	Score           float64
 	ScoreComponents TagScoreComponents
+	Trend           TagTrend
 }
@@ -1,10 +1,13 @@
 package domain
 
 import (
+	"errors"
 	"math"
 	"time"
 )
 
+// The constants below are DefaultTagRelevanceConfig: the tuning every
+// tenant gets until it sets its own (PUT /v1/settings/relevance).
 const (
 	// tagRelevanceCountWeight, tagRelevanceRecencyWeight and
 	// tagRelevanceFreshnessWeight split relevance between "how much has this
@@ -53,69 +56,159 @@ type TagScoreComponents struct {
 	Density   float64
 }
 
+// weightSumTolerance absorbs float rounding in a client's weights: 0.3 +
+// 0.35 + 0.35 isn't exactly 1.
+const weightSumTolerance = 1e-6
+
+var (
+	ErrRelevanceWeightsSum    = errors.New("count, recency and freshness weights must each be in [0,1] and sum to 1")
+	ErrRelevanceDensityWeight = errors.New("density weight must be in [0,1]")
+	ErrRelevanceHalfLife      = errors.New("half-life must be positive")
+	ErrRelevanceSaturation    = errors.New("count and density saturation must be positive")
+)
+
+// TagRelevanceConfig is how a tag's relevance is weighed: the split
+// between its components, how fast an insight's contribution decays, and
+// where count and density saturate. The zero value isn't a usable config;
+// TenantSettings reads it as "the defaults" (EffectiveTagRelevance).
+type TagRelevanceConfig struct {
+	CountWeight     float64
+	RecencyWeight   float64
+	FreshnessWeight float64
+	// DensityWeight is the slice of the score ScoreWithDensity hands to
+	// relationship density, as tagRelevanceDensityWeight.
+	DensityWeight     float64
+	HalfLife          time.Duration
+	CountSaturation   float64
+	DensitySaturation float64
+}
+
+func DefaultTagRelevanceConfig() TagRelevanceConfig {
+	return TagRelevanceConfig{
+		CountWeight:       tagRelevanceCountWeight,
+		RecencyWeight:     tagRelevanceRecencyWeight,
+		FreshnessWeight:   tagRelevanceFreshnessWeight,
+		DensityWeight:     tagRelevanceDensityWeight,
+		HalfLife:          tagRelevanceHalfLife,
+		CountSaturation:   tagRelevanceCountSaturation,
+		DensitySaturation: tagRelevanceDensitySaturation,
+	}
+}
+
+// orDefault is c, or DefaultTagRelevanceConfig if c is the zero value.
+func (c TagRelevanceConfig) orDefault() TagRelevanceConfig {
+	if c == (TagRelevanceConfig{}) {
+		return DefaultTagRelevanceConfig()
+	}
+	return c
+}
+
+// Validate keeps scores in [0,1]: the three base weights must sum to 1,
+// and the density weight is a share of the whole. Half-life and
+// saturations divide, so must be positive.
+func (c TagRelevanceConfig) Validate() error {
+	for _, w := range []float64{c.CountWeight, c.RecencyWeight, c.FreshnessWeight} {
+		if w < 0 || w > 1 {
+			return ErrRelevanceWeightsSum
+		}
+	}
+	if math.Abs(c.CountWeight+c.RecencyWeight+c.FreshnessWeight-1) > weightSumTolerance {
+		return ErrRelevanceWeightsSum
+	}
+	if c.DensityWeight < 0 || c.DensityWeight > 1 {
+		return ErrRelevanceDensityWeight
+	}
+	if c.HalfLife <= 0 {
+		return ErrRelevanceHalfLife
+	}
+	if c.CountSaturation <= 0 || c.DensitySaturation <= 0 {
+		return ErrRelevanceSaturation
+	}
+	return nil
+}
+
 // TagRelevanceScore ranks a tag by how active and current its usage is, not
-// just how many insights carry it. It is a pure function: given the
-// creation time of every insight tagged with it and the current time
-// (injected, never read from a clock), it deterministically returns a score
-// in [0,1] plus the component breakdown that produced it.
+// just how many insights carry it, under DefaultTagRelevanceConfig.
 func TagRelevanceScore(insightTimestamps []time.Time, now time.Time) (float64, TagScoreComponents) {
+	return DefaultTagRelevanceConfig().Score(insightTimestamps, now)
+}
+
+// TagRelevanceScoreWithDensity is TagRelevanceScore plus relationship
+// density, under DefaultTagRelevanceConfig.
+func TagRelevanceScoreWithDensity(insightTimestamps []time.Time, now time.Time, avgRelationshipsPerInsight float64) (float64, TagScoreComponents) {
+	return DefaultTagRelevanceConfig().ScoreWithDensity(insightTimestamps, now, avgRelationshipsPerInsight)
+}
+
+// Score is a pure function: given the creation time of every insight
+// tagged with it and the current time (injected, never read from a
+// clock), it deterministically returns a score in [0,1] plus the
+// component breakdown that produced it.
+func (c TagRelevanceConfig) Score(insightTimestamps []time.Time, now time.Time) (float64, TagScoreComponents) {
 	if len(insightTimestamps) == 0 {
 		return 0, TagScoreComponents{}
 	}
 
-	count := float64(len(insightTimestamps))
-	countComponent := count / (count + tagRelevanceCountSaturation)
-
 	mostRecent := insightTimestamps[0]
 	var freshnessSum float64
 	for _, ts := range insightTimestamps {
 		if ts.After(mostRecent) {
 			mostRecent = ts
 		}
-		freshnessSum += tagRelevanceDecay(now.Sub(ts))
+		freshnessSum += c.decay(now.Sub(ts))
 	}
+	return c.score(len(insightTimestamps), mostRecent, freshnessSum/float64(len(insightTimestamps)), now)
+}
 
+// score weighs the three base components, given a tag's insight count,
+// its most recent insight, and its insights' mean decay (Freshness).
+func (c TagRelevanceConfig) score(count int, mostRecent time.Time, freshness float64, now time.Time) (float64, TagScoreComponents) {
+	n := float64(count)
 	components := TagScoreComponents{
-		Count:     countComponent,
-		Recency:   tagRelevanceDecay(now.Sub(mostRecent)),
-		Freshness: freshnessSum / count,
+		Count:     n / (n + c.CountSaturation),
+		Recency:   c.decay(now.Sub(mostRecent)),
+		Freshness: freshness,
 	}
-	score := tagRelevanceCountWeight*components.Count +
-		tagRelevanceRecencyWeight*components.Recency +
-		tagRelevanceFreshnessWeight*components.Freshness
+	score := c.CountWeight*components.Count +
+		c.RecencyWeight*components.Recency +
+		c.FreshnessWeight*components.Freshness
 
 	return score, components
 }
 
-// tagRelevanceDecay is the exponential falloff applied to a single
-// insight's age: 1.0 when brand new, halving every tagRelevanceHalfLife.
-func tagRelevanceDecay(age time.Duration) float64 {
+// decay is the exponential falloff applied to a single insight's age: 1.0
+// when brand new, halving every HalfLife.
+func (c TagRelevanceConfig) decay(age time.Duration) float64 {
 	if age < 0 {
 		age = 0
 	}
-	return math.Exp(-math.Ln2 * age.Hours() / tagRelevanceHalfLife.Hours())
+	return math.Exp(-math.Ln2 * age.Hours() / c.HalfLife.Hours())
 }
 
-// TagRelevanceScoreWithDensity is TagRelevanceScore plus a relationship-
-// density component (REL 5/IPP-101): well-connected topics — insights with
-// relationships between them — rank higher, not just often- or recently-
-// used ones. avgRelationshipsPerInsight is the tag's insights' average
+// ScoreWithDensity is Score plus a relationship-density component (REL
+// 5/IPP-101): well-connected topics — insights with relationships between
+// them — rank higher, not just often- or recently-used ones.
+// avgRelationshipsPerInsight is the tag's insights' average
 // relationship-edge count (both directions), computed by the caller from
 // RelationshipRepository.
-func TagRelevanceScoreWithDensity(insightTimestamps []time.Time, now time.Time, avgRelationshipsPerInsight float64) (float64, TagScoreComponents) {
-	score, components := TagRelevanceScore(insightTimestamps, now)
+func (c TagRelevanceConfig) ScoreWithDensity(insightTimestamps []time.Time, now time.Time, avgRelationshipsPerInsight float64) (float64, TagScoreComponents) {
+	score, components := c.Score(insightTimestamps, now)
 	if len(insightTimestamps) == 0 {
 		return score, components
 	}
 
-	components.Density = tagRelevanceDensityComponent(avgRelationshipsPerInsight)
-	score = score*(1-tagRelevanceDensityWeight) + tagRelevanceDensityWeight*components.Density
+	return c.withDensity(score, components, avgRelationshipsPerInsight)
+}
+
+// withDensity hands DensityWeight of score to the density component.
+func (c TagRelevanceConfig) withDensity(score float64, components TagScoreComponents, avgRelationshipsPerInsight float64) (float64, TagScoreComponents) {
+	components.Density = c.densityComponent(avgRelationshipsPerInsight)
+	score = score*(1-c.DensityWeight) + c.DensityWeight*components.Density
 	return score, components
 }
 
-// tagRelevanceDensityComponent normalizes an average relationship count
-// into [0,1], the same diminishing-returns saturation curve
-// TagRelevanceScore's count component uses.
-func tagRelevanceDensityComponent(avgRelationshipsPerInsight float64) float64 {
-	return avgRelationshipsPerInsight / (avgRelationshipsPerInsight + tagRelevanceDensitySaturation)
+// densityComponent normalizes an average relationship count into [0,1],
+// the same diminishing-returns saturation curve Score's count component
+// uses.
+func (c TagRelevanceConfig) densityComponent(avgRelationshipsPerInsight float64) float64 {
+	return avgRelationshipsPerInsight / (avgRelationshipsPerInsight + c.DensitySaturation)
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -112,3 +113,47 @@ func TestTagRelevanceScoreWithDensity(t *testing.T) {
 		}
 	})
 }
+
+func TestTagRelevanceConfig_Validate(t *testing.T) {
+	if err := DefaultTagRelevanceConfig().Validate(); err != nil {
+		t.Fatalf("defaults: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		change func(*TagRelevanceConfig)
+		want   error
+	}{
+		"weights under 1":     {func(c *TagRelevanceConfig) { c.CountWeight = 0.1 }, ErrRelevanceWeightsSum},
+		"negative weight":     {func(c *TagRelevanceConfig) { c.CountWeight, c.RecencyWeight = -0.1, 0.75 }, ErrRelevanceWeightsSum},
+		"density weight > 1":  {func(c *TagRelevanceConfig) { c.DensityWeight = 1.5 }, ErrRelevanceDensityWeight},
+		"zero half-life":      {func(c *TagRelevanceConfig) { c.HalfLife = 0 }, ErrRelevanceHalfLife},
+		"zero saturation":     {func(c *TagRelevanceConfig) { c.DensitySaturation = 0 }, ErrRelevanceSaturation},
+		"count only":          {func(c *TagRelevanceConfig) { c.CountWeight, c.RecencyWeight, c.FreshnessWeight = 1, 0, 0 }, nil},
+		"rounded weights sum": {func(c *TagRelevanceConfig) { c.CountWeight, c.RecencyWeight, c.FreshnessWeight = 0.1, 0.2, 0.7 }, nil},
+	} {
+		c := DefaultTagRelevanceConfig()
+		tc.change(&c)
+		if err := c.Validate(); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: Validate = %v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestTagRelevanceConfig_Score_HalfLifeAndWeights(t *testing.T) {
+	now := time.Date(2026, 8, 4, 12, 0, 0, 0, time.UTC)
+	timestamps := []time.Time{now.Add(-14 * 24 * time.Hour)}
+
+	slow := DefaultTagRelevanceConfig()
+	slow.HalfLife = 30 * 24 * time.Hour
+	_, fast := DefaultTagRelevanceConfig().Score(timestamps, now)
+	_, slowComponents := slow.Score(timestamps, now)
+	if slowComponents.Recency <= fast.Recency {
+		t.Fatalf("recency with a 30-day half-life = %v, want above the default's %v", slowComponents.Recency, fast.Recency)
+	}
+
+	countOnly := TagRelevanceConfig{CountWeight: 1, HalfLife: time.Hour, CountSaturation: 5, DensitySaturation: 2}
+	score, components := countOnly.Score(timestamps, now)
+	if score != components.Count {
+		t.Fatalf("count-only score = %v, want the count component %v", score, components.Count)
+	}
+}
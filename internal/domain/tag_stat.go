@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// TagStat is a tag's materialized aggregate: everything ScoreStat needs
+// to rank the tag, kept up to date as memberships and relationship edges
+// are written, so ranking never reads the memberships themselves.
+type TagStat struct {
+	Tag               string
+	InsightCount      int
+	LastHighlightedAt time.Time
+	// HighlightedBuckets counts the tag's insights by the span of time
+	// they were highlighted in (TagStatBucketAt). Freshness only needs
+	// each insight's age, and a bucket's width is small next to that age:
+	// an hour while the insight is under a week old, a day until it's 90
+	// days old, a week after that, when it has all but decayed away.
+	HighlightedBuckets map[TagStatBucket]int
+	// ReviewedBuckets counts reviews of the tag's insights the same way
+	// (see ScoreStat).
+	ReviewedBuckets map[TagStatBucket]int
+	// DegreeSum is the relationship edges of the tag's insights, summed:
+	// an edge between two insights carrying the tag counts twice, as it
+	// does in each insight's degree.
+	DegreeSum int
+}
+
+const (
+	// tagStatHourlyFor and tagStatDailyFor are how old a bucket can start
+	// and still be an hour, or a day, wide; older ones fold into the day,
+	// or week, containing them. They bound a TagStat's buckets to a few
+	// hundred plus one per week of history.
+	tagStatHourlyFor = 7 * 24 * time.Hour
+	tagStatDailyFor  = 90 * 24 * time.Hour
+
+	tagStatDay  = 24 * time.Hour
+	tagStatWeek = 7 * tagStatDay
+)
+
+// TagStatBucket is a span of time HighlightedBuckets and ReviewedBuckets
+// count in: an hour, a day or a week, aligned to its width, so a wider
+// bucket always contains narrower ones whole.
+type TagStatBucket struct {
+	Start time.Time
+	Width time.Duration
+}
+
+// TagStatHour is the hour t falls in.
+func TagStatHour(t time.Time) TagStatBucket {
+	return TagStatBucket{Start: t.UTC().Truncate(time.Hour), Width: time.Hour}
+}
+
+// TagStatBucketAt is the bucket t falls in as of now: its hour, or the
+// day or week containing that hour once the hour or the day is old enough
+// (tagStatHourlyFor, tagStatDailyFor). Buckets only ever widen as now
+// moves on, so a count made into one bucket and taken back out of a
+// wider one later still cancels once FoldTagStatBuckets has merged them.
+func TagStatBucketAt(t, now time.Time) TagStatBucket {
+	bucket := TagStatHour(t)
+	if now.Sub(bucket.Start) < tagStatHourlyFor {
+		return bucket
+	}
+	bucket = TagStatBucket{Start: bucket.Start.Truncate(tagStatDay), Width: tagStatDay}
+	if now.Sub(bucket.Start) < tagStatDailyFor {
+		return bucket
+	}
+	return TagStatBucket{Start: bucket.Start.Truncate(tagStatWeek), Width: tagStatWeek}
+}
+
+// FoldTagStatBuckets merges each of buckets into the bucket its start
+// falls in as of now, dropping those left counting nothing.
+func FoldTagStatBuckets(buckets map[TagStatBucket]int, now time.Time) map[TagStatBucket]int {
+	folded := make(map[TagStatBucket]int, len(buckets))
+	for bucket, n := range buckets {
+		folded[bucket.FoldAt(now)] += n
+	}
+	for bucket, n := range folded {
+		if n == 0 {
+			delete(folded, bucket)
+		}
+	}
+	return folded
+}
+
+// FoldAt is the bucket b belongs in as of now: itself, or the wider one
+// containing it once it's old enough.
+func (b TagStatBucket) FoldAt(now time.Time) TagStatBucket {
+	if wider := TagStatBucketAt(b.Start, now); wider.Width > b.Width {
+		return wider
+	}
+	return b
+}
+
+func (b TagStatBucket) middle() time.Time {
+	return b.Start.Add(b.Width / 2)
+}
+
+// BuildTagStats aggregates each tag's memberships, with degree giving
+// each insight's relationship edge count and reviewed when it was
+// reviewed, sorted by tag. It's what the incremental updates maintain,
+// computed from scratch, except that a review counts toward the tags its
+// insight carries now rather than those it carried when reviewed. Every
+// bucket is an hour; FoldTagStatBuckets widens the old ones.
+func BuildTagStats(memberships map[string][]TagMembership, degree map[string]int, reviewed map[string][]time.Time) []TagStat {
+	stats := make([]TagStat, 0, len(memberships))
+	for tag, members := range memberships {
+		if len(members) == 0 {
+			continue
+		}
+		stat := TagStat{
+			Tag:                tag,
+			InsightCount:       len(members),
+			HighlightedBuckets: map[TagStatBucket]int{},
+			ReviewedBuckets:    map[TagStatBucket]int{},
+		}
+		for _, m := range members {
+			if m.HighlightedAt.After(stat.LastHighlightedAt) {
+				stat.LastHighlightedAt = m.HighlightedAt
+			}
+			stat.HighlightedBuckets[TagStatHour(m.HighlightedAt)]++
+			stat.DegreeSum += degree[m.InsightID]
+			for _, reviewedAt := range reviewed[m.InsightID] {
+				stat.ReviewedBuckets[TagStatHour(reviewedAt)]++
+			}
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Tag < stats[j].Tag })
+	return stats
+}
+
+// ScoreStat is ScoreWithDensity over stat instead of every insight's
+// timestamp. Each insight is aged from the middle of its bucket, which is
+// never more than half the bucket's width off; Recency still uses the
+// exact LastHighlightedAt.
+//
+// Reviews count as engagement: each adds its own decayed weight to
+// Freshness as a fresh highlight would, capped at 1, so a tag whose old
+// insights are still being reviewed doesn't read as stale.
+func (c TagRelevanceConfig) ScoreStat(stat TagStat, now time.Time) (float64, TagScoreComponents) {
+	if stat.InsightCount <= 0 {
+		return 0, TagScoreComponents{}
+	}
+
+	var bucketed int
+	var freshnessSum float64
+	for bucket, n := range stat.HighlightedBuckets {
+		if n <= 0 {
+			continue
+		}
+		bucketed += n
+		freshnessSum += float64(n) * c.decay(now.Sub(bucket.middle()))
+	}
+	for bucket, n := range stat.ReviewedBuckets {
+		if n > 0 {
+			freshnessSum += float64(n) * c.decay(now.Sub(bucket.middle()))
+		}
+	}
+	var freshness float64
+	if bucketed > 0 {
+		freshness = min(1, freshnessSum/float64(bucketed))
+	}
+
+	score, components := c.score(stat.InsightCount, stat.LastHighlightedAt, freshness, now)
+	return c.withDensity(score, components, float64(stat.DegreeSum)/float64(stat.InsightCount))
+}
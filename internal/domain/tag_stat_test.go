@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBuildTagStats(t *testing.T) {
+	t1 := time.Date(2026, 3, 1, 9, 15, 0, 0, time.UTC)
+	t2 := time.Date(2026, 3, 1, 9, 45, 0, 0, time.UTC)
+	t3 := time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)
+
+	stats := BuildTagStats(map[string][]TagMembership{
+		"sleep": {{InsightID: "c", HighlightedAt: t3}},
+		"focus": {{InsightID: "a", HighlightedAt: t1}, {InsightID: "b", HighlightedAt: t2}, {InsightID: "c", HighlightedAt: t3}},
+		"gone":  nil,
+	}, map[string]int{"a": 2, "c": 1}, map[string][]time.Time{"b": {t3}})
+
+	if len(stats) != 2 || stats[0].Tag != "focus" || stats[1].Tag != "sleep" {
+		t.Fatalf("stats = %+v, want focus then sleep, and nothing for a tag with no memberships", stats)
+	}
+	focus := stats[0]
+	if focus.InsightCount != 3 || !focus.LastHighlightedAt.Equal(t3) || focus.DegreeSum != 3 {
+		t.Fatalf("focus = %+v, want 3 insights, last %v, degree sum 3", focus, t3)
+	}
+	if focus.HighlightedBuckets[TagStatHour(t1)] != 2 || focus.HighlightedBuckets[TagStatHour(t3)] != 1 {
+		t.Fatalf("focus hours = %v, want two in 09:00 and one in 18:00", focus.HighlightedBuckets)
+	}
+	if len(focus.ReviewedBuckets) != 1 || focus.ReviewedBuckets[TagStatHour(t3)] != 1 || len(stats[1].ReviewedBuckets) != 0 {
+		t.Fatalf("reviewed hours = %v / %v, want b's review under focus only", focus.ReviewedBuckets, stats[1].ReviewedBuckets)
+	}
+}
+
+func TestTagStatBucketAt_WidensWithAge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		at   time.Time
+		want TagStatBucket
+	}{
+		"recent: its hour": {
+			at:   time.Date(2026, 3, 9, 7, 40, 0, 0, time.UTC),
+			want: TagStatBucket{Start: time.Date(2026, 3, 9, 7, 0, 0, 0, time.UTC), Width: time.Hour},
+		},
+		"over a week old: its day": {
+			at:   time.Date(2026, 2, 20, 7, 40, 0, 0, time.UTC),
+			want: TagStatBucket{Start: time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC), Width: 24 * time.Hour},
+		},
+		"over 90 days old: its week, from Monday": {
+			at:   time.Date(2025, 10, 2, 7, 40, 0, 0, time.UTC),
+			want: TagStatBucket{Start: time.Date(2025, 9, 29, 0, 0, 0, 0, time.UTC), Width: 7 * 24 * time.Hour},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := TagStatBucketAt(tc.at, now); got != tc.want {
+				t.Fatalf("TagStatBucketAt = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFoldTagStatBuckets_MergesOldBucketsAndCancels(t *testing.T) {
+	highlighted := time.Date(2026, 3, 1, 9, 15, 0, 0, time.UTC)
+	buckets := map[TagStatBucket]int{
+		// Counted in while the hour was recent, taken out once only its
+		// day was kept.
+		TagStatHour(highlighted): 2,
+		TagStatBucketAt(highlighted, highlighted.Add(10*24*time.Hour)): -1,
+		TagStatHour(highlighted.Add(-time.Hour)):                       1,
+	}
+
+	now := highlighted.Add(8 * 24 * time.Hour)
+	day := TagStatBucket{Start: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Width: 24 * time.Hour}
+	if got := FoldTagStatBuckets(buckets, now); len(got) != 1 || got[day] != 2 {
+		t.Fatalf("folded = %v, want both hours in their day, less the one taken out", got)
+	}
+
+	buckets[TagStatHour(highlighted.Add(-time.Hour))] = -1
+	if got := FoldTagStatBuckets(buckets, now); len(got) != 0 {
+		t.Fatalf("folded = %v, want nothing left of buckets that cancel", got)
+	}
+}
+
+func TestScoreStat_MatchesScoreWithDensity(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := DefaultTagRelevanceConfig()
+	members := []TagMembership{
+		{InsightID: "a", HighlightedAt: now.Add(-26 * time.Hour)},
+		{InsightID: "b", HighlightedAt: now.Add(-9 * 24 * time.Hour)},
+		{InsightID: "c", HighlightedAt: now.Add(-40 * 24 * time.Hour)},
+	}
+	var timestamps []time.Time
+	for _, m := range members {
+		timestamps = append(timestamps, m.HighlightedAt.Add(time.Hour/2))
+	}
+	stat := BuildTagStats(map[string][]TagMembership{"focus": members}, map[string]int{"a": 2, "b": 1}, nil)[0]
+
+	got, gotComponents := config.ScoreStat(stat, now)
+	want, wantComponents := config.ScoreWithDensity(timestamps, now, 1)
+
+	// Every timestamp sits on the hour, so the bucket midpoints are its
+	// timestamps shifted half an hour; only Recency sees the real ones.
+	if math.Abs(gotComponents.Freshness-wantComponents.Freshness) > 1e-9 || gotComponents.Density != wantComponents.Density {
+		t.Fatalf("components = %+v, want %+v", gotComponents, wantComponents)
+	}
+	if gotComponents.Recency >= wantComponents.Recency || math.Abs(got-want) > 0.01 {
+		t.Fatalf("score = %v (%+v), want close to %v", got, gotComponents, want)
+	}
+}
+
+func TestScoreStat_EmptyScoresZero(t *testing.T) {
+	score, components := DefaultTagRelevanceConfig().ScoreStat(TagStat{Tag: "gone", HighlightedBuckets: map[TagStatBucket]int{}}, time.Now())
+	if score != 0 || components != (TagScoreComponents{}) {
+		t.Fatalf("ScoreStat = %v %+v, want zero", score, components)
+	}
+}
+
+func TestScoreStat_ReviewsRaiseFreshness(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := DefaultTagRelevanceConfig()
+	old := now.Add(-60 * 24 * time.Hour)
+	stat := TagStat{InsightCount: 2, LastHighlightedAt: old, HighlightedBuckets: map[TagStatBucket]int{TagStatHour(old): 2}}
+
+	_, before := config.ScoreStat(stat, now)
+	stat.ReviewedBuckets = map[TagStatBucket]int{TagStatHour(now.Add(-time.Hour)): 1}
+	_, after := config.ScoreStat(stat, now)
+	if after.Freshness <= before.Freshness || after.Recency != before.Recency {
+		t.Fatalf("after review = %+v, before = %+v; want only Freshness up", after, before)
+	}
+
+	stat.ReviewedBuckets[TagStatHour(now)] = 5
+	if _, capped := config.ScoreStat(stat, now); capped.Freshness != 1 {
+		t.Fatalf("Freshness = %v, want capped at 1", capped.Freshness)
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// TenantSettings are the choices a tenant makes about its own library.
+// The zero value is every default.
+type TenantSettings struct {
+	TenantID string
+	// TagLanguage is the language enrichment writes every tag in, whatever
+	// the highlight's own language, so a bilingual library's tags meet in
+	// one vocabulary. LanguageUnknown means DefaultTagLanguage.
+	TagLanguage Language
+	// RelationshipPolicy decides which of the agent's edges are stored.
+	RelationshipPolicy RelationshipPolicy
+	// TagRelevance weighs tag relevance (ListTags); the zero value means
+	// DefaultTagRelevanceConfig.
+	TagRelevance TagRelevanceConfig
+	UpdatedAt    time.Time
+}
+
+// EffectiveTagLanguage is TagLanguage, or DefaultTagLanguage when unset.
+func (s TenantSettings) EffectiveTagLanguage() Language {
+	if s.TagLanguage == LanguageUnknown {
+		return DefaultTagLanguage
+	}
+	return s.TagLanguage
+}
+
+// EffectiveTagRelevance is TagRelevance, or DefaultTagRelevanceConfig when
+// unset.
+func (s TenantSettings) EffectiveTagRelevance() TagRelevanceConfig {
+	return s.TagRelevance.orDefault()
+}
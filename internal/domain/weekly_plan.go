@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"time"
 )
@@ -18,13 +19,46 @@ const (
 	PlanStatusFailed  PlanStatus = "failed"
 )
 
+// ActionStatus is how far the user has got with one Action of a ready
+// plan. Every action starts as ActionTodo; the user moves it freely
+// between all four.
+type ActionStatus string
+
+const (
+	ActionTodo       ActionStatus = "todo"
+	ActionInProgress ActionStatus = "in_progress"
+	ActionDone       ActionStatus = "done"
+	ActionSkipped    ActionStatus = "skipped"
+)
+
+func (s ActionStatus) Valid() bool {
+	switch s {
+	case ActionTodo, ActionInProgress, ActionDone, ActionSkipped:
+		return true
+	}
+	return false
+}
+
 // maxFocusSentenceLength keeps the focus submission to one sentence, not an
 // essay, per IPP-103's acceptance criteria.
 const maxFocusSentenceLength = 280
 
+// maxReflectionLength bounds an action's reflection note: a few
+// sentences on how it went, not a journal entry.
+const maxReflectionLength = 2000
+
 var (
 	ErrEmptyFocusSentence   = errors.New("focus sentence is required")
 	ErrFocusSentenceTooLong = errors.New("focus sentence too long")
+
+	ErrUnknownActionStatus = errors.New("status must be one of todo, in_progress, done, skipped")
+	ErrReflectionTooLong   = errors.New("reflection too long")
+	ErrEmptyActionUpdate   = errors.New("status or reflection is required")
+	// ErrActionNotFound is an action index outside the plan's actions.
+	ErrActionNotFound = errors.New("action not found")
+	// ErrPlanNotReady is progress reported on a plan that has no actions
+	// to track yet, or never will.
+	ErrPlanNotReady = errors.New("weekly plan is not ready")
 )
 
 // WeeklyPlan is a user's request to plan their week from a tag and a focus
@@ -42,6 +76,9 @@ type WeeklyPlan struct {
 	FocusSentence string
 	Status        PlanStatus
 	CreatedAt     time.Time
+	// ResolvedAt is when the plan became ready or failed; zero while it's
+	// pending, and on plans resolved before it was recorded.
+	ResolvedAt    time.Time
 	Actions       []Action
 	FailureReason string
 }
@@ -50,10 +87,107 @@ type WeeklyPlan struct {
 // SupportingInsightIDs already passed PLAN 3's hallucination check
 // (services/ai/application/action_generation.py) before this ever reaches
 // SetReady — this type stores that verified claim, it doesn't re-verify it.
+//
+// Status and the fields after it are the user's progress on the action,
+// never the agent's: SetReady stores them as ActionTodo and zero.
 type Action struct {
 	Title                string
 	Why                  string
 	SupportingInsightIDs []string
+	Status               ActionStatus
+	// StartedAt is when the action first left ActionTodo; CompletedAt when
+	// it last became ActionDone or ActionSkipped, zero while it's open.
+	StartedAt   time.Time
+	CompletedAt time.Time
+	// Reflection is the user's optional note on how it went.
+	Reflection string
+}
+
+// ActionUpdate is a change to one action's progress; a nil field is left
+// as it is. An empty Reflection clears it.
+type ActionUpdate struct {
+	Status     *ActionStatus
+	Reflection *string
+}
+
+// Validate checks update on its own, before the plan is loaded.
+func (u ActionUpdate) Validate() error {
+	if u.Status == nil && u.Reflection == nil {
+		return ErrEmptyActionUpdate
+	}
+	if u.Status != nil && !u.Status.Valid() {
+		return ErrUnknownActionStatus
+	}
+	if u.Reflection != nil && len(*u.Reflection) > maxReflectionLength {
+		return ErrReflectionTooLong
+	}
+	return nil
+}
+
+// Apply returns a with update made at now. Reopening an action clears
+// CompletedAt but keeps StartedAt; moving it back to ActionTodo clears
+// both.
+func (a Action) Apply(update ActionUpdate, now time.Time) Action {
+	if update.Reflection != nil {
+		a.Reflection = strings.TrimSpace(*update.Reflection)
+	}
+	if update.Status == nil || *update.Status == a.Status {
+		return a
+	}
+
+	a.Status = *update.Status
+	switch a.Status {
+	case ActionTodo:
+		a.StartedAt, a.CompletedAt = time.Time{}, time.Time{}
+	case ActionInProgress:
+		a.CompletedAt = time.Time{}
+	case ActionDone, ActionSkipped:
+		a.CompletedAt = now
+	}
+	if a.Status != ActionTodo && a.StartedAt.IsZero() {
+		a.StartedAt = now
+	}
+	return a
+}
+
+// UpdateAction applies update to the plan's action at index, returning
+// the action before and after.
+func (p WeeklyPlan) UpdateAction(index int, update ActionUpdate, now time.Time) (before, after Action, err error) {
+	if err := update.Validate(); err != nil {
+		return Action{}, Action{}, err
+	}
+	if p.Status != PlanStatusReady {
+		return Action{}, Action{}, ErrPlanNotReady
+	}
+	if index < 0 || index >= len(p.Actions) {
+		return Action{}, Action{}, ErrActionNotFound
+	}
+	before = p.Actions[index]
+	return before, before.Apply(update, now), nil
+}
+
+// CompletionPercent is the share of the plan's actions done, rounded, out
+// of those not skipped: skipping an action takes it off the plan rather
+// than counting against it. A plan whose actions were all skipped is
+// complete; one without actions is at 0.
+func (p WeeklyPlan) CompletionPercent() int {
+	var done, skipped int
+	for _, a := range p.Actions {
+		switch a.Status {
+		case ActionDone:
+			done++
+		case ActionSkipped:
+			skipped++
+		}
+	}
+	if len(p.Actions) == 0 {
+		return 0
+	}
+	remaining := len(p.Actions) - skipped
+	if remaining == 0 {
+		return 100
+	}
+	return int(math.Round(100 * float64(done) / float64(remaining)))
 }
 
 // ResolvedInsight is one of an Action's supporting insights, resolved to a
@@ -72,6 +206,10 @@ type ResolvedAction struct {
 	Title              string
 	Why                string
 	SupportingInsights []ResolvedInsight
+	Status             ActionStatus
+	StartedAt          time.Time
+	CompletedAt        time.Time
+	Reflection         string
 }
 
 // PlanDetail is a WeeklyPlan with its actions' citations resolved — the
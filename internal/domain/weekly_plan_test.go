@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestWeeklyPlan_Validate_HappyPath(t *testing.T) {
@@ -26,3 +27,103 @@ func TestWeeklyPlan_Validate_OversizedFocusSentence_Rejected(t *testing.T) {
 		t.Fatalf("Validate err = %v, want ErrFocusSentenceTooLong", err)
 	}
 }
+
+func statusPtr(s ActionStatus) *ActionStatus { return &s }
+
+func TestAction_Apply_Timestamps(t *testing.T) {
+	t0 := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	a := Action{Title: "Block 9-11", Status: ActionTodo}
+
+	a = a.Apply(ActionUpdate{Status: statusPtr(ActionInProgress)}, t0)
+	if !a.StartedAt.Equal(t0) || !a.CompletedAt.IsZero() {
+		t.Fatalf("in progress = %+v, want started at t0", a)
+	}
+	a = a.Apply(ActionUpdate{Status: statusPtr(ActionDone)}, t0.Add(time.Hour))
+	if !a.StartedAt.Equal(t0) || !a.CompletedAt.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("done = %+v, want started at t0, completed an hour later", a)
+	}
+	// Re-sending the same status changes nothing.
+	if again := a.Apply(ActionUpdate{Status: statusPtr(ActionDone)}, t0.Add(2*time.Hour)); !again.CompletedAt.Equal(a.CompletedAt) {
+		t.Fatalf("repeat done moved CompletedAt to %v", again.CompletedAt)
+	}
+	a = a.Apply(ActionUpdate{Status: statusPtr(ActionInProgress)}, t0.Add(2*time.Hour))
+	if !a.StartedAt.Equal(t0) || !a.CompletedAt.IsZero() {
+		t.Fatalf("reopened = %+v, want StartedAt kept, CompletedAt cleared", a)
+	}
+	a = a.Apply(ActionUpdate{Status: statusPtr(ActionTodo)}, t0.Add(3*time.Hour))
+	if !a.StartedAt.IsZero() || !a.CompletedAt.IsZero() {
+		t.Fatalf("back to todo = %+v, want no timestamps", a)
+	}
+
+	skipped := Action{Status: ActionTodo}.Apply(ActionUpdate{Status: statusPtr(ActionSkipped)}, t0)
+	if !skipped.StartedAt.Equal(t0) || !skipped.CompletedAt.Equal(t0) {
+		t.Fatalf("skipped = %+v, want started and completed at t0", skipped)
+	}
+}
+
+func TestAction_Apply_Reflection(t *testing.T) {
+	note, empty := "  Mornings worked.  ", ""
+	a := Action{Status: ActionDone}.Apply(ActionUpdate{Reflection: &note}, time.Now())
+	if a.Reflection != "Mornings worked." || a.Status != ActionDone {
+		t.Fatalf("a = %+v, want the note trimmed and status untouched", a)
+	}
+	if a = a.Apply(ActionUpdate{Reflection: &empty}, time.Now()); a.Reflection != "" {
+		t.Fatalf("reflection = %q, want cleared", a.Reflection)
+	}
+}
+
+func TestWeeklyPlan_UpdateAction_Rejections(t *testing.T) {
+	ready := WeeklyPlan{Status: PlanStatusReady, Actions: []Action{{Status: ActionTodo}}}
+	long := strings.Repeat("a", maxReflectionLength+1)
+	cases := []struct {
+		name   string
+		plan   WeeklyPlan
+		index  int
+		update ActionUpdate
+		want   error
+	}{
+		{"empty update", ready, 0, ActionUpdate{}, ErrEmptyActionUpdate},
+		{"unknown status", ready, 0, ActionUpdate{Status: statusPtr("finished")}, ErrUnknownActionStatus},
+		{"long reflection", ready, 0, ActionUpdate{Reflection: &long}, ErrReflectionTooLong},
+		{"pending plan", WeeklyPlan{Status: PlanStatusPending}, 0, ActionUpdate{Status: statusPtr(ActionDone)}, ErrPlanNotReady},
+		{"index past the end", ready, 1, ActionUpdate{Status: statusPtr(ActionDone)}, ErrActionNotFound},
+		{"negative index", ready, -1, ActionUpdate{Status: statusPtr(ActionDone)}, ErrActionNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := tc.plan.UpdateAction(tc.index, tc.update, time.Now()); !errors.Is(err, tc.want) {
+				t.Fatalf("err = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	before, after, err := ready.UpdateAction(0, ActionUpdate{Status: statusPtr(ActionDone)}, time.Now())
+	if err != nil || before.Status != ActionTodo || after.Status != ActionDone {
+		t.Fatalf("UpdateAction = %+v, %+v, %v", before, after, err)
+	}
+}
+
+func TestWeeklyPlan_CompletionPercent(t *testing.T) {
+	plan := func(statuses ...ActionStatus) WeeklyPlan {
+		p := WeeklyPlan{Status: PlanStatusReady}
+		for _, s := range statuses {
+			p.Actions = append(p.Actions, Action{Status: s})
+		}
+		return p
+	}
+	cases := []struct {
+		plan WeeklyPlan
+		want int
+	}{
+		{plan(), 0},
+		{plan(ActionTodo, ActionInProgress, ActionDone), 33},
+		{plan(ActionDone, ActionDone, ActionSkipped), 100},
+		{plan(ActionDone, ActionTodo, ActionSkipped, ActionSkipped, ActionTodo), 33},
+		{plan(ActionSkipped, ActionSkipped), 100},
+	}
+	for _, tc := range cases {
+		if got := tc.plan.CompletionPercent(); got != tc.want {
+			t.Fatalf("CompletionPercent(%+v) = %d, want %d", tc.plan.Actions, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBudgetExhausted is returned by Budget.Check once a tenant has reached
+// any of its LLM limits for the current day or month.
+var ErrBudgetExhausted = errors.New("llm budget exhausted")
+
+// Budget gates LLM calls per tenant. llm.Service checks it before calling
+// the client and records what the call reported afterwards.
+type Budget interface {
+	// Check returns ErrBudgetExhausted if tenantID may not make another
+	// call now.
+	Check(ctx context.Context, tenantID string) error
+
+	// Record counts one billed call, whether or not its answer was usable,
+	// from the tokens it used. A batch call is one call.
+	Record(ctx context.Context, tenantID string, inputTokens, outputTokens int64) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// ErrContradictionNotFound is returned when a user resolves or reopens a
+// pair of insights with no contradicts edge between them.
+var ErrContradictionNotFound = errors.New("contradiction not found")
+
+type ContradictionResolutionRepository interface {
+	// SaveContradictionResolution records resolution, replacing an earlier
+	// one for the same pair.
+	SaveContradictionResolution(ctx context.Context, resolution domain.ContradictionResolution) error
+
+	// DeleteContradictionResolution reopens the pair. Deleting a
+	// resolution that doesn't exist is a no-op.
+	DeleteContradictionResolution(ctx context.Context, tenantID, insightID, otherInsightID string) error
+
+	// ListContradictionResolutions returns every resolution in tenantID.
+	ListContradictionResolutions(ctx context.Context, tenantID string) ([]domain.ContradictionResolution, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type DigestRepository interface {
+	// ListInsightsIngestedSince returns tenantID's insights stored after
+	// since, by when they were ingested rather than HighlightedAt, which
+	// domain.Insight doesn't carry.
+	ListInsightsIngestedSince(ctx context.Context, tenantID string, since time.Time) ([]domain.Insight, error)
+
+	// SaveDigest stores digest, to be dropped after expiresAt.
+	SaveDigest(ctx context.Context, digest domain.Digest, expiresAt time.Time) error
+
+	// ListDigests returns up to limit of tenantID's unexpired digests,
+	// newest first.
+	ListDigests(ctx context.Context, tenantID string, limit int) ([]domain.Digest, error)
+
+	// LastNotifiedAt returns when tenantID's latest notified digest of
+	// period was generated; ok is false if none was.
+	LastNotifiedAt(ctx context.Context, tenantID string, period domain.DigestPeriod) (at time.Time, ok bool, err error)
+}
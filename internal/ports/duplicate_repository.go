@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// DuplicateRepository persists what duplicate detection decides: merged
+// sources on the surviving insight, and flagged pairs for review.
+type DuplicateRepository interface {
+	// AddMergedSource records ref on insightID. Adding a ref whose
+	// InsightID is already recorded is a no-op, so redelivery is safe.
+	AddMergedSource(ctx context.Context, tenantID, insightID string, ref domain.SourceRef) error
+
+	// SaveDuplicate records a flagged pair, replacing an earlier flag for
+	// the same InsightID.
+	SaveDuplicate(ctx context.Context, duplicate domain.Duplicate) error
+
+	// ListDuplicates returns tenantID's flagged pairs, most recent first.
+	ListDuplicates(ctx context.Context, tenantID string) ([]domain.Duplicate, error)
+}
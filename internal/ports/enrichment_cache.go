@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// EnrichmentCache keeps a tenant's LLM enrichments by content key (see
+// llm.Service.WithCache), so a passage that arrives twice is only paid for
+// once.
+type EnrichmentCache interface {
+	// GetCachedEnrichment returns key's enrichment and true, or false if
+	// there is none or it has expired.
+	GetCachedEnrichment(ctx context.Context, tenantID, key string) (domain.Enrichment, bool, error)
+
+	// PutCachedEnrichment stores enrichment under key until expiresAt,
+	// replacing whatever was there.
+	PutCachedEnrichment(ctx context.Context, tenantID, key string, enrichment domain.Enrichment, expiresAt time.Time) error
+}
@@ -2,10 +2,52 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 )
 
+// EnrichmentClient makes one LLM call for one piece of text. Alongside the
+// tags it fills in the call's provenance (Model, PromptVersion, token usage,
+// Latency); EnrichedAt is left to the caller, which owns the write it dates.
+// A call that was answered but failed anyway returns a *BilledError.
 type EnrichmentClient interface {
-	Enrich(ctx context.Context, text string) (domain.Enrichment, error)
+	// Enrich writes the tags in tagLanguage, whatever text's own language;
+	// LanguageUnknown means domain.DefaultTagLanguage.
+	Enrich(ctx context.Context, text string, tagLanguage domain.Language) (domain.Enrichment, error)
+
+	// Version names the model and prompt version Enrich answers with. Two
+	// calls for the same text under the same Version are interchangeable;
+	// the enrichment cache keys on it.
+	Version() string
+}
+
+// BilledError is a client failure on a call the provider did answer, and
+// so billed: an answer that didn't parse, or didn't map back onto its
+// inputs. It carries what the call cost, so the budget can count it like
+// any other.
+type BilledError struct {
+	InputTokens  int64
+	OutputTokens int64
+	Err          error
+}
+
+func (e *BilledError) Error() string { return e.Err.Error() }
+func (e *BilledError) Unwrap() error { return e.Err }
+
+// ErrMalformedBatch is a BatchEnrichmentClient answer that doesn't map
+// back onto its inputs one to one: an index missing, repeated or out of
+// range. The provider did answer, so it says nothing about availability.
+var ErrMalformedBatch = errors.New("malformed batch enrichment response")
+
+// BatchEnrichmentClient is an EnrichmentClient that can also enrich several
+// texts in one call. It's optional: llm.Service uses it when the client has
+// it and calls Enrich per text otherwise.
+type BatchEnrichmentClient interface {
+	EnrichmentClient
+
+	// EnrichBatch returns one enrichment per text, in texts' order. The
+	// call's token usage is split across them; each carries the whole
+	// call's Latency.
+	EnrichBatch(ctx context.Context, texts []string, tagLanguage domain.Language) ([]domain.Enrichment, error)
 }
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type GraphAnalyticsRepository interface {
+	// GetGraphAnalytics returns tenantID's last computed analytics, or
+	// found=false if they've never been computed.
+	GetGraphAnalytics(ctx context.Context, tenantID string) (analytics domain.GraphAnalytics, found bool, err error)
+
+	// SaveGraphAnalytics replaces analytics.TenantID's analytics.
+	SaveGraphAnalytics(ctx context.Context, analytics domain.GraphAnalytics) error
+}
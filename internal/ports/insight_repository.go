@@ -8,6 +8,9 @@ import (
 
 type InsightRepository interface {
 	CreateIfAbsent(ctx context.Context, insight domain.Insight) (inserted bool, err error)
+	// GetByID returns one insight; found is false if tenantID has none
+	// with that ID.
+	GetByID(ctx context.Context, tenantID, insightID string) (insight domain.Insight, found bool, err error)
 	Update(ctx context.Context, insight domain.Insight) error
 	ListByTenantID(ctx context.Context, tenantID, tag string) ([]domain.Insight, error)
 	ListByTag(ctx context.Context, tenantID, tag string) ([]domain.TagMembership, error)
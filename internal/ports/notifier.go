@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// Notifier delivers a digest to its reader, after it's been stored.
+type Notifier interface {
+	Notify(ctx context.Context, digest domain.Digest) error
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// ErrReenrichJobNotFound is returned by GetReenrichJob for a tenant/jobID
+// pair with no stored job.
+var ErrReenrichJobNotFound = errors.New("reenrich job not found")
+
+// ReenrichJobRepository persists application/reenrich's jobs and their
+// progress. Method names carry the "ReenrichJob" noun for the same reason
+// WeeklyPlanRepository.ListPlansByTenantID does: *InsightAdapter
+// implements every repository port, in one method namespace.
+type ReenrichJobRepository interface {
+	CreateReenrichJob(ctx context.Context, job domain.ReenrichJob) error
+
+	// GetReenrichJob loads one tenant's job by id, or ErrReenrichJobNotFound.
+	GetReenrichJob(ctx context.Context, tenantID, jobID string) (domain.ReenrichJob, error)
+
+	// ListPendingReenrichJobs returns tenantID's not-yet-done jobs, oldest
+	// first, so they're worked through in the order they were requested.
+	ListPendingReenrichJobs(ctx context.Context, tenantID string) ([]domain.ReenrichJob, error)
+
+	// SaveReenrichJobProgress overwrites job's cursor, counters and status.
+	SaveReenrichJobProgress(ctx context.Context, job domain.ReenrichJob) error
+}
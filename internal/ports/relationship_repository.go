@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
 )
@@ -11,6 +12,10 @@ import (
 // side of the edge doesn't exist in rel.TenantID's partition.
 var ErrInsightNotFound = errors.New("insight not found")
 
+// ErrRelationshipNotFound is returned when there's no edge between the
+// two insights a user tries to correct or remove.
+var ErrRelationshipNotFound = errors.New("relationship not found")
+
 type RelationshipRepository interface {
 	// Put stores rel as a bidirectional edge, upserting on
 	// (FromInsightID, ToInsightID) so re-posting the same edge updates it
@@ -21,4 +26,42 @@ type RelationshipRepository interface {
 	// descending, regardless of which side they were originally
 	// discovered from.
 	ListByInsightID(ctx context.Context, tenantID, insightID string) ([]domain.RelatedInsight, error)
+
+	// ListRelationships returns every edge in tenantID once, in its
+	// original direction.
+	ListRelationships(ctx context.Context, tenantID string) ([]domain.Relationship, error)
+
+	// GetRelationship returns the edge between insightID and
+	// otherInsightID in its original direction, whichever side it's asked
+	// from. found is false if there is none.
+	GetRelationship(ctx context.Context, tenantID, insightID, otherInsightID string) (rel domain.Relationship, found bool, err error)
+
+	// DeleteRelationship removes the edge between insightID and
+	// otherInsightID, whichever direction it was discovered in. Deleting
+	// an edge that doesn't exist is a no-op.
+	DeleteRelationship(ctx context.Context, tenantID, insightID, otherInsightID string) error
+}
+
+// RejectedRelationshipLog keeps the edges relationship policy turned away
+// or evicted, for the agent to read back.
+type RejectedRelationshipLog interface {
+	// RecordRejectedRelationship appends rejected, to be dropped after
+	// expiresAt.
+	RecordRejectedRelationship(ctx context.Context, rejected domain.RejectedRelationship, expiresAt time.Time) error
+
+	// ListRejectedRelationships returns tenantID's unexpired entries, most
+	// recent first.
+	ListRejectedRelationships(ctx context.Context, tenantID string) ([]domain.RejectedRelationship, error)
+}
+
+// RelationshipFeedbackRepository keeps users' verdicts on pairs of
+// insights (see domain.RelationshipFeedback).
+type RelationshipFeedbackRepository interface {
+	// SaveRelationshipFeedback records feedback for the pair, replacing an
+	// earlier verdict on it, readable from either insight's side.
+	SaveRelationshipFeedback(ctx context.Context, feedback domain.RelationshipFeedback) error
+
+	// GetRelationshipFeedback returns the verdict on the pair, from
+	// insightID's side. found is false if the user never ruled on it.
+	GetRelationshipFeedback(ctx context.Context, tenantID, insightID, otherInsightID string) (feedback domain.RelationshipFeedback, found bool, err error)
 }
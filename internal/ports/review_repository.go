@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// ErrReviewConflict is SaveReview finding the insight reviewed again since
+// the schedule it advanced was read.
+var ErrReviewConflict = errors.New("insight was reviewed concurrently")
+
+type ReviewRepository interface {
+	// GetReviewSchedule reports false for an insight never reviewed.
+	GetReviewSchedule(ctx context.Context, tenantID, insightID string) (domain.ReviewSchedule, bool, error)
+
+	// ListReviewSchedules returns every schedule in tenantID.
+	ListReviewSchedules(ctx context.Context, tenantID string) ([]domain.ReviewSchedule, error)
+
+	// ListReviewHistory returns when each insight in tenantID was
+	// reviewed, every review SaveReview recorded, by insight ID.
+	ListReviewHistory(ctx context.Context, tenantID string) (map[string][]time.Time, error)
+
+	// SaveReview stores schedule, replacing the insight's earlier one, and
+	// counts the review it records (at LastReviewedAt) toward the
+	// freshness of each of tags, the insight's tags. It returns
+	// ErrReviewConflict, having written nothing, unless the stored
+	// schedule is still the one schedule follows (schedule.Reviews - 1
+	// reviews, or none for a first review).
+	SaveReview(ctx context.Context, schedule domain.ReviewSchedule, tags []string) error
+}
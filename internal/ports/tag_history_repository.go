@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type TagHistoryRepository interface {
+	// SaveTagSnapshot stores snapshot, to be dropped after expiresAt. A
+	// second snapshot at the same TakenAt replaces the first.
+	SaveTagSnapshot(ctx context.Context, snapshot domain.TagSnapshot, expiresAt time.Time) error
+
+	// ListTagSnapshots returns tenantID's snapshots taken at or after
+	// since, oldest first — only those scored under its current tag
+	// relevance config (domain.TagSnapshot.ScoredUnder), so a trend or a
+	// digest's tag moves never measure a config change as a change in
+	// the tags. A new config starts the history afresh.
+	ListTagSnapshots(ctx context.Context, tenantID string, since time.Time) ([]domain.TagSnapshot, error)
+}
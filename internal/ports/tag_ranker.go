@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// TagRanker ranks a tenant's tags as InsightRepository.ListTags does, but
+// under config rather than the tenant's saved one — to preview a change
+// before it's saved.
+type TagRanker interface {
+	RankTags(ctx context.Context, tenantID string, config domain.TagRelevanceConfig) ([]domain.TagSummary, error)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// TagStatRepository reads what a tenant's domain.TagStat aggregates are
+// derived from, and replaces the aggregates wholesale. InsightRepository
+// and RelationshipRepository keep them up to date as they write; this is
+// for rebuilding them when they've drifted or predate those writes.
+type TagStatRepository interface {
+	// ListAllTagMemberships returns every tag in tenantID with its
+	// memberships.
+	ListAllTagMemberships(ctx context.Context, tenantID string) (map[string][]domain.TagMembership, error)
+
+	// RelationshipDegrees returns each insight's relationship edge count,
+	// both directions; insights with none are absent.
+	RelationshipDegrees(ctx context.Context, tenantID string) (map[string]int, error)
+
+	// ReplaceTagStats makes stats tenantID's aggregates, dropping those of
+	// any tag not among them.
+	ReplaceTagStats(ctx context.Context, tenantID string, stats []domain.TagStat) error
+}
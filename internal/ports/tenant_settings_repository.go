@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+type TenantSettingsRepository interface {
+	// GetTenantSettings returns tenantID's settings, or the defaults (the
+	// zero value, TenantID set) if it has never saved any.
+	GetTenantSettings(ctx context.Context, tenantID string) (domain.TenantSettings, error)
+
+	// SaveTenantSettings replaces settings.TenantID's settings.
+	SaveTenantSettings(ctx context.Context, settings domain.TenantSettings) error
+}
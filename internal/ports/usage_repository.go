@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcogerstmann/insight-processing-platform/internal/domain"
+)
+
+// UsageRepository keeps per-tenant LLM usage counters, one per UTC day and
+// one per month. Method names carry "LLMUsage" for the same reason
+// WeeklyPlanRepository.ListPlansByTenantID does.
+type UsageRepository interface {
+	// AddLLMUsage adds usage to both of at's counters, creating them on
+	// first use.
+	AddLLMUsage(ctx context.Context, tenantID string, at time.Time, usage domain.LLMUsage) error
+
+	// GetLLMUsage reads at's day and month counters; a period with no
+	// calls yet reads as zero.
+	GetLLMUsage(ctx context.Context, tenantID string, at time.Time) (day, month domain.LLMUsage, err error)
+}
@@ -9,7 +9,8 @@ import (
 
 var (
 	// ErrUnknownTag is returned by WeeklyPlanRepository.Create when plan.Tag
-	// doesn't exist in plan.TenantID's partition.
+	// doesn't exist in plan.TenantID's partition, and by tagmap.Service
+	// for a tag no insight carries.
 	ErrUnknownTag = errors.New("unknown tag")
 
 	// ErrPlanNotFound is returned by Get for a tenant/planID pair with no
@@ -23,6 +24,10 @@ var (
 	// the same conditional write PLAN 5 (IPP-107) will lean on for
 	// idempotency under redelivery.
 	ErrPlanNotPending = errors.New("weekly plan is not pending")
+
+	// ErrActionChanged is returned by UpdateAction when the action's
+	// progress moved on after the caller read it.
+	ErrActionChanged = errors.New("action changed since it was read")
 )
 
 type WeeklyPlanRepository interface {
@@ -44,4 +49,10 @@ type WeeklyPlanRepository interface {
 	// SetFailed conditionally transitions a pending plan to failed with a
 	// human-readable reason, or returns ErrPlanNotPending.
 	SetFailed(ctx context.Context, tenantID, planID, reason string) error
+
+	// UpdateAction replaces the ready plan's action at index, which must
+	// be within its actions, with after, if it's still before as the
+	// caller read it. It returns domain.ErrPlanNotReady if the plan is
+	// missing or not ready, or ErrActionChanged if the action isn't before.
+	UpdateAction(ctx context.Context, tenantID, planID string, index int, before, after domain.Action) error
 }
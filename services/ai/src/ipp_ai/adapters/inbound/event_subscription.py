@@ -94,8 +94,8 @@ class Handler:
     (ADR-009's taxonomy, translated).
 
     Safe as a per-record loop only because the event source mapping in
-    terraform/envs/dev/ai.tf keeps batch_size = 1 — same constraint as the
-    Go worker; raising it needs ReportBatchItemFailures first.
+    terraform/envs/dev/ai.tf keeps batch_size = 1. Raising it needs
+    ReportBatchItemFailures first, as the Go worker's did (ADR-021).
     """
 
     def __init__(
@@ -146,6 +146,7 @@ class Handler:
                     insight_reader=self._reader,
                     embedding_reader=self._embedding_reader,
                     labeler=self._labeler,
+                    relationship_reader=self._relationship_reader,
                     relationship_writer=self._relationship_writer,
                 )
             except PermanentError as exc:
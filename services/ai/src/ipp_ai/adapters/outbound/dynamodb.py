@@ -44,6 +44,10 @@ def _rel_sk_prefix(insight_id: str) -> str:
     return f"REL#{insight_id}#"
 
 
+def _rel_feedback_sk_prefix(insight_id: str) -> str:
+    return f"RELFEEDBACK#{insight_id}#"
+
+
 class DynamoDbInsightReader:
     """Read-only access to the insights table."""
 
@@ -90,6 +94,17 @@ class DynamoDbInsightReader:
         related.sort(key=lambda r: r.confidence, reverse=True)
         return related
 
+    def list_ruled_out(self, tenant_id: str, insight_id: str) -> set[str]:
+        """Satisfies ports.RelationshipReader. The Go API files a user's
+        verdict under both insights (`RELFEEDBACK#<insight_id>#<other>`), so
+        this is one prefix query, like list_by_insight.
+        """
+        response = self._table.query(
+            KeyConditionExpression=Key("pk").eq(_pk(tenant_id))
+            & Key("sk").begins_with(_rel_feedback_sk_prefix(insight_id)),
+        )
+        return {item["other_insight_id"] for item in response["Items"]}
+
 
 def _unmarshal_related_insight(insight_id: str, item: dict[str, Any]) -> RelatedInsight:
     try:
@@ -10,7 +10,9 @@ DynamoDB directly, per the Epic 3 boundary rule (services/ai/README.md).
 Auth is CognitoServiceTokenClient's client_credentials token (IPP-94); the
 endpoint requires the agent.write scope (router.go's auth.RequireScope).
 Idempotent server-side (IPP-100: re-posting the same edge updates it), so
-no idempotency handling is needed here beyond the retry-on-5xx below.
+no idempotency handling is needed here beyond the retry-on-5xx below. A 422
+is the tenant's relationship policy refusing the edge, translated here to
+`RelationshipRejected` the way plan_result_api.py translates its 409.
 """
 
 from __future__ import annotations
@@ -21,6 +23,7 @@ import urllib.request
 from typing import Protocol
 
 from ipp_ai.domain.relationship import Relationship
+from ipp_ai.errors import RelationshipRejected
 
 _TIMEOUT_SECONDS = 8
 _MAX_ATTEMPTS = 3
@@ -64,7 +67,15 @@ class GoApiRelationshipWriter:
                 "Content-Type": "application/json",
             },
         )
-        _send_json(request)
+        try:
+            _send_json(request)
+        except urllib.error.HTTPError as exc:
+            if exc.code == 422:
+                raise RelationshipRejected(
+                    f"relationship {relationship.from_insight_id} -> "
+                    f"{relationship.to_insight_id} rejected by tenant policy"
+                ) from exc
+            raise
 
 
 def _send_json(request: urllib.request.Request) -> dict:
@@ -22,7 +22,14 @@ from ipp_ai.domain.candidate import select_candidates
 from ipp_ai.domain.embedding import Embedding
 from ipp_ai.domain.insight import Insight
 from ipp_ai.domain.relationship import Relationship
-from ipp_ai.ports import EmbeddingReader, InsightReader, RelationLabeler, RelationshipWriter
+from ipp_ai.errors import RelationshipRejected
+from ipp_ai.ports import (
+    EmbeddingReader,
+    InsightReader,
+    RelationLabeler,
+    RelationshipReader,
+    RelationshipWriter,
+)
 
 logger = logging.getLogger(__name__)
 
@@ -91,6 +98,7 @@ def discover_relationships(
     insight_reader: InsightReader,
     embedding_reader: EmbeddingReader,
     labeler: RelationLabeler,
+    relationship_reader: RelationshipReader,
     relationship_writer: RelationshipWriter,
 ) -> None:
     """The REL 2 -> REL 3 -> REL 4 pipeline for one newly embedded insight.
@@ -101,17 +109,36 @@ def discover_relationships(
     relationship_writer.put failure propagates, same as embed_insight's own
     failures, so the runtime redelivers the event. Safe to redeliver — both
     the embedding upsert and the Go endpoint's write are idempotent by key.
+    The exception is RelationshipRejected: the tenant's policy said no, which
+    a redelivery can't change, so that edge is logged and the rest written.
+
+    Candidates the user has already ruled on (relationship_reader's
+    list_ruled_out) are dropped before labeling: the API would reject them,
+    and the LLM call would be wasted.
     """
     query_insight = insight_reader.get_by_id(tenant_id, query_embedding.insight_id)
     if query_insight is None:
         return  # insight deleted since it was embedded; nothing to relate
 
+    ruled_out = relationship_reader.list_ruled_out(tenant_id, query_insight.id)
     candidate_ids = select_candidates(query_embedding, embedding_reader.list_by_tenant(tenant_id))
     candidates = [
         insight
         for insight_id, _score in candidate_ids
-        if (insight := insight_reader.get_by_id(tenant_id, insight_id)) is not None
+        if insight_id not in ruled_out
+        and (insight := insight_reader.get_by_id(tenant_id, insight_id)) is not None
     ]
 
     for relationship in label_relationships(tenant_id, query_insight, candidates, labeler=labeler):
-        relationship_writer.put(relationship)
+        try:
+            relationship_writer.put(relationship)
+        except RelationshipRejected:
+            logger.info(
+                "relationship rejected by tenant policy, skipping",
+                extra={
+                    "tenant_id": tenant_id,
+                    "insight_id": relationship.from_insight_id,
+                    "candidate_insight_id": relationship.to_insight_id,
+                    "relation_type": relationship.relation_type.value,
+                },
+            )
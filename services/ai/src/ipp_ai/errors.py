@@ -24,3 +24,14 @@ class PlanAlreadyResolved(Exception):
     on that same conditional write as its only redelivery guard (see
     weekly_plan_repository.go's doc comment), not a new lock or table.
     """
+
+
+class RelationshipRejected(Exception):
+    """The Go API's tenant relationship policy refused an edge (422): below
+    the tenant's minimum confidence for its type, contradicting an edge
+    already stored between the same two insights, or losing to stronger
+    edges under the per-insight cap. Mirrors internal/domain's
+    ErrBelowMinConfidence/ErrContradictoryEdge/ErrEdgeCapReached. Retrying
+    changes nothing, but it isn't a failed event either: the rejection is
+    logged server-side, readable at GET .../relationships/rejected.
+    """
@@ -51,6 +51,13 @@ class RelationshipReader(Protocol):
 
     def list_by_insight(self, tenant_id: str, insight_id: str) -> list[RelatedInsight]: ...
 
+    def list_ruled_out(self, tenant_id: str, insight_id: str) -> set[str]:
+        """IDs of the insights a user has ruled on pairing with insight_id —
+        removed or retyped through the Go API. The agent never posts those
+        pairs again; the API would reject them anyway.
+        """
+        ...
+
 
 class EmbeddingClient(Protocol):
     """Turns text into a vector. Mirrors internal/ports.EnrichmentClient's
@@ -121,6 +128,9 @@ class RelationshipWriter(Protocol):
     Unlike EmbeddingWriter, this write leaves the AI service's own AWS
     account: a Relationship is domain data, so it goes through the Go API
     rather than DynamoDB directly (services/ai/README.md's boundary rule).
+
+    `put` raises `ipp_ai.errors.RelationshipRejected` when the tenant's
+    relationship policy refuses the edge.
     """
 
     def put(self, relationship: Relationship) -> None: ...
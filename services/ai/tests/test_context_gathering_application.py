@@ -26,6 +26,9 @@ class FakeRelationshipReader:
     def list_by_insight(self, tenant_id: str, insight_id: str) -> list[RelatedInsight]:
         return self.edges.get((tenant_id, insight_id), [])
 
+    def list_ruled_out(self, tenant_id: str, insight_id: str) -> set[str]:
+        return set()
+
 
 def _insight(insight_id: str) -> Insight:
     return Insight(
@@ -204,3 +204,24 @@ def test_list_by_insight_malformed_item_raises_permanent_error(stubbed_reader) -
 
     with pytest.raises(PermanentError):
         stubbed_reader.reader.list_by_insight("t1", "i1")
+
+
+def test_list_ruled_out_returns_the_other_side_of_each_verdict(stubbed_reader) -> None:
+    stubbed_reader.stubber.add_response(
+        "query",
+        {
+            "Items": [
+                {
+                    "pk": {"S": "TENANT#t1"},
+                    "sk": {"S": f"RELFEEDBACK#i1#{other}"},
+                    "insight_id": {"S": "i1"},
+                    "other_insight_id": {"S": other},
+                    "verdict": {"S": "not_related"},
+                }
+                for other in ("i2", "i3")
+            ]
+        },
+        {"TableName": "test-insights", "KeyConditionExpression": ANY},
+    )
+
+    assert stubbed_reader.reader.list_ruled_out("t1", "i1") == {"i2", "i3"}
@@ -8,6 +8,7 @@ import pytest
 
 from ipp_ai.adapters.outbound.relationship_api import GoApiRelationshipWriter
 from ipp_ai.domain.relationship import Relationship, RelationType
+from ipp_ai.errors import RelationshipRejected
 
 
 class _FakeResponse:
@@ -97,3 +98,20 @@ def test_put_does_not_retry_on_4xx(monkeypatch: pytest.MonkeyPatch) -> None:
         GoApiRelationshipWriter("https://api.example.com", _FakeTokenClient()).put(_relationship())
 
     assert attempts["n"] == 1
+
+
+def test_put_translates_a_policy_rejection_to_relationship_rejected(
+    monkeypatch: pytest.MonkeyPatch,
+) -> None:
+    attempts = {"n": 0}
+
+    def fake_urlopen(request: urllib.request.Request, timeout: float) -> _FakeResponse:
+        attempts["n"] += 1
+        raise urllib.error.HTTPError(request.full_url, 422, "unprocessable", {}, None)
+
+    monkeypatch.setattr(urllib.request, "urlopen", fake_urlopen)
+
+    with pytest.raises(RelationshipRejected):
+        GoApiRelationshipWriter("https://api.example.com", _FakeTokenClient()).put(_relationship())
+
+    assert attempts["n"] == 1
@@ -11,7 +11,8 @@ from ipp_ai.application.relationship import (
 )
 from ipp_ai.domain.embedding import Embedding
 from ipp_ai.domain.insight import Insight
-from ipp_ai.domain.relationship import RelationJudgement, Relationship, RelationType
+from ipp_ai.domain.relationship import RelatedInsight, RelationJudgement, Relationship, RelationType
+from ipp_ai.errors import RelationshipRejected
 
 
 @dataclass
@@ -127,11 +128,25 @@ class FakeEmbeddingReader:
 @dataclass
 class SpyRelationshipWriter:
     puts: list[Relationship] = field(default_factory=list)
+    rejected_ids: set[str] = field(default_factory=set)
 
     def put(self, relationship: Relationship) -> None:
+        if relationship.to_insight_id in self.rejected_ids:
+            raise RelationshipRejected(relationship.to_insight_id)
         self.puts.append(relationship)
 
 
+@dataclass
+class FakeRelationshipReader:
+    ruled_out: set[str] = field(default_factory=set)
+
+    def list_by_insight(self, tenant_id: str, insight_id: str) -> list[RelatedInsight]:
+        return []
+
+    def list_ruled_out(self, tenant_id: str, insight_id: str) -> set[str]:
+        return self.ruled_out
+
+
 def _embedding(insight_id: str, vector: tuple[float, ...]) -> Embedding:
     return Embedding(
         insight_id=insight_id, tenant_id="t1", model="m", dimension=len(vector), vector=vector
@@ -152,6 +167,7 @@ def test_discover_relationships_persists_labeled_candidates_above_threshold() ->
         insight_reader=insight_reader,
         embedding_reader=embedding_reader,
         labeler=labeler,
+        relationship_reader=FakeRelationshipReader(),
         relationship_writer=relationship_writer,
     )
 
@@ -172,6 +188,7 @@ def test_discover_relationships_with_no_candidates_never_calls_the_labeler() ->
         insight_reader=insight_reader,
         embedding_reader=embedding_reader,
         labeler=labeler,
+        relationship_reader=FakeRelationshipReader(),
         relationship_writer=relationship_writer,
     )
 
@@ -194,7 +211,55 @@ def test_discover_relationships_returns_quietly_if_the_query_insight_is_gone() -
         insight_reader=insight_reader,
         embedding_reader=embedding_reader,
         labeler=labeler,
+        relationship_reader=FakeRelationshipReader(),
         relationship_writer=relationship_writer,
     )
 
     assert relationship_writer.puts == []
+
+
+def test_discover_relationships_skips_an_edge_the_tenant_policy_rejects() -> None:
+    insight_reader = FakeInsightReader(
+        {"q": _insight("q"), "c1": _insight("c1"), "c2": _insight("c2")}
+    )
+    embedding_reader = FakeEmbeddingReader(
+        [_embedding("c1", (1.0, 0.0)), _embedding("c2", (1.0, 0.1))]
+    )
+    labeler = StubLabeler(judgements=[_judgement(), _judgement()])
+    relationship_writer = SpyRelationshipWriter(rejected_ids={"c1"})
+
+    discover_relationships(
+        "t1",
+        _embedding("q", (1.0, 0.0)),
+        insight_reader=insight_reader,
+        embedding_reader=embedding_reader,
+        labeler=labeler,
+        relationship_reader=FakeRelationshipReader(),
+        relationship_writer=relationship_writer,
+    )
+
+    assert [r.to_insight_id for r in relationship_writer.puts] == ["c2"]
+
+
+def test_discover_relationships_never_labels_a_pair_the_user_ruled_out() -> None:
+    insight_reader = FakeInsightReader(
+        {"q": _insight("q"), "c1": _insight("c1", "ruled out"), "c2": _insight("c2", "fresh")}
+    )
+    embedding_reader = FakeEmbeddingReader(
+        [_embedding("c1", (1.0, 0.0)), _embedding("c2", (1.0, 0.1))]
+    )
+    labeler = StubLabeler(judgements=[_judgement()])
+    relationship_writer = SpyRelationshipWriter()
+
+    discover_relationships(
+        "t1",
+        _embedding("q", (1.0, 0.0)),
+        insight_reader=insight_reader,
+        embedding_reader=embedding_reader,
+        labeler=labeler,
+        relationship_reader=FakeRelationshipReader(ruled_out={"c1"}),
+        relationship_writer=relationship_writer,
+    )
+
+    assert [to_text for _from_text, to_text in labeler.received] == ["fresh"]
+    assert [r.to_insight_id for r in relationship_writer.puts] == ["c2"]
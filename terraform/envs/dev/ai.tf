@@ -321,8 +321,8 @@ resource "aws_lambda_event_source_mapping" "ai_from_subscription_queue" {
   function_name    = module.ai_lambda.function_arn
 
   # Must stay 1 — see the batch_size comment in
-  # services/ai/src/ipp_ai/adapters/inbound/event_subscription.py (same
-  # constraint as the Go worker, ADR-009).
+  # services/ai/src/ipp_ai/adapters/inbound/event_subscription.py (ADR-009,
+  # ADR-021).
   batch_size = 1
   enabled    = true
 }
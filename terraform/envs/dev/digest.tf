@@ -0,0 +1,130 @@
+# ---------------------------------------
+# Digest Lambda (ZIP packaging)
+# ---------------------------------------
+# Sums up what changed since the last digest — new insights and
+# relationships, top-moving tags, weekly plans — on a daily and a weekly
+# schedule, same trigger shape as the tag snapshot (tag-snapshot.tf); each
+# schedule passes its period as the invocation's input. Digests are stored
+# for GET /v1/digests and expire through the table's TTL after a year.
+# There's no mail delivery from here yet: the Lambda's notifier only logs.
+
+data "archive_file" "digest_lambda_zip" {
+  type        = "zip"
+  source_file = "${path.module}/../../../cmd/digest-lambda/bootstrap"
+  output_path = "${path.module}/digest-lambda.zip"
+}
+
+module "digest_lambda_role" {
+  source                     = "../../modules/iam"
+  name                       = "${var.project}-${var.env}-digest-lambda-role"
+  assume_role_policy         = data.aws_iam_policy_document.lambda_assume_role.json
+  basic_execution_policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy" "digest_dynamodb" {
+  name = "${var.project}-${var.env}-digest-dynamodb"
+  role = module.digest_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        # Reads the tenant's INSIGHT#, REL#, TAGSTAT#, TAGHIST#, PLAN#,
+        # DIGEST# and DIGESTNOTIFIED# items and its SETTINGS item; writes
+        # one DIGEST# item per run and, once it's sent, the period's
+        # DIGESTNOTIFIED# pointer.
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query", "dynamodb:GetItem", "dynamodb:PutItem"]
+        Resource = module.dynamodb_insights.table_arn
+      }
+    ]
+  })
+}
+
+module "digest_lambda" {
+  source           = "../../modules/lambda-zip"
+  name             = "${var.project}-${var.env}-digest"
+  role_arn         = module.digest_lambda_role.role_arn
+  filename         = data.archive_file.digest_lambda_zip.output_path
+  source_code_hash = data.archive_file.digest_lambda_zip.output_base64sha256
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  memory_size      = 128
+  timeout          = 30
+
+  environment_variables = {
+    DEFAULT_TENANT_ID   = var.default_tenant_id
+    TABLE_NAME_INSIGHTS = module.dynamodb_insights.table_name
+  }
+}
+
+resource "aws_iam_role" "digest_scheduler" {
+  name               = "${var.project}-${var.env}-digest-scheduler-role"
+  assume_role_policy = data.aws_iam_policy_document.scheduler_assume_role.json
+}
+
+resource "aws_iam_role_policy" "digest_scheduler_invoke" {
+  name = "${var.project}-${var.env}-digest-scheduler-invoke"
+  role = aws_iam_role.digest_scheduler.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["lambda:InvokeFunction"]
+        Resource = module.digest_lambda.lambda_arn
+      }
+    ]
+  })
+}
+
+resource "aws_scheduler_schedule" "digest_daily" {
+  name       = "${var.project}-${var.env}-digest-daily"
+  group_name = "default"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  schedule_expression = "cron(0 ${var.digest_hour_utc} * * ? *)"
+
+  target {
+    arn      = module.digest_lambda.lambda_arn
+    role_arn = aws_iam_role.digest_scheduler.arn
+    input    = jsonencode({ period = "daily" })
+  }
+}
+
+resource "aws_scheduler_schedule" "digest_weekly" {
+  name       = "${var.project}-${var.env}-digest-weekly"
+  group_name = "default"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  schedule_expression = "cron(0 ${var.digest_hour_utc} ? * MON *)"
+
+  target {
+    arn      = module.digest_lambda.lambda_arn
+    role_arn = aws_iam_role.digest_scheduler.arn
+    input    = jsonencode({ period = "weekly" })
+  }
+}
+
+resource "aws_lambda_permission" "allow_scheduler_invoke_digest_daily" {
+  statement_id  = "AllowSchedulerInvokeDaily"
+  action        = "lambda:InvokeFunction"
+  function_name = module.digest_lambda.lambda_function_name
+  principal     = "scheduler.amazonaws.com"
+  source_arn    = aws_scheduler_schedule.digest_daily.arn
+}
+
+resource "aws_lambda_permission" "allow_scheduler_invoke_digest_weekly" {
+  statement_id  = "AllowSchedulerInvokeWeekly"
+  action        = "lambda:InvokeFunction"
+  function_name = module.digest_lambda.lambda_function_name
+  principal     = "scheduler.amazonaws.com"
+  source_arn    = aws_scheduler_schedule.digest_weekly.arn
+}
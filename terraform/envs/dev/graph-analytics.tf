@@ -0,0 +1,107 @@
+# ---------------------------------------
+# Graph analytics Lambda (ZIP packaging)
+# ---------------------------------------
+# Recomputes a tenant's clusters, central insights and bridge insights
+# (GET /v1/graph/clusters, /v1/graph/insights) when its graph changes:
+# KnowledgeUpdated -> this subscriber's own queue+DLQ (event-subscription
+# module) -> this Lambda.
+#
+# The debounce is the event source mapping's batching window: SQS holds
+# events for up to graph_analytics_debounce_seconds, so a burst of edge
+# writes (one discovery run, a bulk import) costs one recompute per
+# tenant rather than one per edge.
+
+data "archive_file" "graph_analytics_lambda_zip" {
+  type        = "zip"
+  source_file = "${path.module}/../../../cmd/graph-analytics-lambda/bootstrap"
+  output_path = "${path.module}/graph-analytics-lambda.zip"
+}
+
+module "graph_analytics_subscription" {
+  source = "../../modules/event-subscription"
+
+  bus_name        = module.domain_events_bus.bus_name
+  subscriber_name = "${var.project}-${var.env}-graph-analytics"
+  detail_types    = ["KnowledgeUpdated"]
+
+  tags = {
+    Project = var.project
+    Env     = var.env
+  }
+}
+
+module "graph_analytics_lambda_role" {
+  source                     = "../../modules/iam"
+  name                       = "${var.project}-${var.env}-graph-analytics-lambda-role"
+  assume_role_policy         = data.aws_iam_policy_document.lambda_assume_role.json
+  basic_execution_policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy" "graph_analytics_dynamodb" {
+  name = "${var.project}-${var.env}-graph-analytics-dynamodb"
+  role = module.graph_analytics_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        # Reads the tenant's insights and REL# items; reads and replaces
+        # its GRAPHSTATS item.
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query", "dynamodb:GetItem", "dynamodb:PutItem"]
+        Resource = module.dynamodb_insights.table_arn
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "graph_analytics_sqs_consume" {
+  name = "${var.project}-${var.env}-graph-analytics-sqs-consume"
+  role = module.graph_analytics_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "sqs:ReceiveMessage",
+          "sqs:DeleteMessage",
+          "sqs:GetQueueAttributes",
+          "sqs:ChangeMessageVisibility"
+        ]
+        Resource = module.graph_analytics_subscription.queue_arn
+      }
+    ]
+  })
+}
+
+module "graph_analytics_lambda" {
+  source           = "../../modules/lambda-zip"
+  name             = "${var.project}-${var.env}-graph-analytics"
+  role_arn         = module.graph_analytics_lambda_role.role_arn
+  filename         = data.archive_file.graph_analytics_lambda_zip.output_path
+  source_code_hash = data.archive_file.graph_analytics_lambda_zip.output_base64sha256
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  memory_size      = 256
+  # Must stay under the subscription queue's 60s visibility timeout, so a
+  # batch still running is never redelivered.
+  timeout = 50
+
+  environment_variables = {
+    TABLE_NAME_INSIGHTS = module.dynamodb_insights.table_name
+  }
+}
+
+resource "aws_lambda_event_source_mapping" "graph_analytics_from_subscription_queue" {
+  event_source_arn = module.graph_analytics_subscription.queue_arn
+  function_name    = module.graph_analytics_lambda.lambda_arn
+
+  # Large batches are the point: every event for a tenant in one batch is
+  # one recompute. The handler reports failures per tenant (ADR-009).
+  batch_size                         = 100
+  maximum_batching_window_in_seconds = var.graph_analytics_debounce_seconds
+  function_response_types            = ["ReportBatchItemFailures"]
+  enabled                            = true
+}
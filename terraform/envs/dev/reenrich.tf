@@ -0,0 +1,150 @@
+# ---------------------------------------
+# Reenrich Lambda (ZIP packaging)
+# ---------------------------------------
+# Works through pending re-enrichment jobs (POST /v1/admin/reenrich) on a
+# schedule, same trigger shape as the Raindrop poll (raindrop.tf). Each run
+# is bounded by reenrich_batch_limit and saves its cursor as it goes, so a
+# job larger than one run simply spans several.
+
+data "archive_file" "reenrich_lambda_zip" {
+  type        = "zip"
+  source_file = "${path.module}/../../../cmd/reenrich-lambda/bootstrap"
+  output_path = "${path.module}/reenrich-lambda.zip"
+}
+
+module "reenrich_lambda_role" {
+  source                     = "../../modules/iam"
+  name                       = "${var.project}-${var.env}-reenrich-lambda-role"
+  assume_role_policy         = data.aws_iam_policy_document.lambda_assume_role.json
+  basic_execution_policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy" "reenrich_dynamodb" {
+  name = "${var.project}-${var.env}-reenrich-dynamodb"
+  role = module.reenrich_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        # Same write set as the worker's enrichment Update (tag membership
+        # sync included), plus UpdateItem for saving job progress.
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query", "dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:UpdateItem", "dynamodb:DeleteItem"]
+        Resource = module.dynamodb_insights.table_arn
+      },
+      {
+        Sid      = "QueryTagIndex"
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query"]
+        Resource = "${module.dynamodb_insights.table_arn}/index/*"
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "reenrich_ssm_read" {
+  name = "${var.project}-${var.env}-reenrich-ssm-read"
+  role = module.reenrich_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["ssm:GetParameter"]
+        Resource = "arn:aws:ssm:${data.aws_region.current.id}:${data.aws_caller_identity.current.account_id}:parameter/${var.project}/${var.env}/openai/api_key"
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "reenrich_eventbridge_publish" {
+  name = "${var.project}-${var.env}-reenrich-eventbridge-publish"
+  role = module.reenrich_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["events:PutEvents"]
+        Resource = module.domain_events_bus.bus_arn
+      }
+    ]
+  })
+}
+
+module "reenrich_lambda" {
+  source           = "../../modules/lambda-zip"
+  name             = "${var.project}-${var.env}-reenrich"
+  role_arn         = module.reenrich_lambda_role.role_arn
+  filename         = data.archive_file.reenrich_lambda_zip.output_path
+  source_code_hash = data.archive_file.reenrich_lambda_zip.output_base64sha256
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  memory_size      = 128
+  # reenrich_batch_limit calls at reenrich_rate_per_minute, plus headroom:
+  # the defaults (100 at 60/min) need a little under two minutes.
+  timeout = 300
+
+  environment_variables = {
+    DEFAULT_TENANT_ID         = var.default_tenant_id
+    TABLE_NAME_INSIGHTS       = module.dynamodb_insights.table_name
+    OPENAI_API_KEY            = "ssm:/${var.project}/${var.env}/openai/api_key"
+    DOMAIN_EVENTS_BUS_NAME    = module.domain_events_bus.bus_name
+    REENRICH_BATCH_LIMIT      = tostring(var.reenrich_batch_limit)
+    REENRICH_RATE_PER_MINUTE  = tostring(var.reenrich_rate_per_minute)
+    LLM_BUDGET_DAILY_TOKENS   = tostring(var.llm_budget_daily_tokens)
+    LLM_BUDGET_MONTHLY_TOKENS = tostring(var.llm_budget_monthly_tokens)
+    LLM_BUDGET_DAILY_USD      = tostring(var.llm_budget_daily_usd)
+    LLM_BUDGET_MONTHLY_USD    = tostring(var.llm_budget_monthly_usd)
+    LLM_PRICE_INPUT_PER_MTOK  = tostring(var.llm_price_input_per_mtok)
+    LLM_PRICE_OUTPUT_PER_MTOK = tostring(var.llm_price_output_per_mtok)
+  }
+}
+
+resource "aws_iam_role" "reenrich_scheduler" {
+  name               = "${var.project}-${var.env}-reenrich-scheduler-role"
+  assume_role_policy = data.aws_iam_policy_document.scheduler_assume_role.json
+}
+
+resource "aws_iam_role_policy" "reenrich_scheduler_invoke" {
+  name = "${var.project}-${var.env}-reenrich-scheduler-invoke"
+  role = aws_iam_role.reenrich_scheduler.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["lambda:InvokeFunction"]
+        Resource = module.reenrich_lambda.lambda_arn
+      }
+    ]
+  })
+}
+
+resource "aws_scheduler_schedule" "reenrich" {
+  name       = "${var.project}-${var.env}-reenrich"
+  group_name = "default"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  schedule_expression = "rate(${var.reenrich_interval_hours} hours)"
+
+  target {
+    arn      = module.reenrich_lambda.lambda_arn
+    role_arn = aws_iam_role.reenrich_scheduler.arn
+  }
+}
+
+resource "aws_lambda_permission" "allow_scheduler_invoke_reenrich" {
+  statement_id  = "AllowSchedulerInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = module.reenrich_lambda.lambda_function_name
+  principal     = "scheduler.amazonaws.com"
+  source_arn    = aws_scheduler_schedule.reenrich.arn
+}
@@ -32,9 +32,17 @@ resource "aws_iam_role_policy" "rest_dynamodb" {
       {
         Effect = "Allow"
         # UpdateItem is SetReady/SetFailed's conditional write (PLAN 4,
-        # IPP-106's PUT .../weekly-plans/:id/result) — easy to forget since
-        # every other REST route only reads or PutItems.
-        Action   = ["dynamodb:Query", "dynamodb:PutItem", "dynamodb:GetItem", "dynamodb:UpdateItem"]
+        # IPP-106's PUT .../weekly-plans/:id/result) and UpdateAction's
+        # per-action progress write; DeleteItem is the
+        # relationship policy evicting an insight's weakest edge. Edge writes
+        # go through TransactWriteItems, which IAM authorizes per item: its
+        # Puts/Deletes as PutItem/DeleteItem, its insight-exists checks as
+        # ConditionCheckItem — easy to forget since every other REST route
+        # only reads or PutItems.
+        Action = [
+          "dynamodb:Query", "dynamodb:PutItem", "dynamodb:GetItem", "dynamodb:UpdateItem",
+          "dynamodb:DeleteItem", "dynamodb:ConditionCheckItem",
+        ]
         Resource = module.dynamodb_insights.table_arn
       },
       {
@@ -121,6 +129,15 @@ module "rest_lambda" {
     # aws_ssm_parameter resource in this repo — see readwise.tf).
     READWISE_API_TOKEN = "ssm:/${var.project}/${var.env}/readwise/api_token"
     RAINDROP_API_TOKEN = "ssm:/${var.project}/${var.env}/raindrop/api_token"
+    # GET /v1/usage reports against the same limits the worker enforces.
+    LLM_BUDGET_DAILY_TOKENS   = tostring(var.llm_budget_daily_tokens)
+    LLM_BUDGET_MONTHLY_TOKENS = tostring(var.llm_budget_monthly_tokens)
+    LLM_BUDGET_DAILY_USD      = tostring(var.llm_budget_daily_usd)
+    LLM_BUDGET_MONTHLY_USD    = tostring(var.llm_budget_monthly_usd)
+    LLM_PRICE_INPUT_PER_MTOK  = tostring(var.llm_price_input_per_mtok)
+    LLM_PRICE_OUTPUT_PER_MTOK = tostring(var.llm_price_output_per_mtok)
+    # POST /v1/insights applies the same duplicate policy as the worker.
+    DUPLICATE_POLICY = var.duplicate_policy
   }
 }
 
@@ -250,7 +267,7 @@ resource "aws_apigatewayv2_api" "rest" {
       "https://${aws_cloudfront_distribution.web.domain_name}",
       "https://${var.domain_name}",
     ])
-    allow_methods = ["GET", "POST"]
+    allow_methods = ["GET", "POST", "PUT", "PATCH", "DELETE"]
     allow_headers = ["Authorization", "Content-Type"]
   }
 }
@@ -316,6 +333,26 @@ resource "aws_apigatewayv2_route" "get_tags" {
   target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
 }
 
+resource "aws_apigatewayv2_route" "get_related_tags" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/tags/{tag}/related"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_tag_history" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/tags/{tag}/history"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
 resource "aws_apigatewayv2_route" "post_readwise_import" {
   api_id    = aws_apigatewayv2_api.rest.id
   route_key = "POST /v1/readwise/import"
@@ -356,6 +393,39 @@ resource "aws_apigatewayv2_route" "get_relationships" {
   target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
 }
 
+# Agent-only: same JWT authorizer + Gin RequireScope split as
+# post_relationships.
+resource "aws_apigatewayv2_route" "get_rejected_relationships" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/tenants/{tenantID}/relationships/rejected"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+# User-only: a user's verdict on one edge (Gin RequireUser).
+resource "aws_apigatewayv2_route" "patch_relationship" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "PATCH /v1/tenants/{tenantID}/insights/{insightID}/relationships/{otherInsightID}"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "delete_relationship" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "DELETE /v1/tenants/{tenantID}/insights/{insightID}/relationships/{otherInsightID}"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
 resource "aws_apigatewayv2_route" "post_weekly_plans" {
   api_id    = aws_apigatewayv2_api.rest.id
   route_key = "POST /v1/tenants/{tenantID}/weekly-plans"
@@ -386,6 +456,16 @@ resource "aws_apigatewayv2_route" "get_weekly_plan" {
   target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
 }
 
+resource "aws_apigatewayv2_route" "patch_weekly_plan_action" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "PATCH /v1/tenants/{tenantID}/weekly-plans/{planID}/actions/{index}"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
 # Agent-only (PLAN 4, IPP-106): same JWT authorizer as every other route —
 # it accepts both app clients (see cognito_jwt's audience comment above),
 # and Gin's auth.RequireScope(ScopeAgentWrite) is what actually rejects a
@@ -412,6 +492,206 @@ resource "aws_apigatewayv2_route" "get_weekly_plan_status" {
   target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
 }
 
+resource "aws_apigatewayv2_route" "post_reenrich" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "POST /v1/admin/reenrich"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_reenrich" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/admin/reenrich/{jobID}"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_usage" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/usage"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_duplicates" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/duplicates"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_insight_graph" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/insights/{insightID}/graph"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_graph_path" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/graph/path"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_graph_export" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/graph/export"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_graph_insights" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/graph/insights"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_graph_clusters" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/graph/clusters"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_contradictions" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/contradictions"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "put_contradiction_resolution" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "PUT /v1/contradictions/{insightID}/{otherInsightID}/resolution"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "delete_contradiction_resolution" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "DELETE /v1/contradictions/{insightID}/{otherInsightID}/resolution"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_settings" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/settings"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "put_settings" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "PUT /v1/settings"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "put_settings_relationship_policy" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "PUT /v1/settings/relationship-policy"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "put_settings_relevance" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "PUT /v1/settings/relevance"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "post_settings_relevance_preview" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "POST /v1/settings/relevance/preview"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_review_due" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/review/due"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "post_review" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "POST /v1/review/{insightID}"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
+resource "aws_apigatewayv2_route" "get_digests" {
+  api_id    = aws_apigatewayv2_api.rest.id
+  route_key = "GET /v1/digests"
+
+  authorization_type = "JWT"
+  authorizer_id      = aws_apigatewayv2_authorizer.cognito_jwt.id
+
+  target = "integrations/${aws_apigatewayv2_integration.rest_lambda.id}"
+}
+
 resource "aws_lambda_permission" "allow_rest_apigw" {
   statement_id  = "AllowRestAPIGatewayInvoke"
   action        = "lambda:InvokeFunction"
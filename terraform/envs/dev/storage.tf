@@ -5,6 +5,9 @@ module "dynamodb_insights" {
 
   enable_tag_gsi = true
 
+  # Expires enrichment cache entries (ENRICHCACHE#, see llm.Service.WithCache).
+  ttl_attribute = "expires_at"
+
   tags = {
     Project = var.project
     Env     = var.env
@@ -0,0 +1,100 @@
+# ---------------------------------------
+# Tag snapshot Lambda (ZIP packaging)
+# ---------------------------------------
+# Records every tag's relevance score on a schedule, same trigger shape as
+# the Raindrop poll (raindrop.tf), so GET /v1/tags/:tag/history has a
+# series to return and GET /v1/tags a slope to flag trending/fading tags
+# from. Snapshots expire through the table's TTL after a year.
+
+data "archive_file" "tag_snapshot_lambda_zip" {
+  type        = "zip"
+  source_file = "${path.module}/../../../cmd/tag-snapshot-lambda/bootstrap"
+  output_path = "${path.module}/tag-snapshot-lambda.zip"
+}
+
+module "tag_snapshot_lambda_role" {
+  source                     = "../../modules/iam"
+  name                       = "${var.project}-${var.env}-tag-snapshot-lambda-role"
+  assume_role_policy         = data.aws_iam_policy_document.lambda_assume_role.json
+  basic_execution_policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy" "tag_snapshot_dynamodb" {
+  name = "${var.project}-${var.env}-tag-snapshot-dynamodb"
+  role = module.tag_snapshot_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        # Reads the tenant's TAG# and REL# items and its SETTINGS item
+        # (ListTags); writes one TAGHIST# item per run.
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query", "dynamodb:GetItem", "dynamodb:PutItem"]
+        Resource = module.dynamodb_insights.table_arn
+      }
+    ]
+  })
+}
+
+module "tag_snapshot_lambda" {
+  source           = "../../modules/lambda-zip"
+  name             = "${var.project}-${var.env}-tag-snapshot"
+  role_arn         = module.tag_snapshot_lambda_role.role_arn
+  filename         = data.archive_file.tag_snapshot_lambda_zip.output_path
+  source_code_hash = data.archive_file.tag_snapshot_lambda_zip.output_base64sha256
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  memory_size      = 128
+  timeout          = 30
+
+  environment_variables = {
+    DEFAULT_TENANT_ID   = var.default_tenant_id
+    TABLE_NAME_INSIGHTS = module.dynamodb_insights.table_name
+  }
+}
+
+resource "aws_iam_role" "tag_snapshot_scheduler" {
+  name               = "${var.project}-${var.env}-tag-snapshot-scheduler-role"
+  assume_role_policy = data.aws_iam_policy_document.scheduler_assume_role.json
+}
+
+resource "aws_iam_role_policy" "tag_snapshot_scheduler_invoke" {
+  name = "${var.project}-${var.env}-tag-snapshot-scheduler-invoke"
+  role = aws_iam_role.tag_snapshot_scheduler.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["lambda:InvokeFunction"]
+        Resource = module.tag_snapshot_lambda.lambda_arn
+      }
+    ]
+  })
+}
+
+resource "aws_scheduler_schedule" "tag_snapshot" {
+  name       = "${var.project}-${var.env}-tag-snapshot"
+  group_name = "default"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  schedule_expression = "rate(${var.tag_snapshot_interval_hours} hours)"
+
+  target {
+    arn      = module.tag_snapshot_lambda.lambda_arn
+    role_arn = aws_iam_role.tag_snapshot_scheduler.arn
+  }
+}
+
+resource "aws_lambda_permission" "allow_scheduler_invoke_tag_snapshot" {
+  statement_id  = "AllowSchedulerInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = module.tag_snapshot_lambda.lambda_function_name
+  principal     = "scheduler.amazonaws.com"
+  source_arn    = aws_scheduler_schedule.tag_snapshot.arn
+}
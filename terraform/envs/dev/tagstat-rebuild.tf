@@ -0,0 +1,60 @@
+# ---------------------------------------
+# Tag stat rebuild Lambda (ZIP packaging)
+# ---------------------------------------
+# Recomputes the tenant's per-tag aggregates (TAGSTAT# items) from its
+# memberships, relationship edges and reviews. No trigger: a rebuild races
+# ingestion, so it's invoked by hand, after a deploy that changes the
+# aggregates or when GET /v1/tags looks off:
+#
+#   aws lambda invoke --function-name <project>-<env>-tagstat-rebuild out.json
+
+data "archive_file" "tagstat_rebuild_lambda_zip" {
+  type        = "zip"
+  source_file = "${path.module}/../../../cmd/tagstat-rebuild-lambda/bootstrap"
+  output_path = "${path.module}/tagstat-rebuild-lambda.zip"
+}
+
+module "tagstat_rebuild_lambda_role" {
+  source                     = "../../modules/iam"
+  name                       = "${var.project}-${var.env}-tagstat-rebuild-lambda-role"
+  assume_role_policy         = data.aws_iam_policy_document.lambda_assume_role.json
+  basic_execution_policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy" "tagstat_rebuild_dynamodb" {
+  name = "${var.project}-${var.env}-tagstat-rebuild-dynamodb"
+  role = module.tagstat_rebuild_lambda_role.role_name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        # Reads the tenant's TAG#, REL#, REVIEW#, REVIEWLOG# and TAGSTAT#
+        # items; puts each TAGSTAT# item afresh and deletes those of
+        # vanished tags.
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query", "dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:DeleteItem"]
+        Resource = module.dynamodb_insights.table_arn
+      }
+    ]
+  })
+}
+
+module "tagstat_rebuild_lambda" {
+  source           = "../../modules/lambda-zip"
+  name             = "${var.project}-${var.env}-tagstat-rebuild"
+  role_arn         = module.tagstat_rebuild_lambda_role.role_arn
+  filename         = data.archive_file.tagstat_rebuild_lambda_zip.output_path
+  source_code_hash = data.archive_file.tagstat_rebuild_lambda_zip.output_base64sha256
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  memory_size      = 256
+  # One put per tag after a handful of queries; generous so a large
+  # tenant's rebuild isn't cut off halfway.
+  timeout = 300
+
+  environment_variables = {
+    DEFAULT_TENANT_ID   = var.default_tenant_id
+    TABLE_NAME_INSIGHTS = module.dynamodb_insights.table_name
+  }
+}
@@ -61,3 +61,98 @@ variable "raindrop_poll_limit" {
   type        = number
   default     = 50
 }
+
+variable "graph_analytics_debounce_seconds" {
+  description = "How long KnowledgeUpdated events collect before the graph analytics Lambda recomputes the tenants they name (the event source mapping's batching window, at most 300)."
+  type        = number
+  default     = 60
+}
+
+variable "reenrich_interval_hours" {
+  description = "How often the reenrich Lambda checks for pending re-enrichment jobs. Runs with nothing pending cost one DynamoDB query."
+  type        = number
+  default     = 2
+}
+
+variable "tag_snapshot_interval_hours" {
+  description = "How often the tag snapshot Lambda records every tag's relevance. Trend detection needs a few snapshots inside its 28-day window, so keep this well under a week."
+  type        = number
+  default     = 24
+}
+
+variable "digest_hour_utc" {
+  description = "Hour of the day (UTC) the digest Lambda generates the daily digest, and on Mondays the weekly one"
+  type        = number
+  default     = 6
+}
+
+variable "reenrich_batch_limit" {
+  description = "Max enrichment calls the reenrich Lambda makes per run"
+  type        = number
+  default     = 100
+}
+
+variable "reenrich_rate_per_minute" {
+  description = "Max enrichment calls per minute during a reenrich run, so a backfill never crowds out the worker's live enrichment"
+  type        = number
+  default     = 60
+}
+
+variable "llm_budget_daily_tokens" {
+  description = "Per-tenant enrichment tokens (input + output) per UTC day; past it the worker falls back to keyword tagging and reenrich jobs pause. 0 = unlimited"
+  type        = number
+  default     = 200000
+}
+
+variable "llm_budget_monthly_tokens" {
+  description = "Per-tenant enrichment tokens per calendar month (UTC). 0 = unlimited"
+  type        = number
+  default     = 3000000
+}
+
+variable "llm_budget_daily_usd" {
+  description = "Per-tenant enrichment spend per UTC day, in USD. Only enforced once llm_price_* are set. 0 = unlimited"
+  type        = number
+  default     = 0
+}
+
+variable "llm_budget_monthly_usd" {
+  description = "Per-tenant enrichment spend per calendar month (UTC), in USD. Only enforced once llm_price_* are set. 0 = unlimited"
+  type        = number
+  default     = 0
+}
+
+variable "llm_price_input_per_mtok" {
+  description = "The enrichment model's list price per million input tokens, in USD; prices every call for the USD budgets"
+  type        = number
+  default     = 0
+}
+
+variable "llm_price_output_per_mtok" {
+  description = "The enrichment model's list price per million output tokens, in USD"
+  type        = number
+  default     = 0
+}
+
+variable "llm_breaker_failure_threshold" {
+  description = "Consecutive enrichment failures before the worker's circuit breaker opens and skips straight to the keyword fallback"
+  type        = number
+  default     = 5
+}
+
+variable "llm_breaker_cooldown" {
+  description = "How long the worker's circuit breaker stays open before probing the provider again (Go duration)"
+  type        = string
+  default     = "1m"
+}
+
+variable "duplicate_policy" {
+  description = "What ingest does with a highlight matching one the tenant already has from another source: merge (fold into the existing insight), flag (store it and list the pair under GET /v1/duplicates) or off"
+  type        = string
+  default     = "flag"
+
+  validation {
+    condition     = contains(["merge", "flag", "off"], var.duplicate_policy)
+    error_message = "duplicate_policy must be merge, flag or off."
+  }
+}
@@ -145,6 +145,13 @@ resource "aws_iam_policy" "worker_dynamodb" {
         Effect   = "Allow"
         Action   = ["dynamodb:Query"]
         Resource = "${module.dynamodb_insights.table_arn}/index/*"
+      },
+      {
+        # The keyword fallback (llm.Fallback) reads the tenant's corpus.
+        Sid      = "QueryTenantCorpusForFallback"
+        Effect   = "Allow"
+        Action   = ["dynamodb:Query"]
+        Resource = module.dynamodb_insights.table_arn
       }
     ]
   })
@@ -176,14 +183,31 @@ module "worker_lambda" {
   name        = "${var.project}-${var.env}-worker"
   role_arn    = module.worker_lambda_role.role_arn
   image_uri   = var.worker_image_uri
-  timeout     = 30
   memory_size = 256
 
+  # A batch of up to 10 records, one tenant's: a batch enrichment call of
+  # up to 30s, then, if its answer was malformed, concurrent per-record
+  # calls of up to 30s, plus each record's writes — about 70s. More tenants
+  # mean more calls in turn, so every call is cut off 10s before this
+  # timeout (llm.DeadlineReserve) and falls back to keyword tags, leaving
+  # the writes time to finish. Stays under the ingest queue's 120s
+  # visibility timeout, so a batch still running is never redelivered.
+  timeout = 100
+
   environment_variables = {
-    TABLE_NAME_INSIGHTS    = module.dynamodb_insights.table_name
-    INGEST_DLQ_URL         = module.ingest_queue.dlq_url
-    OPENAI_API_KEY         = "ssm:/${var.project}/${var.env}/openai/api_key"
-    DOMAIN_EVENTS_BUS_NAME = module.domain_events_bus.bus_name
+    TABLE_NAME_INSIGHTS           = module.dynamodb_insights.table_name
+    INGEST_DLQ_URL                = module.ingest_queue.dlq_url
+    OPENAI_API_KEY                = "ssm:/${var.project}/${var.env}/openai/api_key"
+    DOMAIN_EVENTS_BUS_NAME        = module.domain_events_bus.bus_name
+    LLM_BUDGET_DAILY_TOKENS       = tostring(var.llm_budget_daily_tokens)
+    LLM_BUDGET_MONTHLY_TOKENS     = tostring(var.llm_budget_monthly_tokens)
+    LLM_BUDGET_DAILY_USD          = tostring(var.llm_budget_daily_usd)
+    LLM_BUDGET_MONTHLY_USD        = tostring(var.llm_budget_monthly_usd)
+    LLM_PRICE_INPUT_PER_MTOK      = tostring(var.llm_price_input_per_mtok)
+    LLM_PRICE_OUTPUT_PER_MTOK     = tostring(var.llm_price_output_per_mtok)
+    LLM_BREAKER_FAILURE_THRESHOLD = tostring(var.llm_breaker_failure_threshold)
+    LLM_BREAKER_COOLDOWN          = var.llm_breaker_cooldown
+    DUPLICATE_POLICY              = var.duplicate_policy
   }
 
   depends_on = [aws_iam_role_policy.worker_ecr_pull, aws_ecr_repository_policy.worker]
@@ -193,6 +217,27 @@ resource "aws_lambda_event_source_mapping" "worker_from_sqs" {
   event_source_arn = module.ingest_queue.queue_arn
   function_name    = module.worker_lambda.function_arn
 
-  batch_size = 1
-  enabled    = true
+  # Batches let a bulk import's highlights share one enrichment call
+  # (llm.MaxBatchSize). ReportBatchItemFailures is what makes more than one
+  # record per batch safe: the handler reports transient failures per
+  # record, so the rest of the batch isn't redelivered with them (ADR-009).
+  batch_size                         = 10
+  maximum_batching_window_in_seconds = 5
+  function_response_types            = ["ReportBatchItemFailures"]
+  enabled                            = true
+}
+
+# One data point per circuit breaker state change (llm.Breaker), with the
+# new state as a dimension: alarm on to=open to hear about provider outages.
+resource "aws_cloudwatch_log_metric_filter" "worker_llm_breaker_transitions" {
+  name           = "${var.project}-${var.env}-worker-llm-breaker-transitions"
+  log_group_name = module.worker_lambda.log_group_name
+  pattern        = "{ $.msg = \"llm circuit breaker state changed\" }"
+
+  metric_transformation {
+    name       = "LLMCircuitBreakerTransitions"
+    namespace  = "${var.project}/${var.env}"
+    value      = "1"
+    dimensions = { to = "$.to" }
+  }
 }
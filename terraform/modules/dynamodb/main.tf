@@ -33,6 +33,14 @@ resource "aws_dynamodb_table" "this" {
     }
   }
 
+  dynamic "ttl" {
+    for_each = var.ttl_attribute != "" ? [1] : []
+    content {
+      attribute_name = var.ttl_attribute
+      enabled        = true
+    }
+  }
+
   point_in_time_recovery {
     enabled = true
   }
@@ -13,4 +13,9 @@ variable "enable_tag_gsi" {
   type        = bool
   description = "Add the sparse gsi1 index (gsi1pk/gsi1sk) used for tag membership queries"
   default     = false
-}
\ No newline at end of file
+}
+variable "ttl_attribute" {
+  type        = string
+  description = "Number attribute holding an item's expiry in epoch seconds; empty leaves TTL off"
+  default     = ""
+}
@@ -5,3 +5,7 @@ output "function_name" {
 output "function_arn"  {
   value = aws_lambda_function.this.arn
 }
+
+output "log_group_name" {
+  value = aws_cloudwatch_log_group.this.name
+}